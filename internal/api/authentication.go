@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/session"
 )
 
@@ -73,6 +74,16 @@ func authenticateHandler() func(http.Handler) http.Handler {
 
 			ctx := r.Context()
 
+			// users other than the configured user are stored in the database.
+			// Sessions of deleted users are treated as unauthenticated.
+			var user *models.User
+			if userID != "" && userID != c.GetUsername() {
+				user = manager.GetInstance().FindUser(userID)
+				if user == nil {
+					userID = ""
+				}
+			}
+
 			if c.HasCredentials() {
 				// authentication is required
 				if userID == "" && !allowUnauthenticated(r) {
@@ -104,9 +115,39 @@ func authenticateHandler() func(http.Handler) http.Handler {
 
 			ctx = session.SetCurrentUserID(ctx, userID)
 
+			// the configured user is an admin, as are all users if
+			// authentication is not required
+			if user != nil {
+				ctx = models.WithUserID(ctx, user.ID)
+				ctx = withUserRole(ctx, user.Role)
+			} else {
+				ctx = withUserRole(ctx, models.UserRoleAdmin)
+			}
+
 			r = r.WithContext(ctx)
 
 			next.ServeHTTP(w, r)
 		})
 	}
 }
+
+// historySourceHandler sets the source recorded in the entity history for
+// changes made by the request. It must run after the visited plugin handler,
+// which identifies plugin requests.
+func historySourceHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		source := models.HistorySourceUI
+		switch {
+		case session.IsPluginSession(ctx):
+			source = models.HistorySourcePlugin
+		case r.Header.Get(session.ApiKeyHeader) != "" || r.URL.Query().Get(session.ApiKeyParameter) != "":
+			source = models.HistorySourceAPIKey
+		}
+
+		r = r.WithContext(models.WithHistorySource(ctx, source))
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,344 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+type userRoleKey struct{}
+
+func withUserRole(ctx context.Context, role models.UserRole) context.Context {
+	return context.WithValue(ctx, userRoleKey{}, role)
+}
+
+// getUserRole returns the role of the current user. Returns false if the
+// request was not authenticated, such as for internal requests made by
+// plugins.
+func getUserRole(ctx context.Context) (models.UserRole, bool) {
+	role, ok := ctx.Value(userRoleKey{}).(models.UserRole)
+	return role, ok
+}
+
+// viewerMutations are the mutations that viewers may perform. They only
+// modify the viewer's own activity.
+var viewerMutations = []string{
+	"sceneIncrementO",
+	"sceneDecrementO",
+	"sceneAddO",
+	"sceneDeleteO",
+	"sceneResetO",
+	"sceneSaveActivity",
+	"sceneResetActivity",
+	"sceneIncrementPlayCount",
+	"sceneAddPlay",
+	"sceneDeletePlay",
+	"sceneResetPlayCount",
+}
+
+// viewerSceneUpdateFields are the sceneUpdate input fields that viewers may
+// set. These are stored per user.
+var viewerSceneUpdateFields = []string{
+	"clientMutationId",
+	"id",
+	"rating100",
+	"resume_time",
+	"play_duration",
+}
+
+// editorMutations are the mutations that editors may perform in addition to
+// viewerMutations. They modify the library contents, but not the system
+// configuration or the database as a whole. Mutations that are not listed
+// here are only permitted for admins.
+var editorMutations = []string{
+	"sceneCreate",
+	"sceneUpdate",
+	"sceneMerge",
+	"bulkSceneUpdate",
+	"sceneDestroy",
+	"scenesDestroy",
+	"scenesUpdate",
+	"sceneGenerateScreenshot",
+	"sceneAssignFile",
+	"sceneMarkerCreate",
+	"sceneMarkerUpdate",
+	"sceneMarkerDestroy",
+
+	"imageUpdate",
+	"bulkImageUpdate",
+	"imageDestroy",
+	"imagesDestroy",
+	"imagesUpdate",
+	"imageIncrementO",
+	"imageDecrementO",
+	"imageResetO",
+
+	"galleryCreate",
+	"galleryUpdate",
+	"bulkGalleryUpdate",
+	"galleryDestroy",
+	"galleriesMerge",
+	"galleriesUpdate",
+	"addGalleryImages",
+	"removeGalleryImages",
+	"setGalleryCover",
+	"resetGalleryCover",
+	"galleryChapterCreate",
+	"galleryChapterUpdate",
+	"galleryChapterDestroy",
+
+	"performerCreate",
+	"performerUpdate",
+	"performerDestroy",
+	"performersDestroy",
+	"performersMerge",
+	"bulkPerformerUpdate",
+
+	"studioCreate",
+	"studioUpdate",
+	"studioDestroy",
+	"studiosDestroy",
+	"studiosMerge",
+
+	"movieCreate",
+	"movieUpdate",
+	"movieDestroy",
+	"moviesDestroy",
+	"bulkMovieUpdate",
+
+	"groupCreate",
+	"groupUpdate",
+	"groupDestroy",
+	"groupsDestroy",
+	"bulkGroupUpdate",
+	"addGroupSubGroups",
+	"removeGroupSubGroups",
+	"reorderSubGroups",
+
+	"tagCreate",
+	"tagUpdate",
+	"tagDestroy",
+	"tagsDestroy",
+	"tagsMerge",
+	"bulkTagUpdate",
+
+	"moveFiles",
+	"deleteFiles",
+	"fileSetFingerprints",
+	"restoreFiles",
+	"revertChange",
+
+	"saveFilter",
+	"destroySavedFilter",
+	"setDefaultFilter",
+
+	"exportObjects",
+	"importObjectsDryRun",
+
+	"metadataExport",
+	"metadataScan",
+	"metadataGenerate",
+	"metadataAutoTag",
+	"metadataClean",
+	"metadataRename",
+	"metadataRankSceneFiles",
+	"metadataCleanGenerated",
+	"metadataIdentify",
+
+	"stopJob",
+	"stopAllJobs",
+	"pauseJob",
+	"resumeJob",
+	"setJobPriority",
+
+	"submitStashBoxFingerprints",
+	"submitStashBoxSceneDraft",
+	"submitStashBoxPerformerDraft",
+	"stashBoxBatchPerformerTag",
+	"stashBoxBatchStudioTag",
+	"stashBoxBatchTagTag",
+}
+
+// viewerQueries are the queries that all users may perform. They read the
+// library contents and the state that the UI requires.
+var viewerQueries = []string{
+	"__schema",
+	"__type",
+	"__typename",
+
+	"findSavedFilter",
+	"findSavedFilters",
+	"findDefaultFilter",
+
+	"findScene",
+	"findSceneByHash",
+	"findScenes",
+	"findScenesByPathRegex",
+	"sceneStreams",
+	"findSceneMarkers",
+	"markerWall",
+	"sceneWall",
+	"markerStrings",
+	"sceneMarkerTags",
+
+	"findImage",
+	"findImages",
+	"findPerformer",
+	"findPerformers",
+	"findStudio",
+	"findStudios",
+	"findMovie",
+	"findMovies",
+	"findGroup",
+	"findGroups",
+	"findGallery",
+	"findGalleries",
+	"findTag",
+	"findTags",
+
+	"allScenes",
+	"allSceneMarkers",
+	"allImages",
+	"allGalleries",
+	"allPerformers",
+	"allTags",
+	"allStudios",
+	"allMovies",
+
+	"stats",
+	"plugins",
+	"systemStatus",
+	"jobQueue",
+	"findJob",
+	"currentUser",
+	"version",
+	"latestversion",
+}
+
+// editorQueries are the queries that editors may perform in addition to
+// viewerQueries. Queries that are not listed in either are only permitted
+// for admins.
+var editorQueries = []string{
+	"findDuplicateScenes",
+	"findDuplicateImages",
+	"parseSceneFilenames",
+	"planFileRenames",
+	"planSceneFileRanking",
+	"importDryRunReport",
+
+	"listScrapers",
+	"scrapeSingleScene",
+	"scrapeMultiScenes",
+	"scrapeSingleStudio",
+	"scrapeSinglePerformer",
+	"scrapeMultiPerformers",
+	"scrapeSingleGallery",
+	"scrapeSingleMovie",
+	"scrapeSingleGroup",
+	"scrapeURL",
+	"scrapePerformerURL",
+	"scrapeSceneURL",
+	"scrapeGalleryURL",
+	"scrapeMovieURL",
+	"scrapeGroupURL",
+
+	"findJobHistory",
+	"trashedFiles",
+	"entityHistory",
+}
+
+// viewerSubscriptions are the subscriptions that all users may use.
+// Subscriptions that are not listed are only permitted for admins.
+var viewerSubscriptions = []string{
+	"jobsSubscribe",
+	"scanCompleteSubscribe",
+}
+
+type forbiddenError struct {
+	operation string
+	role      models.UserRole
+}
+
+func (e forbiddenError) Error() string {
+	return fmt.Sprintf("%s is not permitted for %s users", e.operation, e.role)
+}
+
+// roleAllows returns true if the role may use the field, where viewerFields
+// are permitted for all roles and editorFields are also permitted for
+// editors. All fields are permitted for admins.
+func roleAllows(role models.UserRole, field string, viewerFields []string, editorFields []string) bool {
+	switch role {
+	case models.UserRoleAdmin:
+		return true
+	case models.UserRoleEditor:
+		return sliceutil.Contains(editorFields, field) || sliceutil.Contains(viewerFields, field)
+	case models.UserRoleViewer:
+		return sliceutil.Contains(viewerFields, field)
+	}
+
+	return false
+}
+
+func canQuery(role models.UserRole, field string) bool {
+	return roleAllows(role, field, viewerQueries, editorQueries)
+}
+
+func canSubscribe(role models.UserRole, field string) bool {
+	return roleAllows(role, field, viewerSubscriptions, nil)
+}
+
+func canMutate(ctx context.Context, role models.UserRole, field string) bool {
+	switch role {
+	case models.UserRoleAdmin:
+		return true
+	case models.UserRoleEditor:
+		return sliceutil.Contains(editorMutations, field) || sliceutil.Contains(viewerMutations, field)
+	case models.UserRoleViewer:
+		if sliceutil.Contains(viewerMutations, field) {
+			return true
+		}
+
+		if field == "sceneUpdate" {
+			for k := range getUpdateInputMap(ctx) {
+				if !sliceutil.Contains(viewerSceneUpdateFields, k) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// authorizeField is a field middleware that enforces the role of the
+// current user.
+func authorizeField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	role, ok := getUserRole(ctx)
+	if !ok || role == models.UserRoleAdmin {
+		return next(ctx)
+	}
+
+	fc := graphql.GetFieldContext(ctx)
+
+	switch fc.Object {
+	case "Query":
+		if !canQuery(role, fc.Field.Name) {
+			return nil, forbiddenError{operation: fc.Field.Name, role: role}
+		}
+	case "Mutation":
+		if !canMutate(ctx, role, fc.Field.Name) {
+			return nil, forbiddenError{operation: fc.Field.Name, role: role}
+		}
+	case "Subscription":
+		if !canSubscribe(role, fc.Field.Name) {
+			return nil, forbiddenError{operation: fc.Field.Name, role: role}
+		}
+	}
+
+	return next(ctx)
+}
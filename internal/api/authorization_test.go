@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestCanMutate(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		role  models.UserRole
+		field string
+		want  bool
+	}{
+		{models.UserRoleAdmin, "execSQL", true},
+		{models.UserRoleAdmin, "runPluginTask", true},
+		{models.UserRoleEditor, "sceneCreate", true},
+		{models.UserRoleEditor, "sceneAddPlay", true},
+		{models.UserRoleEditor, "metadataScan", true},
+		{models.UserRoleEditor, "runPluginTask", false},
+		{models.UserRoleEditor, "runPluginOperation", false},
+		{models.UserRoleEditor, "runScheduledTask", false},
+		{models.UserRoleEditor, "configureGeneral", false},
+		{models.UserRoleEditor, "userCreate", false},
+		{models.UserRoleEditor, "unknownMutation", false},
+		{models.UserRoleViewer, "sceneAddPlay", true},
+		{models.UserRoleViewer, "sceneCreate", false},
+		{models.UserRoleViewer, "runPluginTask", false},
+	}
+
+	for _, tt := range tests {
+		if got := canMutate(ctx, tt.role, tt.field); got != tt.want {
+			t.Errorf("canMutate(%s, %s) = %v, want %v", tt.role, tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestCanQuery(t *testing.T) {
+	tests := []struct {
+		role  models.UserRole
+		field string
+		want  bool
+	}{
+		{models.UserRoleAdmin, "configuration", true},
+		{models.UserRoleAdmin, "unknownQuery", true},
+		{models.UserRoleEditor, "findScenes", true},
+		{models.UserRoleEditor, "planFileRenames", true},
+		{models.UserRoleEditor, "trashedFiles", true},
+		{models.UserRoleEditor, "configuration", false},
+		{models.UserRoleEditor, "directory", false},
+		{models.UserRoleEditor, "logs", false},
+		{models.UserRoleEditor, "unknownQuery", false},
+		{models.UserRoleViewer, "findScenes", true},
+		{models.UserRoleViewer, "jobQueue", true},
+		{models.UserRoleViewer, "planFileRenames", false},
+		{models.UserRoleViewer, "entityHistory", false},
+		{models.UserRoleViewer, "webhooks", false},
+		{models.UserRoleViewer, "validateStashBoxCredentials", false},
+	}
+
+	for _, tt := range tests {
+		if got := canQuery(tt.role, tt.field); got != tt.want {
+			t.Errorf("canQuery(%s, %s) = %v, want %v", tt.role, tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestCanSubscribe(t *testing.T) {
+	tests := []struct {
+		role  models.UserRole
+		field string
+		want  bool
+	}{
+		{models.UserRoleAdmin, "loggingSubscribe", true},
+		{models.UserRoleEditor, "jobsSubscribe", true},
+		{models.UserRoleEditor, "loggingSubscribe", false},
+		{models.UserRoleViewer, "scanCompleteSubscribe", true},
+		{models.UserRoleViewer, "loggingSubscribe", false},
+	}
+
+	for _, tt := range tests {
+		if got := canSubscribe(tt.role, tt.field); got != tt.want {
+			t.Errorf("canSubscribe(%s, %s) = %v, want %v", tt.role, tt.field, got, tt.want)
+		}
+	}
+}
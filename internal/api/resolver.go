@@ -29,6 +29,7 @@ var (
 )
 
 type hookExecutor interface {
+	ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (map[string]interface{}, error)
 	ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
 }
 
@@ -105,6 +106,9 @@ func (r *Resolver) Plugin() PluginResolver {
 func (r *Resolver) ConfigResult() ConfigResultResolver {
 	return &configResultResolver{r}
 }
+func (r *Resolver) FieldChange() FieldChangeResolver {
+	return &fieldChangeResolver{r}
+}
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -129,6 +133,7 @@ type imageFileResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
+type fieldChangeResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
 	return r.repository.WithTxn(ctx, fn)
@@ -138,6 +143,56 @@ func (r *Resolver) withReadTxn(ctx context.Context, fn func(ctx context.Context)
 	return r.repository.WithReadTxn(ctx, fn)
 }
 
+// executePreHooks executes the pre hooks for an operation. input must be a
+// pointer to the operation input, which may be modified by plugins. Fields
+// set by plugins are added to translator, if provided, so that they are
+// applied by the operation.
+func (r *Resolver) executePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, translator *changesetTranslator) error {
+	var inputFields []string
+	if translator != nil {
+		inputFields = translator.getFields()
+	}
+
+	changed, err := r.hookExecutor.ExecutePreHooks(ctx, id, hookType, input, inputFields)
+	if err != nil {
+		return err
+	}
+
+	if translator != nil && len(changed) > 0 {
+		if translator.inputMap == nil {
+			translator.inputMap = make(map[string]interface{})
+		}
+		for k, v := range changed {
+			translator.inputMap[k] = v
+		}
+	}
+
+	return nil
+}
+
+// executeMergePreHooks executes the pre hooks for a merge operation. input
+// must be a pointer to the merge input, which may be modified by plugins.
+// Fields of values set by plugins are added to valuesTranslator, so that they
+// are applied to the destination.
+func (r *Resolver) executeMergePreHooks(ctx context.Context, destinationID int, hookType hook.TriggerEnum, input interface{}, valuesTranslator *changesetTranslator) error {
+	changed, err := r.hookExecutor.ExecutePreHooks(ctx, destinationID, hookType, input, nil)
+	if err != nil {
+		return err
+	}
+
+	values, _ := changed["values"].(map[string]interface{})
+	if valuesTranslator != nil && len(values) > 0 {
+		if valuesTranslator.inputMap == nil {
+			valuesTranslator.inputMap = make(map[string]interface{})
+		}
+		for k, v := range values {
+			valuesTranslator.inputMap[k] = v
+		}
+	}
+
+	return nil
+}
+
 func (r *Resolver) stashboxRepository() stashbox.Repository {
 	return stashbox.NewRepository(r.repository)
 }
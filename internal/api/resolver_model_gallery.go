@@ -216,3 +216,16 @@ func (r *galleryResolver) Image(ctx context.Context, obj *models.Gallery, index
 
 	return
 }
+
+func (r *galleryResolver) CustomFields(ctx context.Context, obj *models.Gallery) ([]*models.CustomField, error) {
+	var ret []models.CustomField
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Gallery.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return customFieldsSliceToPtrSlice(ret), nil
+}
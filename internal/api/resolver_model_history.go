@@ -0,0 +1,29 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func decodeFieldValue(v json.RawMessage) (interface{}, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	var ret interface{}
+	if err := json.Unmarshal(v, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *fieldChangeResolver) OldValue(ctx context.Context, obj *models.FieldChange) (interface{}, error) {
+	return decodeFieldValue(obj.OldValue)
+}
+
+func (r *fieldChangeResolver) NewValue(ctx context.Context, obj *models.FieldChange) (interface{}, error) {
+	return decodeFieldValue(obj.NewValue)
+}
@@ -192,3 +192,16 @@ func (r *groupResolver) Scenes(ctx context.Context, obj *models.Group) (ret []*m
 
 	return ret, nil
 }
+
+func (r *groupResolver) CustomFields(ctx context.Context, obj *models.Group) ([]*models.CustomField, error) {
+	var ret []models.CustomField
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Group.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return customFieldsSliceToPtrSlice(ret), nil
+}
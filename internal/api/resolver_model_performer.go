@@ -272,3 +272,16 @@ func (r *performerResolver) Groups(ctx context.Context, obj *models.Performer) (
 func (r *performerResolver) Movies(ctx context.Context, obj *models.Performer) (ret []*models.Group, err error) {
 	return r.Groups(ctx, obj)
 }
+
+func (r *performerResolver) CustomFields(ctx context.Context, obj *models.Performer) ([]*models.CustomField, error) {
+	var ret []models.CustomField
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Performer.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return customFieldsSliceToPtrSlice(ret), nil
+}
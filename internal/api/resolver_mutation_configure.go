@@ -12,6 +12,7 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file/rename"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -84,6 +85,8 @@ func (r *mutationResolver) setConfigFloat(key string, value *float64) {
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
+	refreshWatcher := false
+
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
@@ -101,8 +104,15 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 					return makeConfigGeneralResult(), err
 				}
 			}
+
+			if s.RenameTemplate != nil && *s.RenameTemplate != "" {
+				if _, err := rename.ParseTemplate(*s.RenameTemplate); err != nil {
+					return makeConfigGeneralResult(), fmt.Errorf("invalid rename template for %s: %w", s.Path, err)
+				}
+			}
 		}
 		c.SetInterface(config.Stash, input.Stashes)
+		refreshWatcher = true
 	}
 
 	checkConfigOverride := func(key string) error {
@@ -149,6 +159,15 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.BackupDirectoryPath, *input.BackupDirectoryPath)
 	}
 
+	existingTrashPath := c.GetTrashPath()
+	if input.TrashPath != nil && existingTrashPath != *input.TrashPath {
+		if err := validateDir(config.TrashPath, *input.TrashPath, true); err != nil {
+			return makeConfigGeneralResult(), err
+		}
+
+		c.SetString(config.TrashPath, *input.TrashPath)
+	}
+
 	existingGeneratedPath := c.GetGeneratedPath()
 	if input.GeneratedPath != nil && existingGeneratedPath != *input.GeneratedPath {
 		if err := validateDir(config.Generated, *input.GeneratedPath, false); err != nil {
@@ -299,6 +318,14 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	if input.Username != nil && *input.Username != c.GetUsername() {
+		mgr := manager.GetInstance()
+		if *input.Username == "" && mgr.HasUsers() {
+			return makeConfigGeneralResult(), errors.New("username cannot be cleared while users exist")
+		}
+		if mgr.FindUser(*input.Username) != nil {
+			return makeConfigGeneralResult(), fmt.Errorf("username %q is already in use", *input.Username)
+		}
+
 		c.SetString(config.Username, *input.Username)
 		if *input.Password == "" {
 			logger.Info("Username cleared")
@@ -313,6 +340,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		currentPWHash := c.GetPasswordHash()
 
 		if *input.Password != currentPWHash {
+			if *input.Password == "" && manager.GetInstance().HasUsers() {
+				return makeConfigGeneralResult(), errors.New("password cannot be cleared while users exist")
+			}
+
 			if *input.Password == "" {
 				logger.Info("Password cleared")
 			} else {
@@ -327,6 +358,24 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	r.setConfigBool(config.LogOut, input.LogOut)
 	r.setConfigBool(config.LogAccess, input.LogAccess)
 
+	if input.JobHistoryRetention != nil {
+		if *input.JobHistoryRetention < 0 {
+			return makeConfigGeneralResult(), errors.New("jobHistoryRetention must not be negative")
+		}
+		c.SetInt(config.JobHistoryRetention, *input.JobHistoryRetention)
+	}
+
+	if input.TrashRetention != nil {
+		if *input.TrashRetention < 0 {
+			return makeConfigGeneralResult(), errors.New("trashRetention must not be negative")
+		}
+		c.SetInt(config.TrashRetention, *input.TrashRetention)
+	}
+
+	if input.SceneFileRanking != nil {
+		c.SetInterface(config.SceneFileRanking, input.SceneFileRanking)
+	}
+
 	if input.LogLevel != nil && *input.LogLevel != c.GetLogLevel() {
 		c.SetString(config.LogLevel, *input.LogLevel)
 		logger := manager.GetInstance().Logger
@@ -341,6 +390,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 			}
 		}
 		c.SetInterface(config.Exclude, input.Excludes)
+		refreshWatcher = true
 	}
 
 	if input.ImageExcludes != nil {
@@ -351,12 +401,17 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 			}
 		}
 		c.SetInterface(config.ImageExclude, input.ImageExcludes)
+		refreshWatcher = true
 	}
 
 	if input.VideoExtensions != nil {
 		c.SetInterface(config.VideoExtensions, input.VideoExtensions)
 	}
 
+	if input.AudioExtensions != nil {
+		c.SetInterface(config.AudioExtensions, input.AudioExtensions)
+	}
+
 	if input.ImageExtensions != nil {
 		c.SetInterface(config.ImageExtensions, input.ImageExtensions)
 	}
@@ -367,6 +422,19 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
 
+	if input.WatchStashes != nil {
+		c.SetBool(config.WatchStashes, *input.WatchStashes)
+		refreshWatcher = true
+	}
+
+	if input.WatchDebounce != nil {
+		if *input.WatchDebounce <= 0 {
+			return makeConfigGeneralResult(), errors.New("watchDebounce must be greater than zero")
+		}
+		c.SetInt(config.WatchDebounce, *input.WatchDebounce)
+		refreshWatcher = true
+	}
+
 	if input.CustomPerformerImageLocation != nil {
 		c.SetString(config.CustomPerformerImageLocation, *input.CustomPerformerImageLocation)
 		initCustomPerformerImages(*input.CustomPerformerImageLocation)
@@ -437,6 +505,9 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	if refreshPluginSource {
 		manager.GetInstance().RefreshPluginSourceManager()
 	}
+	if refreshWatcher {
+		manager.GetInstance().RefreshWatcher()
+	}
 
 	return makeConfigGeneralResult(), nil
 }
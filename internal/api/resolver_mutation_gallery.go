@@ -14,6 +14,7 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -31,15 +32,19 @@ func (r *mutationResolver) getGallery(ctx context.Context, id int) (ret *models.
 }
 
 func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreateInput) (*models.Gallery, error) {
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, 0, hook.GalleryCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// name must be provided
 	if input.Title == "" {
 		return nil, errors.New("title must not be empty")
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
 	// Populate a new gallery from the input
 	newGallery := models.NewGallery()
 
@@ -86,6 +91,14 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newGallery.ID, models.CustomFieldsInput{
+				Full: sliceutil.PtrsToValues(input.CustomFields),
+			}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -96,10 +109,19 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 }
 
 func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.GalleryUpdateInput) (ret *models.Gallery, err error) {
+	galleryID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
 	translator := changesetTranslator{
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, galleryID, hook.GalleryUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the gallery
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.galleryUpdate(ctx, input, translator)
@@ -115,15 +137,27 @@ func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.Galle
 
 func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.GalleryUpdateInput) (ret []*models.Gallery, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
+	translators := make([]changesetTranslator, len(input))
+
+	for i, gallery := range input {
+		galleryID, err := strconv.Atoi(gallery.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		translators[i] = changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		if err := r.executePreHooks(ctx, galleryID, hook.GalleryUpdatePre, gallery, &translators[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	// Start the transaction and save the galleries
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, gallery := range input {
-			translator := changesetTranslator{
-				inputMap: inputMaps[i],
-			}
-
-			thisGallery, err := r.galleryUpdate(ctx, *gallery, translator)
+			thisGallery, err := r.galleryUpdate(ctx, *gallery, translators[i])
 			if err != nil {
 				return err
 			}
@@ -139,11 +173,7 @@ func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.
 	// execute post hooks outside txn
 	var newRet []*models.Gallery
 	for i, gallery := range ret {
-		translator := changesetTranslator{
-			inputMap: inputMaps[i],
-		}
-
-		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, hook.GalleryUpdatePost, input, translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, hook.GalleryUpdatePost, input, translators[i].getFields())
 
 		gallery, err = r.getGallery(ctx, gallery.ID)
 		if err != nil {
@@ -156,6 +186,45 @@ func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.
 	return newRet, nil
 }
 
+// galleryPartialFromInput returns a gallery partial populated from the input.
+// The title and primary file are not populated, and must be handled by the caller.
+func galleryPartialFromInput(input models.GalleryUpdateInput, translator changesetTranslator) (*models.GalleryPartial, error) {
+	updatedGallery := models.NewGalleryPartial()
+
+	updatedGallery.Code = translator.optionalString(input.Code, "code")
+	updatedGallery.Details = translator.optionalString(input.Details, "details")
+	updatedGallery.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedGallery.Rating = translator.optionalInt(input.Rating100, "rating100")
+	updatedGallery.Organized = translator.optionalBool(input.Organized, "organized")
+
+	var err error
+	updatedGallery.Date, err = translator.optionalDate(input.Date, "date")
+	if err != nil {
+		return nil, fmt.Errorf("converting date: %w", err)
+	}
+	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting studio id: %w", err)
+	}
+
+	updatedGallery.URLs = translator.optionalURLs(input.Urls, input.URL)
+
+	updatedGallery.PerformerIDs, err = translator.updateIds(input.PerformerIds, "performer_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting performer ids: %w", err)
+	}
+	updatedGallery.TagIDs, err = translator.updateIds(input.TagIds, "tag_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+	updatedGallery.SceneIDs, err = translator.updateIds(input.SceneIds, "scene_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	return &updatedGallery, nil
+}
+
 func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.GalleryUpdateInput, translator changesetTranslator) (*models.Gallery, error) {
 	galleryID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -174,7 +243,10 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 	}
 
 	// Populate gallery from the input
-	updatedGallery := models.NewGalleryPartial()
+	updatedGallery, err := galleryPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
 
 	if input.Title != nil {
 		// ensure title is not empty
@@ -185,23 +257,6 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		updatedGallery.Title = models.NewOptionalString(*input.Title)
 	}
 
-	updatedGallery.Code = translator.optionalString(input.Code, "code")
-	updatedGallery.Details = translator.optionalString(input.Details, "details")
-	updatedGallery.Photographer = translator.optionalString(input.Photographer, "photographer")
-	updatedGallery.Rating = translator.optionalInt(input.Rating100, "rating100")
-	updatedGallery.Organized = translator.optionalBool(input.Organized, "organized")
-
-	updatedGallery.Date, err = translator.optionalDate(input.Date, "date")
-	if err != nil {
-		return nil, fmt.Errorf("converting date: %w", err)
-	}
-	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
-	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
-	}
-
-	updatedGallery.URLs = translator.optionalURLs(input.Urls, input.URL)
-
 	updatedGallery.PrimaryFileID, err = translator.fileIDPtrFromString(input.PrimaryFileID)
 	if err != nil {
 		return nil, fmt.Errorf("converting primary file id: %w", err)
@@ -226,26 +281,19 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		}
 	}
 
-	updatedGallery.PerformerIDs, err = translator.updateIds(input.PerformerIds, "performer_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting performer ids: %w", err)
-	}
-	updatedGallery.TagIDs, err = translator.updateIds(input.TagIds, "tag_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
-	}
-	updatedGallery.SceneIDs, err = translator.updateIds(input.SceneIds, "scene_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting scene ids: %w", err)
-	}
-
 	// gallery scene is set from the scene only
 
-	gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
+	gallery, err := qb.UpdatePartial(ctx, galleryID, *updatedGallery)
 	if err != nil {
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	return gallery, nil
 }
 
@@ -259,6 +307,13 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// fields set by plugins apply to all galleries in the operation
+	for _, galleryID := range galleryIDs {
+		if err := r.executePreHooks(ctx, galleryID, hook.GalleryUpdatePre, &input, &translator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate gallery from the input
 	updatedGallery := models.NewGalleryPartial()
 
@@ -303,6 +358,12 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, gallery)
 		}
 
@@ -333,10 +394,16 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, id := range galleryIDs {
+		if err := r.executePreHooks(ctx, id, hook.GalleryDestroyPre, &input, nil); err != nil {
+			return false, err
+		}
+	}
+
 	var galleries []*models.Gallery
 	var imgsDestroyed []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 
@@ -407,6 +474,59 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 	return true, nil
 }
 
+func (r *mutationResolver) GalleriesMerge(ctx context.Context, input GalleriesMergeInput) (*models.Gallery, error) {
+	destID, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, "input.values"),
+	}
+
+	if err := r.executeMergePreHooks(ctx, destID, hook.GalleryMergePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	var values *models.GalleryPartial
+
+	if input.Values != nil {
+		values, err = galleryPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		values.Title = translator.optionalString(input.Values.Title, "title")
+	} else {
+		v := models.NewGalleryPartial()
+		values = &v
+	}
+
+	fileDeleter := &image.FileDeleter{
+		Deleter: file.NewDeleter(),
+		Paths:   manager.GetInstance().Paths,
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.galleryService.Merge(ctx, srcIDs, destID, fileDeleter, *values)
+	}); err != nil {
+		fileDeleter.Rollback()
+		return nil, err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.GalleryMergePost, input, nil)
+
+	return r.getGallery(ctx, destID)
+}
+
 func isStashPath(path string) bool {
 	stashConfigs := manager.GetInstance().Config.GetStashPaths()
 	for _, config := range stashConfigs {
@@ -545,6 +665,10 @@ func (r *mutationResolver) getGalleryChapter(ctx context.Context, id int) (ret *
 }
 
 func (r *mutationResolver) GalleryChapterCreate(ctx context.Context, input GalleryChapterCreateInput) (*models.GalleryChapter, error) {
+	if err := r.executePreHooks(ctx, 0, hook.GalleryChapterCreatePre, &input, nil); err != nil {
+		return nil, err
+	}
+
 	galleryID, err := strconv.Atoi(input.GalleryID)
 	if err != nil {
 		return nil, fmt.Errorf("converting gallery id: %w", err)
@@ -588,6 +712,10 @@ func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input Galle
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, chapterID, hook.GalleryChapterUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate gallery chapter from the input
 	updatedChapter := models.NewGalleryChapterPartial()
 
@@ -650,6 +778,10 @@ func (r *mutationResolver) GalleryChapterDestroy(ctx context.Context, id string)
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, chapterID, hook.GalleryChapterDestroyPre, &id, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.GalleryChapter
 
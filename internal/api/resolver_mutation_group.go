@@ -9,6 +9,7 @@ import (
 	"github.com/stashapp/stash/pkg/group"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -62,6 +63,10 @@ func groupFromGroupCreateInput(ctx context.Context, input GroupCreateInput) (*mo
 }
 
 func (r *mutationResolver) GroupCreate(ctx context.Context, input GroupCreateInput) (*models.Group, error) {
+	if err := r.executePreHooks(ctx, 0, hook.GroupCreatePre, &input, nil); err != nil {
+		return nil, err
+	}
+
 	newGroup, err := groupFromGroupCreateInput(ctx, input)
 	if err != nil {
 		return nil, err
@@ -97,6 +102,14 @@ func (r *mutationResolver) GroupCreate(ctx context.Context, input GroupCreateInp
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := r.repository.Group.SetCustomFields(ctx, newGroup.ID, models.CustomFieldsInput{
+				Full: sliceutil.PtrsToValues(input.CustomFields),
+			}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -163,6 +176,10 @@ func (r *mutationResolver) GroupUpdate(ctx context.Context, input GroupUpdateInp
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, groupID, hook.GroupUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	updatedGroup, err := groupPartialFromGroupUpdateInput(translator, input)
 	if err != nil {
 		return nil, err
@@ -202,6 +219,12 @@ func (r *mutationResolver) GroupUpdate(ctx context.Context, input GroupUpdateInp
 			return err
 		}
 
+		if input.CustomFields != nil {
+			if err := r.repository.Group.SetCustomFields(ctx, groupID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -258,6 +281,13 @@ func (r *mutationResolver) BulkGroupUpdate(ctx context.Context, input BulkGroupU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// fields set by plugins apply to all groups in the operation
+	for _, groupID := range groupIDs {
+		if err := r.executePreHooks(ctx, groupID, hook.GroupUpdatePre, &input, &translator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate group from the input
 	updatedGroup, err := groupPartialFromBulkGroupUpdateInput(translator, input)
 	if err != nil {
@@ -273,6 +303,12 @@ func (r *mutationResolver) BulkGroupUpdate(ctx context.Context, input BulkGroupU
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := r.repository.Group.SetCustomFields(ctx, groupID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, group)
 		}
 
@@ -304,6 +340,10 @@ func (r *mutationResolver) GroupDestroy(ctx context.Context, input GroupDestroyI
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.GroupDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Group.Destroy(ctx, id)
 	}); err != nil {
@@ -323,6 +363,12 @@ func (r *mutationResolver) GroupsDestroy(ctx context.Context, groupIDs []string)
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, id := range ids {
+		if err := r.executePreHooks(ctx, id, hook.GroupDestroyPre, &groupIDs, nil); err != nil {
+			return false, err
+		}
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 		for _, id := range ids {
@@ -0,0 +1,51 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/history"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+var historyUpdatePostHooks = map[models.HistoryEntityType]hook.TriggerEnum{
+	models.HistoryEntityTypeScene:     hook.SceneUpdatePost,
+	models.HistoryEntityTypePerformer: hook.PerformerUpdatePost,
+	models.HistoryEntityTypeStudio:    hook.StudioUpdatePost,
+	models.HistoryEntityTypeTag:       hook.TagUpdatePost,
+	models.HistoryEntityTypeGallery:   hook.GalleryUpdatePost,
+}
+
+func (r *mutationResolver) RevertChange(ctx context.Context, id string) (bool, error) {
+	historyID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	var h *models.EntityHistory
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		h, err = r.repository.EntityHistory.Find(ctx, historyID)
+		if err != nil {
+			return err
+		}
+
+		if h == nil {
+			return fmt.Errorf("history with id %d not found", historyID)
+		}
+
+		return history.Revert(ctx, r.repository, h)
+	}); err != nil {
+		return false, err
+	}
+
+	fields := make([]string, len(h.Changes))
+	for i, c := range h.Changes {
+		fields[i] = c.Field
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, h.EntityID, historyUpdatePostHooks[h.EntityType], nil, fields)
+
+	return true, nil
+}
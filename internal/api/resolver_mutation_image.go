@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
-	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
@@ -29,10 +28,19 @@ func (r *mutationResolver) getImage(ctx context.Context, id int) (ret *models.Im
 }
 
 func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInput) (ret *models.Image, err error) {
+	imageID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
 	translator := changesetTranslator{
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, imageID, hook.ImageUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the image
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.imageUpdate(ctx, input, translator)
@@ -48,15 +56,27 @@ func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInp
 
 func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*ImageUpdateInput) (ret []*models.Image, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
+	translators := make([]changesetTranslator, len(input))
+
+	for i, image := range input {
+		imageID, err := strconv.Atoi(image.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		translators[i] = changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		if err := r.executePreHooks(ctx, imageID, hook.ImageUpdatePre, image, &translators[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	// Start the transaction and save the image
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, image := range input {
-			translator := changesetTranslator{
-				inputMap: inputMaps[i],
-			}
-
-			thisImage, err := r.imageUpdate(ctx, *image, translator)
+			thisImage, err := r.imageUpdate(ctx, *image, translators[i])
 			if err != nil {
 				return err
 			}
@@ -72,11 +92,7 @@ func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*ImageUpdat
 	// execute post hooks outside txn
 	var newRet []*models.Image
 	for i, image := range ret {
-		translator := changesetTranslator{
-			inputMap: inputMaps[i],
-		}
-
-		r.hookExecutor.ExecutePostHooks(ctx, image.ID, hook.ImageUpdatePost, input, translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, image.ID, hook.ImageUpdatePost, input, translators[i].getFields())
 
 		image, err = r.getImage(ctx, image.ID)
 		if err != nil {
@@ -203,6 +219,13 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// fields set by plugins apply to all images in the operation
+	for _, imageID := range imageIDs {
+		if err := r.executePreHooks(ctx, imageID, hook.ImageUpdatePre, &input, &translator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate image from the input
 	updatedImage := models.NewImagePartial()
 
@@ -308,9 +331,13 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, imageID, hook.ImageDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	var i *models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -348,9 +375,15 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, imageID := range imageIDs {
+		if err := r.executePreHooks(ctx, imageID, hook.ImageDestroyPre, &input, nil); err != nil {
+			return false, err
+		}
+	}
+
 	var images []*models.Image
 	fileDeleter := &image.FileDeleter{
-		Deleter: file.NewDeleter(),
+		Deleter: manager.GetInstance().NewFileDeleter(),
 		Paths:   manager.GetInstance().Paths,
 	}
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -22,3 +22,33 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) PauseJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+	manager.GetInstance().JobManager.PauseJob(id)
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+	manager.GetInstance().JobManager.ResumeJob(id)
+
+	return true, nil
+}
+
+func (r *mutationResolver) SetJobPriority(ctx context.Context, jobID string, priority int) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+	manager.GetInstance().JobManager.SetPriority(id, priority)
+
+	return true, nil
+}
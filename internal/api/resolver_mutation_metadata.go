@@ -24,8 +24,12 @@ func (r *mutationResolver) MetadataScan(ctx context.Context, input manager.ScanM
 	return strconv.Itoa(jobID), nil
 }
 
-func (r *mutationResolver) MetadataImport(ctx context.Context) (string, error) {
-	jobID, err := manager.GetInstance().Import(ctx)
+func (r *mutationResolver) MetadataImport(ctx context.Context, input *manager.ImportMetadataInput) (string, error) {
+	if input == nil {
+		input = &manager.ImportMetadataInput{}
+	}
+
+	jobID, err := manager.GetInstance().Import(ctx, *input)
 	if err != nil {
 		return "", err
 	}
@@ -44,8 +48,21 @@ func (r *mutationResolver) ImportObjects(ctx context.Context, input manager.Impo
 	return strconv.Itoa(jobID), nil
 }
 
-func (r *mutationResolver) MetadataExport(ctx context.Context) (string, error) {
-	jobID, err := manager.GetInstance().Export(ctx)
+func (r *mutationResolver) ImportObjectsDryRun(ctx context.Context, input manager.ImportObjectsInput) (string, error) {
+	jobID, err := manager.GetInstance().ImportDryRun(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataExport(ctx context.Context, input *manager.ExportMetadataInput) (string, error) {
+	if input == nil {
+		input = &manager.ExportMetadataInput{}
+	}
+
+	jobID, err := manager.GetInstance().Export(ctx, *input)
 	if err != nil {
 		return "", err
 	}
@@ -88,9 +105,7 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 }
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
-	t := manager.CreateIdentifyJob(input)
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
-
+	jobID := manager.GetInstance().Identify(ctx, input)
 	return strconv.Itoa(jobID), nil
 }
 
@@ -99,6 +114,16 @@ func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.Clea
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataRename(ctx context.Context, input manager.RenameMetadataInput) (string, error) {
+	jobID := manager.GetInstance().Rename(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataRankSceneFiles(ctx context.Context, input manager.RankSceneFilesInput) (string, error) {
+	jobID := manager.GetInstance().RankSceneFiles(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input task.CleanGeneratedOptions) (string, error) {
 	mgr := manager.GetInstance()
 	t := &task.CleanGeneratedJob{
@@ -34,6 +34,10 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.PerformerCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new performer from the input
 	newPerformer := models.NewPerformer()
 
@@ -120,6 +124,14 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newPerformer.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -219,16 +231,7 @@ func (r *mutationResolver) handleLegacyURLs(ctx context.Context, performerID int
 	return nil
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
-	performerID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
+func (r *mutationResolver) performerPartialFromInput(input models.PerformerUpdateInput, translator changesetTranslator) (*models.PerformerPartial, error) {
 	// Populate performer from the input
 	updatedPerformer := models.NewPerformerPartial()
 
@@ -262,10 +265,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		updatedPerformer.URLs = translator.updateStrings(input.Urls, "urls")
 	}
 
-	legacyURL := translator.optionalString(input.URL, "url")
-	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
-	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
-
+	var err error
 	updatedPerformer.Birthdate, err = translator.optionalDate(input.Birthdate, "birthdate")
 	if err != nil {
 		return nil, fmt.Errorf("converting birthdate: %w", err)
@@ -290,6 +290,32 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	return &updatedPerformer, nil
+}
+
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
+	performerID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, performerID, hook.PerformerUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	updatedPerformer, err := r.performerPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyURL := translator.optionalString(input.URL, "url")
+	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
+	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
+
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
 	if input.Image != nil {
@@ -304,16 +330,16 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		qb := r.repository.Performer
 
 		if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
-			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
+			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, updatedPerformer); err != nil {
 				return err
 			}
 		}
 
-		if err := performer.ValidateUpdate(ctx, performerID, updatedPerformer, qb); err != nil {
+		if err := performer.ValidateUpdate(ctx, performerID, *updatedPerformer, qb); err != nil {
 			return err
 		}
 
-		_, err = qb.UpdatePartial(ctx, performerID, updatedPerformer)
+		_, err = qb.UpdatePartial(ctx, performerID, *updatedPerformer)
 		if err != nil {
 			return err
 		}
@@ -325,6 +351,12 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -344,6 +376,13 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// fields set by plugins apply to all performers in the operation
+	for _, performerID := range performerIDs {
+		if err := r.executePreHooks(ctx, performerID, hook.PerformerUpdatePre, &input, &translator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate performer from the input
 	updatedPerformer := models.NewPerformerPartial()
 
@@ -427,6 +466,12 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, performer)
 		}
 
@@ -457,6 +502,10 @@ func (r *mutationResolver) PerformerDestroy(ctx context.Context, input Performer
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.PerformerDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Performer.Destroy(ctx, id)
 	}); err != nil {
@@ -474,6 +523,12 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, id := range ids {
+		if err := r.executePreHooks(ctx, id, hook.PerformerDestroyPre, &performerIDs, nil); err != nil {
+			return false, err
+		}
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
 		for _, id := range ids {
@@ -493,3 +548,87 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+// performersMergeInput is the input of the performersMerge mutation, as
+// passed to plugin hooks.
+type performersMergeInput struct {
+	Source      []string                     `json:"source"`
+	Destination string                       `json:"destination"`
+	Values      *models.PerformerUpdateInput `json:"values"`
+}
+
+func (r *mutationResolver) PerformersMerge(ctx context.Context, source []string, destination string, values *models.PerformerUpdateInput) (*models.Performer, error) {
+	destID, err := strconv.Atoi(destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	input := performersMergeInput{
+		Source:      source,
+		Destination: destination,
+		Values:      values,
+	}
+
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, "values"),
+	}
+
+	if err := r.executeMergePreHooks(ctx, destID, hook.PerformerMergePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	var partial *models.PerformerPartial
+	var imageData []byte
+	imageIncluded := false
+
+	if input.Values != nil {
+		// legacy url fields are not supported when merging
+		if translator.hasField("url") || translator.hasField("twitter") || translator.hasField("instagram") {
+			return nil, fmt.Errorf("url, twitter and instagram fields are not supported when merging, use urls instead")
+		}
+
+		partial, err = r.performerPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		imageIncluded = translator.hasField("image")
+		if input.Values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *input.Values.Image)
+			if err != nil {
+				return nil, fmt.Errorf("processing image: %w", err)
+			}
+		}
+	} else {
+		v := models.NewPerformerPartial()
+		partial = &v
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		if err := performer.Merge(ctx, srcIDs, destID, *partial, qb); err != nil {
+			return err
+		}
+
+		// update image table
+		if imageIncluded {
+			if err := qb.UpdateImage(ctx, destID, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.PerformerMergePost, input, nil)
+
+	return r.getPerformer(ctx, destID)
+}
@@ -38,10 +38,24 @@ func (r *mutationResolver) SaveFilter(ctx context.Context, input SaveFilterInput
 			UIOptions:    input.UIOptions,
 		}
 
+		if input.DlnaEnabled != nil {
+			f.DLNAEnabled = *input.DlnaEnabled
+		}
+
 		if id == nil {
 			err = qb.Create(ctx, &f)
 			ret = &f
 		} else {
+			if input.DlnaEnabled == nil {
+				existing, err := qb.Find(ctx, *id)
+				if err != nil {
+					return err
+				}
+				if existing != nil {
+					f.DLNAEnabled = existing.DLNAEnabled
+				}
+			}
+
 			f.ID = *id
 			err = qb.Update(ctx, &f)
 			ret = &f
@@ -36,6 +36,10 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.SceneCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	fileIDs, err := translator.fileIDSliceFromStringSlice(input.FileIds)
 	if err != nil {
 		return nil, fmt.Errorf("converting file ids: %w", err)
@@ -104,7 +108,19 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, fileIDs, coverImageData)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if len(input.CustomFields) > 0 {
+			if err := r.repository.Scene.SetCustomFields(ctx, ret.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -113,10 +129,19 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 }
 
 func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUpdateInput) (ret *models.Scene, err error) {
+	sceneID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
 	translator := changesetTranslator{
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, sceneID, hook.SceneUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.sceneUpdate(ctx, input, translator)
@@ -131,15 +156,27 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 
 func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.SceneUpdateInput) (ret []*models.Scene, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
+	translators := make([]changesetTranslator, len(input))
+
+	for i, scene := range input {
+		sceneID, err := strconv.Atoi(scene.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		translators[i] = changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		if err := r.executePreHooks(ctx, sceneID, hook.SceneUpdatePre, scene, &translators[i]); err != nil {
+			return nil, err
+		}
+	}
 
 	// Start the transaction and save the scenes
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, scene := range input {
-			translator := changesetTranslator{
-				inputMap: inputMaps[i],
-			}
-
-			thisScene, err := r.sceneUpdate(ctx, *scene, translator)
+			thisScene, err := r.sceneUpdate(ctx, *scene, translators[i])
 			if err != nil {
 				return err
 			}
@@ -155,11 +192,7 @@ func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.Sce
 	// execute post hooks outside of txn
 	var newRet []*models.Scene
 	for i, scene := range ret {
-		translator := changesetTranslator{
-			inputMap: inputMaps[i],
-		}
-
-		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, hook.SceneUpdatePost, input, translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, hook.SceneUpdatePost, input, translators[i].getFields())
 
 		scene, err = r.getScene(ctx, scene.ID)
 		if err != nil {
@@ -313,6 +346,12 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	return scene, nil
 }
 
@@ -339,6 +378,13 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// fields set by plugins apply to all scenes in the operation
+	for _, sceneID := range sceneIDs {
+		if err := r.executePreHooks(ctx, sceneID, hook.SceneUpdatePre, &input, &translator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate scene from the input
 	updatedScene := models.NewScenePartial()
 
@@ -397,6 +443,12 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, scene)
 		}
 
@@ -427,11 +479,15 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, sceneID, hook.SceneDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	var s *models.Scene
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -480,11 +536,17 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, id := range sceneIDs {
+		if err := r.executePreHooks(ctx, id, hook.SceneDestroyPre, &input, nil); err != nil {
+			return false, err
+		}
+	}
+
 	var scenes []*models.Scene
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
-		Deleter:        file.NewDeleter(),
+		Deleter:        manager.GetInstance().NewFileDeleter(),
 		FileNamingAlgo: fileNamingAlgo,
 		Paths:          manager.GetInstance().Paths,
 	}
@@ -637,6 +699,10 @@ func (r *mutationResolver) getSceneMarker(ctx context.Context, id int) (ret *mod
 }
 
 func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMarkerCreateInput) (*models.SceneMarker, error) {
+	if err := r.executePreHooks(ctx, 0, hook.SceneMarkerCreatePre, &input, nil); err != nil {
+		return nil, err
+	}
+
 	sceneID, err := strconv.Atoi(input.SceneID)
 	if err != nil {
 		return nil, fmt.Errorf("converting scene id: %w", err)
@@ -652,9 +718,14 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 
 	newMarker.Title = input.Title
 	newMarker.Seconds = input.Seconds
+	newMarker.EndSeconds = input.EndSeconds
 	newMarker.PrimaryTagID = primaryTagID
 	newMarker.SceneID = sceneID
 
+	if err := scene.ValidateMarkerEndSeconds(newMarker.Seconds, newMarker.EndSeconds); err != nil {
+		return nil, err
+	}
+
 	tagIDs, err := stringslice.StringSliceToIntSlice(input.TagIds)
 	if err != nil {
 		return nil, fmt.Errorf("converting tag ids: %w", err)
@@ -690,11 +761,16 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, markerID, hook.SceneMarkerUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate scene marker from the input
 	updatedMarker := models.NewSceneMarkerPartial()
 
 	updatedMarker.Title = translator.optionalString(input.Title, "title")
 	updatedMarker.Seconds = translator.optionalFloat64(input.Seconds, "seconds")
+	updatedMarker.EndSeconds = translator.optionalFloat64(input.EndSeconds, "end_seconds")
 	updatedMarker.SceneID, err = translator.optionalIntFromString(input.SceneID, "scene_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting scene id: %w", err)
@@ -735,6 +811,10 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			return fmt.Errorf("scene marker with id %d not found", markerID)
 		}
 
+		if err := scene.ValidateUpdateMarkerEndSeconds(*existingMarker, updatedMarker.Seconds, updatedMarker.EndSeconds); err != nil {
+			return err
+		}
+
 		newMarker, err := qb.UpdatePartial(ctx, markerID, updatedMarker)
 		if err != nil {
 			return err
@@ -748,8 +828,11 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 			return fmt.Errorf("scene with id %d not found", existingMarker.SceneID)
 		}
 
-		// remove the marker preview if the scene changed or if the timestamp was changed
-		if existingMarker.SceneID != newMarker.SceneID || existingMarker.Seconds != newMarker.Seconds {
+		oldEnd, newEnd := existingMarker.EndSeconds, newMarker.EndSeconds
+		endChanged := (oldEnd == nil) != (newEnd == nil) || (oldEnd != nil && *oldEnd != *newEnd)
+
+		// remove the marker preview if the scene changed or if the start or end time was changed
+		if existingMarker.SceneID != newMarker.SceneID || existingMarker.Seconds != newMarker.Seconds || endChanged {
 			seconds := int(existingMarker.Seconds)
 			if err := fileDeleter.MarkMarkerFiles(existingScene, seconds); err != nil {
 				return err
@@ -784,6 +867,10 @@ func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (b
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, markerID, hook.SceneMarkerDestroyPre, &id, nil); err != nil {
+		return false, err
+	}
+
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
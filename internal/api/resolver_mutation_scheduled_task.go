@@ -0,0 +1,227 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func findScheduledTask(tasks []*config.ScheduledTask, id string) (int, *config.ScheduledTask) {
+	for i, t := range tasks {
+		if t.ID == id {
+			return i, t
+		}
+	}
+
+	return -1, nil
+}
+
+func nextScheduledTaskID(tasks []*config.ScheduledTask) string {
+	maxID := 0
+	for _, t := range tasks {
+		if id, err := strconv.Atoi(t.ID); err == nil && id > maxID {
+			maxID = id
+		}
+	}
+
+	return strconv.Itoa(maxID + 1)
+}
+
+func scanOptionsFromInput(input *manager.ScanMetadataInput) *config.ScanMetadataOptions {
+	if input == nil {
+		return nil
+	}
+
+	ret := input.ScanMetadataOptions
+	return &ret
+}
+
+func generateOptionsFromInput(input *manager.GenerateMetadataInput) *models.GenerateMetadataOptions {
+	if input == nil {
+		return nil
+	}
+
+	ret := &models.GenerateMetadataOptions{
+		Covers:                    input.Covers,
+		Sprites:                   input.Sprites,
+		Previews:                  input.Previews,
+		ImagePreviews:             input.ImagePreviews,
+		Markers:                   input.Markers,
+		MarkerImagePreviews:       input.MarkerImagePreviews,
+		MarkerScreenshots:         input.MarkerScreenshots,
+		Transcodes:                input.Transcodes,
+		Phashes:                   input.Phashes,
+		InteractiveHeatmapsSpeeds: input.InteractiveHeatmapsSpeeds,
+		ImageThumbnails:           input.ImageThumbnails,
+		ClipPreviews:              input.ClipPreviews,
+		ImagePhashes:              input.ImagePhashes,
+	}
+
+	if input.PreviewOptions != nil {
+		ret.PreviewOptions = &models.GeneratePreviewOptions{
+			PreviewSegments:        input.PreviewOptions.PreviewSegments,
+			PreviewSegmentDuration: input.PreviewOptions.PreviewSegmentDuration,
+			PreviewExcludeStart:    input.PreviewOptions.PreviewExcludeStart,
+			PreviewExcludeEnd:      input.PreviewOptions.PreviewExcludeEnd,
+			PreviewPreset:          input.PreviewOptions.PreviewPreset,
+		}
+	}
+
+	return ret
+}
+
+func autoTagOptionsFromInput(input *manager.AutoTagMetadataInput) *config.AutoTagMetadataOptions {
+	if input == nil {
+		return nil
+	}
+
+	return &config.AutoTagMetadataOptions{
+		Performers: input.Performers,
+		Studios:    input.Studios,
+		Tags:       input.Tags,
+	}
+}
+
+func (r *mutationResolver) saveScheduledTasks(tasks []*config.ScheduledTask) error {
+	c := config.GetInstance()
+	c.SetInterface(config.ScheduledTasks, tasks)
+	if err := c.Write(); err != nil {
+		return err
+	}
+
+	manager.GetInstance().RefreshScheduler()
+	return nil
+}
+
+func (r *mutationResolver) ScheduledTaskCreate(ctx context.Context, input ScheduledTaskInput) (*ScheduledTask, error) {
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	var translator changesetTranslator
+
+	t := &config.ScheduledTask{
+		ID:         nextScheduledTaskID(tasks),
+		Name:       strings.TrimSpace(input.Name),
+		Cron:       strings.TrimSpace(input.Cron),
+		Enabled:    input.Enabled == nil || *input.Enabled,
+		Task:       input.Task,
+		Paths:      input.Paths,
+		Scan:       scanOptionsFromInput(input.Scan),
+		Generate:   generateOptionsFromInput(input.Generate),
+		Identify:   input.Identify,
+		AutoTag:    autoTagOptionsFromInput(input.AutoTag),
+		PluginID:   translator.string(input.PluginID),
+		PluginTask: translator.string(input.PluginTask),
+		PluginArgs: input.PluginArgs,
+	}
+
+	if err := manager.ValidateScheduledTask(t); err != nil {
+		return nil, err
+	}
+
+	tasks = append(tasks, t)
+	if err := r.saveScheduledTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return scheduledTaskToModel(t), nil
+}
+
+func (r *mutationResolver) ScheduledTaskUpdate(ctx context.Context, input ScheduledTaskUpdateInput) (*ScheduledTask, error) {
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	i, existing := findScheduledTask(tasks, input.ID)
+	if existing == nil {
+		return nil, fmt.Errorf("scheduled task with id %s not found", input.ID)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	t := *existing
+
+	if input.Name != nil {
+		t.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Cron != nil {
+		t.Cron = strings.TrimSpace(*input.Cron)
+	}
+	if input.Enabled != nil {
+		t.Enabled = *input.Enabled
+	}
+	if input.Task != nil {
+		t.Task = *input.Task
+	}
+
+	// nullable fields are cleared if explicitly set to null
+	if translator.hasField("paths") {
+		t.Paths = input.Paths
+	}
+	if translator.hasField("scan") {
+		t.Scan = scanOptionsFromInput(input.Scan)
+	}
+	if translator.hasField("generate") {
+		t.Generate = generateOptionsFromInput(input.Generate)
+	}
+	if translator.hasField("identify") {
+		t.Identify = input.Identify
+	}
+	if translator.hasField("autoTag") {
+		t.AutoTag = autoTagOptionsFromInput(input.AutoTag)
+	}
+	if translator.hasField("pluginId") {
+		t.PluginID = translator.string(input.PluginID)
+	}
+	if translator.hasField("pluginTask") {
+		t.PluginTask = translator.string(input.PluginTask)
+	}
+	if translator.hasField("pluginArgs") {
+		t.PluginArgs = input.PluginArgs
+	}
+
+	if err := manager.ValidateScheduledTask(&t); err != nil {
+		return nil, err
+	}
+
+	tasks[i] = &t
+	if err := r.saveScheduledTasks(tasks); err != nil {
+		return nil, err
+	}
+
+	return scheduledTaskToModel(&t), nil
+}
+
+func (r *mutationResolver) ScheduledTaskDestroy(ctx context.Context, input ScheduledTaskDestroyInput) (bool, error) {
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	i, existing := findScheduledTask(tasks, input.ID)
+	if existing == nil {
+		return false, fmt.Errorf("scheduled task with id %s not found", input.ID)
+	}
+
+	tasks = append(tasks[:i], tasks[i+1:]...)
+	if err := r.saveScheduledTasks(tasks); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) RunScheduledTask(ctx context.Context, id string) (string, error) {
+	_, t := findScheduledTask(config.GetInstance().GetScheduledTasks(), id)
+	if t == nil {
+		return "", fmt.Errorf("scheduled task with id %s not found", id)
+	}
+
+	jobID, err := manager.GetInstance().RunScheduledTask(ctx, t)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
@@ -39,6 +39,16 @@ func (r *mutationResolver) StashBoxBatchStudioTag(ctx context.Context, input man
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) StashBoxBatchTagTag(ctx context.Context, input manager.StashBoxBatchTagInput) (string, error) {
+	b, err := resolveStashBoxBatchTagInput(input.Endpoint, input.StashBoxEndpoint)
+	if err != nil {
+		return "", err
+	}
+
+	jobID := manager.GetInstance().StashBoxBatchTagTag(ctx, b, input)
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
 	b, err := resolveStashBox(input.StashBoxIndex, input.StashBoxEndpoint)
 	if err != nil {
@@ -29,6 +29,10 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.StudioCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new studio from the input
 	newStudio := models.NewStudio()
 
@@ -82,6 +86,14 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newStudio.ID, models.CustomFieldsInput{
+				Full: input.CustomFields,
+			}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -91,20 +103,10 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	return r.getStudio(ctx, newStudio.ID)
 }
 
-func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
-	studioID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
+func studioPartialFromInput(input models.StudioUpdateInput, translator changesetTranslator) (*models.StudioPartial, error) {
 	// Populate studio from the input
 	updatedStudio := models.NewStudioPartial()
 
-	updatedStudio.ID = studioID
 	updatedStudio.Name = translator.optionalString(input.Name, "name")
 	updatedStudio.URL = translator.optionalString(input.URL, "url")
 	updatedStudio.Details = translator.optionalString(input.Details, "details")
@@ -114,6 +116,7 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 	updatedStudio.Aliases = translator.updateStrings(input.Aliases, "aliases")
 	updatedStudio.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
 
+	var err error
 	updatedStudio.ParentID, err = translator.optionalIntFromString(input.ParentID, "parent_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting parent id: %w", err)
@@ -124,6 +127,30 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	return &updatedStudio, nil
+}
+
+func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
+	studioID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	if err := r.executePreHooks(ctx, studioID, hook.StudioUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	updatedStudio, err := studioPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStudio.ID = studioID
+
 	// Process the base 64 encoded image string
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
@@ -139,11 +166,11 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Studio
 
-		if err := studio.ValidateModify(ctx, updatedStudio, qb); err != nil {
+		if err := studio.ValidateModify(ctx, *updatedStudio, qb); err != nil {
 			return err
 		}
 
-		_, err = qb.UpdatePartial(ctx, updatedStudio)
+		_, err = qb.UpdatePartial(ctx, *updatedStudio)
 		if err != nil {
 			return err
 		}
@@ -154,6 +181,12 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, studioID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -169,6 +202,10 @@ func (r *mutationResolver) StudioDestroy(ctx context.Context, input StudioDestro
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, id, hook.StudioDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Studio.Destroy(ctx, id)
 	}); err != nil {
@@ -186,6 +223,12 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, id := range ids {
+		if err := r.executePreHooks(ctx, id, hook.StudioDestroyPre, &studioIDs, nil); err != nil {
+			return false, err
+		}
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Studio
 		for _, id := range ids {
@@ -205,3 +248,67 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 
 	return true, nil
 }
+
+func (r *mutationResolver) StudiosMerge(ctx context.Context, input StudiosMergeInput) (*models.Studio, error) {
+	destID, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, "input.values"),
+	}
+
+	if err := r.executeMergePreHooks(ctx, destID, hook.StudioMergePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
+	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	var values *models.StudioPartial
+	var imageData []byte
+	imageIncluded := false
+
+	if input.Values != nil {
+		values, err = studioPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		imageIncluded = translator.hasField("image")
+		if input.Values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *input.Values.Image)
+			if err != nil {
+				return nil, fmt.Errorf("processing image: %w", err)
+			}
+		}
+	} else {
+		v := models.NewStudioPartial()
+		values = &v
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+
+		if err := studio.Merge(ctx, srcIDs, destID, *values, qb); err != nil {
+			return err
+		}
+
+		if imageIncluded {
+			if err := qb.UpdateImage(ctx, destID, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.StudioMergePost, input, nil)
+
+	return r.getStudio(ctx, destID)
+}
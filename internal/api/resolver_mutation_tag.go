@@ -8,6 +8,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/utils"
@@ -29,6 +30,10 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, 0, hook.TagCreatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate a new tag from the input
 	newTag := models.NewTag()
 
@@ -37,6 +42,7 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 	newTag.Favorite = translator.bool(input.Favorite)
 	newTag.Description = translator.string(input.Description)
 	newTag.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
+	newTag.StashIDs = models.NewRelatedStashIDs(stashIDsPtrSliceToSlice(input.StashIds))
 
 	var err error
 
@@ -79,6 +85,14 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newTag.ID, models.CustomFieldsInput{
+				Full: sliceutil.PtrsToValues(input.CustomFields),
+			}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -98,6 +112,10 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	if err := r.executePreHooks(ctx, tagID, hook.TagUpdatePre, &input, &translator); err != nil {
+		return nil, err
+	}
+
 	// Populate tag from the input
 	updatedTag := models.NewTagPartial()
 
@@ -107,6 +125,7 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 	updatedTag.Description = translator.optionalString(input.Description, "description")
 
 	updatedTag.Aliases = translator.updateStrings(input.Aliases, "aliases")
+	updatedTag.StashIDs = translator.updateStashIDs(stashIDsPtrSliceToSlice(input.StashIds), "stash_ids")
 
 	updatedTag.ParentIDs, err = translator.updateIds(input.ParentIds, "parent_ids")
 	if err != nil {
@@ -148,6 +167,12 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, tagID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -167,6 +192,13 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 		inputMap: getUpdateInputMap(ctx),
 	}
 
+	// fields set by plugins apply to all tags in the operation
+	for _, tagID := range tagIDs {
+		if err := r.executePreHooks(ctx, tagID, hook.TagUpdatePre, &input, &translator); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate scene from the input
 	updatedTag := models.NewTagPartial()
 
@@ -202,6 +234,12 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, tagID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, tag)
 		}
 
@@ -232,6 +270,10 @@ func (r *mutationResolver) TagDestroy(ctx context.Context, input TagDestroyInput
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := r.executePreHooks(ctx, tagID, hook.TagDestroyPre, &input, nil); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Tag.Destroy(ctx, tagID)
 	}); err != nil {
@@ -249,6 +291,12 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	for _, id := range ids {
+		if err := r.executePreHooks(ctx, id, hook.TagDestroyPre, &tagIDs, nil); err != nil {
+			return false, err
+		}
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
 		for _, id := range ids {
@@ -270,14 +318,18 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 }
 
 func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput) (*models.Tag, error) {
-	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	destination, err := strconv.Atoi(input.Destination)
 	if err != nil {
-		return nil, fmt.Errorf("converting source ids: %w", err)
+		return nil, fmt.Errorf("converting destination id: %w", err)
 	}
 
-	destination, err := strconv.Atoi(input.Destination)
+	if err := r.executePreHooks(ctx, destination, hook.TagMergePre, &input, nil); err != nil {
+		return nil, err
+	}
+
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
 	if err != nil {
-		return nil, fmt.Errorf("converting destination id: %w", err)
+		return nil, fmt.Errorf("converting source ids: %w", err)
 	}
 
 	if len(source) == 0 {
@@ -35,6 +35,10 @@ var testCtx = context.Background()
 
 type mockHookExecutor struct{}
 
+func (*mockHookExecutor) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (map[string]interface{}, error) {
+	return nil, nil
+}
+
 func (*mockHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
 }
 
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *mutationResolver) RestoreFiles(ctx context.Context, ids []string) (*RestoreFilesResult, error) {
+	trashedIDs, err := stringslice.StringSliceToIntSlice(ids)
+	if err != nil {
+		return nil, fmt.Errorf("converting ids: %w", err)
+	}
+
+	result, err := manager.GetInstance().RestoreTrashedFiles(ctx, trashedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &RestoreFilesResult{
+		Errors: []string{},
+	}
+
+	if result.JobID != 0 {
+		jobID := strconv.Itoa(result.JobID)
+		ret.JobID = &jobID
+	}
+
+	for _, err := range result.Errors {
+		ret.Errors = append(ret.Errors, err.Error())
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) EmptyTrash(ctx context.Context, ids []string) (int, error) {
+	var trashedIDs []int
+	if ids != nil {
+		var err error
+		trashedIDs, err = stringslice.StringSliceToIntSlice(ids)
+		if err != nil {
+			return 0, fmt.Errorf("converting ids: %w", err)
+		}
+	}
+
+	return manager.GetInstance().EmptyTrash(ctx, trashedIDs)
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var errBlankPassword = errors.New("password must not be blank")
+
+func (r *mutationResolver) findUser(ctx context.Context, id int) (*models.User, error) {
+	u, err := r.repository.User.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if u == nil {
+		return nil, fmt.Errorf("user with id %d not found", id)
+	}
+
+	return u, nil
+}
+
+// checkUsernameAvailable returns an error if a user other than the user with
+// the provided id already has the username.
+func (r *mutationResolver) checkUsernameAvailable(ctx context.Context, username string, id int) error {
+	existing, err := r.repository.User.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("username %q is already in use", username)
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) UserCreate(ctx context.Context, input UserCreateInput) (*models.User, error) {
+	if input.Password == "" {
+		return nil, errBlankPassword
+	}
+
+	now := time.Now()
+	u := &models.User{
+		Username:  strings.TrimSpace(input.Username),
+		Role:      input.Role,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	mgr := manager.GetInstance()
+	if err := mgr.ValidateUser(u); err != nil {
+		return nil, err
+	}
+
+	var err error
+	u.PasswordHash, err = manager.HashUserPassword(input.Password)
+	if err != nil {
+		return nil, fmt.Errorf("hashing password: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if err := r.checkUsernameAvailable(ctx, u.Username, 0); err != nil {
+			return err
+		}
+
+		return r.repository.User.Create(ctx, u)
+	}); err != nil {
+		return nil, err
+	}
+
+	mgr.RefreshUsers()
+	return u, nil
+}
+
+func (r *mutationResolver) UserUpdate(ctx context.Context, input UserUpdateInput) (*models.User, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var passwordHash string
+	if input.Password != nil {
+		if *input.Password == "" {
+			return nil, errBlankPassword
+		}
+
+		passwordHash, err = manager.HashUserPassword(*input.Password)
+		if err != nil {
+			return nil, fmt.Errorf("hashing password: %w", err)
+		}
+	}
+
+	mgr := manager.GetInstance()
+
+	var u *models.User
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		u, err = r.findUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if input.Username != nil {
+			u.Username = strings.TrimSpace(*input.Username)
+		}
+		if input.Role != nil {
+			u.Role = *input.Role
+		}
+		if passwordHash != "" {
+			u.PasswordHash = passwordHash
+		}
+		u.UpdatedAt = time.Now()
+
+		if err := mgr.ValidateUser(u); err != nil {
+			return err
+		}
+
+		if err := r.checkUsernameAvailable(ctx, u.Username, u.ID); err != nil {
+			return err
+		}
+
+		return r.repository.User.Update(ctx, u)
+	}); err != nil {
+		return nil, err
+	}
+
+	mgr.RefreshUsers()
+	return u, nil
+}
+
+func (r *mutationResolver) UserDestroy(ctx context.Context, input UserDestroyInput) (bool, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.User.Destroy(ctx, id)
+	}); err != nil {
+		return false, err
+	}
+
+	manager.GetInstance().RefreshUsers()
+	return true, nil
+}
+
+func (r *mutationResolver) UserGenerateAPIKey(ctx context.Context, input UserGenerateAPIKeyInput) (string, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	var newAPIKey string
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		u, err := r.findUser(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if input.Clear == nil || !*input.Clear {
+			newAPIKey, err = manager.GenerateAPIKey(u.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		u.APIKey = newAPIKey
+		u.UpdatedAt = time.Now()
+		return r.repository.User.Update(ctx, u)
+	}); err != nil {
+		return "", err
+	}
+
+	manager.GetInstance().RefreshUsers()
+	return newAPIKey, nil
+}
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/webhook"
+)
+
+func findWebhook(webhooks []*webhook.Webhook, id string) (int, *webhook.Webhook) {
+	for i, w := range webhooks {
+		if w.ID == id {
+			return i, w
+		}
+	}
+
+	return -1, nil
+}
+
+func nextWebhookID(webhooks []*webhook.Webhook) string {
+	maxID := 0
+	for _, w := range webhooks {
+		if id, err := strconv.Atoi(w.ID); err == nil && id > maxID {
+			maxID = id
+		}
+	}
+
+	return strconv.Itoa(maxID + 1)
+}
+
+func webhookTriggersFromInput(input []string) []hook.TriggerEnum {
+	if len(input) == 0 {
+		return nil
+	}
+
+	ret := make([]hook.TriggerEnum, len(input))
+	for i, t := range input {
+		ret[i] = hook.TriggerEnum(strings.TrimSpace(t))
+	}
+
+	return ret
+}
+
+func (r *mutationResolver) saveWebhooks(webhooks []*webhook.Webhook) error {
+	c := config.GetInstance()
+	c.SetInterface(config.Webhooks, webhooks)
+	if err := c.Write(); err != nil {
+		return err
+	}
+
+	manager.GetInstance().RefreshWebhooks()
+	return nil
+}
+
+func (r *mutationResolver) WebhookCreate(ctx context.Context, input WebhookCreateInput) (*Webhook, error) {
+	webhooks := config.GetInstance().GetWebhooks()
+
+	var translator changesetTranslator
+
+	w := &webhook.Webhook{
+		ID:       nextWebhookID(webhooks),
+		Name:     strings.TrimSpace(input.Name),
+		URL:      strings.TrimSpace(input.URL),
+		Enabled:  input.Enabled == nil || *input.Enabled,
+		Triggers: webhookTriggersFromInput(input.Triggers),
+		Secret:   translator.string(input.Secret),
+	}
+
+	if err := manager.ValidateWebhook(w); err != nil {
+		return nil, err
+	}
+
+	webhooks = append(webhooks, w)
+	if err := r.saveWebhooks(webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhookToModel(w), nil
+}
+
+func (r *mutationResolver) WebhookUpdate(ctx context.Context, input WebhookUpdateInput) (*Webhook, error) {
+	webhooks := config.GetInstance().GetWebhooks()
+
+	i, existing := findWebhook(webhooks, input.ID)
+	if existing == nil {
+		return nil, fmt.Errorf("webhook with id %s not found", input.ID)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	w := *existing
+
+	if input.Name != nil {
+		w.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.URL != nil {
+		w.URL = strings.TrimSpace(*input.URL)
+	}
+	if input.Enabled != nil {
+		w.Enabled = *input.Enabled
+	}
+
+	// nullable fields are cleared if explicitly set to null
+	if translator.hasField("triggers") {
+		w.Triggers = webhookTriggersFromInput(input.Triggers)
+	}
+	if translator.hasField("secret") {
+		w.Secret = translator.string(input.Secret)
+	}
+
+	if err := manager.ValidateWebhook(&w); err != nil {
+		return nil, err
+	}
+
+	webhooks[i] = &w
+	if err := r.saveWebhooks(webhooks); err != nil {
+		return nil, err
+	}
+
+	return webhookToModel(&w), nil
+}
+
+func (r *mutationResolver) WebhookDestroy(ctx context.Context, input WebhookDestroyInput) (bool, error) {
+	webhooks := config.GetInstance().GetWebhooks()
+
+	i, existing := findWebhook(webhooks, input.ID)
+	if existing == nil {
+		return false, fmt.Errorf("webhook with id %s not found", input.ID)
+	}
+
+	webhooks = append(webhooks[:i], webhooks[i+1:]...)
+	if err := r.saveWebhooks(webhooks); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -79,10 +79,13 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 
 	customPerformerImageLocation := config.GetCustomPerformerImageLocation()
 
+	sceneFileRanking := config.GetSceneFileRanking()
+
 	return &ConfigGeneralResult{
 		Stashes:                       config.GetStashPaths(),
 		DatabasePath:                  config.GetDatabasePath(),
 		BackupDirectoryPath:           config.GetBackupDirectoryPath(),
+		TrashPath:                     config.GetTrashPath(),
 		GeneratedPath:                 config.GetGeneratedPath(),
 		MetadataPath:                  config.GetMetadataPath(),
 		ConfigFilePath:                config.GetConfigFile(),
@@ -116,10 +119,16 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		LogOut:                        config.GetLogOut(),
 		LogLevel:                      config.GetLogLevel(),
 		LogAccess:                     config.GetLogAccess(),
+		JobHistoryRetention:           config.GetJobHistoryRetention(),
+		TrashRetention:                config.GetTrashRetention(),
+		SceneFileRanking:              &sceneFileRanking,
 		VideoExtensions:               config.GetVideoExtensions(),
+		AudioExtensions:               config.GetAudioExtensions(),
 		ImageExtensions:               config.GetImageExtensions(),
 		GalleryExtensions:             config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders:    config.GetCreateGalleriesFromFolders(),
+		WatchStashes:                  config.IsWatchStashes(),
+		WatchDebounce:                 config.GetWatchDebounce(),
 		Excludes:                      config.GetExcludes(),
 		ImageExcludes:                 config.GetImageExcludes(),
 		CustomPerformerImageLocation:  &customPerformerImageLocation,
@@ -2,10 +2,12 @@ package api
 
 import (
 	"context"
+	"sort"
 	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 func (r *queryResolver) JobQueue(ctx context.Context) ([]*Job, error) {
@@ -42,6 +44,7 @@ func jobToJobModel(j job.Job) *Job {
 		EndTime:     j.EndTime,
 		AddTime:     j.AddTime,
 		Error:       j.Error,
+		Priority:    j.Priority,
 	}
 
 	if j.Progress != -1 {
@@ -50,3 +53,61 @@ func jobToJobModel(j job.Job) *Job {
 
 	return ret
 }
+
+func (r *queryResolver) FindJobHistory(ctx context.Context, filter *models.FindFilterType, jobFilter *JobHistoryFilterType) (*FindJobHistoryResultType, error) {
+	var historyFilter *models.JobHistoryFilter
+	if jobFilter != nil {
+		historyFilter = &models.JobHistoryFilter{}
+		for _, s := range jobFilter.Status {
+			historyFilter.Status = append(historyFilter.Status, s.String())
+		}
+	}
+
+	var ret *FindJobHistoryResultType
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		history, count, err := r.repository.JobHistory.Query(ctx, historyFilter, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindJobHistoryResultType{
+			Count: count,
+			Jobs:  make([]*JobHistoryEntry, len(history)),
+		}
+		for i, h := range history {
+			ret.Jobs[i] = jobHistoryToModel(h)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func jobHistoryToModel(h *models.JobHistory) *JobHistoryEntry {
+	ret := &JobHistoryEntry{
+		ID:          strconv.Itoa(h.ID),
+		Status:      JobStatus(h.Status),
+		Description: h.Description,
+		AddTime:     h.AddTime,
+		StartTime:   h.StartTime,
+		EndTime:     h.EndTime,
+		Error:       h.Error,
+		Counters:    []*JobCounter{},
+	}
+
+	for name, value := range h.Counters {
+		ret.Counters = append(ret.Counters, &JobCounter{
+			Name:  name,
+			Value: value,
+		})
+	}
+
+	sort.Slice(ret.Counters, func(i, j int) bool {
+		return ret.Counters[i].Name < ret.Counters[j].Name
+	})
+
+	return ret
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/stashapp/stash/internal/manager"
 )
@@ -9,3 +10,20 @@ import (
 func (r *queryResolver) SystemStatus(ctx context.Context) (*manager.SystemStatus, error) {
 	return manager.GetInstance().GetSystemStatus(), nil
 }
+
+func (r *queryResolver) PlanFileRenames(ctx context.Context, input manager.RenameMetadataInput) ([]*manager.PlannedFileRename, error) {
+	return manager.GetInstance().PlanRename(ctx, input)
+}
+
+func (r *queryResolver) PlanSceneFileRanking(ctx context.Context, input manager.RankSceneFilesInput) ([]*manager.PlannedPrimaryFile, error) {
+	return manager.GetInstance().PlanSceneFileRanking(ctx, input)
+}
+
+func (r *queryResolver) ImportDryRunReport(ctx context.Context, jobID string) (*manager.ImportReport, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.GetInstance().GetImportReport(id), nil
+}
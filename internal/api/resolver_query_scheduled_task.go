@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *queryResolver) ScheduledTasks(ctx context.Context) ([]*ScheduledTask, error) {
+	tasks := config.GetInstance().GetScheduledTasks()
+
+	ret := make([]*ScheduledTask, len(tasks))
+	for i, t := range tasks {
+		ret[i] = scheduledTaskToModel(t)
+	}
+
+	return ret, nil
+}
+
+func scheduledTaskToModel(t *config.ScheduledTask) *ScheduledTask {
+	s := manager.GetInstance().Scheduler
+
+	ret := &ScheduledTask{
+		ID:         t.ID,
+		Name:       t.Name,
+		Cron:       t.Cron,
+		Enabled:    t.Enabled,
+		Task:       t.Task,
+		Paths:      t.Paths,
+		Scan:       t.Scan,
+		Generate:   t.Generate,
+		Identify:   t.Identify,
+		AutoTag:    t.AutoTag,
+		PluginArgs: t.PluginArgs,
+		NextRun:    s.NextRun(t.ID),
+		LastRun:    s.LastRun(t.ID),
+	}
+
+	if t.PluginID != "" {
+		ret.PluginID = &t.PluginID
+	}
+	if t.PluginTask != "" {
+		ret.PluginTask = &t.PluginTask
+	}
+
+	return ret
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) TrashedFiles(ctx context.Context, filter *models.FindFilterType, trashedFilter *TrashedFileFilterType) (*FindTrashedFilesResultType, error) {
+	var f *models.TrashedFileFilter
+	if trashedFilter != nil {
+		f = &models.TrashedFileFilter{
+			EntityType: trashedFilter.EntityType,
+		}
+	}
+
+	var ret *FindTrashedFilesResultType
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		files, count, err := r.repository.TrashedFile.Query(ctx, f, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindTrashedFilesResultType{
+			Count:        count,
+			TrashedFiles: files,
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/webhook"
+)
+
+func (r *queryResolver) Webhooks(ctx context.Context) ([]*Webhook, error) {
+	webhooks := config.GetInstance().GetWebhooks()
+
+	ret := make([]*Webhook, len(webhooks))
+	for i, w := range webhooks {
+		ret[i] = webhookToModel(w)
+	}
+
+	return ret, nil
+}
+
+func webhookToModel(w *webhook.Webhook) *Webhook {
+	ret := &Webhook{
+		ID:        w.ID,
+		Name:      w.Name,
+		URL:       w.URL,
+		Enabled:   w.Enabled,
+		Triggers:  make([]string, len(w.Triggers)),
+		HasSecret: w.Secret != "",
+	}
+
+	for i, t := range w.Triggers {
+		ret.Triggers[i] = t.String()
+	}
+
+	return ret
+}
+
+func (r *queryResolver) FindWebhookDeliveries(ctx context.Context, filter *models.FindFilterType, deliveryFilter *WebhookDeliveryFilterType) (*FindWebhookDeliveriesResultType, error) {
+	var f *models.WebhookDeliveryFilter
+	if deliveryFilter != nil {
+		f = &models.WebhookDeliveryFilter{
+			WebhookID: deliveryFilter.WebhookID,
+			Success:   deliveryFilter.Success,
+		}
+	}
+
+	var ret *FindWebhookDeliveriesResultType
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		deliveries, count, err := r.repository.WebhookDelivery.Query(ctx, f, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindWebhookDeliveriesResultType{
+			Count:      count,
+			Deliveries: make([]*WebhookDeliveryEntry, len(deliveries)),
+		}
+		for i, d := range deliveries {
+			ret.Deliveries[i] = webhookDeliveryToModel(d)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func webhookDeliveryToModel(d *models.WebhookDelivery) *WebhookDeliveryEntry {
+	return &WebhookDeliveryEntry{
+		ID:         strconv.Itoa(d.ID),
+		WebhookID:  d.WebhookID,
+		URL:        d.URL,
+		Trigger:    d.Trigger,
+		EntityID:   strconv.Itoa(d.EntityID),
+		Attempt:    d.Attempt,
+		Success:    d.Success,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Duration:   int(d.Duration.Milliseconds()),
+		CreatedAt:  d.CreatedAt,
+	}
+}
@@ -62,6 +62,7 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/stream.mp4", rs.StreamMp4)
 		r.Get("/stream.webm", rs.StreamWebM)
 		r.Get("/stream.mkv", rs.StreamMKV)
+		r.Get("/stream.mp3", rs.StreamMP3)
 		r.Get("/stream.m3u8", rs.StreamHLS)
 		r.Get("/stream.m3u8/{segment}.ts", rs.StreamHLSSegment)
 		r.Get("/stream.mpd", rs.StreamDASH)
@@ -131,6 +132,10 @@ func (rs sceneRoutes) StreamMKV(w http.ResponseWriter, r *http.Request) {
 	rs.streamTranscode(w, r, ffmpeg.StreamTypeMKV)
 }
 
+func (rs sceneRoutes) StreamMP3(w http.ResponseWriter, r *http.Request) {
+	rs.streamTranscode(w, r, ffmpeg.StreamTypeMP3)
+}
+
 func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, streamType ffmpeg.StreamFormat) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -320,8 +325,12 @@ func (rs sceneRoutes) VttChapter(w http.ResponseWriter, r *http.Request) {
 	vttLines := []string{"WEBVTT", ""}
 	for i, marker := range sceneMarkers {
 		vttLines = append(vttLines, strconv.Itoa(i+1))
-		time := utils.GetVTTTime(marker.Seconds)
-		vttLines = append(vttLines, time+" --> "+time)
+		start := utils.GetVTTTime(marker.Seconds)
+		end := start
+		if marker.EndSeconds != nil {
+			end = utils.GetVTTTime(*marker.EndSeconds)
+		}
+		vttLines = append(vttLines, start+" --> "+end)
 
 		vttTitle, err := rs.getChapterVttTitle(r, marker)
 		if errors.Is(err, context.Canceled) {
@@ -124,6 +124,7 @@ func Initialize() (*Server, error) {
 	r.Use(authenticateHandler())
 	visitedPluginHandler := mgr.SessionStore.VisitedPluginHandler()
 	r.Use(visitedPluginHandler)
+	r.Use(historySourceHandler)
 
 	r.Use(middleware.Recoverer)
 
@@ -187,6 +188,7 @@ func Initialize() (*Server, error) {
 
 	gqlSrv.SetQueryCache(gqlLru.New(1000))
 	gqlSrv.Use(gqlExtension.Introspection{})
+	gqlSrv.AroundFields(authorizeField)
 
 	gqlSrv.SetErrorPresenter(gqlErrorHandler)
 
@@ -8,3 +8,11 @@ import (
 func stashIDsSliceToPtrSlice(v []models.StashID) []*models.StashID {
 	return sliceutil.ValuesToPtrs(v)
 }
+
+func stashIDsPtrSliceToSlice(v []*models.StashID) []models.StashID {
+	return sliceutil.PtrsToValues(v)
+}
+
+func customFieldsSliceToPtrSlice(v []models.CustomField) []*models.CustomField {
+	return sliceutil.ValuesToPtrs(v)
+}
@@ -24,7 +24,9 @@ package dlna
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -37,6 +39,7 @@ import (
 	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/upnp"
 	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -45,6 +48,13 @@ import (
 
 var pageSize = 100
 
+// images are sorted by title so that page titles match the page contents
+const imageSortOrder = "title"
+
+// imageIDPrefix is the prefix of image object IDs, distinguishing them from
+// scene object IDs.
+const imageIDPrefix = "image/"
+
 type browse struct {
 	ObjectID       string
 	BrowseFlag     string
@@ -94,7 +104,7 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		Restricted:  1,
 		ParentID:    parent,
 		Title:       scene.GetTitle(),
-		Class:       "object.item.videoItem",
+		Class:       sceneClass,
 		Icon:        iconURI,
 		AlbumArtURI: iconURI,
 	}
@@ -145,6 +155,75 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 	return item
 }
 
+func imageToContainer(image *models.Image, parent string, host string) interface{} {
+	imageID := strconv.Itoa(image.ID)
+	makeURI := func(path string) string {
+		return (&url.URL{
+			Scheme: "http",
+			Host:   host,
+			Path:   path,
+			RawQuery: url.Values{
+				"image": {imageID},
+			}.Encode(),
+		}).String()
+	}
+
+	thumbnailURI := makeURI(imageThumbnailPath)
+
+	obj := upnpav.Object{
+		ID:          imageIDPrefix + imageID,
+		Restricted:  1,
+		ParentID:    parent,
+		Title:       image.GetTitle(),
+		Class:       imageClass,
+		Icon:        thumbnailURI,
+		AlbumArtURI: thumbnailURI,
+	}
+
+	item := upnpav.Item{
+		Object: obj,
+		Res:    make([]upnpav.Resource, 0, 2),
+	}
+
+	mimeType := "image/jpeg"
+	var (
+		size       uint64
+		resolution string
+	)
+
+	f := image.Files.Primary()
+	if f != nil {
+		size = uint64(f.Base().Size)
+		if t := mime.TypeByExtension(filepath.Ext(f.Base().Path)); t != "" {
+			mimeType = t
+		}
+		if vf, ok := f.(models.VisualFile); ok {
+			resolution = fmt.Sprintf("%dx%d", vf.GetWidth(), vf.GetHeight())
+		}
+	}
+
+	contentFeatures := dlna.ContentFeatures{
+		SupportRange: true,
+	}
+	if mimeType == "image/jpeg" {
+		contentFeatures.ProfileName = "JPEG_LRG"
+	}
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          makeURI(imagePath),
+		ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", mimeType, contentFeatures.String()),
+		Size:         size,
+		Resolution:   resolution,
+	})
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          thumbnailURI,
+		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_TN",
+	})
+
+	return item
+}
+
 // ContentDirectory object from ObjectID.
 func (me *contentDirectoryService) objectFromID(id string) (o object, err error) {
 	o.Path, err = url.QueryUnescape(id)
@@ -203,9 +282,16 @@ func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http
 		default:
 			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "unhandled browse flag: %v", browse.BrowseFlag)
 		}
+	case "Search":
+		var search search
+		if err := xml.Unmarshal([]byte(argsXML), &search); err != nil {
+			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "cannot unmarshal search argument: %s", err.Error())
+		}
+
+		return me.handleSearch(search, host)
 	case "GetSearchCapabilities":
 		return map[string]string{
-			"SearchCaps": "",
+			"SearchCaps": strings.Join(searchCapabilities, ","),
 		}, nil
 	// from https://github.com/rclone/rclone/blob/master/cmd/serve/dlna/cds.go
 	// Samsung Extensions
@@ -251,43 +337,14 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 		}
 	}
 
-	// Saved searches
-	// if obj.Path == "saved-searches" {
-	// 	var savedPlaylists []models.Playlist
-	// 	db, _ := models.GetDB()
-	// 	db.Where("is_deo_enabled = ?", true).Order("ordering asc").Find(&savedPlaylists)
-	// 	db.Close()
-
-	// 	for _, playlist := range savedPlaylists {
-	// 		objs = append(objs, upnpav.Container{Object: upnpav.Object{
-	// 			ID:         "saved-searches/" + strconv.Itoa(int(playlist.ID)),
-	// 			Restricted: 1,
-	// 			ParentID:   "saved-searches",
-	// 			Class:      "object.container.storageFolder",
-	// 			Title:      playlist.Name,
-	// 		}})
-	// 	}
-	// }
-
-	// if strings.HasPrefix(obj.Path, "saved-searches/") {
-	// 	id := strings.Split(obj.Path, "/")
-
-	// 	var savedPlaylist models.Playlist
-	// 	db, _ := models.GetDB()
-	// 	db.Where("id = ?", id[1]).First(&savedPlaylist)
-	// 	db.Close()
-
-	// 	var r models.RequestSceneList
-	// 	if err := json.Unmarshal([]byte(savedPlaylist.SearchParams), &r); err == nil {
-	// 		r.IsAccessible = optional.NewBool(true)
-	// 		r.IsAvailable = optional.NewBool(true)
-	// 		data := models.QueryScenesFull(r)
+	// Saved filters
+	if obj.Path == "saved-filters" {
+		objs = me.getSavedFilters()
+	}
 
-	// 		for i := range data.Scenes {
-	// 			objs = append(objs, me.sceneToContainer(data.Scenes[i], "sites/"+id[1], host))
-	// 		}
-	// 	}
-	// }
+	if strings.HasPrefix(obj.Path, "saved-filters/") {
+		objs = me.getSavedFilterScenes(childPath(paths), host)
+	}
 
 	// Studios
 	if obj.Path == "studios" {
@@ -334,10 +391,35 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 		objs = me.getRatingScenes(childPath(paths), host)
 	}
 
+	// Images
+	if obj.Path == "images" {
+		objs = me.getImages(&models.ImageFilterType{}, "images", host)
+	}
+
+	if strings.HasPrefix(obj.Path, "images/") {
+		page := getPageFromID(paths)
+		if page != nil {
+			objs = me.getPageImages(&models.ImageFilterType{}, "images", *page, host)
+		}
+	}
+
+	// Galleries
+	if obj.Path == "galleries" {
+		objs = me.getGalleries()
+	}
+
+	if strings.HasPrefix(obj.Path, "galleries/") {
+		objs = me.getGalleryImages(childPath(paths), host)
+	}
+
 	return makeBrowseResult(objs, me.updateIDString())
 }
 
 func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string) (map[string]string, error) {
+	if strings.HasPrefix(obj.Path, imageIDPrefix) {
+		return me.handleBrowseImageMetadata(obj, host)
+	}
+
 	var objs []interface{}
 	var updateID string
 
@@ -390,14 +472,212 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 	return makeBrowseResult(objs, updateID)
 }
 
+func (me *contentDirectoryService) handleBrowseImageMetadata(obj object, host string) (map[string]string, error) {
+	imageID, err := strconv.Atoi(strings.TrimPrefix(obj.Path, imageIDPrefix))
+	if err != nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "image not found")
+	}
+
+	var image *models.Image
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		image, err = r.ImageFinder.Find(ctx, imageID)
+		if image != nil {
+			err = image.LoadPrimaryFile(ctx, r.FileGetter)
+		}
+
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	if image == nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "image not found")
+	}
+
+	objs := []interface{}{imageToContainer(image, "-1", host)}
+
+	// see handleBrowseMetadata for the update ID limit
+	const maxUpdateID int64 = 1 << 32
+	updateID := fmt.Sprint(image.UpdatedAt.Unix() % maxUpdateID)
+
+	return makeBrowseResult(objs, updateID)
+}
+
+// handleSearch returns the scenes and images matching the search criteria.
+// Scenes are returned before images, and the requested range applies to the
+// combined results. The ContainerID of the search is ignored: searches
+// always cover all scenes and images, regardless of the container.
+func (me *contentDirectoryService) handleSearch(search search, host string) (map[string]string, error) {
+	exp, err := parseSearchCriteria(search.SearchCriteria)
+	if err != nil {
+		return nil, invalidSearchCriteria("invalid search criteria: %v", err)
+	}
+
+	var objs []interface{}
+	total := 0
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		sceneObjs, sceneTotal, err := me.searchScenes(ctx, exp, search.StartingIndex, search.RequestedCount, host)
+		if err != nil {
+			return err
+		}
+
+		objs = sceneObjs
+
+		// images follow the scenes, so offset the range by the scene total
+		imageStart := max(search.StartingIndex-sceneTotal, 0)
+		imageCount := search.RequestedCount
+		countOnly := false
+		if imageCount > 0 {
+			imageCount -= len(sceneObjs)
+			countOnly = imageCount <= 0
+		}
+
+		imageObjs, imageTotal, err := me.searchImages(ctx, exp, imageStart, imageCount, countOnly, host)
+		if err != nil {
+			return err
+		}
+
+		objs = append(objs, imageObjs...)
+		total = sceneTotal + imageTotal
+
+		return nil
+	}); err != nil {
+		var upnpErr *upnp.Error
+		if errors.As(err, &upnpErr) {
+			return nil, upnpErr
+		}
+
+		logger.Errorf("error searching: %v", err)
+		return nil, upnp.Errorf(upnp.ActionFailedErrorCode, "could not search: %s", err.Error())
+	}
+
+	return makeResult(objs, total, me.updateIDString())
+}
+
+// searchScenes returns the requested range of scenes matching the search
+// expression, and the total number of matching scenes.
+func (me *contentDirectoryService) searchScenes(ctx context.Context, exp *searchExpression, startingIndex, requestedCount int, host string) ([]interface{}, int, error) {
+	r := me.repository
+	translator := searchTranslator{
+		performerFinder: r.PerformerFinder,
+		tagFinder:       r.TagFinder,
+		class:           sceneClass,
+	}
+
+	f, err := translator.translate(ctx, exp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if f.none {
+		return nil, 0, nil
+	}
+
+	sort := me.VideoSortOrder
+	direction := getSortDirection(f.filter, sort)
+	findFilter, skip := searchFindFilter(startingIndex, requestedCount, sort, direction)
+
+	scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, f.filter, findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if skip < len(scenes) {
+		scenes = scenes[skip:]
+	} else {
+		scenes = nil
+	}
+
+	var objs []interface{}
+	for _, s := range scenes {
+		if err := s.LoadPrimaryFile(ctx, r.FileGetter); err != nil {
+			return nil, 0, err
+		}
+
+		objs = append(objs, sceneToContainer(s, "all", host))
+	}
+
+	return objs, total, nil
+}
+
+// searchImages returns the requested range of images matching the search
+// expression, and the total number of matching images. If countOnly is true,
+// only the total is returned.
+func (me *contentDirectoryService) searchImages(ctx context.Context, exp *searchExpression, startingIndex, requestedCount int, countOnly bool, host string) ([]interface{}, int, error) {
+	r := me.repository
+	translator := searchTranslator{
+		performerFinder: r.PerformerFinder,
+		tagFinder:       r.TagFinder,
+		class:           imageClass,
+	}
+
+	f, err := translator.translate(ctx, exp)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if f.none {
+		return nil, 0, nil
+	}
+
+	imageFilter := imageFilterFromSceneFilter(f.filter)
+	findFilter, skip := searchFindFilter(startingIndex, requestedCount, imageSortOrder, models.SortDirectionEnumAsc)
+	if countOnly {
+		perPage := 0
+		findFilter.PerPage = &perPage
+	}
+
+	result, err := r.ImageFinder.Query(ctx, image.QueryOptions(imageFilter, findFilter, true))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if countOnly {
+		return nil, result.Count, nil
+	}
+
+	images, err := result.Resolve(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if skip < len(images) {
+		images = images[skip:]
+	} else {
+		images = nil
+	}
+
+	var objs []interface{}
+	for _, i := range images {
+		if err := i.LoadPrimaryFile(ctx, r.FileGetter); err != nil {
+			return nil, 0, err
+		}
+
+		objs = append(objs, imageToContainer(i, "images", host))
+	}
+
+	return objs, result.Count, nil
+}
+
 func makeBrowseResult(objs []interface{}, updateID string) (map[string]string, error) {
+	return makeResult(objs, len(objs), updateID)
+}
+
+// makeResult returns the result of a Browse or Search action. totalMatches
+// is the total number of matching objects, which may exceed the number of
+// objects returned.
+func makeResult(objs []interface{}, totalMatches int, updateID string) (map[string]string, error) {
 	result, err := xml.Marshal(objs)
 	if err != nil {
 		return nil, upnp.Errorf(upnp.ActionFailedErrorCode, "could not marshal objects: %s", err.Error())
 	}
 
 	return map[string]string{
-		"TotalMatches":   fmt.Sprint(len(objs)),
+		"TotalMatches":   fmt.Sprint(totalMatches),
 		"NumberReturned": fmt.Sprint(len(objs)),
 		"Result":         didl_lite(string(result)),
 		"UpdateID":       updateID,
@@ -430,11 +710,14 @@ func getRootObjects() []interface{} {
 	var objs []interface{}
 
 	objs = append(objs, makeStorageFolder("all", "all", rootID))
+	objs = append(objs, makeStorageFolder("saved-filters", "saved filters", rootID))
 	objs = append(objs, makeStorageFolder("performers", "performers", rootID))
 	objs = append(objs, makeStorageFolder("tags", "tags", rootID))
 	objs = append(objs, makeStorageFolder("studios", "studios", rootID))
 	objs = append(objs, makeStorageFolder("groups", "groups", rootID))
 	objs = append(objs, makeStorageFolder("rating", "rating", rootID))
+	objs = append(objs, makeStorageFolder("images", "images", rootID))
+	objs = append(objs, makeStorageFolder("galleries", "galleries", rootID))
 
 	return objs
 }
@@ -448,29 +731,41 @@ func getSortDirection(sceneFilter *models.SceneFilterType, sort string) models.S
 	return direction
 }
 
+// defaultFindFilter returns a find filter using the configured video sort
+// order.
+func (me *contentDirectoryService) defaultFindFilter(sceneFilter *models.SceneFilterType) *models.FindFilterType {
+	sort := me.VideoSortOrder
+	direction := getSortDirection(sceneFilter, sort)
+	return &models.FindFilterType{
+		Sort:      &sort,
+		Direction: &direction,
+	}
+}
+
 func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType, parentID string, host string) []interface{} {
+	return me.getFilteredVideos(sceneFilter, me.defaultFindFilter(sceneFilter), parentID, host)
+}
+
+// getFilteredVideos returns the scenes matching the scene filter, sorted by
+// the find filter. Returns page containers if there is more than one page of
+// scenes.
+func (me *contentDirectoryService) getFilteredVideos(sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType, parentID string, host string) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
 	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
-		sort := me.VideoSortOrder
-		direction := getSortDirection(sceneFilter, sort)
-		findFilter := &models.FindFilterType{
-			PerPage:   &pageSize,
-			Sort:      &sort,
-			Direction: &direction,
+		pager := scenePager{
+			sceneFilter: sceneFilter,
+			findFilter:  findFilter,
+			parentID:    parentID,
 		}
 
-		scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, sceneFilter, findFilter)
+		scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, sceneFilter, pager.pageFindFilter(1))
 		if err != nil {
 			return err
 		}
 
 		if total > pageSize {
-			pager := scenePager{
-				sceneFilter: sceneFilter,
-				parentID:    parentID,
-			}
 
 			objs, err = pager.getPages(ctx, r.SceneFinder, total)
 			if err != nil {
@@ -495,19 +790,22 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 }
 
 func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilterType, parentID string, page int, host string) []interface{} {
+	return me.getFilteredPageVideos(sceneFilter, me.defaultFindFilter(sceneFilter), parentID, page, host)
+}
+
+func (me *contentDirectoryService) getFilteredPageVideos(sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType, parentID string, page int, host string) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
 	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
 		pager := scenePager{
 			sceneFilter: sceneFilter,
+			findFilter:  findFilter,
 			parentID:    parentID,
 		}
 
-		sort := me.VideoSortOrder
-		direction := getSortDirection(sceneFilter, sort)
 		var err error
-		objs, err = pager.getPageVideos(ctx, r.SceneFinder, r.FileGetter, page, host, sort, direction)
+		objs, err = pager.getPageVideos(ctx, r.SceneFinder, r.FileGetter, page, host)
 		if err != nil {
 			return err
 		}
@@ -538,6 +836,94 @@ func (me *contentDirectoryService) getAllScenes(host string) []interface{} {
 	return me.getVideos(&models.SceneFilterType{}, "all", host)
 }
 
+// findDLNASavedFilter returns the saved scene filter with the provided id,
+// or nil if it does not exist or is not exposed over DLNA.
+func findDLNASavedFilter(ctx context.Context, r SavedFilterFinder, id int) (*models.SavedFilter, error) {
+	f, err := r.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if f == nil || f.Mode != models.FilterModeScenes || !f.DLNAEnabled {
+		return nil, nil
+	}
+
+	return f, nil
+}
+
+func (me *contentDirectoryService) getSavedFilters() []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		filters, err := r.SavedFilterFinder.FindByMode(ctx, models.FilterModeScenes)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range filters {
+			if !f.DLNAEnabled {
+				continue
+			}
+
+			objs = append(objs, makeStorageFolder("saved-filters/"+strconv.Itoa(f.ID), f.Name, "saved-filters"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf(err.Error())
+	}
+
+	return objs
+}
+
+// getSavedFilterScenes returns the scenes of a saved filter. The filter is
+// evaluated when browsed, using its search term and sort order.
+func (me *contentDirectoryService) getSavedFilterScenes(paths []string, host string) []interface{} {
+	id, err := strconv.Atoi(paths[0])
+	if err != nil {
+		return nil
+	}
+
+	var savedFilter *models.SavedFilter
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		savedFilter, err = findDLNASavedFilter(ctx, r.SavedFilterFinder, id)
+		return err
+	}); err != nil {
+		logger.Errorf(err.Error())
+		return nil
+	}
+
+	if savedFilter == nil {
+		return nil
+	}
+
+	sceneFilter, err := savedSceneFilter(savedFilter.ObjectFilter)
+	if err != nil {
+		logger.Errorf("error converting saved filter %q: %v", savedFilter.Name, err)
+		return nil
+	}
+
+	findFilter := me.defaultFindFilter(sceneFilter)
+	if savedFilter.FindFilter != nil {
+		findFilter.Q = savedFilter.FindFilter.Q
+		if savedFilter.FindFilter.Sort != nil {
+			findFilter.Sort = savedFilter.FindFilter.Sort
+			findFilter.Direction = savedFilter.FindFilter.Direction
+		}
+	}
+
+	parentID := "saved-filters/" + strings.Join(paths, "/")
+
+	page := getPageFromID(paths)
+	if page != nil {
+		return me.getFilteredPageVideos(sceneFilter, findFilter, parentID, *page, host)
+	}
+
+	return me.getFilteredVideos(sceneFilter, findFilter, parentID, host)
+}
+
 func (me *contentDirectoryService) getStudios() []interface{} {
 	var objs []interface{}
 
@@ -732,6 +1118,115 @@ func (me *contentDirectoryService) getRatingScenes(paths []string, host string)
 	return me.getVideos(sceneFilter, parentID, host)
 }
 
+func (me *contentDirectoryService) getImages(imageFilter *models.ImageFilterType, parentID string, host string) []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		sort := imageSortOrder
+		direction := models.SortDirectionEnumAsc
+		findFilter := &models.FindFilterType{
+			PerPage:   &pageSize,
+			Sort:      &sort,
+			Direction: &direction,
+		}
+
+		result, err := r.ImageFinder.Query(ctx, image.QueryOptions(imageFilter, findFilter, true))
+		if err != nil {
+			return err
+		}
+
+		if result.Count > pageSize {
+			pager := imagePager{
+				imageFilter: imageFilter,
+				parentID:    parentID,
+			}
+
+			objs, err = pager.getPages(ctx, r.ImageFinder, result.Count)
+			return err
+		}
+
+		images, err := result.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range images {
+			if err := i.LoadPrimaryFile(ctx, r.FileGetter); err != nil {
+				return err
+			}
+
+			objs = append(objs, imageToContainer(i, parentID, host))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getPageImages(imageFilter *models.ImageFilterType, parentID string, page int, host string) []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		pager := imagePager{
+			imageFilter: imageFilter,
+			parentID:    parentID,
+		}
+
+		var err error
+		objs, err = pager.getPageImages(ctx, r.ImageFinder, r.FileGetter, page, host)
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getGalleries() []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		galleries, err := r.GalleryFinder.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, g := range galleries {
+			objs = append(objs, makeStorageFolder("galleries/"+strconv.Itoa(g.ID), g.GetTitle(), "galleries"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getGalleryImages(paths []string, host string) []interface{} {
+	imageFilter := &models.ImageFilterType{
+		Galleries: &models.MultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "galleries/" + strings.Join(paths, "/")
+
+	page := getPageFromID(paths)
+	if page != nil {
+		return me.getPageImages(imageFilter, parentID, *page, host)
+	}
+
+	return me.getImages(imageFilter, parentID, host)
+}
+
 // Represents a ContentDirectory object.
 type object struct {
 	Path           string // The cleaned, absolute path for the object relative to the server.
@@ -31,7 +31,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/anacrolix/dms/upnpav"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
 func TestEscapeObjectID(t *testing.T) {
@@ -78,3 +81,45 @@ func TestBrowseMetadataTags(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestImageToContainer(t *testing.T) {
+	img := &models.Image{
+		ID:    1,
+		Title: "image",
+		Files: models.NewRelatedFiles([]models.File{
+			&models.ImageFile{
+				BaseFile: &models.BaseFile{
+					Path: "/images/image.png",
+					Size: 1024,
+				},
+				Width:  640,
+				Height: 480,
+			},
+		}),
+	}
+
+	item, ok := imageToContainer(img, "images", "localhost").(upnpav.Item)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	assert.Equal(t, "image/1", item.ID)
+	assert.Equal(t, "images", item.ParentID)
+	assert.Equal(t, "object.item.imageItem.photo", item.Class)
+	assert.Equal(t, "http://localhost/thumbnail?image=1", item.AlbumArtURI)
+
+	if assert.Len(t, item.Res, 2) {
+		assert.Equal(t, "http://localhost/image?image=1", item.Res[0].URL)
+		assert.True(t, strings.HasPrefix(item.Res[0].ProtocolInfo, "http-get:*:image/png:"))
+		assert.Equal(t, uint64(1024), item.Res[0].Size)
+		assert.Equal(t, "640x480", item.Res[0].Resolution)
+		assert.Equal(t, "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_TN", item.Res[1].ProtocolInfo)
+	}
+}
+
+func TestBrowseMetadataInvalidImage(t *testing.T) {
+	argsXML := `<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1"><ObjectID>image/abc</ObjectID><BrowseFlag>BrowseMetadata</BrowseFlag><Filter>*</Filter><StartingIndex>0</StartingIndex><RequestedCount>0</RequestedCount><SortCriteria></SortCriteria></u:Browse>`
+	_, err := testHandleBrowse(argsXML)
+
+	assert.NotNil(t, err)
+}
@@ -61,10 +61,12 @@ type StudioFinder interface {
 }
 
 type TagFinder interface {
+	models.TagQueryer
 	All(ctx context.Context) ([]*models.Tag, error)
 }
 
 type PerformerFinder interface {
+	models.PerformerQueryer
 	All(ctx context.Context) ([]*models.Performer, error)
 }
 
@@ -72,12 +74,28 @@ type GroupFinder interface {
 	All(ctx context.Context) ([]*models.Group, error)
 }
 
+type ImageFinder interface {
+	models.ImageGetter
+	models.ImageQueryer
+}
+
+type GalleryFinder interface {
+	All(ctx context.Context) ([]*models.Gallery, error)
+}
+
+type SavedFilterFinder interface {
+	Find(ctx context.Context, id int) (*models.SavedFilter, error)
+	FindByMode(ctx context.Context, mode models.FilterMode) ([]*models.SavedFilter, error)
+}
+
 const (
 	serverField                 = "Linux/3.4 DLNADOC/1.50 UPnP/1.0 DMS/1.0"
 	rootDeviceType              = "urn:schemas-upnp-org:device:MediaServer:1"
 	rootDeviceModelName         = "dms 1.0xb"
 	resPath                     = "/res"
 	iconPath                    = "/icon"
+	imagePath                   = "/image"
+	imageThumbnailPath          = "/thumbnail"
 	rootDescPath                = "/rootDesc.xml"
 	contentDirectoryEventSubURL = "/evt/ContentDirectory"
 	serviceControlURL           = "/ctl"
@@ -273,6 +291,7 @@ type Server struct {
 
 	repository         Repository
 	sceneServer        sceneServer
+	imageServer        imageServer
 	ipWhitelistManager *ipWhitelistManager
 	VideoSortOrder     string
 
@@ -388,8 +407,9 @@ func (me *Server) soapActionResponse(sa upnp.SoapAction, actionRequestXML []byte
 	return ret, err
 }
 
-// Handle a service control HTTP request.
-func (me *Server) serviceControlHandler(w http.ResponseWriter, r *http.Request) {
+// checkClientAllowed returns true if the client of the request is in the IP
+// whitelist. Otherwise, it writes a forbidden response and returns false.
+func (me *Server) checkClientAllowed(w http.ResponseWriter, r *http.Request) bool {
 	clientIp, _, _ := net.SplitHostPort(r.RemoteAddr)
 
 	ip := net.ParseIP(clientIp).String()
@@ -400,6 +420,15 @@ func (me *Server) serviceControlHandler(w http.ResponseWriter, r *http.Request)
 		}
 
 		http.Error(w, "forbidden", http.StatusForbidden)
+		return false
+	}
+
+	return true
+}
+
+// Handle a service control HTTP request.
+func (me *Server) serviceControlHandler(w http.ResponseWriter, r *http.Request) {
+	if !me.checkClientAllowed(w, r) {
 		return
 	}
 
@@ -460,6 +489,63 @@ func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 	me.sceneServer.ServeScreenshot(scene, w, r)
 }
 
+func (me *Server) findImage(r *http.Request) *models.Image {
+	imageId := r.URL.Query().Get("image")
+	if imageId == "" {
+		return nil
+	}
+
+	var image *models.Image
+	repo := me.repository
+	err := repo.WithReadTxn(r.Context(), func(ctx context.Context) error {
+		idInt, err := strconv.Atoi(imageId)
+		if err != nil {
+			return nil
+		}
+		image, _ = repo.ImageFinder.Find(ctx, idInt)
+		if image != nil {
+			return image.LoadPrimaryFile(ctx, repo.FileGetter)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("failed to execute read transaction for image id (%v): %v", imageId, err)
+		return nil
+	}
+
+	return image
+}
+
+func (me *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	if !me.checkClientAllowed(w, r) {
+		return
+	}
+
+	image := me.findImage(r)
+	if image == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("transferMode.dlna.org", "Interactive")
+	me.imageServer.ServeImage(image, w, r)
+}
+
+func (me *Server) serveImageThumbnail(w http.ResponseWriter, r *http.Request) {
+	if !me.checkClientAllowed(w, r) {
+		return
+	}
+
+	image := me.findImage(r)
+	if image == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("transferMode.dlna.org", "Interactive")
+	me.imageServer.ServeThumbnail(image, w, r)
+}
+
 func (me *Server) contentDirectoryInitialEvent(ctx context.Context, urls []*url.URL, sid string) {
 	body := xmlMarshalOrPanic(upnp.PropertySet{
 		Properties: []upnp.Property{
@@ -589,6 +675,8 @@ func (me *Server) initMux(mux *http.ServeMux) {
 	})
 	mux.HandleFunc(contentDirectoryEventSubURL, me.contentDirectoryEventSubHandler)
 	mux.HandleFunc(iconPath, me.serveIcon)
+	mux.HandleFunc(imagePath, me.serveImage)
+	mux.HandleFunc(imageThumbnailPath, me.serveImageThumbnail)
 	mux.HandleFunc(resPath, func(w http.ResponseWriter, r *http.Request) {
 		sceneId := r.URL.Query().Get("scene")
 		var scene *models.Scene
@@ -6,62 +6,136 @@ import (
 	"math"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 )
 
-type scenePager struct {
-	sceneFilter *models.SceneFilterType
-	parentID    string
-}
-
-func (p *scenePager) getPageID(page int) string {
-	return p.parentID + "/page/" + strconv.Itoa(page)
+func getPageID(parentID string, page int) string {
+	return parentID + "/page/" + strconv.Itoa(page)
 }
 
-func (p *scenePager) getPages(ctx context.Context, r models.SceneQueryer, total int) ([]interface{}, error) {
+// getPages returns a storage folder for each page of total items.
+// firstTitle returns the title of the first item on the page starting at the
+// provided one-based index when the title is sorted; it is used to give the
+// pages an appropriate title.
+func getPages(parentID string, total int, firstTitle func(index int) (string, error)) ([]interface{}, error) {
 	var objs []interface{}
 
-	// get the first scene of each page to set an appropriate title
 	pages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	singlePageSize := 1
-	sort := "title"
-	findFilter := &models.FindFilterType{
-		PerPage: &singlePageSize,
-		Sort:    &sort,
-	}
-
 	for page := 1; page <= pages; page++ {
 		// TODO - this is really slow. Not sure if there's a better way
 		title := fmt.Sprintf("Page %d", page)
 		if pages <= 10 || (page-1)%(pages/10) == 0 {
-			thisPage := ((page - 1) * pageSize) + 1
-			findFilter.Page = &thisPage
-			scenes, err := scene.Query(ctx, r, p.sceneFilter, findFilter)
+			itemTitle, err := firstTitle(((page - 1) * pageSize) + 1)
 			if err != nil {
 				return nil, err
 			}
 
-			sceneTitle := scenes[0].GetTitle()
-
 			// use the first three letters as a prefix
-			if len(sceneTitle) > 3 {
-				sceneTitle = sceneTitle[0:3]
+			if len(itemTitle) > 3 {
+				itemTitle = itemTitle[0:3]
 			}
 
-			title += fmt.Sprintf(" (%s...)", sceneTitle)
+			title += fmt.Sprintf(" (%s...)", itemTitle)
 		}
 
-		objs = append(objs, makeStorageFolder(p.getPageID(page), title, p.parentID))
+		objs = append(objs, makeStorageFolder(getPageID(parentID, page), title, parentID))
 	}
 
 	return objs, nil
 }
 
-func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.FileGetter, page int, host string, sort string, direction models.SortDirectionEnum) ([]interface{}, error) {
+// singleTitleFilter returns a find filter returning the single item at the
+// provided index when sorted by title.
+func singleTitleFilter(index int) *models.FindFilterType {
+	singlePageSize := 1
+	sort := "title"
+	return &models.FindFilterType{
+		PerPage: &singlePageSize,
+		Page:    &index,
+		Sort:    &sort,
+	}
+}
+
+type scenePager struct {
+	sceneFilter *models.SceneFilterType
+	// findFilter provides the search term and sort order of the scenes
+	findFilter *models.FindFilterType
+	parentID   string
+}
+
+// pageFindFilter returns the find filter for the provided page.
+func (p *scenePager) pageFindFilter(page int) *models.FindFilterType {
+	var ret models.FindFilterType
+	if p.findFilter != nil {
+		ret = *p.findFilter
+	}
+
+	ret.PerPage = &pageSize
+	ret.Page = &page
+	return &ret
+}
+
+func (p *scenePager) getPages(ctx context.Context, r models.SceneQueryer, total int) ([]interface{}, error) {
+	// get the first scene of each page to set an appropriate title
+	return getPages(p.parentID, total, func(index int) (string, error) {
+		findFilter := singleTitleFilter(index)
+		if p.findFilter != nil {
+			findFilter.Q = p.findFilter.Q
+		}
+
+		scenes, err := scene.Query(ctx, r, p.sceneFilter, findFilter)
+		if err != nil {
+			return "", err
+		}
+
+		return scenes[0].GetTitle(), nil
+	})
+}
+
+func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.FileGetter, page int, host string) ([]interface{}, error) {
+	var objs []interface{}
+
+	scenes, err := scene.Query(ctx, r, p.sceneFilter, p.pageFindFilter(page))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range scenes {
+		if err := s.LoadPrimaryFile(ctx, f); err != nil {
+			return nil, err
+		}
+
+		objs = append(objs, sceneToContainer(s, p.parentID, host))
+	}
+
+	return objs, nil
+}
+
+type imagePager struct {
+	imageFilter *models.ImageFilterType
+	parentID    string
+}
+
+func (p *imagePager) getPages(ctx context.Context, r models.ImageQueryer, total int) ([]interface{}, error) {
+	// get the first image of each page to set an appropriate title
+	return getPages(p.parentID, total, func(index int) (string, error) {
+		images, err := image.Query(ctx, r, p.imageFilter, singleTitleFilter(index))
+		if err != nil {
+			return "", err
+		}
+
+		return images[0].GetTitle(), nil
+	})
+}
+
+func (p *imagePager) getPageImages(ctx context.Context, r ImageFinder, f models.FileGetter, page int, host string) ([]interface{}, error) {
 	var objs []interface{}
 
+	sort := imageSortOrder
+	direction := models.SortDirectionEnumAsc
 	findFilter := &models.FindFilterType{
 		PerPage:   &pageSize,
 		Page:      &page,
@@ -69,17 +143,17 @@ func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.
 		Direction: &direction,
 	}
 
-	scenes, err := scene.Query(ctx, r, p.sceneFilter, findFilter)
+	images, err := image.Query(ctx, r, p.imageFilter, findFilter)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, s := range scenes {
-		if err := s.LoadPrimaryFile(ctx, f); err != nil {
+	for _, i := range images {
+		if err := i.LoadPrimaryFile(ctx, f); err != nil {
 			return nil, err
 		}
 
-		objs = append(objs, sceneToContainer(s, p.parentID, host))
+		objs = append(objs, imageToContainer(i, p.parentID, host))
 	}
 
 	return objs, nil
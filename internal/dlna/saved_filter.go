@@ -0,0 +1,292 @@
+package dlna
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// savedCriterion is a criterion as stored in the object filter of a saved
+// filter. Saved criteria use the UI representation of the criterion values,
+// which differs from the filter input types.
+type savedCriterion struct {
+	Modifier models.CriterionModifier `json:"modifier"`
+	Value    json.RawMessage          `json:"value"`
+}
+
+type savedLabeledID struct {
+	ID string `json:"id"`
+}
+
+type savedHierarchicalValue struct {
+	Items    []savedLabeledID `json:"items"`
+	Excluded []savedLabeledID `json:"excluded"`
+	Depth    *int             `json:"depth"`
+}
+
+type savedRangeValue struct {
+	Value  interface{} `json:"value"`
+	Value2 interface{} `json:"value2"`
+}
+
+// savedResolutions maps the resolution strings used by the UI to their enum
+// values.
+var savedResolutions = map[string]models.ResolutionEnum{
+	"144p":  models.ResolutionEnumVeryLow,
+	"240p":  models.ResolutionEnumLow,
+	"360p":  models.ResolutionEnumR360p,
+	"480p":  models.ResolutionEnumStandard,
+	"540p":  models.ResolutionEnumWebHd,
+	"720p":  models.ResolutionEnumStandardHd,
+	"1080p": models.ResolutionEnumFullHd,
+	"1440p": models.ResolutionEnumQuadHd,
+	"4k":    models.ResolutionEnumFourK,
+	"5k":    models.ResolutionEnumFiveK,
+	"6k":    models.ResolutionEnumSixK,
+	"7k":    models.ResolutionEnumSevenK,
+	"8k":    models.ResolutionEnumEightK,
+	"Huge":  models.ResolutionEnumHuge,
+}
+
+// savedCaptionLanguages maps the caption language names used by the UI to
+// their language codes.
+var savedCaptionLanguages = map[string]string{
+	"Deutsche":  "de",
+	"English":   "en",
+	"Español":   "es",
+	"Français":  "fr",
+	"Italiano":  "it",
+	"日本":        "ja",
+	"한국인":       "ko",
+	"Holandés":  "nl",
+	"Português": "pt",
+	"Русский":   "ru",
+	"Unknown":   "00",
+}
+
+var savedTimestampRE = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}$`)
+
+var (
+	stringCriterionType       = reflect.TypeOf(models.StringCriterionInput{})
+	intCriterionType          = reflect.TypeOf(models.IntCriterionInput{})
+	floatCriterionType        = reflect.TypeOf(models.FloatCriterionInput{})
+	dateCriterionType         = reflect.TypeOf(models.DateCriterionInput{})
+	timestampCriterionType    = reflect.TypeOf(models.TimestampCriterionInput{})
+	multiCriterionType        = reflect.TypeOf(models.MultiCriterionInput{})
+	hierarchicalCriterionType = reflect.TypeOf(models.HierarchicalMultiCriterionInput{})
+	resolutionCriterionType   = reflect.TypeOf(models.ResolutionCriterionInput{})
+	orientationCriterionType  = reflect.TypeOf(models.OrientationCriterionInput{})
+	phashDistanceType         = reflect.TypeOf(models.PhashDistanceCriterionInput{})
+	phashDuplicationType      = reflect.TypeOf(models.PHashDuplicationCriterionInput{})
+	stashIDCriterionType      = reflect.TypeOf(models.StashIDCriterionInput{})
+)
+
+// sceneFilterFieldTypes returns the types of the scene filter fields, keyed
+// by their json names.
+func sceneFilterFieldTypes() map[string]reflect.Type {
+	ret := make(map[string]reflect.Type)
+
+	t := reflect.TypeOf(models.SceneFilterType{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			continue
+		}
+
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		ret[name] = ft
+	}
+
+	return ret
+}
+
+func labeledIDs(v []savedLabeledID) []string {
+	ret := make([]string, len(v))
+	for i, id := range v {
+		ret[i] = id.ID
+	}
+	return ret
+}
+
+// convertSavedCriterion converts a saved criterion into the json
+// representation of the criterion input of type t.
+func convertSavedCriterion(name string, t reflect.Type, c savedCriterion) (interface{}, error) {
+	switch t {
+	case reflect.TypeOf(true):
+		var v interface{}
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+		return v == true || v == "true", nil
+	case reflect.TypeOf(""):
+		var v string
+		err := json.Unmarshal(c.Value, &v)
+		return v, err
+	case stringCriterionType:
+		var v string
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		if name == "captions" {
+			v = savedCaptionLanguages[v]
+		}
+
+		return map[string]interface{}{"value": v, "modifier": c.Modifier}, nil
+	case intCriterionType, floatCriterionType, dateCriterionType, timestampCriterionType:
+		var v savedRangeValue
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		if t == timestampCriterionType {
+			for _, vv := range []*interface{}{&v.Value, &v.Value2} {
+				if s, ok := (*vv).(string); ok && savedTimestampRE.MatchString(strings.TrimSpace(s)) {
+					*vv = strings.Replace(strings.TrimSpace(s), " ", "T", 1)
+				}
+			}
+		}
+
+		ret := map[string]interface{}{"modifier": c.Modifier}
+		if v.Value != nil {
+			ret["value"] = v.Value
+		}
+		if v.Value2 != nil {
+			ret["value2"] = v.Value2
+		}
+		return ret, nil
+	case multiCriterionType, hierarchicalCriterionType:
+		var v savedHierarchicalValue
+		if len(c.Value) > 0 && c.Value[0] == '[' {
+			// plain list of labeled ids
+			if err := json.Unmarshal(c.Value, &v.Items); err != nil {
+				return nil, err
+			}
+		} else if len(c.Value) > 0 {
+			if err := json.Unmarshal(c.Value, &v); err != nil {
+				return nil, err
+			}
+		}
+
+		ret := map[string]interface{}{
+			"value":    labeledIDs(v.Items),
+			"excludes": labeledIDs(v.Excluded),
+			"modifier": c.Modifier,
+		}
+
+		if t == hierarchicalCriterionType {
+			depth := 0
+			if v.Depth != nil && c.Modifier != models.CriterionModifierEquals {
+				depth = *v.Depth
+			}
+			ret["depth"] = depth
+		}
+
+		return ret, nil
+	case resolutionCriterionType:
+		var v string
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		resolution, ok := savedResolutions[v]
+		if !ok {
+			return nil, fmt.Errorf("unknown resolution %q", v)
+		}
+
+		return map[string]interface{}{"value": resolution, "modifier": c.Modifier}, nil
+	case orientationCriterionType:
+		var v []string
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		for i := range v {
+			v[i] = strings.ToUpper(v[i])
+		}
+
+		return map[string]interface{}{"value": v}, nil
+	case phashDistanceType:
+		var v struct {
+			Value    string `json:"value"`
+			Distance *int   `json:"distance"`
+		}
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"value": v.Value, "distance": v.Distance, "modifier": c.Modifier}, nil
+	case phashDuplicationType:
+		var v string
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"duplicated": v == "true"}, nil
+	case stashIDCriterionType:
+		var v struct {
+			Endpoint *string `json:"endpoint"`
+			StashID  *string `json:"stashID"`
+		}
+		if err := json.Unmarshal(c.Value, &v); err != nil {
+			return nil, err
+		}
+
+		return map[string]interface{}{"endpoint": v.Endpoint, "stash_id": v.StashID, "modifier": c.Modifier}, nil
+	default:
+		return nil, fmt.Errorf("unsupported criterion type %s", t.Name())
+	}
+}
+
+// savedSceneFilter converts the object filter of a saved scene filter into
+// a scene filter.
+func savedSceneFilter(objectFilter map[string]interface{}) (*models.SceneFilterType, error) {
+	fieldTypes := sceneFilterFieldTypes()
+
+	converted := make(map[string]interface{})
+	for name, v := range objectFilter {
+		t, ok := fieldTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown criterion %q", name)
+		}
+
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+
+		var c savedCriterion
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("decoding criterion %q: %w", name, err)
+		}
+
+		converted[name], err = convertSavedCriterion(name, t, c)
+		if err != nil {
+			return nil, fmt.Errorf("converting criterion %q: %w", name, err)
+		}
+	}
+
+	data, err := json.Marshal(converted)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.SceneFilterType{}
+	if err := json.Unmarshal(data, ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
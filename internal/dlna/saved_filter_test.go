@@ -0,0 +1,164 @@
+package dlna
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestSavedSceneFilter(t *testing.T) {
+	depth := 1
+	value2 := 80
+	organized := true
+	isMissing := "cover"
+	tests := []struct {
+		name         string
+		objectFilter map[string]interface{}
+		want         *models.SceneFilterType
+		wantErr      bool
+	}{
+		{
+			"empty",
+			nil,
+			&models.SceneFilterType{},
+			false,
+		},
+		{
+			"string and bool",
+			map[string]interface{}{
+				"title": map[string]interface{}{
+					"modifier": "INCLUDES",
+					"value":    "foo",
+				},
+				"organized": map[string]interface{}{
+					"modifier": "EQUALS",
+					"value":    "true",
+				},
+				"is_missing": map[string]interface{}{
+					"modifier": "EQUALS",
+					"value":    "cover",
+				},
+			},
+			&models.SceneFilterType{
+				Title: &models.StringCriterionInput{
+					Modifier: models.CriterionModifierIncludes,
+					Value:    "foo",
+				},
+				Organized: &organized,
+				IsMissing: &isMissing,
+			},
+			false,
+		},
+		{
+			"range",
+			map[string]interface{}{
+				"rating100": map[string]interface{}{
+					"modifier": "BETWEEN",
+					"value": map[string]interface{}{
+						"value":  60,
+						"value2": 80,
+					},
+				},
+			},
+			&models.SceneFilterType{
+				Rating100: &models.IntCriterionInput{
+					Modifier: models.CriterionModifierBetween,
+					Value:    60,
+					Value2:   &value2,
+				},
+			},
+			false,
+		},
+		{
+			"labeled ids",
+			map[string]interface{}{
+				"performers": map[string]interface{}{
+					"modifier": "INCLUDES_ALL",
+					"value": map[string]interface{}{
+						"items":    []interface{}{map[string]interface{}{"id": "1", "label": "a"}},
+						"excluded": []interface{}{map[string]interface{}{"id": "2", "label": "b"}},
+					},
+				},
+				"tags": map[string]interface{}{
+					"modifier": "INCLUDES",
+					"value": map[string]interface{}{
+						"items":    []interface{}{map[string]interface{}{"id": "3", "label": "c"}},
+						"excluded": []interface{}{},
+						"depth":    1,
+					},
+				},
+				"galleries": map[string]interface{}{
+					"modifier": "INCLUDES",
+					"value":    []interface{}{map[string]interface{}{"id": "4", "label": "d"}},
+				},
+			},
+			&models.SceneFilterType{
+				Performers: &models.MultiCriterionInput{
+					Modifier: models.CriterionModifierIncludesAll,
+					Value:    []string{"1"},
+					Excludes: []string{"2"},
+				},
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Modifier: models.CriterionModifierIncludes,
+					Value:    []string{"3"},
+					Excludes: []string{},
+					Depth:    &depth,
+				},
+				Galleries: &models.MultiCriterionInput{
+					Modifier: models.CriterionModifierIncludes,
+					Value:    []string{"4"},
+					Excludes: []string{},
+				},
+			},
+			false,
+		},
+		{
+			"enums",
+			map[string]interface{}{
+				"resolution": map[string]interface{}{
+					"modifier": "EQUALS",
+					"value":    "1080p",
+				},
+				"orientation": map[string]interface{}{
+					"modifier": "EQUALS",
+					"value":    []interface{}{"Landscape"},
+				},
+			},
+			&models.SceneFilterType{
+				Resolution: &models.ResolutionCriterionInput{
+					Modifier: models.CriterionModifierEquals,
+					Value:    models.ResolutionEnumFullHd,
+				},
+				Orientation: &models.OrientationCriterionInput{
+					Value: []models.OrientationEnum{models.OrientationLandscape},
+				},
+			},
+			false,
+		},
+		{
+			"unknown criterion",
+			map[string]interface{}{
+				"unknown": map[string]interface{}{
+					"modifier": "EQUALS",
+					"value":    "foo",
+				},
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := savedSceneFilter(tt.objectFilter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("savedSceneFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,640 @@
+package dlna
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anacrolix/dms/upnp"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// invalidSearchCriteriaErrorCode is the UPnP error code returned when the
+// search criteria is unsupported or invalid.
+const invalidSearchCriteriaErrorCode = 708
+
+// sceneClass is the UPnP class of scene items.
+const sceneClass = "object.item.videoItem"
+
+// imageClass is the UPnP class of image items.
+const imageClass = "object.item.imageItem.photo"
+
+// searchCapabilities are the properties that may be used in search criteria.
+var searchCapabilities = []string{"dc:title", "upnp:artist", "upnp:genre", "upnp:class"}
+
+var (
+	errUnexpectedEnd        = errors.New("unexpected end of search criteria")
+	errUnterminatedQuote    = errors.New("unterminated quoted value")
+	errInvalidExistsValue   = errors.New("exists operator requires true or false")
+	errMissingClosingParens = errors.New("missing closing parenthesis")
+)
+
+type search struct {
+	ContainerID    string
+	SearchCriteria string
+	Filter         string
+	StartingIndex  int
+	RequestedCount int
+	SortCriteria   string
+}
+
+// searchExpression is a node of a parsed search criteria expression.
+// Logical expressions have op set to "and" or "or", with left and right
+// operands. Relational expressions have property, operator and value set.
+type searchExpression struct {
+	op    string
+	left  *searchExpression
+	right *searchExpression
+
+	property string
+	operator string
+	value    string
+}
+
+type searchToken struct {
+	value  string
+	quoted bool
+}
+
+func isSearchSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func isSearchOperatorChar(c byte) bool {
+	return c == '=' || c == '!' || c == '<' || c == '>'
+}
+
+func tokenizeSearchCriteria(criteria string) ([]searchToken, error) {
+	var tokens []searchToken
+
+	i := 0
+	for i < len(criteria) {
+		c := criteria[i]
+		switch {
+		case isSearchSpace(c):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, searchToken{value: string(c)})
+			i++
+		case c == '"':
+			var b strings.Builder
+			closed := false
+			i++
+			for i < len(criteria) && !closed {
+				switch {
+				case criteria[i] == '\\' && i+1 < len(criteria):
+					b.WriteByte(criteria[i+1])
+					i += 2
+				case criteria[i] == '"':
+					closed = true
+					i++
+				default:
+					b.WriteByte(criteria[i])
+					i++
+				}
+			}
+
+			if !closed {
+				return nil, errUnterminatedQuote
+			}
+
+			tokens = append(tokens, searchToken{value: b.String(), quoted: true})
+		case isSearchOperatorChar(c):
+			// operators are one character, optionally followed by =
+			j := i + 1
+			if j < len(criteria) && criteria[j] == '=' {
+				j++
+			}
+			tokens = append(tokens, searchToken{value: criteria[i:j]})
+			i = j
+		default:
+			j := i
+			for j < len(criteria) && !isSearchSpace(criteria[j]) && !isSearchOperatorChar(criteria[j]) && !strings.ContainsRune(`()"`, rune(criteria[j])) {
+				j++
+			}
+			tokens = append(tokens, searchToken{value: criteria[i:j]})
+			i = j
+		}
+	}
+
+	return tokens, nil
+}
+
+type searchParser struct {
+	tokens []searchToken
+	pos    int
+}
+
+// parseSearchCriteria parses a UPnP ContentDirectory search criteria string.
+// Returns nil if the criteria matches all objects.
+func parseSearchCriteria(criteria string) (*searchExpression, error) {
+	criteria = strings.TrimSpace(criteria)
+	if criteria == "" || criteria == "*" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeSearchCriteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &searchParser{tokens: tokens}
+	ret, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in search criteria", p.tokens[p.pos].value)
+	}
+
+	return ret, nil
+}
+
+func (p *searchParser) next() (searchToken, error) {
+	if p.pos >= len(p.tokens) {
+		return searchToken{}, errUnexpectedEnd
+	}
+
+	ret := p.tokens[p.pos]
+	p.pos++
+	return ret, nil
+}
+
+// acceptKeyword consumes the next token and returns true if it is the
+// provided unquoted keyword.
+func (p *searchParser) acceptKeyword(keyword string) bool {
+	if p.pos >= len(p.tokens) {
+		return false
+	}
+
+	t := p.tokens[p.pos]
+	if t.quoted || !strings.EqualFold(t.value, keyword) {
+		return false
+	}
+
+	p.pos++
+	return true
+}
+
+// parseOr parses expressions joined by "or". "and" binds more tightly than
+// "or".
+func (p *searchParser) parseOr() (*searchExpression, error) {
+	ret, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = &searchExpression{op: "or", left: ret, right: right}
+	}
+
+	return ret, nil
+}
+
+func (p *searchParser) parseAnd() (*searchExpression, error) {
+	ret, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.acceptKeyword("and") {
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+
+		ret = &searchExpression{op: "and", left: ret, right: right}
+	}
+
+	return ret, nil
+}
+
+func (p *searchParser) parsePrimary() (*searchExpression, error) {
+	t, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.quoted && t.value == "(" {
+		ret, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		closing, err := p.next()
+		if err != nil || closing.quoted || closing.value != ")" {
+			return nil, errMissingClosingParens
+		}
+
+		return ret, nil
+	}
+
+	if t.quoted || t.value == ")" {
+		return nil, fmt.Errorf("expected property, found %q", t.value)
+	}
+
+	operator, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	if operator.quoted {
+		return nil, fmt.Errorf("expected operator, found %q", operator.value)
+	}
+
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &searchExpression{
+		property: t.value,
+		operator: strings.ToLower(operator.value),
+		value:    value.value,
+	}
+
+	if ret.operator == "exists" {
+		if value.quoted {
+			return nil, errInvalidExistsValue
+		}
+
+		ret.value = strings.ToLower(ret.value)
+		if ret.value != "true" && ret.value != "false" {
+			return nil, errInvalidExistsValue
+		}
+	}
+
+	return ret, nil
+}
+
+// searchFilter is the result of translating a search expression. A nil
+// filter matches all items of the translated class, unless none is set.
+type searchFilter struct {
+	filter *models.SceneFilterType
+	none   bool
+}
+
+// searchTranslator translates search expressions for items of the UPnP
+// class set in class.
+type searchTranslator struct {
+	performerFinder PerformerFinder
+	tagFinder       TagFinder
+	class           string
+}
+
+func invalidSearchCriteria(format string, args ...interface{}) error {
+	return upnp.Errorf(invalidSearchCriteriaErrorCode, format, args...)
+}
+
+// translate converts a parsed search expression into a scene filter.
+func (t *searchTranslator) translate(ctx context.Context, exp *searchExpression) (searchFilter, error) {
+	if exp == nil {
+		return searchFilter{}, nil
+	}
+
+	switch exp.op {
+	case "and", "or":
+		left, err := t.translate(ctx, exp.left)
+		if err != nil {
+			return searchFilter{}, err
+		}
+
+		right, err := t.translate(ctx, exp.right)
+		if err != nil {
+			return searchFilter{}, err
+		}
+
+		if exp.op == "and" {
+			return andSearchFilters(left, right)
+		}
+		return orSearchFilters(left, right)
+	}
+
+	switch exp.property {
+	case "dc:title":
+		c, err := titleCriterion(exp.operator, exp.value)
+		if err != nil {
+			return searchFilter{}, err
+		}
+
+		return searchFilter{filter: &models.SceneFilterType{Title: c}}, nil
+	case "upnp:artist":
+		return t.performerFilter(ctx, exp.operator, exp.value)
+	case "upnp:genre":
+		return t.tagFilter(ctx, exp.operator, exp.value)
+	case "upnp:class":
+		matches, err := classMatches(t.class, exp.operator, exp.value)
+		if err != nil {
+			return searchFilter{}, err
+		}
+
+		return searchFilter{none: !matches}, nil
+	default:
+		return searchFilter{}, invalidSearchCriteria("unsupported search property %q", exp.property)
+	}
+}
+
+func titleCriterion(operator, value string) (*models.StringCriterionInput, error) {
+	ret := &models.StringCriterionInput{
+		Value: value,
+	}
+
+	switch operator {
+	case "=":
+		ret.Modifier = models.CriterionModifierEquals
+	case "!=":
+		ret.Modifier = models.CriterionModifierNotEquals
+	case "contains":
+		ret.Modifier = models.CriterionModifierIncludes
+	case "doesnotcontain":
+		ret.Modifier = models.CriterionModifierExcludes
+	case "exists":
+		ret.Value = ""
+		ret.Modifier = models.CriterionModifierIsNull
+		if value == "true" {
+			ret.Modifier = models.CriterionModifierNotNull
+		}
+	default:
+		return nil, invalidSearchCriteria("unsupported operator %q for dc:title", operator)
+	}
+
+	return ret, nil
+}
+
+// nameCriterion returns the criterion used to find the performers or tags
+// matched by a search operator. Returns true if the matching objects should
+// be excluded instead of included.
+func nameCriterion(property, operator, value string) (*models.StringCriterionInput, bool, error) {
+	ret := &models.StringCriterionInput{
+		Value: value,
+	}
+
+	exclude := false
+	switch operator {
+	case "=", "!=":
+		ret.Modifier = models.CriterionModifierEquals
+		exclude = operator == "!="
+	case "contains", "doesnotcontain":
+		ret.Modifier = models.CriterionModifierIncludes
+		exclude = operator == "doesnotcontain"
+	default:
+		return nil, false, invalidSearchCriteria("unsupported operator %q for %s", operator, property)
+	}
+
+	return ret, exclude, nil
+}
+
+// relatedSearchFilter returns a search filter matching scenes with, or
+// without if exclude is set, any of the provided related object ids.
+func relatedSearchFilter(ids []string, exclude bool, makeFilter func(modifier models.CriterionModifier, ids []string) *models.SceneFilterType) searchFilter {
+	if len(ids) == 0 {
+		// nothing to exclude matches everything, nothing to include matches nothing
+		return searchFilter{none: !exclude}
+	}
+
+	modifier := models.CriterionModifierIncludes
+	if exclude {
+		modifier = models.CriterionModifierExcludes
+	}
+
+	return searchFilter{filter: makeFilter(modifier, ids)}
+}
+
+func existsModifier(value string) models.CriterionModifier {
+	if value == "true" {
+		return models.CriterionModifierNotNull
+	}
+	return models.CriterionModifierIsNull
+}
+
+func (t *searchTranslator) performerFilter(ctx context.Context, operator, value string) (searchFilter, error) {
+	makeFilter := func(modifier models.CriterionModifier, ids []string) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Performers: &models.MultiCriterionInput{
+				Modifier: modifier,
+				Value:    ids,
+			},
+		}
+	}
+
+	if operator == "exists" {
+		return searchFilter{filter: makeFilter(existsModifier(value), nil)}, nil
+	}
+
+	c, exclude, err := nameCriterion("upnp:artist", operator, value)
+	if err != nil {
+		return searchFilter{}, err
+	}
+
+	perPage := models.PerPageAll
+	performers, _, err := t.performerFinder.Query(ctx, &models.PerformerFilterType{
+		Name: c,
+	}, &models.FindFilterType{
+		PerPage: &perPage,
+	})
+	if err != nil {
+		return searchFilter{}, err
+	}
+
+	var ids []string
+	for _, p := range performers {
+		ids = append(ids, strconv.Itoa(p.ID))
+	}
+
+	return relatedSearchFilter(ids, exclude, makeFilter), nil
+}
+
+func (t *searchTranslator) tagFilter(ctx context.Context, operator, value string) (searchFilter, error) {
+	makeFilter := func(modifier models.CriterionModifier, ids []string) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Tags: &models.HierarchicalMultiCriterionInput{
+				Modifier: modifier,
+				Value:    ids,
+			},
+		}
+	}
+
+	if operator == "exists" {
+		return searchFilter{filter: makeFilter(existsModifier(value), nil)}, nil
+	}
+
+	c, exclude, err := nameCriterion("upnp:genre", operator, value)
+	if err != nil {
+		return searchFilter{}, err
+	}
+
+	perPage := models.PerPageAll
+	tags, _, err := t.tagFinder.Query(ctx, &models.TagFilterType{
+		Name: c,
+	}, &models.FindFilterType{
+		PerPage: &perPage,
+	})
+	if err != nil {
+		return searchFilter{}, err
+	}
+
+	var ids []string
+	for _, tag := range tags {
+		ids = append(ids, strconv.Itoa(tag.ID))
+	}
+
+	return relatedSearchFilter(ids, exclude, makeFilter), nil
+}
+
+// classMatches returns true if the provided item class satisfies the
+// provided upnp:class criterion.
+func classMatches(class, operator, value string) (bool, error) {
+	switch operator {
+	case "=":
+		return value == class, nil
+	case "!=":
+		return value != class, nil
+	case "derivedfrom":
+		return class == value || strings.HasPrefix(class, value+"."), nil
+	case "contains":
+		return strings.Contains(class, value), nil
+	case "doesnotcontain":
+		return !strings.Contains(class, value), nil
+	case "exists":
+		return value == "true", nil
+	default:
+		return false, invalidSearchCriteria("unsupported operator %q for upnp:class", operator)
+	}
+}
+
+func andSearchFilters(left, right searchFilter) (searchFilter, error) {
+	switch {
+	case left.none || right.none:
+		return searchFilter{none: true}, nil
+	case left.filter == nil:
+		return right, nil
+	case right.filter == nil:
+		return left, nil
+	}
+
+	f, err := combineSceneFilters(left.filter, right.filter, true)
+	if err != nil {
+		return searchFilter{}, err
+	}
+
+	return searchFilter{filter: f}, nil
+}
+
+func orSearchFilters(left, right searchFilter) (searchFilter, error) {
+	switch {
+	case left.none:
+		return right, nil
+	case right.none:
+		return left, nil
+	case left.filter == nil || right.filter == nil:
+		return searchFilter{}, nil
+	}
+
+	f, err := combineSceneFilters(left.filter, right.filter, false)
+	if err != nil {
+		return searchFilter{}, err
+	}
+
+	return searchFilter{filter: f}, nil
+}
+
+// combineSceneFilters joins two scene filters with AND, or with OR if and is
+// false. A scene filter may only have a single sub-filter, so one of the
+// filters must either have no sub-filter, or a sub-filter using the same
+// operator that the other filter can be appended to.
+func combineSceneFilters(a, b *models.SceneFilterType, and bool) (*models.SceneFilterType, error) {
+	getSub := func(f *models.SceneFilterType) *models.SceneFilterType {
+		if and {
+			return f.And
+		}
+		return f.Or
+	}
+
+	withSub := func(f *models.SceneFilterType, sub *models.SceneFilterType) *models.SceneFilterType {
+		ret := *f
+		if and {
+			ret.And = sub
+		} else {
+			ret.Or = sub
+		}
+		return &ret
+	}
+
+	switch {
+	case a.SubFilter() == nil:
+		return withSub(a, b), nil
+	case b.SubFilter() == nil:
+		return withSub(b, a), nil
+	case getSub(a) != nil:
+		sub, err := combineSceneFilters(getSub(a), b, and)
+		if err != nil {
+			return nil, err
+		}
+		return withSub(a, sub), nil
+	case getSub(b) != nil:
+		sub, err := combineSceneFilters(getSub(b), a, and)
+		if err != nil {
+			return nil, err
+		}
+		return withSub(b, sub), nil
+	}
+
+	return nil, invalidSearchCriteria("search criteria is too complex")
+}
+
+// imageFilterFromSceneFilter converts a translated scene filter into the
+// equivalent image filter. Only the criteria produced by searchTranslator
+// are converted.
+func imageFilterFromSceneFilter(f *models.SceneFilterType) *models.ImageFilterType {
+	if f == nil {
+		return nil
+	}
+
+	return &models.ImageFilterType{
+		OperatorFilter: models.OperatorFilter[models.ImageFilterType]{
+			And: imageFilterFromSceneFilter(f.And),
+			Or:  imageFilterFromSceneFilter(f.Or),
+			Not: imageFilterFromSceneFilter(f.Not),
+		},
+		Title:      f.Title,
+		Performers: f.Performers,
+		Tags:       f.Tags,
+	}
+}
+
+// searchFindFilter returns the find filter for the requested range of search
+// results, and the number of results to skip from the start of the returned
+// page. A requested count of zero returns all results.
+func searchFindFilter(startingIndex, requestedCount int, sort string, direction models.SortDirectionEnum) (*models.FindFilterType, int) {
+	ret := &models.FindFilterType{
+		Sort:      &sort,
+		Direction: &direction,
+	}
+
+	page := 1
+	perPage := models.PerPageAll
+	skip := startingIndex
+
+	switch {
+	case requestedCount <= 0:
+	case startingIndex%requestedCount == 0:
+		perPage = requestedCount
+		page = startingIndex/requestedCount + 1
+		skip = 0
+	default:
+		perPage = startingIndex + requestedCount
+	}
+
+	ret.Page = &page
+	ret.PerPage = &perPage
+
+	return ret, skip
+}
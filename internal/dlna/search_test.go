@@ -0,0 +1,296 @@
+package dlna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestParseSearchCriteria(t *testing.T) {
+	titleContains := &searchExpression{property: "dc:title", operator: "contains", value: "foo"}
+
+	tests := []struct {
+		name     string
+		criteria string
+		want     *searchExpression
+		wantErr  bool
+	}{
+		{"all", "*", nil, false},
+		{"empty", "", nil, false},
+		{"relational", `dc:title contains "foo"`, titleContains, false},
+		{"escaped quote", `dc:title = "a \"b\""`, &searchExpression{property: "dc:title", operator: "=", value: `a "b"`}, false},
+		{"operator without spaces", `dc:title!="foo"`, &searchExpression{property: "dc:title", operator: "!=", value: "foo"}, false},
+		{"case insensitive operator", `dc:title Contains "foo"`, titleContains, false},
+		{"exists", `upnp:artist exists true`, &searchExpression{property: "upnp:artist", operator: "exists", value: "true"}, false},
+		{
+			"and binds tighter than or",
+			`dc:title contains "foo" or upnp:genre = "a" and upnp:artist = "b"`,
+			&searchExpression{
+				op:   "or",
+				left: titleContains,
+				right: &searchExpression{
+					op:    "and",
+					left:  &searchExpression{property: "upnp:genre", operator: "=", value: "a"},
+					right: &searchExpression{property: "upnp:artist", operator: "=", value: "b"},
+				},
+			},
+			false,
+		},
+		{
+			"parentheses",
+			`(upnp:class derivedfrom "object.item.videoItem") and (dc:title contains "foo")`,
+			&searchExpression{
+				op:    "and",
+				left:  &searchExpression{property: "upnp:class", operator: "derivedfrom", value: "object.item.videoItem"},
+				right: titleContains,
+			},
+			false,
+		},
+		{"missing value", `dc:title contains`, nil, true},
+		{"unterminated quote", `dc:title contains "foo`, nil, true},
+		{"missing closing parenthesis", `(dc:title contains "foo"`, nil, true},
+		{"trailing tokens", `dc:title contains "foo" "bar"`, nil, true},
+		{"invalid exists value", `dc:title exists "true"`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchCriteria(tt.criteria)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSearchCriteria() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchTranslate(t *testing.T) {
+	titleFilter := func(modifier models.CriterionModifier, value string) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Title: &models.StringCriterionInput{
+				Modifier: modifier,
+				Value:    value,
+			},
+		}
+	}
+
+	const performerID = 1
+
+	db := mocks.NewDatabase()
+	db.Performer.On("Query", mock.Anything, &models.PerformerFilterType{
+		Name: &models.StringCriterionInput{
+			Modifier: models.CriterionModifierEquals,
+			Value:    "performer",
+		},
+	}, mock.Anything).Return([]*models.Performer{{ID: performerID}}, 1, nil)
+	db.Performer.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	translator := searchTranslator{
+		performerFinder: db.Performer,
+		tagFinder:       db.Tag,
+		class:           sceneClass,
+	}
+
+	withOr := func(f *models.SceneFilterType, or *models.SceneFilterType) *models.SceneFilterType {
+		f.Or = or
+		return f
+	}
+
+	withAnd := func(f *models.SceneFilterType, and *models.SceneFilterType) *models.SceneFilterType {
+		f.And = and
+		return f
+	}
+
+	tests := []struct {
+		name     string
+		criteria string
+		want     searchFilter
+		wantErr  bool
+	}{
+		{"all", "*", searchFilter{}, false},
+		{"title contains", `dc:title contains "foo"`, searchFilter{filter: titleFilter(models.CriterionModifierIncludes, "foo")}, false},
+		{"title not equals", `dc:title != "foo"`, searchFilter{filter: titleFilter(models.CriterionModifierNotEquals, "foo")}, false},
+		{"video class", `upnp:class derivedfrom "object.item"`, searchFilter{}, false},
+		{"image class", `upnp:class derivedfrom "object.item.imageItem"`, searchFilter{none: true}, false},
+		{
+			"class and title",
+			`upnp:class derivedfrom "object.item.videoItem" and dc:title contains "foo"`,
+			searchFilter{filter: titleFilter(models.CriterionModifierIncludes, "foo")},
+			false,
+		},
+		{
+			"image class or title",
+			`upnp:class = "object.item.imageItem" or dc:title contains "foo"`,
+			searchFilter{filter: titleFilter(models.CriterionModifierIncludes, "foo")},
+			false,
+		},
+		{
+			"artist",
+			`upnp:artist = "performer"`,
+			searchFilter{filter: &models.SceneFilterType{
+				Performers: &models.MultiCriterionInput{
+					Modifier: models.CriterionModifierIncludes,
+					Value:    []string{"1"},
+				},
+			}},
+			false,
+		},
+		{"unknown artist", `upnp:artist = "unknown"`, searchFilter{none: true}, false},
+		{"exclude unknown artist", `upnp:artist != "unknown"`, searchFilter{}, false},
+		{
+			"or chain",
+			`dc:title contains "a" or dc:title contains "b" or dc:title contains "c"`,
+			searchFilter{filter: withOr(
+				titleFilter(models.CriterionModifierIncludes, "c"),
+				withOr(
+					titleFilter(models.CriterionModifierIncludes, "a"),
+					titleFilter(models.CriterionModifierIncludes, "b"),
+				),
+			)},
+			false,
+		},
+		{
+			"and of or",
+			`dc:title contains "a" and (dc:title contains "b" or dc:title contains "c")`,
+			searchFilter{filter: withAnd(
+				titleFilter(models.CriterionModifierIncludes, "a"),
+				withOr(
+					titleFilter(models.CriterionModifierIncludes, "b"),
+					titleFilter(models.CriterionModifierIncludes, "c"),
+				),
+			)},
+			false,
+		},
+		{"too complex", `(dc:title = "a" or dc:title = "b") and (dc:title = "c" or dc:title = "d")`, searchFilter{}, true},
+		{"unsupported property", `dc:date = "2020-01-01"`, searchFilter{}, true},
+		{"unsupported operator", `dc:title < "foo"`, searchFilter{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp, err := parseSearchCriteria(tt.criteria)
+			if err != nil {
+				t.Errorf("parseSearchCriteria() error = %v", err)
+				return
+			}
+
+			got, err := translator.translate(context.Background(), exp)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("translate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchTranslateImageClass(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	translator := searchTranslator{
+		performerFinder: db.Performer,
+		tagFinder:       db.Tag,
+		class:           imageClass,
+	}
+
+	titleFilter := &models.SceneFilterType{
+		Title: &models.StringCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    "foo",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		criteria string
+		want     searchFilter
+	}{
+		{"image class", `upnp:class derivedfrom "object.item.imageItem"`, searchFilter{}},
+		{"photo class", `upnp:class = "object.item.imageItem.photo"`, searchFilter{}},
+		{"video class", `upnp:class derivedfrom "object.item.videoItem"`, searchFilter{none: true}},
+		{
+			"video class or title",
+			`upnp:class = "object.item.videoItem" or dc:title contains "foo"`,
+			searchFilter{filter: titleFilter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exp, err := parseSearchCriteria(tt.criteria)
+			if err != nil {
+				t.Errorf("parseSearchCriteria() error = %v", err)
+				return
+			}
+
+			got, err := translator.translate(context.Background(), exp)
+			if err != nil {
+				t.Errorf("translate() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestImageFilterFromSceneFilter(t *testing.T) {
+	title := &models.StringCriterionInput{
+		Modifier: models.CriterionModifierIncludes,
+		Value:    "foo",
+	}
+	performers := &models.MultiCriterionInput{
+		Modifier: models.CriterionModifierIncludes,
+		Value:    []string{"1"},
+	}
+
+	sceneFilter := &models.SceneFilterType{
+		Title: title,
+	}
+	sceneFilter.Or = &models.SceneFilterType{
+		Performers: performers,
+	}
+
+	want := &models.ImageFilterType{
+		Title: title,
+	}
+	want.Or = &models.ImageFilterType{
+		Performers: performers,
+	}
+
+	assert.Equal(t, want, imageFilterFromSceneFilter(sceneFilter))
+	assert.Nil(t, imageFilterFromSceneFilter(nil))
+}
+
+func TestSearchFindFilter(t *testing.T) {
+	tests := []struct {
+		name           string
+		startingIndex  int
+		requestedCount int
+		wantPage       int
+		wantPerPage    int
+		wantSkip       int
+	}{
+		{"all", 0, 0, 1, models.PerPageAll, 0},
+		{"all from index", 5, 0, 1, models.PerPageAll, 5},
+		{"aligned", 20, 10, 3, 10, 0},
+		{"unaligned", 5, 10, 1, 15, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, skip := searchFindFilter(tt.startingIndex, tt.requestedCount, "title", models.SortDirectionEnumAsc)
+			assert.Equal(t, tt.wantPage, *got.Page)
+			assert.Equal(t, tt.wantPerPage, *got.PerPage)
+			assert.Equal(t, tt.wantSkip, skip)
+		})
+	}
+}
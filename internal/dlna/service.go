@@ -23,6 +23,10 @@ type Repository struct {
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
 	GroupFinder     GroupFinder
+	ImageFinder     ImageFinder
+	GalleryFinder   GalleryFinder
+
+	SavedFilterFinder SavedFilterFinder
 }
 
 func NewRepository(repo models.Repository) Repository {
@@ -34,6 +38,10 @@ func NewRepository(repo models.Repository) Repository {
 		TagFinder:       repo.Tag,
 		PerformerFinder: repo.Performer,
 		GroupFinder:     repo.Group,
+		ImageFinder:     repo.Image,
+		GalleryFinder:   repo.Gallery,
+
+		SavedFilterFinder: repo.SavedFilter,
 	}
 }
 
@@ -71,6 +79,11 @@ type sceneServer interface {
 	ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request)
 }
 
+type imageServer interface {
+	ServeImage(image *models.Image, w http.ResponseWriter, r *http.Request)
+	ServeThumbnail(image *models.Image, w http.ResponseWriter, r *http.Request)
+}
+
 type Config interface {
 	GetDLNAInterfaces() []string
 	GetDLNAServerName() string
@@ -83,6 +96,7 @@ type Service struct {
 	repository     Repository
 	config         Config
 	sceneServer    sceneServer
+	imageServer    imageServer
 	ipWhitelistMgr *ipWhitelistManager
 
 	server  *Server
@@ -154,6 +168,7 @@ func (s *Service) init() error {
 	s.server = &Server{
 		repository:         s.repository,
 		sceneServer:        s.sceneServer,
+		imageServer:        s.imageServer,
 		ipWhitelistManager: s.ipWhitelistMgr,
 		Interfaces:         interfaces,
 		HTTPConn: func() net.Listener {
@@ -215,10 +230,11 @@ func (s *Service) init() error {
 // }
 
 // NewService initialises and returns a new DLNA service.
-func NewService(repo Repository, cfg Config, sceneServer sceneServer) *Service {
+func NewService(repo Repository, cfg Config, sceneServer sceneServer, imageServer imageServer) *Service {
 	ret := &Service{
 		repository:  repo,
 		sceneServer: sceneServer,
+		imageServer: imageServer,
 		config:      cfg,
 		ipWhitelistMgr: &ipWhitelistManager{
 			config: cfg,
@@ -57,12 +57,14 @@ type SceneIdentifier struct {
 	SceneUpdatePostHookExecutor SceneUpdatePostHookExecutor
 }
 
-func (t *SceneIdentifier) Identify(ctx context.Context, scene *models.Scene) error {
+// Identify scrapes the scene from the configured sources and applies the
+// first result found. Returns true if the scene was modified using a result.
+func (t *SceneIdentifier) Identify(ctx context.Context, scene *models.Scene) (bool, error) {
 	result, err := t.scrapeScene(ctx, scene)
 	var multipleMatchErr *MultipleMatchesFoundError
 	if err != nil {
 		if !errors.As(err, &multipleMatchErr) {
-			return err
+			return false, err
 		}
 	}
 
@@ -76,22 +78,22 @@ func (t *SceneIdentifier) Identify(ctx context.Context, scene *models.Scene) err
 				// Tag it with the multiple results tag
 				err := t.addTagToScene(ctx, scene, *options.SkipMultipleMatchTag)
 				if err != nil {
-					return err
+					return false, err
 				}
-				return nil
+				return false, nil
 			}
 		} else {
 			logger.Debugf("Unable to identify %s", scene.Path)
 		}
-		return nil
+		return false, nil
 	}
 
 	// results were found, modify the scene
 	if err := t.modifyScene(ctx, scene, result); err != nil {
-		return fmt.Errorf("error modifying scene: %v", err)
+		return false, fmt.Errorf("error modifying scene: %v", err)
 	}
 
-	return nil
+	return true, nil
 }
 
 type scrapeResult struct {
@@ -205,7 +205,7 @@ func TestSceneIdentifier_Identify(t *testing.T) {
 				TagIDs:       models.NewRelatedIDs([]int{}),
 				StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
 			}
-			if err := identifier.Identify(testCtx, scene); (err != nil) != tt.wantErr {
+			if _, err := identifier.Identify(testCtx, scene); (err != nil) != tt.wantErr {
 				t.Errorf("SceneIdentifier.Identify() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
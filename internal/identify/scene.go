@@ -132,6 +132,7 @@ func (g sceneRelationships) tags(ctx context.Context) ([]int, error) {
 	fieldStrategy := g.fieldOptions["tags"]
 	scraped := g.result.result.Tags
 	target := g.scene
+	endpoint := g.result.source.RemoteSite
 
 	// just check if ignored
 	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
@@ -162,10 +163,9 @@ func (g sceneRelationships) tags(ctx context.Context) ([]int, error) {
 
 			tagIDs = sliceutil.AppendUnique(tagIDs, int(tagID))
 		} else if createMissing {
-			newTag := models.NewTag()
-			newTag.Name = t.Name
+			newTag := t.ToTag(endpoint)
 
-			err := g.tagCreator.Create(ctx, &newTag)
+			err := g.tagCreator.Create(ctx, newTag)
 			if err != nil {
 				return nil, fmt.Errorf("error creating tag: %w", err)
 			}
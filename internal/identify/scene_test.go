@@ -345,6 +345,9 @@ func Test_sceneRelationships_tags(t *testing.T) {
 	existingIDStr := strconv.Itoa(existingID)
 	validName := "validName"
 	invalidName := "invalidName"
+	stashIDName := "stashIDName"
+	remoteSiteID := "remoteSiteID"
+	endpoint := "endpoint"
 
 	defaultOptions := &FieldOptions{
 		Strategy: FieldStrategyMerge,
@@ -369,7 +372,18 @@ func Test_sceneRelationships_tags(t *testing.T) {
 	db := mocks.NewDatabase()
 
 	db.Tag.On("Create", testCtx, mock.MatchedBy(func(p *models.Tag) bool {
-		return p.Name == validName
+		return p.Name == validName && !p.StashIDs.Loaded()
+	})).Run(func(args mock.Arguments) {
+		t := args.Get(1).(*models.Tag)
+		t.ID = validStoredIDInt
+	}).Return(nil)
+	db.Tag.On("Create", testCtx, mock.MatchedBy(func(p *models.Tag) bool {
+		return p.Name == stashIDName && reflect.DeepEqual(p.StashIDs.List(), []models.StashID{
+			{
+				StashID:  remoteSiteID,
+				Endpoint: endpoint,
+			},
+		})
 	})).Run(func(args mock.Arguments) {
 		t := args.Get(1).(*models.Tag)
 		t.ID = validStoredIDInt
@@ -485,6 +499,22 @@ func Test_sceneRelationships_tags(t *testing.T) {
 			[]int{validStoredIDInt},
 			false,
 		},
+		{
+			"create missing with stash id",
+			emptyScene,
+			&FieldOptions{
+				Strategy:      FieldStrategyOverwrite,
+				CreateMissing: &createMissing,
+			},
+			[]*models.ScrapedTag{
+				{
+					Name:         stashIDName,
+					RemoteSiteID: &remoteSiteID,
+				},
+			},
+			[]int{validStoredIDInt},
+			false,
+		},
 		{
 			"error creating",
 			emptyScene,
@@ -509,6 +539,9 @@ func Test_sceneRelationships_tags(t *testing.T) {
 				result: &scraper.ScrapedScene{
 					Tags: tt.scraped,
 				},
+				source: ScraperSource{
+					RemoteSite: endpoint,
+				},
 			}
 
 			got, err := tr.tags(testCtx)
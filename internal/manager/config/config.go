@@ -27,12 +27,14 @@ import (
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/utils"
+	"github.com/stashapp/stash/pkg/webhook"
 )
 
 const (
 	Stash               = "stash"
 	Cache               = "cache"
 	BackupDirectoryPath = "backup_directory_path"
+	TrashPath           = "trash_path"
 	Generated           = "generated"
 	Metadata            = "metadata"
 	BlobsPath           = "blobs_path"
@@ -55,10 +57,21 @@ const (
 	ImageExclude = "image_exclude"
 
 	VideoExtensions            = "video_extensions"
+	AudioExtensions            = "audio_extensions"
 	ImageExtensions            = "image_extensions"
 	GalleryExtensions          = "gallery_extensions"
 	CreateGalleriesFromFolders = "create_galleries_from_folders"
 
+	// WatchStashes is the config key used to determine if the stash paths
+	// should be watched for changes.
+	WatchStashes        = "watch_stashes"
+	watchStashesDefault = false
+
+	// WatchDebounce is the number of seconds to wait after the last change
+	// before queuing a scan of the changed paths.
+	WatchDebounce        = "watch_debounce"
+	watchDebounceDefault = 5
+
 	// CalculateMD5 is the config key used to determine if MD5 should be calculated
 	// for video files.
 	CalculateMD5 = "calculate_md5"
@@ -256,6 +269,20 @@ const (
 	LogAccess        = "logaccess"
 	defaultLogAccess = true
 
+	// JobHistoryRetention is the number of days to keep the history of
+	// finished jobs. Zero keeps the history indefinitely.
+	JobHistoryRetention        = "job_history_retention"
+	jobHistoryRetentionDefault = 30
+
+	// TrashRetention is the number of days to keep deleted files in the
+	// trash. Zero keeps the files indefinitely.
+	TrashRetention        = "trash_retention"
+	trashRetentionDefault = 30
+
+	// SceneFileRanking are the rules used to select the primary file of
+	// scenes with multiple files.
+	SceneFileRanking = "scene_file_ranking"
+
 	// Default settings
 	DefaultScanSettings     = "defaults.scan_task"
 	DefaultIdentifySettings = "defaults.identify_task"
@@ -266,6 +293,12 @@ const (
 	DeleteGeneratedDefault        = "defaults.delete_generated"
 	deleteGeneratedDefaultDefault = true
 
+	// Scheduled tasks
+	ScheduledTasks = "scheduled_tasks"
+
+	// Outgoing webhooks
+	Webhooks = "webhooks"
+
 	// Desktop Integration Options
 	NoBrowser                           = "nobrowser"
 	NoBrowserDefault                    = false
@@ -284,6 +317,7 @@ const (
 // slice default values
 var (
 	defaultVideoExtensions   = []string{"m4v", "mp4", "mov", "wmv", "avi", "mpg", "mpeg", "rmvb", "rm", "flv", "asf", "mkv", "webm"}
+	defaultAudioExtensions   = []string{"mp3", "m4a", "aac", "flac", "ogg", "oga", "opus", "wav", "wma"}
 	defaultImageExtensions   = []string{"png", "jpg", "jpeg", "gif", "webp"}
 	defaultGalleryExtensions = []string{"zip", "cbz"}
 	defaultMenuItems         = []string{"scenes", "images", "movies", "markers", "galleries", "performers", "studios", "tags"}
@@ -707,6 +741,21 @@ func (i *Config) GetBackupDirectoryPathOrDefault() string {
 	return ret
 }
 
+func (i *Config) GetTrashPath() string {
+	return i.getString(TrashPath)
+}
+
+// GetTrashPathOrDefault returns the directory that deleted files are moved
+// into. Defaults to the trash directory in the config directory.
+func (i *Config) GetTrashPathOrDefault() string {
+	ret := i.GetTrashPath()
+	if ret == "" {
+		return filepath.Join(i.GetConfigPath(), "trash")
+	}
+
+	return ret
+}
+
 // GetFFMpegPath returns the path to the FFMpeg executable.
 // If empty, stash will attempt to resolve it from the path.
 func (i *Config) GetFFMpegPath() string {
@@ -750,6 +799,14 @@ func (i *Config) GetVideoExtensions() []string {
 	return ret
 }
 
+func (i *Config) GetAudioExtensions() []string {
+	ret := i.getStringSlice(AudioExtensions)
+	if len(ret) == 0 {
+		ret = defaultAudioExtensions
+	}
+	return ret
+}
+
 func (i *Config) GetImageExtensions() []string {
 	ret := i.getStringSlice(ImageExtensions)
 	if len(ret) == 0 {
@@ -770,6 +827,22 @@ func (i *Config) GetCreateGalleriesFromFolders() bool {
 	return i.getBool(CreateGalleriesFromFolders)
 }
 
+// IsWatchStashes returns true if the stash paths should be watched for
+// changes.
+func (i *Config) IsWatchStashes() bool {
+	return i.getBool(WatchStashes)
+}
+
+// GetWatchDebounce returns the number of seconds to wait after the last
+// filesystem change before queuing a scan of the changed paths.
+func (i *Config) GetWatchDebounce() int {
+	ret := i.getInt(WatchDebounce)
+	if ret <= 0 {
+		ret = watchDebounceDefault
+	}
+	return ret
+}
+
 func (i *Config) GetLanguage() string {
 	ret := i.getString(Language)
 
@@ -1450,6 +1523,26 @@ func (i *Config) GetDeleteGeneratedDefault() bool {
 	return i.getBoolDefault(DeleteGeneratedDefault, deleteGeneratedDefaultDefault)
 }
 
+// GetScheduledTasks returns the configured scheduled tasks.
+func (i *Config) GetScheduledTasks() []*ScheduledTask {
+	var ret []*ScheduledTask
+	if err := i.unmarshalKey(ScheduledTasks, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// GetWebhooks returns the configured outgoing webhooks.
+func (i *Config) GetWebhooks() []*webhook.Webhook {
+	var ret []*webhook.Webhook
+	if err := i.unmarshalKey(Webhooks, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
 // GetDefaultIdentifySettings returns the default Identify task settings.
 // Returns nil if the settings could not be unmarshalled, or if it
 // has not been set.
@@ -1619,6 +1712,46 @@ func (i *Config) GetLogAccess() bool {
 	return i.getBoolDefault(LogAccess, defaultLogAccess)
 }
 
+// GetJobHistoryRetention returns the number of days to keep the history of
+// finished jobs. Returns zero if the history should be kept indefinitely.
+func (i *Config) GetJobHistoryRetention() int {
+	ret := i.getInt(JobHistoryRetention)
+	if ret < 0 {
+		ret = 0
+	}
+	return ret
+}
+
+// GetTrashRetention returns the number of days to keep deleted files in the
+// trash. Returns zero if the files should be kept indefinitely.
+func (i *Config) GetTrashRetention() int {
+	ret := i.getInt(TrashRetention)
+	if ret < 0 {
+		ret = 0
+	}
+	return ret
+}
+
+// GetSceneFileRanking returns the rules used to select the primary file of
+// scenes with multiple files. Returns the default rules if they have not
+// been set.
+func (i *Config) GetSceneFileRanking() models.SceneFileRanking {
+	i.RLock()
+	defer i.RUnlock()
+	v := i.forKey(SceneFileRanking)
+
+	if v.Exists(SceneFileRanking) && v.Get(SceneFileRanking) != nil {
+		var ret models.SceneFileRanking
+		if err := v.Unmarshal(SceneFileRanking, &ret); err != nil {
+			logger.Warnf("error in unmarshalkey: %v", err)
+		} else {
+			return ret
+		}
+	}
+
+	return models.DefaultSceneFileRanking()
+}
+
 // Max allowed graphql upload size in megabytes
 func (i *Config) GetMaxUploadSize() int64 {
 	i.RLock()
@@ -1775,6 +1908,12 @@ func (i *Config) setDefaultValues() {
 	i.setDefault(WriteImageThumbnails, writeImageThumbnailsDefault)
 	i.setDefault(CreateImageClipsFromVideos, createImageClipsFromVideosDefault)
 
+	i.setDefault(WatchStashes, watchStashesDefault)
+	i.setDefault(WatchDebounce, watchDebounceDefault)
+
+	i.setDefault(JobHistoryRetention, jobHistoryRetentionDefault)
+	i.setDefault(TrashRetention, trashRetentionDefault)
+
 	i.setDefault(Database, defaultDatabaseFilePath)
 
 	i.setDefault(dangerousAllowPublicWithoutAuth, dangerousAllowPublicWithoutAuthDefault)
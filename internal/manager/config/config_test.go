@@ -3,6 +3,9 @@ package config
 import (
 	"testing"
 
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,3 +35,35 @@ func TestConfig_GetAllPluginConfiguration(t *testing.T) {
 		"plugin2": {"key3": "value3"},
 	}, i.GetAllPluginConfiguration())
 }
+
+func TestConfig_GetSceneFileRanking(t *testing.T) {
+	i := InitializeEmpty()
+
+	assert.Equal(t, models.DefaultSceneFileRanking(), i.GetSceneFileRanking())
+
+	ranking := models.SceneFileRanking{
+		Criteria: []models.SceneFileRankingCriterion{
+			models.SceneFileRankingCriterionPath,
+			models.SceneFileRankingCriterionCodec,
+		},
+		PreferredCodecs:     []string{"hevc", "h264"},
+		PreferredContainers: []string{},
+		PathPriority:        []string{"/stash/best"},
+	}
+	i.SetInterface(SceneFileRanking, ranking)
+
+	assert.Equal(t, ranking, i.GetSceneFileRanking())
+
+	// ensure the rules are read back from the config file
+	data, err := i.marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := InitializeEmpty()
+	if err := loaded.main.Load(rawbytes.Provider(data), yaml.Parser()); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ranking, loaded.GetSceneFileRanking())
+}
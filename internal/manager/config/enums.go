@@ -48,3 +48,52 @@ func (e *BlobsStorageType) UnmarshalGQL(v interface{}) error {
 func (e BlobsStorageType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+type ScheduledTaskType string
+
+const (
+	ScheduledTaskTypeScan     ScheduledTaskType = "SCAN"
+	ScheduledTaskTypeGenerate ScheduledTaskType = "GENERATE"
+	ScheduledTaskTypeIdentify ScheduledTaskType = "IDENTIFY"
+	ScheduledTaskTypeAutoTag  ScheduledTaskType = "AUTO_TAG"
+	ScheduledTaskTypeBackup   ScheduledTaskType = "BACKUP"
+	ScheduledTaskTypePlugin   ScheduledTaskType = "PLUGIN"
+)
+
+var AllScheduledTaskType = []ScheduledTaskType{
+	ScheduledTaskTypeScan,
+	ScheduledTaskTypeGenerate,
+	ScheduledTaskTypeIdentify,
+	ScheduledTaskTypeAutoTag,
+	ScheduledTaskTypeBackup,
+	ScheduledTaskTypePlugin,
+}
+
+func (e ScheduledTaskType) IsValid() bool {
+	switch e {
+	case ScheduledTaskTypeScan, ScheduledTaskTypeGenerate, ScheduledTaskTypeIdentify, ScheduledTaskTypeAutoTag, ScheduledTaskTypeBackup, ScheduledTaskTypePlugin:
+		return true
+	}
+	return false
+}
+
+func (e ScheduledTaskType) String() string {
+	return string(e)
+}
+
+func (e *ScheduledTaskType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduledTaskType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduledTaskType", str)
+	}
+	return nil
+}
+
+func (e ScheduledTaskType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
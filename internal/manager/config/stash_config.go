@@ -8,15 +8,20 @@ import (
 
 // Stash configuration details
 type StashConfigInput struct {
-	Path         string `json:"path"`
-	ExcludeVideo bool   `json:"excludeVideo"`
-	ExcludeImage bool   `json:"excludeImage"`
+	Path           string  `json:"path"`
+	ExcludeVideo   bool    `json:"excludeVideo"`
+	ExcludeImage   bool    `json:"excludeImage"`
+	RenameTemplate *string `json:"renameTemplate,omitempty"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// RenameTemplate is the template used by the rename task to build the
+	// paths of files in the library, relative to the library path.
+	// Files are not renamed if empty.
+	RenameTemplate string `json:"renameTemplate,omitempty"`
 }
 
 type StashConfigs []*StashConfig
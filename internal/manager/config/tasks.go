@@ -1,5 +1,10 @@
 package config
 
+import (
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/models"
+)
+
 type ScanMetadataOptions struct {
 	// Forces a rescan on files even if they have not changed
 	Rescan bool `json:"rescan"`
@@ -17,6 +22,8 @@ type ScanMetadataOptions struct {
 	ScanGenerateThumbnails bool `json:"scanGenerateThumbnails"`
 	// Generate image thumbnails during scan
 	ScanGenerateClipPreviews bool `json:"scanGenerateClipPreviews"`
+	// Generate image phashes during scan
+	ScanGenerateImagePhashes bool `json:"scanGenerateImagePhashes"`
 }
 
 type AutoTagMetadataOptions struct {
@@ -27,3 +34,26 @@ type AutoTagMetadataOptions struct {
 	// IDs of tags to tag files with, or "*" for all
 	Tags []string `json:"tags"`
 }
+
+// ScheduledTask is a task that is queued according to a cron schedule.
+type ScheduledTask struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name"`
+	Cron    string            `json:"cron"`
+	Enabled bool              `json:"enabled"`
+	Task    ScheduledTaskType `json:"task"`
+
+	// Paths to scan, identify or auto-tag. Null for all files
+	Paths []string `json:"paths"`
+
+	// Task options. If not set, the default task settings are used
+	Scan     *ScanMetadataOptions            `json:"scan"`
+	Generate *models.GenerateMetadataOptions `json:"generate"`
+	Identify *identify.Options               `json:"identify"`
+	AutoTag  *AutoTagMetadataOptions         `json:"autoTag"`
+
+	// Plugin task to run
+	PluginID   string                 `json:"pluginId"`
+	PluginTask string                 `json:"pluginTask"`
+	PluginArgs map[string]interface{} `json:"pluginArgs"`
+}
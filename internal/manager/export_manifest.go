@@ -0,0 +1,227 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models/json"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// exportManifest builds the manifest of an export. For incremental exports,
+// it uses the manifest of the previous export to determine which objects
+// need to be exported, and which objects were deleted or renamed.
+// It is safe for concurrent use by the export workers.
+type exportManifest struct {
+	mutex sync.Mutex
+
+	previous *jsonschema.Manifest
+	manifest jsonschema.Manifest
+
+	// seen contains the ids of the objects considered for export
+	seen map[string]map[int]bool
+	// stale contains the filenames of previously exported objects that
+	// may need to be removed
+	stale map[string][]string
+}
+
+// newExportManifest returns a new exportManifest. The export is incremental
+// if previous is not nil.
+func newExportManifest(exportedAt time.Time, previous *jsonschema.Manifest) *exportManifest {
+	ret := &exportManifest{
+		previous: previous,
+		manifest: jsonschema.Manifest{
+			ExportedAt: json.JSONTime{Time: exportedAt},
+			Objects:    make(map[string]map[int]jsonschema.ManifestObject),
+		},
+		seen:  make(map[string]map[int]bool),
+		stale: make(map[string][]string),
+	}
+
+	if previous != nil {
+		since := previous.ExportedAt
+		ret.manifest.Since = &since
+		ret.manifest.Changed = make(map[string][]string)
+		ret.manifest.Deleted = make(map[string][]jsonschema.ManifestRef)
+		ret.manifest.Renamed = make(map[string][]jsonschema.ManifestRename)
+	}
+
+	return ret
+}
+
+func (m *exportManifest) incremental() bool {
+	return m.previous != nil
+}
+
+func (m *exportManifest) objects(key string) map[int]jsonschema.ManifestObject {
+	ret := m.manifest.Objects[key]
+	if ret == nil {
+		ret = make(map[int]jsonschema.ManifestObject)
+		m.manifest.Objects[key] = ret
+	}
+	return ret
+}
+
+// needsExport returns true if the object with the provided id in the
+// provided JSON directory must be exported. For incremental exports, this
+// is only the case for objects updated since the previous export, or which
+// were not part of it. Objects which are not exported are carried over from
+// the previous manifest.
+func (m *exportManifest) needsExport(dir string, id int, updatedAt time.Time) bool {
+	key := filepath.Base(dir)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	seen := m.seen[key]
+	if seen == nil {
+		seen = make(map[int]bool)
+		m.seen[key] = seen
+	}
+	seen[id] = true
+
+	if !m.incremental() {
+		return true
+	}
+
+	prev, found := m.previous.Objects[key][id]
+	if found && !updatedAt.After(m.previous.ExportedAt.Time) {
+		m.objects(key)[id] = prev
+		return false
+	}
+
+	return true
+}
+
+// exported records that the object with the provided id was written to the
+// provided JSON directory.
+func (m *exportManifest) exported(dir string, id int, obj jsonschema.ManifestObject) {
+	key := filepath.Base(dir)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.objects(key)[id] = obj
+
+	if !m.incremental() {
+		return
+	}
+
+	m.manifest.Changed[key] = append(m.manifest.Changed[key], obj.Filename)
+
+	prev, found := m.previous.Objects[key][id]
+	if !found {
+		return
+	}
+
+	if prev.Filename != obj.Filename {
+		m.stale[key] = append(m.stale[key], prev.Filename)
+	}
+
+	if !jsonschema.CompareJSON(prev.Ref, obj.Ref) {
+		if isNameRef(prev.Ref) && isNameRef(obj.Ref) {
+			m.manifest.Renamed[key] = append(m.manifest.Renamed[key], jsonschema.ManifestRename{
+				From: prev.Ref,
+				To:   obj.Ref,
+			})
+		} else {
+			// the object is identified by its files, so it will be
+			// created again when importing
+			m.manifest.Deleted[key] = append(m.manifest.Deleted[key], prev.Ref)
+		}
+	}
+}
+
+// isNameRef returns true if the object is identified by its name or title.
+func isNameRef(r jsonschema.ManifestRef) bool {
+	if r.Gallery != nil {
+		return r.Gallery.FolderPath == "" && len(r.Gallery.ZipFiles) == 0
+	}
+
+	return len(r.Files) == 0
+}
+
+// exportedFile records that a file or folder was written to the provided
+// JSON directory. Files are not tracked across exports.
+func (m *exportManifest) exportedFile(dir string, fn string) {
+	if !m.incremental() {
+		return
+	}
+
+	key := filepath.Base(dir)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if !sliceutil.Contains(m.manifest.Changed[key], fn) {
+		m.manifest.Changed[key] = append(m.manifest.Changed[key], fn)
+	}
+}
+
+// finish records the objects of the previous export which no longer exist
+// as deleted, and removes their JSON files from the provided directory,
+// along with the JSON files of objects that were exported with a different
+// filename.
+func (m *exportManifest) finish(dir string) {
+	if !m.incremental() {
+		return
+	}
+
+	key := filepath.Base(dir)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	seen := m.seen[key]
+	stale := m.stale[key]
+
+	// sort for a deterministic manifest
+	var ids []int
+	for id := range m.previous.Objects[key] {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+
+		prev := m.previous.Objects[key][id]
+		m.manifest.Deleted[key] = append(m.manifest.Deleted[key], prev.Ref)
+		stale = append(stale, prev.Filename)
+	}
+
+	current := make(map[string]bool)
+	for _, o := range m.manifest.Objects[key] {
+		current[o.Filename] = true
+	}
+
+	for _, fn := range stale {
+		// don't remove files that were overwritten by another object
+		if current[fn] {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(dir, fn)); err != nil && !os.IsNotExist(err) {
+			logger.Warnf("[%s] failed to remove %s: %v", key, fn, err)
+		}
+	}
+}
+
+func (m *exportManifest) save(fn string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// sort for a deterministic manifest
+	for _, v := range m.manifest.Changed {
+		sort.Strings(v)
+	}
+
+	return jsonschema.SaveManifestFile(fn, &m.manifest)
+}
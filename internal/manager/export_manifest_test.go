@@ -0,0 +1,120 @@
+package manager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models/json"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportManifestIncremental(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "performers")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	previousAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := previousAt.Add(-time.Hour)
+	after := previousAt.Add(time.Hour)
+
+	named := func(name string) jsonschema.ManifestObject {
+		return jsonschema.ManifestObject{
+			Filename: name + ".json",
+			Ref:      jsonschema.ManifestRef{Name: name},
+		}
+	}
+
+	previous := &jsonschema.Manifest{
+		ExportedAt: json.JSONTime{Time: previousAt},
+		Objects: map[string]map[int]jsonschema.ManifestObject{
+			"performers": {
+				1: named("unchanged"),
+				2: named("old name"),
+				3: named("deleted"),
+			},
+		},
+	}
+
+	for _, o := range previous.Objects["performers"] {
+		if err := os.WriteFile(filepath.Join(dir, o.Filename), []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := newExportManifest(after, previous)
+
+	assert.False(t, m.needsExport(dir, 1, before), "unchanged object")
+	assert.True(t, m.needsExport(dir, 2, after), "updated object")
+	assert.True(t, m.needsExport(dir, 4, before), "new object")
+
+	m.exported(dir, 2, named("new name"))
+	m.exported(dir, 4, named("added"))
+	m.finish(dir)
+
+	got := m.manifest
+	assert.True(t, got.IsIncremental())
+	assert.Equal(t, previousAt, got.Since.Time)
+	assert.ElementsMatch(t, []string{"new name.json", "added.json"}, got.Changed["performers"])
+	assert.Equal(t, []jsonschema.ManifestRef{{Name: "deleted"}}, got.Deleted["performers"])
+	assert.Equal(t, []jsonschema.ManifestRename{
+		{From: jsonschema.ManifestRef{Name: "old name"}, To: jsonschema.ManifestRef{Name: "new name"}},
+	}, got.Renamed["performers"])
+	assert.Equal(t, map[int]jsonschema.ManifestObject{
+		1: named("unchanged"),
+		2: named("new name"),
+		4: named("added"),
+	}, got.Objects["performers"])
+
+	// stale files are removed
+	assert.FileExists(t, filepath.Join(dir, "unchanged.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "old name.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "deleted.json"))
+}
+
+func TestExportManifestFileRefChanged(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "scenes")
+
+	previousAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	oldRef := jsonschema.ManifestRef{Files: []string{"/stash/a.mp4"}}
+	newRef := jsonschema.ManifestRef{Files: []string{"/stash/b.mp4"}}
+
+	previous := &jsonschema.Manifest{
+		ExportedAt: json.JSONTime{Time: previousAt},
+		Objects: map[string]map[int]jsonschema.ManifestObject{
+			"scenes": {
+				1: {Filename: "scene.json", Ref: oldRef},
+			},
+		},
+	}
+
+	m := newExportManifest(previousAt.Add(time.Hour), previous)
+
+	assert.True(t, m.needsExport(dir, 1, previousAt.Add(time.Minute)))
+	m.exported(dir, 1, jsonschema.ManifestObject{Filename: "scene.json", Ref: newRef})
+	m.finish(dir)
+
+	// scenes are identified by their files, so they are deleted and
+	// created again when importing
+	assert.Equal(t, []jsonschema.ManifestRef{oldRef}, m.manifest.Deleted["scenes"])
+	assert.Empty(t, m.manifest.Renamed["scenes"])
+}
+
+func TestExportManifestFull(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tags")
+
+	m := newExportManifest(time.Now(), nil)
+
+	assert.True(t, m.needsExport(dir, 1, time.Now()))
+	m.exported(dir, 1, jsonschema.ManifestObject{Filename: "tag.json", Ref: jsonschema.ManifestRef{Name: "tag"}})
+	m.exportedFile(dir, "file.json")
+	m.finish(dir)
+
+	assert.False(t, m.manifest.IsIncremental())
+	assert.Nil(t, m.manifest.Changed)
+	assert.Nil(t, m.manifest.Deleted)
+	assert.Len(t, m.manifest.Objects["tags"], 1)
+}
@@ -61,7 +61,24 @@ type importer interface {
 	Update(ctx context.Context, id int) error
 }
 
-func performImport(ctx context.Context, i importer, duplicateBehaviour ImportDuplicateEnum) error {
+// performImport imports the object, recording the result in the dry run
+// report. typ is the type of the object used in the report.
+func (t *ImportTask) performImport(ctx context.Context, typ string, i importer, duplicateBehaviour ImportDuplicateEnum) (err error) {
+	result := &ImportReportObject{
+		Type:   typ,
+		Name:   i.Name(),
+		Action: ImportReportActionFail,
+	}
+	t.report.add(result)
+
+	defer func() {
+		if err != nil {
+			errStr := err.Error()
+			result.Action = ImportReportActionFail
+			result.Error = &errStr
+		}
+	}()
+
 	if err := i.PreImport(ctx); err != nil {
 		return err
 	}
@@ -76,10 +93,13 @@ func performImport(ctx context.Context, i importer, duplicateBehaviour ImportDup
 	var id int
 
 	if existing != nil {
+		result.Duplicate = true
+
 		if duplicateBehaviour == ImportDuplicateEnumFail {
 			return fmt.Errorf("existing object with name '%s'", name)
 		} else if duplicateBehaviour == ImportDuplicateEnumIgnore {
 			logger.Infof("Skipping existing object %q", name)
+			result.Action = ImportReportActionSkip
 			return nil
 		}
 
@@ -88,6 +108,7 @@ func performImport(ctx context.Context, i importer, duplicateBehaviour ImportDup
 		if err := i.Update(ctx, id); err != nil {
 			return fmt.Errorf("error updating existing object: %v", err)
 		}
+		result.Action = ImportReportActionUpdate
 	} else {
 		// creating
 		createdID, err := i.Create(ctx)
@@ -96,6 +117,7 @@ func performImport(ctx context.Context, i importer, duplicateBehaviour ImportDup
 		}
 
 		id = *createdID
+		result.Action = ImportReportActionCreate
 	}
 
 	if err := i.PostImport(ctx, id); err != nil {
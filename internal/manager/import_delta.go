@@ -0,0 +1,313 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+)
+
+var errNotIncremental = errors.New("manifest does not describe an incremental export")
+
+// loadDelta loads the manifest of the incremental export to apply.
+func (t *ImportTask) loadDelta() error {
+	manifest, err := jsonschema.LoadManifestFile(t.json.json.Manifest)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	if !manifest.IsIncremental() {
+		return errNotIncremental
+	}
+
+	t.delta = manifest
+	return nil
+}
+
+// readJSONDir returns the entries of the provided JSON directory. When
+// applying a delta, only the files written by the incremental export are
+// returned.
+func (t *ImportTask) readJSONDir(path string) ([]os.DirEntry, error) {
+	files, err := os.ReadDir(path)
+	if err != nil || t.delta == nil {
+		return files, err
+	}
+
+	changed := make(map[string]bool)
+	for _, fn := range t.delta.Changed[filepath.Base(path)] {
+		changed[fn] = true
+	}
+
+	var ret []os.DirEntry
+	for _, f := range files {
+		if changed[f.Name()] {
+			ret = append(ret, f)
+		}
+	}
+
+	return ret, nil
+}
+
+// applyDeletions destroys the objects deleted since the export that the
+// delta is based on.
+func (t *ImportTask) applyDeletions(ctx context.Context) {
+	r := t.repository
+
+	for _, dir := range t.deltaDirs() {
+		key := filepath.Base(dir)
+		for _, ref := range t.delta.Deleted[key] {
+			if err := r.WithTxn(ctx, func(ctx context.Context) error {
+				ids, err := t.findRef(ctx, dir, ref)
+				if err != nil {
+					return err
+				}
+
+				if len(ids) == 0 {
+					logger.Debugf("[%s] <%s> not found, nothing to delete", key, ref)
+					return nil
+				}
+
+				for _, id := range ids {
+					if err := t.destroy(ctx, dir, id); err != nil {
+						return err
+					}
+				}
+
+				logger.Infof("[%s] <%s> deleted", key, ref)
+				return nil
+			}); err != nil {
+				logger.Errorf("[%s] <%s> failed to delete: %v", key, ref, err)
+			}
+		}
+	}
+}
+
+// applyRenames renames the objects renamed since the export that the delta
+// is based on, so that they are found when importing the changed objects.
+func (t *ImportTask) applyRenames(ctx context.Context) {
+	r := t.repository
+
+	for _, dir := range t.deltaDirs() {
+		key := filepath.Base(dir)
+		for _, rn := range t.delta.Renamed[key] {
+			if err := r.WithTxn(ctx, func(ctx context.Context) error {
+				ids, err := t.findRef(ctx, dir, rn.From)
+				if err != nil {
+					return err
+				}
+
+				if len(ids) == 0 {
+					logger.Warnf("[%s] <%s> not found, it will be imported as <%s>", key, rn.From, rn.To)
+					return nil
+				}
+
+				return t.rename(ctx, dir, ids[0], rn.To)
+			}); err != nil {
+				logger.Errorf("[%s] <%s> failed to rename to <%s>: %v", key, rn.From, rn.To, err)
+			}
+		}
+	}
+}
+
+// deltaDirs returns the JSON directories in the order that deletions and
+// renames are applied.
+func (t *ImportTask) deltaDirs() []string {
+	p := t.json.json
+	return []string{
+		p.Scenes,
+		p.Images,
+		p.Galleries,
+		p.Groups,
+		p.Performers,
+		p.Studios,
+		p.Tags,
+	}
+}
+
+// findRef returns the ids of the objects identified by ref.
+func (t *ImportTask) findRef(ctx context.Context, dir string, ref jsonschema.ManifestRef) ([]int, error) {
+	r := t.repository
+	p := t.json.json
+
+	switch dir {
+	case p.Scenes, p.Images:
+		var ret []int
+		for _, path := range ref.Files {
+			f, err := r.File.FindByPath(ctx, path)
+			if err != nil {
+				return nil, err
+			}
+			if f == nil {
+				continue
+			}
+
+			if dir == p.Scenes {
+				scenes, err := r.Scene.FindByFileID(ctx, f.Base().ID)
+				if err != nil {
+					return nil, err
+				}
+				for _, s := range scenes {
+					ret = append(ret, s.ID)
+				}
+			} else {
+				images, err := r.Image.FindByFileID(ctx, f.Base().ID)
+				if err != nil {
+					return nil, err
+				}
+				for _, i := range images {
+					ret = append(ret, i.ID)
+				}
+			}
+		}
+		return ret, nil
+	case p.Galleries:
+		if ref.Gallery == nil {
+			return nil, nil
+		}
+
+		var galleries []*models.Gallery
+		var err error
+		switch {
+		case ref.Gallery.FolderPath != "":
+			galleries, err = r.Gallery.FindByPath(ctx, ref.Gallery.FolderPath)
+		case len(ref.Gallery.ZipFiles) > 0:
+			for _, path := range ref.Gallery.ZipFiles {
+				galleries, err = r.Gallery.FindByPath(ctx, path)
+				if err != nil || len(galleries) > 0 {
+					break
+				}
+			}
+		default:
+			galleries, err = r.Gallery.FindUserGalleryByTitle(ctx, ref.Gallery.Title)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var ret []int
+		for _, g := range galleries {
+			ret = append(ret, g.ID)
+		}
+		return ret, nil
+	case p.Performers:
+		filter := models.PerformerFilterType{
+			Name: &models.StringCriterionInput{
+				Value:    ref.Name,
+				Modifier: models.CriterionModifierEquals,
+			},
+			Disambiguation: &models.StringCriterionInput{
+				Value:    ref.Disambiguation,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+		if ref.Disambiguation == "" {
+			filter.Disambiguation.Modifier = models.CriterionModifierIsNull
+		}
+
+		performers, _, err := r.Performer.Query(ctx, &filter, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var ret []int
+		for _, p := range performers {
+			ret = append(ret, p.ID)
+		}
+		return ret, nil
+	}
+
+	const nocase = false
+	var id int
+	switch dir {
+	case p.Studios:
+		s, err := r.Studio.FindByName(ctx, ref.Name, nocase)
+		if err != nil || s == nil {
+			return nil, err
+		}
+		id = s.ID
+	case p.Tags:
+		tt, err := r.Tag.FindByName(ctx, ref.Name, nocase)
+		if err != nil || tt == nil {
+			return nil, err
+		}
+		id = tt.ID
+	case p.Groups:
+		g, err := r.Group.FindByName(ctx, ref.Name, nocase)
+		if err != nil || g == nil {
+			return nil, err
+		}
+		id = g.ID
+	default:
+		return nil, fmt.Errorf("unsupported directory %s", dir)
+	}
+
+	return []int{id}, nil
+}
+
+func (t *ImportTask) destroy(ctx context.Context, dir string, id int) error {
+	r := t.repository
+	p := t.json.json
+
+	switch dir {
+	case p.Scenes:
+		return r.Scene.Destroy(ctx, id)
+	case p.Images:
+		return r.Image.Destroy(ctx, id)
+	case p.Galleries:
+		return r.Gallery.Destroy(ctx, id)
+	case p.Groups:
+		return r.Group.Destroy(ctx, id)
+	case p.Performers:
+		return r.Performer.Destroy(ctx, id)
+	case p.Studios:
+		return r.Studio.Destroy(ctx, id)
+	case p.Tags:
+		return r.Tag.Destroy(ctx, id)
+	}
+
+	return fmt.Errorf("unsupported directory %s", dir)
+}
+
+func (t *ImportTask) rename(ctx context.Context, dir string, id int, to jsonschema.ManifestRef) error {
+	r := t.repository
+	p := t.json.json
+
+	var err error
+	switch dir {
+	case p.Galleries:
+		if to.Gallery == nil {
+			return errors.New("missing gallery title")
+		}
+
+		partial := models.NewGalleryPartial()
+		partial.Title = models.NewOptionalString(to.Gallery.Title)
+		_, err = r.Gallery.UpdatePartial(ctx, id, partial)
+	case p.Groups:
+		partial := models.NewGroupPartial()
+		partial.Name = models.NewOptionalString(to.Name)
+		_, err = r.Group.UpdatePartial(ctx, id, partial)
+	case p.Performers:
+		partial := models.NewPerformerPartial()
+		partial.Name = models.NewOptionalString(to.Name)
+		partial.Disambiguation = models.NewOptionalString(to.Disambiguation)
+		_, err = r.Performer.UpdatePartial(ctx, id, partial)
+	case p.Studios:
+		partial := models.NewStudioPartial()
+		partial.ID = id
+		partial.Name = models.NewOptionalString(to.Name)
+		_, err = r.Studio.UpdatePartial(ctx, partial)
+	case p.Tags:
+		partial := models.NewTagPartial()
+		partial.Name = models.NewOptionalString(to.Name)
+		_, err = r.Tag.UpdatePartial(ctx, id, partial)
+	default:
+		err = fmt.Errorf("unsupported directory %s", dir)
+	}
+
+	return err
+}
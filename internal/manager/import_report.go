@@ -0,0 +1,378 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+type ImportReportAction string
+
+const (
+	ImportReportActionCreate ImportReportAction = "CREATE"
+	ImportReportActionUpdate ImportReportAction = "UPDATE"
+	ImportReportActionSkip   ImportReportAction = "SKIP"
+	ImportReportActionFail   ImportReportAction = "FAIL"
+)
+
+var AllImportReportAction = []ImportReportAction{
+	ImportReportActionCreate,
+	ImportReportActionUpdate,
+	ImportReportActionSkip,
+	ImportReportActionFail,
+}
+
+func (e ImportReportAction) IsValid() bool {
+	switch e {
+	case ImportReportActionCreate, ImportReportActionUpdate, ImportReportActionSkip, ImportReportActionFail:
+		return true
+	}
+	return false
+}
+
+func (e ImportReportAction) String() string {
+	return string(e)
+}
+
+func (e *ImportReportAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportReportAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportReportAction", str)
+	}
+	return nil
+}
+
+func (e ImportReportAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ImportReportObject is the result of importing a single object in a dry
+// run.
+type ImportReportObject struct {
+	Type   string             `json:"type"`
+	Name   string             `json:"name"`
+	Action ImportReportAction `json:"action"`
+	// Duplicate is true if the object collides with an existing object.
+	Duplicate bool `json:"duplicate"`
+	// Error is the reason that the object would fail to import.
+	Error *string `json:"error"`
+}
+
+// ImportMissingReference is an object referenced by imported objects which
+// does not exist in the database or the import.
+type ImportMissingReference struct {
+	Type         string   `json:"type"`
+	Name         string   `json:"name"`
+	ReferencedBy []string `json:"referenced_by"`
+}
+
+// ImportReport describes what an import would do.
+type ImportReport struct {
+	Created           int                       `json:"created"`
+	Updated           int                       `json:"updated"`
+	Skipped           int                       `json:"skipped"`
+	Failed            int                       `json:"failed"`
+	Duplicates        int                       `json:"duplicates"`
+	Objects           []*ImportReportObject     `json:"objects"`
+	MissingReferences []*ImportMissingReference `json:"missing_references"`
+}
+
+// importReport builds the ImportReport of a dry run. Results are collected
+// per transaction, and only kept once the transaction completes, since
+// failed objects may be retried in a later transaction.
+// All methods are no-ops on a nil importReport.
+type importReport struct {
+	report ImportReport
+
+	missing        map[string]*ImportMissingReference
+	pending        []*ImportReportObject
+	pendingMissing []missingRef
+}
+
+type missingRef struct {
+	typ          string
+	name         string
+	referencedBy string
+}
+
+func newImportReport() *importReport {
+	return &importReport{
+		missing: make(map[string]*ImportMissingReference),
+	}
+}
+
+// start discards the results of the previous transaction.
+func (r *importReport) start() {
+	if r == nil {
+		return
+	}
+
+	r.pending = nil
+	r.pendingMissing = nil
+}
+
+// add adds the result of importing an object to the current transaction.
+// The result may be modified until the transaction completes.
+func (r *importReport) add(o *ImportReportObject) {
+	if r == nil {
+		return
+	}
+
+	r.pending = append(r.pending, o)
+}
+
+// commit keeps the results of the current transaction. Objects which
+// failed within a successful transaction are retried later, so they are
+// discarded.
+func (r *importReport) commit() {
+	if r == nil {
+		return
+	}
+
+	for _, o := range r.pending {
+		if o.Error == nil {
+			r.addObject(o)
+		}
+	}
+
+	r.commitMissing()
+	r.start()
+}
+
+// failed records that the current transaction failed with err. The first
+// object of the transaction is reported as failed, since none of the
+// objects of the transaction are imported.
+func (r *importReport) failed(err error) {
+	if r == nil || len(r.pending) == 0 {
+		return
+	}
+
+	o := r.pending[0]
+	errStr := err.Error()
+	o.Action = ImportReportActionFail
+	o.Error = &errStr
+	r.addObject(o)
+
+	r.commitMissing()
+	r.start()
+}
+
+// invalid records a JSON file which could not be read.
+func (r *importReport) invalid(typ string, filename string, err error) {
+	if r == nil {
+		return
+	}
+
+	errStr := err.Error()
+	r.addObject(&ImportReportObject{
+		Type:   typ,
+		Name:   filename,
+		Action: ImportReportActionFail,
+		Error:  &errStr,
+	})
+}
+
+func (r *importReport) addObject(o *ImportReportObject) {
+	r.report.Objects = append(r.report.Objects, o)
+
+	switch o.Action {
+	case ImportReportActionCreate:
+		r.report.Created++
+	case ImportReportActionUpdate:
+		r.report.Updated++
+	case ImportReportActionSkip:
+		r.report.Skipped++
+	case ImportReportActionFail:
+		r.report.Failed++
+	}
+
+	if o.Duplicate {
+		r.report.Duplicates++
+	}
+}
+
+func missingRefKey(typ string, name string) string {
+	return typ + "\x00" + name
+}
+
+// isMissing returns true if the object was already reported as missing.
+// Objects created for missing references are then not found in the
+// database.
+func (r *importReport) isMissing(typ string, name string) bool {
+	if _, found := r.missing[missingRefKey(typ, name)]; found {
+		return true
+	}
+
+	for _, m := range r.pendingMissing {
+		if m.typ == typ && m.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *importReport) addMissing(typ string, name string, referencedBy string) {
+	r.pendingMissing = append(r.pendingMissing, missingRef{
+		typ:          typ,
+		name:         name,
+		referencedBy: referencedBy,
+	})
+}
+
+func (r *importReport) commitMissing() {
+	for _, m := range r.pendingMissing {
+		key := missingRefKey(m.typ, m.name)
+		ref := r.missing[key]
+		if ref == nil {
+			ref = &ImportMissingReference{
+				Type: m.typ,
+				Name: m.name,
+			}
+			r.missing[key] = ref
+			r.report.MissingReferences = append(r.report.MissingReferences, ref)
+		}
+
+		ref.ReferencedBy = sliceutil.AppendUnique(ref.ReferencedBy, m.referencedBy)
+	}
+}
+
+// result returns the report.
+func (r *importReport) result() *ImportReport {
+	ret := r.report
+
+	if ret.Objects == nil {
+		ret.Objects = []*ImportReportObject{}
+	}
+	if ret.MissingReferences == nil {
+		ret.MissingReferences = []*ImportMissingReference{}
+	}
+
+	return &ret
+}
+
+// importRefs are the performers, studios and tags referenced by an
+// imported object.
+type importRefs struct {
+	performers []string
+	studio     string
+	tags       []string
+}
+
+// checkRefs records the performers, studios and tags referenced by the
+// object that do not exist.
+func (t *ImportTask) checkRefs(ctx context.Context, typ string, name string, refs importRefs) error {
+	report := t.report
+	if report == nil {
+		return nil
+	}
+
+	r := t.repository
+	referencedBy := fmt.Sprintf("%s <%s>", typ, name)
+
+	var performers []string
+	for _, n := range refs.performers {
+		if report.isMissing("performer", n) {
+			report.addMissing("performer", n, referencedBy)
+		} else {
+			performers = append(performers, n)
+		}
+	}
+
+	if len(performers) > 0 {
+		found, err := r.Performer.FindByNames(ctx, performers, false)
+		if err != nil {
+			return fmt.Errorf("finding performers: %w", err)
+		}
+
+		for _, n := range performers {
+			if !sliceutil.Contains(sliceutil.Map(found, func(p *models.Performer) string { return p.Name }), n) {
+				report.addMissing("performer", n, referencedBy)
+			}
+		}
+	}
+
+	if refs.studio != "" {
+		if report.isMissing("studio", refs.studio) {
+			report.addMissing("studio", refs.studio, referencedBy)
+		} else {
+			found, err := r.Studio.FindByName(ctx, refs.studio, false)
+			if err != nil {
+				return fmt.Errorf("finding studio: %w", err)
+			}
+
+			if found == nil {
+				report.addMissing("studio", refs.studio, referencedBy)
+			}
+		}
+	}
+
+	var tags []string
+	for _, n := range refs.tags {
+		if report.isMissing("tag", n) {
+			report.addMissing("tag", n, referencedBy)
+		} else {
+			tags = append(tags, n)
+		}
+	}
+
+	if len(tags) > 0 {
+		found, err := r.Tag.FindByNames(ctx, tags, false)
+		if err != nil {
+			return fmt.Errorf("finding tags: %w", err)
+		}
+
+		for _, n := range tags {
+			if !sliceutil.Contains(sliceutil.Map(found, func(t *models.Tag) string { return t.Name }), n) {
+				report.addMissing("tag", n, referencedBy)
+			}
+		}
+	}
+
+	return nil
+}
+
+// maxImportReports is the number of import dry run reports that are kept.
+const maxImportReports = 10
+
+// importReportStore holds the reports of finished import dry run jobs, by
+// job ID. Only the most recent reports are kept.
+type importReportStore struct {
+	mutex   sync.Mutex
+	reports map[int]*ImportReport
+	jobIDs  []int
+}
+
+func (s *importReportStore) add(jobID int, report *ImportReport) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.reports == nil {
+		s.reports = make(map[int]*ImportReport)
+	}
+
+	s.reports[jobID] = report
+	s.jobIDs = append(s.jobIDs, jobID)
+
+	if len(s.jobIDs) > maxImportReports {
+		delete(s.reports, s.jobIDs[0])
+		s.jobIDs = s.jobIDs[1:]
+	}
+}
+
+func (s *importReportStore) get(jobID int) *ImportReport {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.reports[jobID]
+}
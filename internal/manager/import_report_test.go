@@ -0,0 +1,83 @@
+package manager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportReport(t *testing.T) {
+	r := newImportReport()
+
+	// successful transaction
+	r.start()
+	r.add(&ImportReportObject{Type: "tag", Name: "created", Action: ImportReportActionCreate})
+	r.add(&ImportReportObject{Type: "tag", Name: "updated", Action: ImportReportActionUpdate, Duplicate: true})
+	retried := "parent not found"
+	r.add(&ImportReportObject{Type: "tag", Name: "retried", Action: ImportReportActionFail, Error: &retried})
+	r.addMissing("tag", "missing", "performer <a>")
+	r.commit()
+
+	// transaction that is retried later
+	r.start()
+	r.add(&ImportReportObject{Type: "tag", Name: "discarded", Action: ImportReportActionCreate})
+	r.addMissing("tag", "discarded", "tag <discarded>")
+
+	// failed transaction
+	r.start()
+	r.add(&ImportReportObject{Type: "scene", Name: "scene", Action: ImportReportActionCreate})
+	r.add(&ImportReportObject{Type: "scene marker", Name: "marker", Action: ImportReportActionCreate})
+	assert.True(t, r.isMissing("tag", "missing"))
+	r.addMissing("tag", "missing", "scene <scene>")
+	r.failed(errors.New("marker failed"))
+
+	r.invalid("image", "image.json", errors.New("invalid json"))
+
+	got := r.result()
+
+	failed := "marker failed"
+	invalid := "invalid json"
+	assert.Equal(t, &ImportReport{
+		Created:    1,
+		Updated:    1,
+		Skipped:    0,
+		Failed:     2,
+		Duplicates: 1,
+		Objects: []*ImportReportObject{
+			{Type: "tag", Name: "created", Action: ImportReportActionCreate},
+			{Type: "tag", Name: "updated", Action: ImportReportActionUpdate, Duplicate: true},
+			{Type: "scene", Name: "scene", Action: ImportReportActionFail, Error: &failed},
+			{Type: "image", Name: "image.json", Action: ImportReportActionFail, Error: &invalid},
+		},
+		MissingReferences: []*ImportMissingReference{
+			{Type: "tag", Name: "missing", ReferencedBy: []string{"performer <a>", "scene <scene>"}},
+		},
+	}, got)
+}
+
+func TestImportReportNil(t *testing.T) {
+	var r *importReport
+
+	// must not panic
+	r.start()
+	r.add(&ImportReportObject{})
+	r.commit()
+	r.failed(errors.New("failed"))
+	r.invalid("scene", "scene.json", errors.New("invalid"))
+}
+
+func TestImportReportStore(t *testing.T) {
+	var s importReportStore
+
+	assert.Nil(t, s.get(1))
+
+	for id := 1; id <= maxImportReports+1; id++ {
+		s.add(id, &ImportReport{Created: id})
+	}
+
+	// the oldest report is removed
+	assert.Nil(t, s.get(1))
+	assert.Equal(t, 2, s.get(2).Created)
+	assert.Equal(t, maxImportReports+1, s.get(maxImportReports+1).Created)
+}
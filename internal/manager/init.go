@@ -18,16 +18,19 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/group"
+	"github.com/stashapp/stash/pkg/history"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scheduler"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stashapp/stash/pkg/utils"
+	"github.com/stashapp/stash/pkg/webhook"
 	"github.com/stashapp/stash/ui"
 )
 
@@ -36,7 +39,8 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	ctx := context.TODO()
 
 	db := sqlite.NewDatabase()
-	repo := db.Repository()
+	// record changes made through the repository in the entity history
+	repo := history.Wrap(db.Repository())
 
 	// start with empty paths
 	mgrPaths := &paths.Paths{}
@@ -48,7 +52,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 	sceneService := &scene.Service{
 		File:             db.File,
-		Repository:       db.Scene,
+		Repository:       repo.Scene,
 		MarkerRepository: db.SceneMarker,
 		PluginCache:      pluginCache,
 		Paths:            mgrPaths,
@@ -61,11 +65,12 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	}
 
 	galleryService := &gallery.Service{
-		Repository:   db.Gallery,
+		Repository:   repo.Gallery,
 		ImageFinder:  db.Image,
 		ImageService: imageService,
 		File:         db.File,
 		Folder:       db.Folder,
+		Chapter:      db.GalleryChapter,
 	}
 
 	groupService := &group.Service{
@@ -77,8 +82,10 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		SceneCoverGetter: repo.Scene,
 	}
 
+	imageServer := &ImageServer{}
+
 	dlnaRepository := dlna.NewRepository(repo)
-	dlnaService := dlna.NewService(dlnaRepository, cfg, sceneServer)
+	dlnaService := dlna.NewService(dlnaRepository, cfg, sceneServer, imageServer)
 
 	mgr := &Manager{
 		Config: cfg,
@@ -90,6 +97,8 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 
 		JobManager:      initJobManager(cfg),
 		ReadLockManager: fsutil.NewReadLockManager(),
+		Scheduler:       scheduler.New(),
+		Webhooks:        webhook.NewDispatcher(),
 
 		DownloadStore: NewDownloadStore(),
 
@@ -107,8 +116,16 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		GroupService:   groupService,
 
 		scanSubs: &subscriptionManager{},
+		users: &userCache{
+			repository: repo,
+			ready:      db.Ready,
+		},
 	}
 
+	mgr.JobManager.SetRecorder(mgr.recordJob)
+	mgr.Webhooks.Recorder = mgr.recordWebhookDelivery
+	pluginCache.RegisterPostHookListener(mgr.Webhooks)
+
 	if !cfg.IsNewSystem() {
 		logger.Infof("using config file: %s", cfg.GetConfigFile())
 
@@ -136,6 +153,13 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	}
 
 	instance = mgr
+
+	if !cfg.IsNewSystem() {
+		// jobs are created using the manager instance, so this must be
+		// done after the instance is set
+		mgr.restoreJobQueue(ctx)
+	}
+
 	return mgr, nil
 }
 
@@ -190,6 +214,7 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshConfig()
 
 	s.SessionStore = session.NewStore(s.Config)
+	s.SessionStore.SetUserStore(s.users)
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
 
 	s.RefreshPluginCache()
@@ -246,6 +271,16 @@ func (s *Manager) postInit(ctx context.Context) error {
 
 	s.RefreshFFMpeg(ctx)
 	s.RefreshStreamManager()
+	s.RefreshWatcher()
+
+	s.loadScheduledTaskLastRuns(ctx)
+	s.RefreshScheduler()
+	s.Scheduler.Start()
+
+	s.RefreshWebhooks()
+	s.Webhooks.Start()
+
+	s.startTrashPurge()
 
 	return nil
 }
@@ -0,0 +1,56 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// recordJob stores a finished job in the job history, removing any history
+// older than the configured retention period.
+func (s *Manager) recordJob(j job.Job) {
+	if err := s.Database.Ready(); err != nil {
+		// nothing to record to
+		return
+	}
+
+	h := &models.JobHistory{
+		Description: j.Description,
+		Status:      string(j.Status),
+		Error:       j.Error,
+		AddTime:     j.AddTime,
+		StartTime:   j.StartTime,
+		EndTime:     j.EndTime,
+		Counters:    j.Counters,
+	}
+
+	if j.ScheduledTaskID != "" {
+		h.ScheduledTaskID = &j.ScheduledTaskID
+	}
+
+	// jobs cancelled before starting have no end time
+	if h.EndTime == nil {
+		now := time.Now()
+		h.EndTime = &now
+	}
+
+	ctx := context.Background()
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.JobHistory
+		if err := qb.Create(ctx, h); err != nil {
+			return err
+		}
+
+		if days := s.Config.GetJobHistoryRetention(); days > 0 {
+			return qb.DestroyBefore(ctx, time.Now().AddDate(0, 0, -days))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("Error recording history for job %q: %v", j.Description, err)
+	}
+}
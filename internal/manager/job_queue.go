@@ -0,0 +1,167 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// jobQueueFile is the name of the file in the config directory that stores
+// the jobs that had not finished at shutdown.
+const jobQueueFile = "job_queue.json"
+
+// types of jobs that are queued again after a restart
+const (
+	jobTypeScan     = "scan"
+	jobTypeGenerate = "generate"
+	jobTypeAutoTag  = "auto_tag"
+	jobTypeIdentify = "identify"
+	jobTypeClean    = "clean"
+	jobTypeRename   = "rename"
+
+	jobTypeRankSceneFiles = "rank_scene_files"
+)
+
+// addResumable queues a job that will be queued again on the next start if
+// it has not finished at shutdown.
+func (s *Manager) addResumable(ctx context.Context, description string, e job.JobExec, jobType string, input interface{}) int {
+	data, err := json.Marshal(input)
+	if err != nil {
+		logger.Warnf("Job %q will not be resumed after restart: %v", description, err)
+		return s.JobManager.Add(ctx, description, e)
+	}
+
+	return s.JobManager.AddResumable(ctx, description, e, job.Spec{
+		Type:  jobType,
+		Input: data,
+	})
+}
+
+func (s *Manager) jobQueuePath() string {
+	return filepath.Join(s.Config.GetConfigPath(), jobQueueFile)
+}
+
+// saveJobQueue writes the jobs that have not finished to the job queue file.
+func (s *Manager) saveJobQueue() {
+	specs := s.JobManager.GetResumable()
+	path := s.jobQueuePath()
+
+	if len(specs) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			logger.Errorf("Error removing job queue file: %v", err)
+		}
+		return
+	}
+
+	data, err := json.Marshal(specs)
+	if err != nil {
+		logger.Errorf("Error encoding job queue: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		logger.Errorf("Error writing job queue file: %v", err)
+		return
+	}
+
+	logger.Infof("Saved %d unfinished job(s) to be resumed on next start", len(specs))
+}
+
+// restoreJobQueue queues the jobs saved in the job queue file, then removes
+// the file.
+func (s *Manager) restoreJobQueue(ctx context.Context) {
+	// don't queue anything until the database is ready
+	if err := s.Database.Ready(); err != nil {
+		return
+	}
+
+	path := s.jobQueuePath()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			logger.Errorf("Error reading job queue file: %v", err)
+		}
+		return
+	}
+
+	if err := os.Remove(path); err != nil {
+		logger.Errorf("Error removing job queue file: %v", err)
+	}
+
+	var specs []job.Spec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		logger.Errorf("Error decoding job queue file: %v", err)
+		return
+	}
+
+	for _, spec := range specs {
+		ctx := job.WithPriority(ctx, spec.Priority)
+		if err := s.queueJobSpec(ctx, spec); err != nil {
+			logger.Errorf("Error resuming job %q: %v", spec.Description, err)
+			continue
+		}
+
+		logger.Infof("Resumed job %q", spec.Description)
+	}
+}
+
+func (s *Manager) queueJobSpec(ctx context.Context, spec job.Spec) error {
+	switch spec.Type {
+	case jobTypeScan:
+		var input ScanMetadataInput
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		_, err := s.Scan(ctx, input)
+		return err
+	case jobTypeGenerate:
+		var input GenerateMetadataInput
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		_, err := s.Generate(ctx, input)
+		return err
+	case jobTypeAutoTag:
+		var input AutoTagMetadataInput
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		s.AutoTag(ctx, input)
+	case jobTypeIdentify:
+		var input identify.Options
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		s.Identify(ctx, input)
+	case jobTypeClean:
+		var input CleanMetadataInput
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		s.Clean(ctx, input)
+	case jobTypeRename:
+		var input RenameMetadataInput
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		s.Rename(ctx, input)
+	case jobTypeRankSceneFiles:
+		var input RankSceneFilesInput
+		if err := json.Unmarshal(spec.Input, &input); err != nil {
+			return err
+		}
+		s.RankSceneFiles(ctx, input)
+	default:
+		return fmt.Errorf("unknown job type %q", spec.Type)
+	}
+
+	return nil
+}
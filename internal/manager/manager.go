@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
@@ -24,9 +25,11 @@ import (
 	"github.com/stashapp/stash/pkg/models/paths"
 	"github.com/stashapp/stash/pkg/pkg"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scheduler"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/session"
 	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stashapp/stash/pkg/webhook"
 
 	// register custom migrations
 	_ "github.com/stashapp/stash/pkg/sqlite/migrations"
@@ -48,6 +51,8 @@ type Manager struct {
 
 	JobManager      *job.Manager
 	ReadLockManager *fsutil.ReadLockManager
+	Scheduler       *scheduler.Scheduler
+	Webhooks        *webhook.Dispatcher
 
 	DownloadStore *DownloadStore
 	SessionStore  *session.Store
@@ -69,6 +74,14 @@ type Manager struct {
 	GroupService   GroupService
 
 	scanSubs *subscriptionManager
+	users    *userCache
+
+	importReports importReportStore
+
+	watcher      *watcher
+	watcherMutex sync.Mutex
+
+	trashPurgeOnce sync.Once
 }
 
 var instance *Manager
@@ -427,6 +440,19 @@ func (s *Manager) Shutdown() {
 		s.StreamManager = nil
 	}
 
+	s.Scheduler.Stop()
+	s.Webhooks.Stop()
+
+	// save unfinished jobs so they can be resumed on next start
+	s.saveJobQueue()
+
+	s.watcherMutex.Lock()
+	if s.watcher != nil {
+		s.watcher.stop()
+		s.watcher = nil
+	}
+	s.watcherMutex.Unlock()
+
 	err := s.Database.Close()
 	if err != nil {
 		logger.Errorf("Error closing database: %s", err)
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strconv"
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/internal/identify"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/file"
 	file_image "github.com/stashapp/stash/pkg/file/image"
@@ -24,7 +26,7 @@ func useAsVideo(pathname string) bool {
 	if instance.Config.IsCreateImageClipsFromVideos() && stash != nil && stash.ExcludeVideo {
 		return false
 	}
-	return isVideo(pathname)
+	return isVideo(pathname) || isAudio(pathname)
 }
 
 func useAsImage(pathname string) bool {
@@ -45,6 +47,11 @@ func isVideo(pathname string) bool {
 	return fsutil.MatchExtension(pathname, vidExt)
 }
 
+func isAudio(pathname string) bool {
+	audExt := config.GetInstance().GetAudioExtensions()
+	return fsutil.MatchExtension(pathname, audExt)
+}
+
 func isImage(pathname string) bool {
 	imgExt := config.GetInstance().GetImageExtensions()
 	return fsutil.MatchExtension(pathname, imgExt)
@@ -96,10 +103,19 @@ type ScanMetaDataFilterInput struct {
 }
 
 func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error) {
-	if err := s.validateFFmpeg(); err != nil {
+	scanJob, err := s.newScanJob(input)
+	if err != nil {
 		return 0, err
 	}
 
+	return s.addResumable(ctx, "Scanning...", scanJob, jobTypeScan, input), nil
+}
+
+func (s *Manager) newScanJob(input ScanMetadataInput) (*ScanJob, error) {
+	if err := s.validateFFmpeg(); err != nil {
+		return nil, err
+	}
+
 	scanner := &file.Scanner{
 		Repository: file.NewRepository(s.Repository),
 		FileDecorators: []file.Decorator{
@@ -120,16 +136,20 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		FS:                    &file.OsFS{},
 	}
 
-	scanJob := ScanJob{
+	return &ScanJob{
 		scanner:       scanner,
 		input:         input,
 		subscriptions: s.scanSubs,
-	}
+	}, nil
+}
 
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
+type ImportMetadataInput struct {
+	// Apply the incremental export in the metadata directory on top of the
+	// existing database, instead of wiping the database
+	Delta bool `json:"delta"`
 }
 
-func (s *Manager) Import(ctx context.Context) (int, error) {
+func (s *Manager) Import(ctx context.Context, input ImportMetadataInput) (int, error) {
 	config := config.GetInstance()
 	metadataPath := config.GetMetadataPath()
 	if metadataPath == "" {
@@ -141,7 +161,8 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 			repository:          s.Repository,
 			resetter:            s.Database,
 			BaseDir:             metadataPath,
-			Reset:               true,
+			Reset:               !input.Delta,
+			Delta:               input.Delta,
 			DuplicateBehaviour:  ImportDuplicateEnumFail,
 			MissingRefBehaviour: models.ImportMissingRefEnumFail,
 			fileNamingAlgorithm: config.GetVideoFileNamingAlgorithm(),
@@ -155,7 +176,86 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 	return s.JobManager.Add(ctx, "Importing...", j), nil
 }
 
-func (s *Manager) Export(ctx context.Context) (int, error) {
+// ImportDryRun queues a job that runs the import described by input against
+// a copy of the database. The database is not modified. Returns the job ID.
+// The report of what the import would do is returned by GetImportReport once
+// the job has finished.
+func (s *Manager) ImportDryRun(ctx context.Context, input ImportObjectsInput) (int, error) {
+	// the uploaded file must be saved before the request completes
+	t, err := CreateImportTask(s.Config.GetVideoFileNamingAlgorithm(), input)
+	if err != nil {
+		return 0, err
+	}
+
+	// the job ID is not known until the job is added
+	jobIDCh := make(chan int, 1)
+
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		report, err := s.importDryRun(ctx, t)
+		if err != nil {
+			return err
+		}
+
+		s.importReports.add(<-jobIDCh, report)
+		return nil
+	})
+
+	jobID := s.JobManager.Add(ctx, "Import dry run...", j)
+	jobIDCh <- jobID
+
+	return jobID, nil
+}
+
+// GetImportReport returns the report of the import dry run job with the
+// provided ID. Returns nil if the job has not finished successfully, or if
+// the report is no longer available.
+func (s *Manager) GetImportReport(jobID int) *ImportReport {
+	return s.importReports.get(jobID)
+}
+
+func (s *Manager) importDryRun(ctx context.Context, t *ImportTask) (*ImportReport, error) {
+	removeDir := func(dir string) {
+		if err := fsutil.RemoveDir(dir); err != nil {
+			logger.Errorf("error removing directory %s: %v", dir, err)
+		}
+	}
+
+	// the task only removes the import directory if a file was provided
+	if t.TmpZip == "" {
+		defer removeDir(t.BaseDir)
+	}
+
+	dir, err := s.Paths.Generated.TempDir("import_dry_run")
+	if err != nil {
+		return nil, fmt.Errorf("creating temporary directory: %w", err)
+	}
+	defer removeDir(dir)
+
+	db, err := s.Database.Copy(filepath.Join(dir, "dry_run.sqlite"))
+	if err != nil {
+		return nil, fmt.Errorf("copying database: %w", err)
+	}
+	defer db.Close()
+
+	t.repository = db.Repository()
+	t.report = newImportReport()
+
+	logger.Info("Starting import dry run")
+	if err := t.run(ctx); err != nil {
+		return nil, err
+	}
+	logger.Info("Import dry run complete")
+
+	return t.report.result(), nil
+}
+
+type ExportMetadataInput struct {
+	// Only export objects changed since the previous export, and record
+	// deleted objects in the manifest
+	Incremental bool `json:"incremental"`
+}
+
+func (s *Manager) Export(ctx context.Context, input ExportMetadataInput) (int, error) {
 	config := config.GetInstance()
 	metadataPath := config.GetMetadataPath()
 	if metadataPath == "" {
@@ -168,6 +268,7 @@ func (s *Manager) Export(ctx context.Context) (int, error) {
 		task := ExportTask{
 			repository:          s.Repository,
 			full:                true,
+			incremental:         input.Incremental,
 			fileNamingAlgorithm: config.GetVideoFileNamingAlgorithm(),
 		}
 		task.Start(ctx, &wg)
@@ -205,7 +306,7 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Generating...", j), nil
+	return s.addResumable(ctx, "Generating...", j, jobTypeGenerate, input), nil
 }
 
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
@@ -278,7 +379,14 @@ func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
+	return s.addResumable(ctx, "Auto-tagging...", &j, jobTypeAutoTag, input)
+}
+
+// Identify queues an identify job using the provided options.
+func (s *Manager) Identify(ctx context.Context, input identify.Options) int {
+	j := CreateIdentifyJob(input)
+
+	return s.addResumable(ctx, "Identifying...", j, jobTypeIdentify, input)
 }
 
 type CleanMetadataInput struct {
@@ -305,7 +413,155 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		scanSubs:     s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+	return s.addResumable(ctx, "Cleaning...", &j, jobTypeClean, input)
+}
+
+type RenameMetadataInput struct {
+	// Scenes to rename. If neither scenes nor images are provided, all
+	// scenes and images are renamed.
+	SceneIDs []string `json:"sceneIDs"`
+	// Images to rename.
+	ImageIDs []string `json:"imageIDs"`
+	// Do a dry run. Don't move any files
+	DryRun bool `json:"dryRun"`
+}
+
+// PlannedFileRename is a planned move of a file by the rename task.
+type PlannedFileRename struct {
+	FileID  string  `json:"file_id"`
+	OldPath string  `json:"old_path"`
+	NewPath *string `json:"new_path"`
+	// Error is the reason that the file will not be moved.
+	Error *string `json:"error"`
+}
+
+// PlanRename returns the moves that a rename job with the provided input
+// would make. Files that are already at their target path are omitted.
+func (s *Manager) PlanRename(ctx context.Context, input RenameMetadataInput) ([]*PlannedFileRename, error) {
+	planner := newRenamePlanner(s.Repository, s.Config.GetStashPaths())
+	renames, err := planner.plan(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*PlannedFileRename
+	for _, rn := range renames {
+		if rn.Unchanged() {
+			continue
+		}
+
+		p := &PlannedFileRename{
+			FileID:  rn.File.Base().ID.String(),
+			OldPath: rn.OldPath(),
+		}
+		if rn.Err != nil {
+			errStr := rn.Err.Error()
+			p.Error = &errStr
+		} else {
+			newPath := rn.NewPath
+			p.NewPath = &newPath
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret, nil
+}
+
+// Rename queues a job that moves scene and image files to the paths built
+// from the rename templates of their libraries.
+func (s *Manager) Rename(ctx context.Context, input RenameMetadataInput) int {
+	j := renameJob{
+		repository: s.Repository,
+		planner:    newRenamePlanner(s.Repository, s.Config.GetStashPaths()),
+		input:      input,
+	}
+
+	return s.addResumable(ctx, "Renaming files...", &j, jobTypeRename, input)
+}
+
+type RankSceneFilesInput struct {
+	// Scenes to evaluate. If not provided, all scenes with multiple files
+	// are evaluated.
+	SceneIDs []string `json:"sceneIDs"`
+	// What to do with the files that are not selected as the primary file.
+	// Defaults to keeping the files.
+	InferiorFiles *InferiorFileHandling `json:"inferiorFiles"`
+	// Do a dry run. Don't change any scenes or files
+	DryRun bool `json:"dryRun"`
+}
+
+// PlannedPrimaryFile is the primary file selected for a scene by the scene
+// file ranking task.
+type PlannedPrimaryFile struct {
+	SceneID    string `json:"scene_id"`
+	SceneTitle string `json:"scene_title"`
+	// Path of the current primary file
+	OldPrimaryPath string `json:"old_primary_path"`
+	PrimaryFileID  string `json:"primary_file_id"`
+	PrimaryPath    string `json:"primary_path"`
+	// Paths of the other files of the scene, from best to worst
+	InferiorPaths []string `json:"inferior_paths"`
+	// Changed is true if the primary file is changed.
+	Changed bool `json:"changed"`
+}
+
+func (s *Manager) newSceneFileRanker() *sceneFileRanker {
+	return &sceneFileRanker{
+		repository: s.Repository,
+		ranking:    s.Config.GetSceneFileRanking(),
+	}
+}
+
+// PlanSceneFileRanking returns the primary files that a scene file ranking
+// job with the provided input would select.
+func (s *Manager) PlanSceneFileRanking(ctx context.Context, input RankSceneFilesInput) ([]*PlannedPrimaryFile, error) {
+	ranks, err := s.newSceneFileRanker().rank(ctx, input.SceneIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*PlannedPrimaryFile, len(ranks))
+	for i, rank := range ranks {
+		var oldPrimaryPath string
+		if primary := rank.scene.Files.Primary(); primary != nil {
+			oldPrimaryPath = primary.Path
+		}
+
+		best := rank.best()
+		p := &PlannedPrimaryFile{
+			SceneID:        strconv.Itoa(rank.scene.ID),
+			SceneTitle:     rank.scene.DisplayName(),
+			OldPrimaryPath: oldPrimaryPath,
+			PrimaryFileID:  best.ID.String(),
+			PrimaryPath:    best.Path,
+			Changed:        rank.changed(),
+		}
+		for _, f := range rank.inferior() {
+			p.InferiorPaths = append(p.InferiorPaths, f.Path)
+		}
+
+		ret[i] = p
+	}
+
+	return ret, nil
+}
+
+// RankSceneFiles queues a job that sets the best file of scenes with
+// multiple files as their primary file, according to the configured
+// ranking rules. The other files are kept, trashed or deleted as per the
+// input.
+func (s *Manager) RankSceneFiles(ctx context.Context, input RankSceneFilesInput) int {
+	j := rankSceneFilesJob{
+		repository:   s.Repository,
+		sceneService: s.SceneService,
+		ranker:       s.newSceneFileRanker(),
+		newDeleter:   s.NewFileDeleter,
+		pluginCache:  s.PluginCache,
+		input:        input,
+	}
+
+	return s.addResumable(ctx, "Selecting primary scene files...", &j, jobTypeRankSceneFiles, input)
 }
 
 func (s *Manager) OptimiseDatabase(ctx context.Context) int {
@@ -641,3 +897,108 @@ func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashB
 
 	return s.JobManager.Add(ctx, "Batch stash-box studio tag...", j)
 }
+
+func (s *Manager) StashBoxBatchTagTag(ctx context.Context, box *models.StashBox, input StashBoxBatchTagInput) int {
+	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		logger.Infof("Initiating stash-box batch tag tag")
+
+		var tasks []StashBoxBatchTagTask
+
+		switch {
+		case len(input.Ids) > 0:
+			// The user has chosen only to tag the items on the current page
+			if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+				tagQuery := s.Repository.Tag
+
+				for _, tagID := range input.Ids {
+					id, err := strconv.Atoi(tagID)
+					if err != nil {
+						continue
+					}
+
+					t, err := tagQuery.Find(ctx, id)
+					if err != nil {
+						return err
+					}
+					if t == nil {
+						continue
+					}
+
+					if err := t.LoadStashIDs(ctx, tagQuery); err != nil {
+						return fmt.Errorf("loading tag stash ids: %w", err)
+					}
+
+					// Check if the user wants to refresh existing or new items
+					hasStashID := t.StashIDs.ForEndpoint(box.Endpoint) != nil
+					if (input.Refresh && hasStashID) || (!input.Refresh && !hasStashID) {
+						tasks = append(tasks, StashBoxBatchTagTask{
+							tag:            t,
+							refresh:        input.Refresh,
+							box:            box,
+							excludedFields: input.ExcludeFields,
+							taskType:       Tag,
+						})
+					}
+				}
+				return nil
+			}); err != nil {
+				logger.Error(err.Error())
+			}
+		case len(input.Names) > 0:
+			// The user is batch adding tags
+			for i := range input.Names {
+				name := input.Names[i]
+				if len(name) > 0 {
+					tasks = append(tasks, StashBoxBatchTagTask{
+						name:           &name,
+						refresh:        false,
+						box:            box,
+						excludedFields: input.ExcludeFields,
+						taskType:       Tag,
+					})
+				}
+			}
+		default:
+			// The user has chosen to tag every item in their database
+			if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+				tags, err := s.Repository.Tag.FindByStashIDStatus(ctx, input.Refresh, box.Endpoint)
+				if err != nil {
+					return fmt.Errorf("error querying tags: %v", err)
+				}
+
+				for _, t := range tags {
+					tasks = append(tasks, StashBoxBatchTagTask{
+						tag:            t,
+						refresh:        input.Refresh,
+						box:            box,
+						excludedFields: input.ExcludeFields,
+						taskType:       Tag,
+					})
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(tasks) == 0 {
+			return nil
+		}
+
+		progress.SetTotal(len(tasks))
+
+		logger.Infof("Starting stash-box batch operation for %d tags", len(tasks))
+
+		for _, task := range tasks {
+			progress.ExecuteTask(task.Description(), func() {
+				task.Start(ctx)
+			})
+
+			progress.Increment()
+		}
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Batch stash-box tag tag...", j)
+}
@@ -3,6 +3,7 @@ package manager
 import (
 	"context"
 
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/group"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
@@ -14,6 +15,7 @@ type SceneService interface {
 	AssignFile(ctx context.Context, sceneID int, fileID models.FileID) error
 	Merge(ctx context.Context, sourceIDs []int, destinationID int, fileDeleter *scene.FileDeleter, options scene.MergeOptions) error
 	Destroy(ctx context.Context, scene *models.Scene, fileDeleter *scene.FileDeleter, deleteGenerated, deleteFile bool) error
+	DestroyFiles(ctx context.Context, scene *models.Scene, files []*models.VideoFile, fileDeleter *file.Deleter, trash bool) ([]*models.VideoFile, error)
 }
 
 type ImageService interface {
@@ -28,6 +30,7 @@ type GalleryService interface {
 	SetCover(ctx context.Context, g *models.Gallery, coverImageId int) error
 	ResetCover(ctx context.Context, g *models.Gallery) error
 
+	Merge(ctx context.Context, sourceIDs []int, destinationID int, fileDeleter *image.FileDeleter, values models.GalleryPartial) error
 	Destroy(ctx context.Context, i *models.Gallery, fileDeleter *image.FileDeleter, deleteGenerated, deleteFile bool) ([]*models.Image, error)
 
 	ValidateImageGalleryChange(ctx context.Context, i *models.Image, updateIDs models.UpdateIDs) error
@@ -8,6 +8,7 @@ import (
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/static"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -95,3 +96,30 @@ func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter
 
 	utils.ServeImage(w, r, cover)
 }
+
+type ImageServer struct{}
+
+func (s *ImageServer) ServeImage(image *models.Image, w http.ResponseWriter, r *http.Request) {
+	f := image.Files.Primary()
+	if f == nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	if err := f.Base().Serve(&file.OsFS{}, w, r); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *ImageServer) ServeThumbnail(image *models.Image, w http.ResponseWriter, r *http.Request) {
+	filepath := GetInstance().Paths.Generated.GetThumbnailPath(image.Checksum, models.DefaultGthumbWidth)
+
+	// fall back to the original image if the thumbnail has not been generated
+	exists, _ := fsutil.FileExists(filepath)
+	if !exists {
+		s.ServeImage(image, w, r)
+		return
+	}
+
+	utils.ServeStaticFile(w, r, filepath)
+}
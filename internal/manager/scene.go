@@ -54,6 +54,11 @@ var (
 		mimeType:  ffmpeg.MimeDASH,
 		extension: ".mpd",
 	}
+	mp3EndpointType = endpointType{
+		label:     "MP3",
+		mimeType:  ffmpeg.MimeMp3Audio,
+		extension: ".mp3",
+	}
 )
 
 func GetVideoFileContainer(file *models.VideoFile) (ffmpeg.Container, error) {
@@ -155,6 +160,19 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	// don't care if we can't get the container
 	container, _ := GetVideoFileContainer(pf)
 
+	// audio files have no video to scale, so only offer the direct stream
+	// and an audio transcode
+	if pf.IsAudio() {
+		if ffmpeg.IsStreamableAudio(audioCodec, container) {
+			direct := directEndpointType
+			direct.mimeType = ffmpeg.AudioMimeType(container)
+			endpoints = append(endpoints, makeStreamEndpoint(direct, ""))
+		}
+		endpoints = append(endpoints, makeStreamEndpoint(mp3EndpointType, ""))
+
+		return endpoints, nil
+	}
+
 	if HasTranscode(scene, config.GetInstance().GetVideoFileNamingAlgorithm()) || ffmpeg.IsValidAudioForContainer(audioCodec, container) {
 		endpoints = append(endpoints, makeStreamEndpoint(directEndpointType, ""))
 	}
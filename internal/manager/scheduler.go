@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scheduler"
+)
+
+var ErrNoTaskOptions = errors.New("task options not set and no default task settings configured")
+
+// ValidateScheduledTask returns an error if the scheduled task is invalid.
+func ValidateScheduledTask(t *config.ScheduledTask) error {
+	if t.Name == "" {
+		return errors.New("name must not be blank")
+	}
+
+	if !t.Task.IsValid() {
+		return fmt.Errorf("invalid task type %q", t.Task)
+	}
+
+	if _, err := scheduler.ParseCron(t.Cron); err != nil {
+		return err
+	}
+
+	if t.Task == config.ScheduledTaskTypePlugin && t.PluginID == "" {
+		return errors.New("plugin ID is required for plugin tasks")
+	}
+
+	return nil
+}
+
+// RefreshScheduler reloads the scheduled tasks from the configuration.
+// Call this when the scheduled tasks change.
+func (s *Manager) RefreshScheduler() {
+	var entries []scheduler.Entry
+
+	for _, t := range s.Config.GetScheduledTasks() {
+		if !t.Enabled {
+			continue
+		}
+
+		c, err := scheduler.ParseCron(t.Cron)
+		if err != nil {
+			logger.Warnf("Skipping scheduled task %q: %v", t.Name, err)
+			continue
+		}
+
+		t := t
+		entries = append(entries, scheduler.Entry{
+			ID:       t.ID,
+			Schedule: c,
+			Run: func() {
+				logger.Infof("Running scheduled task %q", t.Name)
+
+				// scheduled tasks run behind user-triggered jobs
+				ctx := job.WithPriority(context.Background(), job.PriorityLow)
+				// the job history records the last run of the task
+				ctx = job.WithScheduledTask(ctx, t.ID)
+				if _, err := s.RunScheduledTask(ctx, t); err != nil {
+					logger.Errorf("Error running scheduled task %q: %v", t.Name, err)
+				}
+			},
+		})
+	}
+
+	s.Scheduler.Set(entries)
+}
+
+// loadScheduledTaskLastRuns restores the last run times of the scheduled
+// tasks from the job history.
+func (s *Manager) loadScheduledTaskLastRuns(ctx context.Context) {
+	if err := s.Database.Ready(); err != nil {
+		return
+	}
+
+	var lastRuns map[string]time.Time
+	r := s.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		lastRuns, err = r.JobHistory.LastScheduledTaskRuns(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error loading scheduled task last run times: %v", err)
+		return
+	}
+
+	for id, t := range lastRuns {
+		s.Scheduler.SetLastRun(id, t)
+	}
+}
+
+// RunScheduledTask queues the job for the provided scheduled task.
+// Returns the ID of the queued job.
+func (s *Manager) RunScheduledTask(ctx context.Context, t *config.ScheduledTask) (int, error) {
+	switch t.Task {
+	case config.ScheduledTaskTypeScan:
+		opts := t.Scan
+		if opts == nil {
+			opts = s.Config.GetDefaultScanSettings()
+		}
+
+		input := ScanMetadataInput{
+			Paths: t.Paths,
+		}
+		if opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+
+		return s.Scan(ctx, input)
+	case config.ScheduledTaskTypeGenerate:
+		opts := t.Generate
+		if opts == nil {
+			opts = s.Config.GetDefaultGenerateSettings()
+		}
+		if opts == nil {
+			return 0, ErrNoTaskOptions
+		}
+
+		return s.Generate(ctx, generateInputFromOptions(*opts))
+	case config.ScheduledTaskTypeIdentify:
+		opts := t.Identify
+		if opts == nil {
+			opts = s.Config.GetDefaultIdentifySettings()
+		}
+		if opts == nil {
+			return 0, ErrNoTaskOptions
+		}
+
+		input := *opts
+		if len(t.Paths) > 0 {
+			input.Paths = t.Paths
+		}
+
+		return s.Identify(ctx, input), nil
+	case config.ScheduledTaskTypeAutoTag:
+		opts := t.AutoTag
+		if opts == nil {
+			opts = s.Config.GetDefaultAutoTagSettings()
+		}
+		if opts == nil {
+			return 0, ErrNoTaskOptions
+		}
+
+		return s.AutoTag(ctx, AutoTagMetadataInput{
+			Paths:      t.Paths,
+			Performers: opts.Performers,
+			Studios:    opts.Studios,
+			Tags:       opts.Tags,
+		}), nil
+	case config.ScheduledTaskTypeBackup:
+		j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+			backupPath, _, err := s.BackupDatabase(false)
+			if err != nil {
+				return fmt.Errorf("backing up database: %w", err)
+			}
+
+			logger.Infof("Successfully backed up database to: %s", backupPath)
+			return nil
+		})
+
+		return s.JobManager.Add(ctx, "Backing up database...", j), nil
+	case config.ScheduledTaskTypePlugin:
+		var taskName *string
+		if t.PluginTask != "" {
+			taskName = &t.PluginTask
+		}
+
+		return s.RunPluginTask(ctx, t.PluginID, taskName, nil, plugin.OperationInput(t.PluginArgs)), nil
+	}
+
+	return 0, fmt.Errorf("unsupported scheduled task type %q", t.Task)
+}
+
+func generateInputFromOptions(opts models.GenerateMetadataOptions) GenerateMetadataInput {
+	ret := GenerateMetadataInput{
+		Covers:                    opts.Covers,
+		Sprites:                   opts.Sprites,
+		Previews:                  opts.Previews,
+		ImagePreviews:             opts.ImagePreviews,
+		Markers:                   opts.Markers,
+		MarkerImagePreviews:       opts.MarkerImagePreviews,
+		MarkerScreenshots:         opts.MarkerScreenshots,
+		Transcodes:                opts.Transcodes,
+		Phashes:                   opts.Phashes,
+		InteractiveHeatmapsSpeeds: opts.InteractiveHeatmapsSpeeds,
+		ClipPreviews:              opts.ClipPreviews,
+		ImageThumbnails:           opts.ImageThumbnails,
+		ImagePhashes:              opts.ImagePhashes,
+	}
+
+	if opts.PreviewOptions != nil {
+		ret.PreviewOptions = &GeneratePreviewOptionsInput{
+			PreviewSegments:        opts.PreviewOptions.PreviewSegments,
+			PreviewSegmentDuration: opts.PreviewOptions.PreviewSegmentDuration,
+			PreviewExcludeStart:    opts.PreviewOptions.PreviewExcludeStart,
+			PreviewExcludeEnd:      opts.PreviewOptions.PreviewExcludeEnd,
+			PreviewPreset:          opts.PreviewOptions.PreviewPreset,
+		}
+	}
+
+	return ret
+}
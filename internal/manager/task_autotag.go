@@ -27,6 +27,7 @@ type autoTagJob struct {
 
 func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) error {
 	begin := time.Now()
+	ctx = models.WithHistorySource(ctx, models.HistorySourceAutoTag)
 
 	input := j.input
 	if j.isFileBasedAutoTag(input) {
@@ -3,6 +3,7 @@ package manager
 import (
 	"archive/zip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -33,6 +34,9 @@ import (
 type ExportTask struct {
 	repository models.Repository
 	full       bool
+	// incremental only exports the objects changed since the previous full
+	// or incremental export. Only applies to full exports.
+	incremental bool
 
 	baseDir string
 	json    jsonUtils
@@ -49,6 +53,8 @@ type ExportTask struct {
 
 	includeDependencies bool
 
+	manifest *exportManifest
+
 	DownloadHash string
 }
 
@@ -152,7 +158,17 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		json: *paths.GetJSONPaths(t.baseDir),
 	}
 
-	paths.EmptyJSONDirs(t.baseDir)
+	var previous *jsonschema.Manifest
+	if t.full && t.incremental {
+		previous = t.loadPreviousManifest()
+	}
+
+	t.manifest = newExportManifest(startTime, previous)
+
+	// incremental exports only write the changed objects
+	if !t.manifest.incremental() {
+		paths.EmptyJSONDirs(t.baseDir)
+	}
 	paths.EnsureJSONDirs(t.baseDir)
 
 	txnErr := t.repository.WithTxn(ctx, func(ctx context.Context) error {
@@ -181,6 +197,12 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 	})
 	if txnErr != nil {
 		logger.Warnf("error while running export transaction: %v", txnErr)
+	} else if t.full {
+		// only record successful exports, so that the next incremental
+		// export includes the changes of a failed export
+		if err := t.manifest.save(t.json.json.Manifest); err != nil {
+			logger.Errorf("error saving export manifest: %v", err)
+		}
 	}
 
 	if !t.full {
@@ -193,6 +215,25 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 	logger.Infof("Export complete in %s.", time.Since(startTime))
 }
 
+// loadPreviousManifest returns the manifest of the previous export. Returns
+// nil if there is no usable manifest, in which case a full export is
+// performed.
+func (t *ExportTask) loadPreviousManifest() *jsonschema.Manifest {
+	fn := t.json.json.Manifest
+	ret, err := jsonschema.LoadManifestFile(fn)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			logger.Info("No previous export found. Performing a full export.")
+		} else {
+			logger.Warnf("Error reading %s, performing a full export: %v", fn, err)
+		}
+		return nil
+	}
+
+	logger.Infof("Exporting objects changed since %s", ret.ExportedAt.Time.Format(time.RFC3339))
+	return ret
+}
+
 func (t *ExportTask) generateDownload() error {
 	// zip the files and register a download link
 	if err := fsutil.EnsureDir(instance.Paths.Generated.Downloads); err != nil {
@@ -383,11 +424,17 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int) {
 		if (i % 100) == 0 { // make progress easier to read
 			logger.Progressf("[scenes] %d of %d", index, len(scenes))
 		}
+
+		if !t.manifest.needsExport(t.json.json.Scenes, scene.ID, scene.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- scene // feed workers
 	}
 
 	close(jobCh) // close channel so that workers will know no more jobs are available
 	scenesWg.Wait()
+	t.manifest.finish(t.json.json.Scenes)
 
 	logger.Infof("[scenes] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
@@ -399,7 +446,10 @@ func (t *ExportTask) exportFile(f models.File) {
 
 	if err := t.json.saveFile(fn, newFileJSON); err != nil {
 		logger.Errorf("[files] <%s> failed to save json: %v", fn, err)
+		return
 	}
+
+	t.manifest.exportedFile(t.json.json.Files, fn)
 }
 
 func fileToJSON(f models.File) jsonschema.DirEntry {
@@ -438,6 +488,7 @@ func fileToJSON(f models.File) jsonschema.DirEntry {
 			Duration:         ff.Duration,
 			VideoCodec:       ff.VideoCodec,
 			AudioCodec:       ff.AudioCodec,
+			AudioChannels:    ff.AudioChannels,
 			FrameRate:        ff.FrameRate,
 			BitRate:          ff.BitRate,
 			Interactive:      ff.Interactive,
@@ -463,7 +514,10 @@ func (t *ExportTask) exportFolder(f models.Folder) {
 
 	if err := t.json.saveFile(fn, newFileJSON); err != nil {
 		logger.Errorf("[files] <%s> failed to save json: %v", fn, err)
+		return
 	}
+
+	t.manifest.exportedFile(t.json.json.Files, fn)
 }
 
 func folderToJSON(f models.Folder) jsonschema.DirEntry {
@@ -562,6 +616,12 @@ func (t *ExportTask) exportScene(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		newSceneJSON.CustomFields, err = sceneReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene custom fields: %v", sceneHash, err)
+			continue
+		}
+
 		if t.includeDependencies {
 			if s.StudioID != nil {
 				t.studios.IDs = sliceutil.AppendUnique(t.studios.IDs, *s.StudioID)
@@ -593,7 +653,13 @@ func (t *ExportTask) exportScene(ctx context.Context, wg *sync.WaitGroup, jobCha
 
 		if err := t.json.saveScene(fn, newSceneJSON); err != nil {
 			logger.Errorf("[scenes] <%s> failed to save json: %v", sceneHash, err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Scenes, s.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref:      jsonschema.ManifestRef{Files: newSceneJSON.Files},
+		})
 	}
 }
 
@@ -632,11 +698,17 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int) {
 		if (i % 100) == 0 { // make progress easier to read
 			logger.Progressf("[images] %d of %d", index, len(images))
 		}
+
+		if !t.manifest.needsExport(t.json.json.Images, image.ID, image.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- image // feed workers
 	}
 
 	close(jobCh) // close channel so that workers will know no more jobs are available
 	imagesWg.Wait()
+	t.manifest.finish(t.json.json.Images)
 
 	logger.Infof("[images] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
@@ -722,7 +794,13 @@ func (t *ExportTask) exportImage(ctx context.Context, wg *sync.WaitGroup, jobCha
 
 		if err := t.json.saveImage(fn, newImageJSON); err != nil {
 			logger.Errorf("[images] <%s> failed to save json: %v", imageHash, err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Images, s.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref:      jsonschema.ManifestRef{Files: newImageJSON.Files},
+		})
 	}
 }
 
@@ -761,11 +839,16 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int) {
 			logger.Progressf("[galleries] %d of %d", index, len(galleries))
 		}
 
+		if !t.manifest.needsExport(t.json.json.Galleries, gallery.ID, gallery.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- gallery
 	}
 
 	close(jobCh) // close channel so that workers will know no more jobs are available
 	galleriesWg.Wait()
+	t.manifest.finish(t.json.json.Galleries)
 
 	logger.Infof("[galleries] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
@@ -796,6 +879,12 @@ func (t *ExportTask) exportGallery(ctx context.Context, wg *sync.WaitGroup, jobC
 			continue
 		}
 
+		newGalleryJSON.CustomFields, err = r.Gallery.GetCustomFields(ctx, g.ID)
+		if err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery custom fields: %v", g.DisplayName(), err)
+			continue
+		}
+
 		// export files
 		for _, f := range g.Files.List() {
 			t.exportFile(f)
@@ -866,10 +955,17 @@ func (t *ExportTask) exportGallery(ctx context.Context, wg *sync.WaitGroup, jobC
 		}
 
 		fn := newGalleryJSON.Filename(basename, hash)
+		ref := gallery.GetRefs([]*models.Gallery{g})[0]
 
 		if err := t.json.saveGallery(fn, newGalleryJSON); err != nil {
 			logger.Errorf("[galleries] <%s> failed to save json: %v", g.DisplayName(), err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Galleries, g.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref:      jsonschema.ManifestRef{Gallery: &ref},
+		})
 	}
 }
 
@@ -903,11 +999,16 @@ func (t *ExportTask) ExportPerformers(ctx context.Context, workers int) {
 		index := i + 1
 		logger.Progressf("[performers] %d of %d", index, len(performers))
 
+		if !t.manifest.needsExport(t.json.json.Performers, performer.ID, performer.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- performer // feed workers
 	}
 
 	close(jobCh) // close channel so workers will know that no more jobs are available
 	performersWg.Wait()
+	t.manifest.finish(t.json.json.Performers)
 
 	logger.Infof("[performers] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
@@ -926,6 +1027,12 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 			continue
 		}
 
+		newPerformerJSON.CustomFields, err = performerReader.GetCustomFields(ctx, p.ID)
+		if err != nil {
+			logger.Errorf("[performers] <%s> error getting performer custom fields: %v", p.Name, err)
+			continue
+		}
+
 		tags, err := r.Tag.FindByPerformerID(ctx, p.ID)
 		if err != nil {
 			logger.Errorf("[performers] <%s> error getting performer tags: %v", p.Name, err)
@@ -942,7 +1049,16 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 
 		if err := t.json.savePerformer(fn, newPerformerJSON); err != nil {
 			logger.Errorf("[performers] <%s> failed to save json: %v", p.Name, err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Performers, p.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref: jsonschema.ManifestRef{
+				Name:           p.Name,
+				Disambiguation: p.Disambiguation,
+			},
+		})
 	}
 }
 
@@ -977,11 +1093,16 @@ func (t *ExportTask) ExportStudios(ctx context.Context, workers int) {
 		index := i + 1
 		logger.Progressf("[studios] %d of %d", index, len(studios))
 
+		if !t.manifest.needsExport(t.json.json.Studios, studio.ID, studio.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- studio // feed workers
 	}
 
 	close(jobCh)
 	studiosWg.Wait()
+	t.manifest.finish(t.json.json.Studios)
 
 	logger.Infof("[studios] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
@@ -1000,6 +1121,12 @@ func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobCh
 			continue
 		}
 
+		newStudioJSON.CustomFields, err = studioReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[studios] <%s> error getting studio custom fields: %v", s.Name, err)
+			continue
+		}
+
 		tags, err := r.Tag.FindByStudioID(ctx, s.ID)
 		if err != nil {
 			logger.Errorf("[studios] <%s> error getting studio tags: %s", s.Name, err.Error())
@@ -1016,7 +1143,13 @@ func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobCh
 
 		if err := t.json.saveStudio(fn, newStudioJSON); err != nil {
 			logger.Errorf("[studios] <%s> failed to save json: %v", s.Name, err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Studios, s.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref:      jsonschema.ManifestRef{Name: s.Name},
+		})
 	}
 }
 
@@ -1051,11 +1184,16 @@ func (t *ExportTask) ExportTags(ctx context.Context, workers int) {
 		index := i + 1
 		logger.Progressf("[tags] %d of %d", index, len(tags))
 
+		if !t.manifest.needsExport(t.json.json.Tags, tag.ID, tag.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- tag // feed workers
 	}
 
 	close(jobCh)
 	tagsWg.Wait()
+	t.manifest.finish(t.json.json.Tags)
 
 	logger.Infof("[tags] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
@@ -1073,11 +1211,23 @@ func (t *ExportTask) exportTag(ctx context.Context, wg *sync.WaitGroup, jobChan
 			continue
 		}
 
+		newTagJSON.CustomFields, err = tagReader.GetCustomFields(ctx, thisTag.ID)
+		if err != nil {
+			logger.Errorf("[tags] <%s> error getting tag custom fields: %v", thisTag.Name, err)
+			continue
+		}
+
 		fn := newTagJSON.Filename()
 
 		if err := t.json.saveTag(fn, newTagJSON); err != nil {
 			logger.Errorf("[tags] <%s> failed to save json: %v", fn, err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Tags, thisTag.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref:      jsonschema.ManifestRef{Name: thisTag.Name},
+		})
 	}
 }
 
@@ -1112,11 +1262,16 @@ func (t *ExportTask) ExportGroups(ctx context.Context, workers int) {
 		index := i + 1
 		logger.Progressf("[groups] %d of %d", index, len(groups))
 
+		if !t.manifest.needsExport(t.json.json.Groups, group.ID, group.UpdatedAt) {
+			continue
+		}
+
 		jobCh <- group // feed workers
 	}
 
 	close(jobCh)
 	groupsWg.Wait()
+	t.manifest.finish(t.json.json.Groups)
 
 	logger.Infof("[groups] export complete in %s. %d workers used.", time.Since(startTime), workers)
 
@@ -1146,6 +1301,12 @@ func (t *ExportTask) exportGroup(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		newGroupJSON.CustomFields, err = groupReader.GetCustomFields(ctx, m.ID)
+		if err != nil {
+			logger.Errorf("[groups] <%s> error getting group custom fields: %v", m.Name, err)
+			continue
+		}
+
 		tags, err := tagReader.FindByGroupID(ctx, m.ID)
 		if err != nil {
 			logger.Errorf("[groups] <%s> error getting image tag names: %v", m.Name, err)
@@ -1183,6 +1344,12 @@ func (t *ExportTask) exportGroup(ctx context.Context, wg *sync.WaitGroup, jobCha
 
 		if err := t.json.saveGroup(fn, newGroupJSON); err != nil {
 			logger.Errorf("[groups] <%s> failed to save json: %v", m.Name, err)
+			continue
 		}
+
+		t.manifest.exported(t.json.json.Groups, m.ID, jsonschema.ManifestObject{
+			Filename: fn,
+			Ref:      jsonschema.ManifestRef{Name: m.Name},
+		})
 	}
 }
@@ -32,6 +32,7 @@ type GenerateMetadataInput struct {
 	InteractiveHeatmapsSpeeds bool `json:"interactiveHeatmapsSpeeds"`
 	ClipPreviews              bool `json:"clipPreviews"`
 	ImageThumbnails           bool `json:"imageThumbnails"`
+	ImagePhashes              bool `json:"imagePhashes"`
 	// scene ids to generate for
 	SceneIDs []string `json:"sceneIDs"`
 	// marker ids to generate for
@@ -76,6 +77,7 @@ type totalsGenerate struct {
 	interactiveHeatmapSpeeds int64
 	clipPreviews             int64
 	imageThumbnails          int64
+	imagePhashes             int64
 
 	tasks int
 }
@@ -181,6 +183,9 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) error
 		if j.input.ImageThumbnails {
 			logMsg += fmt.Sprintf(" %d Image Thumbnails", totals.imageThumbnails)
 		}
+		if j.input.ImagePhashes {
+			logMsg += fmt.Sprintf(" %d Image phashes", totals.imagePhashes)
+		}
 		if logMsg == "Generating" {
 			logMsg = "Nothing selected to generate"
 		}
@@ -284,7 +289,7 @@ func (j *GenerateJob) queueImagesTasks(ctx context.Context, g *generate.Generato
 
 	r := j.repository
 
-	for more := j.input.ClipPreviews || j.input.ImageThumbnails; more; {
+	for more := j.input.ClipPreviews || j.input.ImageThumbnails || j.input.ImagePhashes; more; {
 		if job.IsCancelled(ctx) {
 			return
 		}
@@ -368,7 +373,13 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.Sprites {
+	// audio files have no video frames to generate from
+	isAudioFile := false
+	if pf := scene.Files.Primary(); pf != nil {
+		isAudioFile = pf.IsAudio()
+	}
+
+	if j.input.Sprites && !isAudioFile {
 		task := &GenerateSpriteTask{
 			Scene:               *scene,
 			Overwrite:           j.overwrite,
@@ -388,7 +399,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 	}
 	options := getGeneratePreviewOptions(*generatePreviewOptions)
 
-	if j.input.Previews {
+	if j.input.Previews && !isAudioFile {
 		task := &GeneratePreviewTask{
 			Scene:               *scene,
 			ImagePreview:        j.input.ImagePreviews,
@@ -411,7 +422,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.Markers {
+	if j.input.Markers && !isAudioFile {
 		task := &GenerateMarkersTask{
 			repository:          r,
 			Scene:               scene,
@@ -432,7 +443,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		}
 	}
 
-	if j.input.Transcodes {
+	if j.input.Transcodes && !isAudioFile {
 		forceTranscode := j.input.ForceTranscodes
 		task := &GenerateTranscodeTask{
 			Scene:               *scene,
@@ -451,6 +462,10 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 	if j.input.Phashes {
 		// generate for all files in scene
 		for _, f := range scene.Files.List() {
+			if f.IsAudio() {
+				continue
+			}
+
 			task := &GeneratePhashTask{
 				repository:          r,
 				File:                f,
@@ -521,4 +536,26 @@ func (j *GenerateJob) queueImageJob(g *generate.Generator, image *models.Image,
 			queue <- task
 		}
 	}
+
+	if j.input.ImagePhashes {
+		// generate for all image files - clips are not supported
+		for _, f := range image.Files.List() {
+			imageFile, ok := f.(*models.ImageFile)
+			if !ok {
+				continue
+			}
+
+			task := &GenerateImagePhashTask{
+				repository: j.repository,
+				File:       imageFile,
+				Overwrite:  j.overwrite,
+			}
+
+			if task.required() {
+				j.totals.imagePhashes++
+				j.totals.tasks++
+				queue <- task
+			}
+		}
+	}
 }
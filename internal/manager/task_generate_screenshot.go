@@ -43,18 +43,6 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		return
 	}
 
-	var at float64
-	if t.ScreenshotAt == nil {
-		at = float64(videoFile.Duration) * 0.2
-	} else {
-		at = *t.ScreenshotAt
-	}
-
-	// we'll generate the screenshot, grab the generated data and set it
-	// in the database.
-
-	logger.Debugf("Creating screenshot for %s", scenePath)
-
 	g := generate.Generator{
 		Encoder:      instance.FFMpeg,
 		FFMpegConfig: instance.Config,
@@ -63,9 +51,31 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		Overwrite:    true,
 	}
 
-	coverImageData, err := g.Screenshot(context.TODO(), videoFile.Path, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
-		At: &at,
-	})
+	var coverImageData []byte
+	var err error
+	if videoFile.IsAudio() {
+		// audio files have no frames to take a screenshot from, so use an
+		// image of the waveform instead
+		logger.Debugf("Creating waveform for %s", scenePath)
+
+		coverImageData, err = g.Waveform(context.TODO(), videoFile.Path)
+	} else {
+		var at float64
+		if t.ScreenshotAt == nil {
+			at = float64(videoFile.Duration) * 0.2
+		} else {
+			at = *t.ScreenshotAt
+		}
+
+		// we'll generate the screenshot, grab the generated data and set it
+		// in the database.
+
+		logger.Debugf("Creating screenshot for %s", scenePath)
+
+		coverImageData, err = g.Screenshot(context.TODO(), videoFile.Path, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
+			At: &at,
+		})
+	}
 	if err != nil {
 		logger.Errorf("Error generating screenshot: %v", err)
 		logErrorOutput(err)
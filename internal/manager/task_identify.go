@@ -18,6 +18,13 @@ import (
 
 var ErrInput = errors.New("invalid request input")
 
+// summary counters recorded in the job history
+const (
+	identifyCounterProcessed  = "scenes_processed"
+	identifyCounterIdentified = "scenes_identified"
+	identifyCounterErrors     = "errors"
+)
+
 type IdentifyJob struct {
 	postHookExecutor identify.SceneUpdatePostHookExecutor
 	input            identify.Options
@@ -36,6 +43,7 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.progress = progress
+	ctx = models.WithHistorySource(ctx, models.HistorySourceIdentify)
 
 	// if no sources provided - just return
 	if len(j.input.Sources) == 0 {
@@ -133,6 +141,7 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 		return
 	}
 
+	var identified bool
 	var taskError error
 	j.progress.ExecuteTask("Identifying "+s.Path, func() {
 		r := instance.Repository
@@ -148,13 +157,17 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 			SceneUpdatePostHookExecutor: j.postHookExecutor,
 		}
 
-		taskError = task.Identify(ctx, s)
+		identified, taskError = task.Identify(ctx, s)
 	})
 
 	if taskError != nil {
 		logger.Errorf("Error encountered identifying %s: %v", s.Path, taskError)
+		j.progress.AddCounter(identifyCounterErrors, 1)
+	} else if identified {
+		j.progress.AddCounter(identifyCounterIdentified, 1)
 	}
 
+	j.progress.AddCounter(identifyCounterProcessed, 1)
 	j.progress.Increment()
 }
 
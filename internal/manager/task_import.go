@@ -23,6 +23,7 @@ import (
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/studio"
 	"github.com/stashapp/stash/pkg/tag"
+	"github.com/stashapp/stash/pkg/txn"
 )
 
 type Resetter interface {
@@ -40,6 +41,13 @@ type ImportTask struct {
 	DuplicateBehaviour  ImportDuplicateEnum
 	MissingRefBehaviour models.ImportMissingRefEnum
 
+	// Delta applies an incremental export on top of the existing database.
+	Delta bool
+	delta *jsonschema.Manifest
+
+	// report collects the results of a dry run. It is nil otherwise.
+	report *importReport
+
 	fileNamingAlgorithm models.HashAlgorithm
 }
 
@@ -47,6 +55,7 @@ type ImportObjectsInput struct {
 	File                graphql.Upload              `json:"file"`
 	DuplicateBehaviour  ImportDuplicateEnum         `json:"duplicateBehaviour"`
 	MissingRefBehaviour models.ImportMissingRefEnum `json:"missingRefBehaviour"`
+	Delta               bool                        `json:"delta"`
 }
 
 func CreateImportTask(a models.HashAlgorithm, input ImportObjectsInput) (*ImportTask, error) {
@@ -80,6 +89,7 @@ func CreateImportTask(a models.HashAlgorithm, input ImportObjectsInput) (*Import
 		Reset:               false,
 		DuplicateBehaviour:  input.DuplicateBehaviour,
 		MissingRefBehaviour: input.MissingRefBehaviour,
+		Delta:               input.Delta,
 		fileNamingAlgorithm: a,
 	}, nil
 }
@@ -89,6 +99,12 @@ func (t *ImportTask) GetDescription() string {
 }
 
 func (t *ImportTask) Start(ctx context.Context) {
+	if err := t.run(ctx); err != nil {
+		logger.Error(err.Error())
+	}
+}
+
+func (t *ImportTask) run(ctx context.Context) error {
 	if t.TmpZip != "" {
 		defer func() {
 			err := fsutil.RemoveDir(t.BaseDir)
@@ -98,8 +114,7 @@ func (t *ImportTask) Start(ctx context.Context) {
 		}()
 
 		if err := t.unzipFile(); err != nil {
-			logger.Errorf("error unzipping provided file for import: %v", err)
-			return
+			return fmt.Errorf("error unzipping provided file for import: %w", err)
 		}
 	}
 
@@ -115,15 +130,30 @@ func (t *ImportTask) Start(ctx context.Context) {
 		t.MissingRefBehaviour = models.ImportMissingRefEnumFail
 	}
 
+	if t.Delta {
+		if err := t.loadDelta(); err != nil {
+			return fmt.Errorf("error loading incremental export: %w", err)
+		}
+
+		// changed objects replace the existing ones
+		t.Reset = false
+		t.DuplicateBehaviour = ImportDuplicateEnumOverwrite
+	}
+
 	if t.Reset {
 		err := t.resetter.Reset()
 
 		if err != nil {
-			logger.Errorf("Error resetting database: %v", err)
-			return
+			return fmt.Errorf("error resetting database: %w", err)
 		}
 	}
 
+	if t.delta != nil {
+		logger.Infof("Applying changes exported since %s", t.delta.Since.Time)
+		t.applyDeletions(ctx)
+		t.applyRenames(ctx)
+	}
+
 	t.ImportTags(ctx)
 	t.ImportPerformers(ctx)
 	t.ImportStudios(ctx)
@@ -133,6 +163,20 @@ func (t *ImportTask) Start(ctx context.Context) {
 
 	t.ImportScenes(ctx)
 	t.ImportImages(ctx)
+
+	return nil
+}
+
+// withTxn runs fn in a transaction, keeping the dry run results of the
+// transaction if it succeeds.
+func (t *ImportTask) withTxn(ctx context.Context, fn txn.TxnFunc) error {
+	t.report.start()
+	if err := t.repository.WithTxn(ctx, fn); err != nil {
+		return err
+	}
+
+	t.report.commit()
+	return nil
 }
 
 func (t *ImportTask) unzipFile() error {
@@ -192,7 +236,7 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 	logger.Info("[performers] importing")
 
 	path := t.json.json.Performers
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[performers] failed to read performers directory: %v", err)
@@ -208,21 +252,29 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 		performerJSON, err := jsonschema.LoadPerformerFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Errorf("[performers] failed to read json: %v", err)
+			t.report.invalid("performer", fi.Name(), err)
 			continue
 		}
 
 		logger.Progressf("[performers] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			importer := &performer.Importer{
 				ReaderWriter: r.Performer,
 				TagWriter:    r.Tag,
 				Input:        *performerJSON,
 			}
 
-			return performImport(ctx, importer, t.DuplicateBehaviour)
+			if err := t.checkRefs(ctx, "performer", importer.Name(), importRefs{
+				tags: performerJSON.Tags,
+			}); err != nil {
+				return err
+			}
+
+			return t.performImport(ctx, "performer", importer, t.DuplicateBehaviour)
 		}); err != nil {
 			logger.Errorf("[performers] <%s> import failed: %v", fi.Name(), err)
+			t.report.failed(err)
 		}
 	}
 
@@ -235,7 +287,7 @@ func (t *ImportTask) ImportStudios(ctx context.Context) {
 	logger.Info("[studios] importing")
 
 	path := t.json.json.Studios
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[studios] failed to read studios directory: %v", err)
@@ -244,19 +296,18 @@ func (t *ImportTask) ImportStudios(ctx context.Context) {
 		return
 	}
 
-	r := t.repository
-
 	for i, fi := range files {
 		index := i + 1
 		studioJSON, err := jsonschema.LoadStudioFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Errorf("[studios] failed to read json: %v", err)
+			t.report.invalid("studio", fi.Name(), err)
 			continue
 		}
 
 		logger.Progressf("[studios] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			return t.importStudio(ctx, studioJSON, pendingParent)
 		}); err != nil {
 			if errors.Is(err, studio.ErrParentStudioNotExist) {
@@ -268,6 +319,7 @@ func (t *ImportTask) ImportStudios(ctx context.Context) {
 			}
 
 			logger.Errorf("[studios] <%s> failed to create: %v", fi.Name(), err)
+			t.report.failed(err)
 			continue
 		}
 	}
@@ -278,10 +330,11 @@ func (t *ImportTask) ImportStudios(ctx context.Context) {
 
 		for _, s := range pendingParent {
 			for _, orphanStudioJSON := range s {
-				if err := r.WithTxn(ctx, func(ctx context.Context) error {
+				if err := t.withTxn(ctx, func(ctx context.Context) error {
 					return t.importStudio(ctx, orphanStudioJSON, nil)
 				}); err != nil {
 					logger.Errorf("[studios] <%s> failed to create: %v", orphanStudioJSON.Name, err)
+					t.report.failed(err)
 					continue
 				}
 			}
@@ -306,7 +359,14 @@ func (t *ImportTask) importStudio(ctx context.Context, studioJSON *jsonschema.St
 		importer.MissingRefBehaviour = models.ImportMissingRefEnumFail
 	}
 
-	if err := performImport(ctx, importer, t.DuplicateBehaviour); err != nil {
+	if err := t.checkRefs(ctx, "studio", importer.Name(), importRefs{
+		studio: studioJSON.ParentStudio,
+		tags:   studioJSON.Tags,
+	}); err != nil {
+		return err
+	}
+
+	if err := t.performImport(ctx, "studio", importer, t.DuplicateBehaviour); err != nil {
 		return err
 	}
 
@@ -330,7 +390,7 @@ func (t *ImportTask) ImportGroups(ctx context.Context) {
 	pendingSubs := make(map[string][]*jsonschema.Group)
 
 	path := t.json.json.Groups
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[groups] failed to read movies directory: %v", err)
@@ -339,19 +399,18 @@ func (t *ImportTask) ImportGroups(ctx context.Context) {
 		return
 	}
 
-	r := t.repository
-
 	for i, fi := range files {
 		index := i + 1
 		groupJSON, err := jsonschema.LoadGroupFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Errorf("[groups] failed to read json: %v", err)
+			t.report.invalid("group", fi.Name(), err)
 			continue
 		}
 
 		logger.Progressf("[groups] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			return t.importGroup(ctx, groupJSON, pendingSubs, false)
 		}); err != nil {
 			var subError group.SubGroupNotExistError
@@ -362,16 +421,18 @@ func (t *ImportTask) ImportGroups(ctx context.Context) {
 			}
 
 			logger.Errorf("[groups] <%s> failed to import: %v", fi.Name(), err)
+			t.report.failed(err)
 			continue
 		}
 	}
 
 	for _, s := range pendingSubs {
 		for _, orphanGroupJSON := range s {
-			if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			if err := t.withTxn(ctx, func(ctx context.Context) error {
 				return t.importGroup(ctx, orphanGroupJSON, nil, true)
 			}); err != nil {
 				logger.Errorf("[groups] <%s> failed to create: %v", orphanGroupJSON.Name, err)
+				t.report.failed(err)
 				continue
 			}
 		}
@@ -396,7 +457,14 @@ func (t *ImportTask) importGroup(ctx context.Context, groupJSON *jsonschema.Grou
 		importer.MissingRefBehaviour = models.ImportMissingRefEnumFail
 	}
 
-	if err := performImport(ctx, importer, t.DuplicateBehaviour); err != nil {
+	if err := t.checkRefs(ctx, "group", importer.Name(), importRefs{
+		studio: groupJSON.Studio,
+		tags:   groupJSON.Tags,
+	}); err != nil {
+		return err
+	}
+
+	if err := t.performImport(ctx, "group", importer, t.DuplicateBehaviour); err != nil {
 		return err
 	}
 
@@ -422,7 +490,7 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 	logger.Info("[files] importing")
 
 	path := t.json.json.Files
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[files] failed to read files directory: %v", err)
@@ -431,8 +499,6 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 		return
 	}
 
-	r := t.repository
-
 	pendingParent := make(map[string][]jsonschema.DirEntry)
 
 	for i, fi := range files {
@@ -440,12 +506,13 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 		fileJSON, err := jsonschema.LoadFileFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Errorf("[files] failed to read json: %v", err)
+			t.report.invalid("file", fi.Name(), err)
 			continue
 		}
 
 		logger.Progressf("[files] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			return t.importFile(ctx, fileJSON, pendingParent)
 		}); err != nil {
 			if errors.Is(err, file.ErrZipFileNotExist) {
@@ -457,6 +524,7 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 			}
 
 			logger.Errorf("[files] <%s> failed to create: %v", fi.Name(), err)
+			t.report.failed(err)
 			continue
 		}
 	}
@@ -467,10 +535,11 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 
 		for _, s := range pendingParent {
 			for _, orphanFileJSON := range s {
-				if err := r.WithTxn(ctx, func(ctx context.Context) error {
+				if err := t.withTxn(ctx, func(ctx context.Context) error {
 					return t.importFile(ctx, orphanFileJSON, nil)
 				}); err != nil {
 					logger.Errorf("[files] <%s> failed to create: %v", orphanFileJSON.DirEntry().Path, err)
+					t.report.failed(err)
 					continue
 				}
 			}
@@ -490,7 +559,7 @@ func (t *ImportTask) importFile(ctx context.Context, fileJSON jsonschema.DirEntr
 	}
 
 	// ignore duplicate files - don't overwrite
-	if err := performImport(ctx, fileImporter, ImportDuplicateEnumIgnore); err != nil {
+	if err := t.performImport(ctx, "file", fileImporter, ImportDuplicateEnumIgnore); err != nil {
 		return err
 	}
 
@@ -513,7 +582,7 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 	logger.Info("[galleries] importing")
 
 	path := t.json.json.Galleries
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[galleries] failed to read galleries directory: %v", err)
@@ -529,12 +598,13 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 		galleryJSON, err := jsonschema.LoadGalleryFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Errorf("[galleries] failed to read json: %v", err)
+			t.report.invalid("gallery", fi.Name(), err)
 			continue
 		}
 
 		logger.Progressf("[galleries] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			galleryImporter := &gallery.Importer{
 				ReaderWriter:        r.Gallery,
 				FolderFinder:        r.Folder,
@@ -546,7 +616,15 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 				MissingRefBehaviour: t.MissingRefBehaviour,
 			}
 
-			if err := performImport(ctx, galleryImporter, t.DuplicateBehaviour); err != nil {
+			if err := t.checkRefs(ctx, "gallery", galleryImporter.Name(), importRefs{
+				performers: galleryJSON.Performers,
+				studio:     galleryJSON.Studio,
+				tags:       galleryJSON.Tags,
+			}); err != nil {
+				return err
+			}
+
+			if err := t.performImport(ctx, "gallery", galleryImporter, t.DuplicateBehaviour); err != nil {
 				return err
 			}
 
@@ -559,7 +637,7 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 					ReaderWriter:        r.GalleryChapter,
 				}
 
-				if err := performImport(ctx, chapterImporter, t.DuplicateBehaviour); err != nil {
+				if err := t.performImport(ctx, "gallery chapter", chapterImporter, t.DuplicateBehaviour); err != nil {
 					return err
 				}
 			}
@@ -567,6 +645,7 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 			return nil
 		}); err != nil {
 			logger.Errorf("[galleries] <%s> import failed to commit: %v", fi.Name(), err)
+			t.report.failed(err)
 			continue
 		}
 	}
@@ -579,7 +658,7 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 	logger.Info("[tags] importing")
 
 	path := t.json.json.Tags
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[tags] failed to read tags directory: %v", err)
@@ -588,19 +667,18 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 		return
 	}
 
-	r := t.repository
-
 	for i, fi := range files {
 		index := i + 1
 		tagJSON, err := jsonschema.LoadTagFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Errorf("[tags] failed to read json: %v", err)
+			t.report.invalid("tag", fi.Name(), err)
 			continue
 		}
 
 		logger.Progressf("[tags] %d of %d", index, len(files))
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			return t.importTag(ctx, tagJSON, pendingParent, false)
 		}); err != nil {
 			var parentError tag.ParentTagNotExistError
@@ -610,16 +688,18 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 			}
 
 			logger.Errorf("[tags] <%s> failed to import: %v", fi.Name(), err)
+			t.report.failed(err)
 			continue
 		}
 	}
 
 	for _, s := range pendingParent {
 		for _, orphanTagJSON := range s {
-			if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			if err := t.withTxn(ctx, func(ctx context.Context) error {
 				return t.importTag(ctx, orphanTagJSON, nil, true)
 			}); err != nil {
 				logger.Errorf("[tags] <%s> failed to create: %v", orphanTagJSON.Name, err)
+				t.report.failed(err)
 				continue
 			}
 		}
@@ -640,7 +720,13 @@ func (t *ImportTask) importTag(ctx context.Context, tagJSON *jsonschema.Tag, pen
 		importer.MissingRefBehaviour = models.ImportMissingRefEnumFail
 	}
 
-	if err := performImport(ctx, importer, t.DuplicateBehaviour); err != nil {
+	if err := t.checkRefs(ctx, "tag", importer.Name(), importRefs{
+		tags: tagJSON.Parents,
+	}); err != nil {
+		return err
+	}
+
+	if err := t.performImport(ctx, "tag", importer, t.DuplicateBehaviour); err != nil {
 		return err
 	}
 
@@ -665,7 +751,7 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 	logger.Info("[scenes] importing")
 
 	path := t.json.json.Scenes
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[scenes] failed to read scenes directory: %v", err)
@@ -684,10 +770,11 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 		sceneJSON, err := jsonschema.LoadSceneFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Infof("[scenes] <%s> json parse failure: %v", fi.Name(), err)
+			t.report.invalid("scene", fi.Name(), err)
 			continue
 		}
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			sceneImporter := &scene.Importer{
 				ReaderWriter: r.Scene,
 				Input:        *sceneJSON,
@@ -703,7 +790,15 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 				TagWriter:       r.Tag,
 			}
 
-			if err := performImport(ctx, sceneImporter, t.DuplicateBehaviour); err != nil {
+			if err := t.checkRefs(ctx, "scene", sceneImporter.Name(), importRefs{
+				performers: sceneJSON.Performers,
+				studio:     sceneJSON.Studio,
+				tags:       sceneJSON.Tags,
+			}); err != nil {
+				return err
+			}
+
+			if err := t.performImport(ctx, "scene", sceneImporter, t.DuplicateBehaviour); err != nil {
 				return err
 			}
 
@@ -717,7 +812,7 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 					TagWriter:           r.Tag,
 				}
 
-				if err := performImport(ctx, markerImporter, t.DuplicateBehaviour); err != nil {
+				if err := t.performImport(ctx, "scene marker", markerImporter, t.DuplicateBehaviour); err != nil {
 					return err
 				}
 			}
@@ -725,6 +820,7 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 			return nil
 		}); err != nil {
 			logger.Errorf("[scenes] <%s> import failed: %v", fi.Name(), err)
+			t.report.failed(err)
 		}
 	}
 
@@ -735,7 +831,7 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 	logger.Info("[images] importing")
 
 	path := t.json.json.Images
-	files, err := os.ReadDir(path)
+	files, err := t.readJSONDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
 			logger.Errorf("[images] failed to read images directory: %v", err)
@@ -754,10 +850,11 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 		imageJSON, err := jsonschema.LoadImageFile(filepath.Join(path, fi.Name()))
 		if err != nil {
 			logger.Infof("[images] <%s> json parse failure: %v", fi.Name(), err)
+			t.report.invalid("image", fi.Name(), err)
 			continue
 		}
 
-		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		if err := t.withTxn(ctx, func(ctx context.Context) error {
 			imageImporter := &image.Importer{
 				ReaderWriter: r.Image,
 				FileFinder:   r.File,
@@ -771,9 +868,18 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 				TagWriter:       r.Tag,
 			}
 
-			return performImport(ctx, imageImporter, t.DuplicateBehaviour)
+			if err := t.checkRefs(ctx, "image", imageImporter.Name(), importRefs{
+				performers: imageJSON.Performers,
+				studio:     imageJSON.Studio,
+				tags:       imageJSON.Tags,
+			}); err != nil {
+				return err
+			}
+
+			return t.performImport(ctx, "image", imageImporter, t.DuplicateBehaviour)
 		}); err != nil {
 			logger.Errorf("[images] <%s> import failed: %v", fi.Name(), err)
+			t.report.failed(err)
 		}
 	}
 
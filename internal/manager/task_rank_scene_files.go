@@ -0,0 +1,291 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+type InferiorFileHandling string
+
+const (
+	// Keep the inferior files
+	InferiorFileHandlingKeep InferiorFileHandling = "KEEP"
+	// Move the inferior files into the trash
+	InferiorFileHandlingTrash InferiorFileHandling = "TRASH"
+	// Delete the inferior files
+	InferiorFileHandlingDelete InferiorFileHandling = "DELETE"
+)
+
+var AllInferiorFileHandling = []InferiorFileHandling{
+	InferiorFileHandlingKeep,
+	InferiorFileHandlingTrash,
+	InferiorFileHandlingDelete,
+}
+
+func (e InferiorFileHandling) IsValid() bool {
+	switch e {
+	case InferiorFileHandlingKeep, InferiorFileHandlingTrash, InferiorFileHandlingDelete:
+		return true
+	}
+	return false
+}
+
+func (e InferiorFileHandling) String() string {
+	return string(e)
+}
+
+func (e *InferiorFileHandling) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InferiorFileHandling(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InferiorFileHandling", str)
+	}
+	return nil
+}
+
+func (e InferiorFileHandling) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+const (
+	rankCounterScenes   = "scenes_ranked"
+	rankCounterChanged  = "primary_changed"
+	rankCounterPlanned  = "primary_planned"
+	rankCounterTrashed  = "files_trashed"
+	rankCounterDeleted  = "files_deleted"
+	rankCounterInferior = "inferior_files_planned"
+	rankCounterErrors   = "errors"
+)
+
+// sceneFileRank is the files of a scene ordered by the ranking rules.
+type sceneFileRank struct {
+	scene *models.Scene
+	// files ordered from best to worst
+	files []*models.VideoFile
+}
+
+func (r sceneFileRank) best() *models.VideoFile {
+	return r.files[0]
+}
+
+func (r sceneFileRank) inferior() []*models.VideoFile {
+	return r.files[1:]
+}
+
+// changed returns true if the best file is not the primary file.
+func (r sceneFileRank) changed() bool {
+	return r.scene.PrimaryFileID == nil || *r.scene.PrimaryFileID != r.best().ID
+}
+
+// sceneFileRanker ranks the files of scenes with multiple files.
+type sceneFileRanker struct {
+	repository models.Repository
+	ranking    models.SceneFileRanking
+}
+
+// rank returns the ranked files of the scenes with the provided IDs that
+// have multiple files. If no IDs are provided, all scenes with multiple
+// files are ranked.
+func (p *sceneFileRanker) rank(ctx context.Context, sceneIDs []string) ([]sceneFileRank, error) {
+	ids, err := stringslice.StringSliceToIntSlice(sceneIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	var ret []sceneFileRank
+	r := p.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var scenes []*models.Scene
+		var err error
+
+		if len(ids) > 0 {
+			scenes, err = r.Scene.FindMany(ctx, ids)
+		} else {
+			perPage := models.PerPageAll
+			scenes, err = scene.Query(ctx, r.Scene, &models.SceneFilterType{
+				FileCount: &models.IntCriterionInput{
+					Value:    1,
+					Modifier: models.CriterionModifierGreaterThan,
+				},
+			}, &models.FindFilterType{
+				PerPage: &perPage,
+			})
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			if err := s.LoadFiles(ctx, r.Scene); err != nil {
+				return fmt.Errorf("loading files of scene %d: %w", s.ID, err)
+			}
+
+			files := s.Files.List()
+			if len(files) < 2 {
+				continue
+			}
+
+			// the primary file is listed first, so that it is kept if
+			// the files rank equally
+			ret = append(ret, sceneFileRank{
+				scene: s,
+				files: scene.RankFiles(files, p.ranking),
+			})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+type rankSceneFilesJob struct {
+	repository   models.Repository
+	sceneService SceneService
+	ranker       *sceneFileRanker
+	newDeleter   func() *file.Deleter
+	pluginCache  *plugin.Cache
+	input        RankSceneFilesInput
+}
+
+func (j *rankSceneFilesJob) Execute(ctx context.Context, progress *job.Progress) error {
+	if j.input.DryRun {
+		logger.Infof("Running in Dry Mode")
+	}
+
+	ranks, err := j.ranker.rank(ctx, j.input.SceneIDs)
+	if err != nil {
+		return fmt.Errorf("ranking scene files: %w", err)
+	}
+
+	progress.SetTotal(len(ranks))
+
+	for _, rank := range ranks {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.AddCounter(rankCounterScenes, 1)
+
+		if j.input.DryRun {
+			j.logPlan(rank, progress)
+		} else if err := j.apply(ctx, rank, progress); err != nil {
+			logger.Errorf("Error selecting primary file of scene %q: %v", rank.scene.DisplayName(), err)
+			progress.AddCounter(rankCounterErrors, 1)
+		}
+
+		progress.Increment()
+	}
+
+	logger.Info("Finished ranking scene files")
+	return nil
+}
+
+func (j *rankSceneFilesJob) inferiorFileHandling() InferiorFileHandling {
+	if j.input.InferiorFiles == nil {
+		return InferiorFileHandlingKeep
+	}
+	return *j.input.InferiorFiles
+}
+
+func (j *rankSceneFilesJob) logPlan(rank sceneFileRank, progress *job.Progress) {
+	name := rank.scene.DisplayName()
+	if rank.changed() {
+		logger.Infof("Would set primary file of scene %q to %s", name, rank.best().Path)
+		progress.AddCounter(rankCounterPlanned, 1)
+	}
+
+	if j.inferiorFileHandling() == InferiorFileHandlingKeep {
+		return
+	}
+
+	for _, f := range rank.inferior() {
+		logger.Infof("Would remove inferior file %s of scene %q", f.Path, name)
+		progress.AddCounter(rankCounterInferior, 1)
+	}
+}
+
+// apply sets the best file as the primary file of the scene, then removes
+// the inferior files if requested. The files are only removed once the
+// transaction is committed.
+func (j *rankSceneFilesJob) apply(ctx context.Context, rank sceneFileRank, progress *job.Progress) error {
+	handling := j.inferiorFileHandling()
+	if !rank.changed() && handling == InferiorFileHandlingKeep {
+		return nil
+	}
+
+	fileDeleter := j.newDeleter()
+	var removed []*models.VideoFile
+
+	r := j.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		s := rank.scene
+		if rank.changed() {
+			best := rank.best().ID
+			scenePartial := models.NewScenePartial()
+			scenePartial.PrimaryFileID = &best
+
+			var err error
+			s, err = r.Scene.UpdatePartial(ctx, s.ID, scenePartial)
+			if err != nil {
+				return err
+			}
+		}
+
+		if handling != InferiorFileHandlingKeep {
+			trash := handling == InferiorFileHandlingTrash
+			var err error
+			removed, err = j.sceneService.DestroyFiles(ctx, s, rank.inferior(), fileDeleter, trash)
+			if err != nil {
+				return err
+			}
+		}
+
+		if rank.changed() || len(removed) > 0 {
+			j.pluginCache.RegisterPostHooks(ctx, s.ID, hook.SceneUpdatePost, nil, nil)
+		}
+		return nil
+	}); err != nil {
+		fileDeleter.Rollback()
+		return err
+	}
+
+	// perform the post-commit actions
+	fileDeleter.Commit()
+
+	name := rank.scene.DisplayName()
+	if rank.changed() {
+		logger.Infof("Set primary file of scene %q to %s", name, rank.best().Path)
+		progress.AddCounter(rankCounterChanged, 1)
+	}
+
+	for _, f := range removed {
+		if handling == InferiorFileHandlingTrash {
+			logger.Infof("Moved inferior file %s of scene %q into the trash", f.Path, name)
+			progress.AddCounter(rankCounterTrashed, 1)
+		} else {
+			logger.Infof("Deleted inferior file %s of scene %q", f.Path, name)
+			progress.AddCounter(rankCounterDeleted, 1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,349 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/file/rename"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+const (
+	renameCounterMoved   = "files_moved"
+	renameCounterPlanned = "files_planned"
+	renameCounterSkipped = "files_skipped"
+	renameCounterErrors  = "errors"
+)
+
+// renamePlanner builds the target paths of scene and image files from the
+// rename templates of the libraries that contain them.
+type renamePlanner struct {
+	repository models.Repository
+	stashes    config.StashConfigs
+
+	templates map[string]*rename.Template
+	studios   map[int]string
+}
+
+func newRenamePlanner(repository models.Repository, stashes config.StashConfigs) *renamePlanner {
+	return &renamePlanner{
+		repository: repository,
+		stashes:    stashes,
+		templates:  make(map[string]*rename.Template),
+		studios:    make(map[int]string),
+	}
+}
+
+// plan returns the planned renames of the primary files of the scenes and
+// images selected by input. Files in libraries without a rename template
+// and files inside zip files are omitted.
+func (p *renamePlanner) plan(ctx context.Context, input RenameMetadataInput) ([]*rename.Rename, error) {
+	sceneIDs, err := stringslice.StringSliceToIntSlice(input.SceneIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	imageIDs, err := stringslice.StringSliceToIntSlice(input.ImageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("converting image ids: %w", err)
+	}
+
+	all := len(sceneIDs) == 0 && len(imageIDs) == 0
+
+	var ret []*rename.Rename
+	r := p.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var scenes []*models.Scene
+		var images []*models.Image
+		var err error
+
+		if all {
+			scenes, err = r.Scene.All(ctx)
+		} else if len(sceneIDs) > 0 {
+			scenes, err = r.Scene.FindMany(ctx, sceneIDs)
+		}
+		if err != nil {
+			return err
+		}
+
+		if all {
+			images, err = r.Image.All(ctx)
+		} else if len(imageIDs) > 0 {
+			images, err = r.Image.FindMany(ctx, imageIDs)
+		}
+		if err != nil {
+			return err
+		}
+
+		for _, s := range scenes {
+			rn, err := p.planScene(ctx, s)
+			if err != nil {
+				return fmt.Errorf("planning rename of scene %d: %w", s.ID, err)
+			}
+			if rn != nil {
+				ret = append(ret, rn)
+			}
+		}
+
+		for _, i := range images {
+			rn, err := p.planImage(ctx, i)
+			if err != nil {
+				return fmt.Errorf("planning rename of image %d: %w", i.ID, err)
+			}
+			if rn != nil {
+				ret = append(ret, rn)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	rename.DetectCollisions(ret, &file.OsFS{})
+
+	return ret, nil
+}
+
+func (p *renamePlanner) planScene(ctx context.Context, s *models.Scene) (*rename.Rename, error) {
+	r := p.repository
+	if err := s.LoadPrimaryFile(ctx, r.File); err != nil {
+		return nil, err
+	}
+
+	f := s.Files.Primary()
+	if f == nil {
+		return nil, nil
+	}
+
+	if err := s.LoadPerformerIDs(ctx, r.Scene); err != nil {
+		return nil, err
+	}
+
+	v := rename.Values{
+		Title: s.Title,
+		Code:  s.Code,
+	}
+	if s.Date != nil {
+		v.Date = s.Date.String()
+	}
+
+	var err error
+	if v.Studio, err = p.studioName(ctx, s.StudioID); err != nil {
+		return nil, err
+	}
+	if v.Performers, err = p.performerNames(ctx, s.PerformerIDs.List()); err != nil {
+		return nil, err
+	}
+
+	return p.planFile(f, v), nil
+}
+
+func (p *renamePlanner) planImage(ctx context.Context, i *models.Image) (*rename.Rename, error) {
+	r := p.repository
+	if err := i.LoadPrimaryFile(ctx, r.File); err != nil {
+		return nil, err
+	}
+
+	f := i.Files.Primary()
+	if f == nil {
+		return nil, nil
+	}
+
+	if err := i.LoadPerformerIDs(ctx, r.Image); err != nil {
+		return nil, err
+	}
+
+	v := rename.Values{
+		Title: i.Title,
+		Code:  i.Code,
+	}
+	if i.Date != nil {
+		v.Date = i.Date.String()
+	}
+
+	var err error
+	if v.Studio, err = p.studioName(ctx, i.StudioID); err != nil {
+		return nil, err
+	}
+	if v.Performers, err = p.performerNames(ctx, i.PerformerIDs.List()); err != nil {
+		return nil, err
+	}
+
+	return p.planFile(f, v), nil
+}
+
+func (p *renamePlanner) studioName(ctx context.Context, id *int) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+
+	if name, found := p.studios[*id]; found {
+		return name, nil
+	}
+
+	s, err := p.repository.Studio.Find(ctx, *id)
+	if err != nil {
+		return "", err
+	}
+
+	var name string
+	if s != nil {
+		name = s.Name
+	}
+	p.studios[*id] = name
+
+	return name, nil
+}
+
+func (p *renamePlanner) performerNames(ctx context.Context, ids []int) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	performers, err := p.repository.Performer.FindMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]string, len(performers))
+	for i, pp := range performers {
+		ret[i] = pp.Name
+	}
+	sort.Strings(ret)
+
+	return ret, nil
+}
+
+func (p *renamePlanner) template(stash *config.StashConfig) (*rename.Template, error) {
+	if t, found := p.templates[stash.Path]; found {
+		return t, nil
+	}
+
+	t, err := rename.ParseTemplate(stash.RenameTemplate)
+	if err != nil {
+		return nil, err
+	}
+	p.templates[stash.Path] = t
+
+	return t, nil
+}
+
+func (p *renamePlanner) planFile(f models.File, v rename.Values) *rename.Rename {
+	base := f.Base()
+	if base.ZipFileID != nil {
+		return nil
+	}
+
+	stash := p.stashes.GetStashFromPath(base.Path)
+	if stash == nil || stash.RenameTemplate == "" {
+		return nil
+	}
+
+	ext := filepath.Ext(base.Basename)
+	v.Ext = strings.TrimPrefix(ext, ".")
+	v.Basename = strings.TrimSuffix(base.Basename, ext)
+	if v.Title == "" {
+		v.Title = v.Basename
+	}
+
+	ret := &rename.Rename{
+		File: f,
+	}
+
+	t, err := p.template(stash)
+	if err != nil {
+		ret.Err = fmt.Errorf("invalid rename template for library %s: %w", stash.Path, err)
+		return ret
+	}
+
+	newPath, err := t.Render(v)
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+
+	ret.NewPath = filepath.Join(stash.Path, newPath)
+	return ret
+}
+
+type renameJob struct {
+	repository models.Repository
+	planner    *renamePlanner
+	input      RenameMetadataInput
+}
+
+func (j *renameJob) Execute(ctx context.Context, progress *job.Progress) error {
+	if j.input.DryRun {
+		logger.Infof("Running in Dry Mode")
+	}
+
+	renames, err := j.planner.plan(ctx, j.input)
+	if err != nil {
+		return fmt.Errorf("planning file renames: %w", err)
+	}
+
+	progress.SetTotal(len(renames))
+
+	for _, rn := range renames {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return nil
+		}
+
+		switch {
+		case rn.Err != nil:
+			logger.Warnf("Not moving %s: %v", rn.OldPath(), rn.Err)
+			progress.AddCounter(renameCounterSkipped, 1)
+		case rn.Unchanged():
+			// nothing to do
+		case j.input.DryRun:
+			logger.Infof("Would move %s to %s", rn.OldPath(), rn.NewPath)
+			progress.AddCounter(renameCounterPlanned, 1)
+		default:
+			oldPath := rn.OldPath()
+			if err := j.move(ctx, rn); err != nil {
+				logger.Errorf("Error moving %s to %s: %v", oldPath, rn.NewPath, err)
+				progress.AddCounter(renameCounterErrors, 1)
+			} else {
+				logger.Infof("Moved %s to %s", oldPath, rn.NewPath)
+				progress.AddCounter(renameCounterMoved, 1)
+			}
+		}
+
+		progress.Increment()
+	}
+
+	logger.Info("Finished renaming files")
+	return nil
+}
+
+// move moves the file to its new path. The file is moved back if the
+// database update fails.
+func (j *renameJob) move(ctx context.Context, rn *rename.Rename) error {
+	r := j.repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		mover := file.NewMover(r.File, r.Folder)
+		mover.RegisterHooks(ctx)
+
+		dir := filepath.Dir(rn.NewPath)
+		folder, err := file.GetOrCreateFolderHierarchy(ctx, r.Folder, dir)
+		if err != nil {
+			return fmt.Errorf("getting folder %s: %w", dir, err)
+		}
+
+		if err := mover.CreateFolderHierarchy(dir); err != nil {
+			return err
+		}
+
+		return mover.Move(ctx, rn.File, folder, filepath.Base(rn.NewPath))
+	})
+}
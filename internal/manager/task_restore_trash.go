@@ -0,0 +1,151 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// RestoreTrashJob scans files restored from the trash, and then reapplies
+// the metadata that was stored with them to the recreated scenes, images
+// and galleries.
+type RestoreTrashJob struct {
+	scanJob    *ScanJob
+	repository models.Repository
+	files      []*models.TrashedFile
+}
+
+func (j *RestoreTrashJob) Execute(ctx context.Context, progress *job.Progress) error {
+	if err := j.scanJob.Execute(ctx, progress); err != nil {
+		return err
+	}
+
+	if job.IsCancelled(ctx) {
+		return nil
+	}
+
+	for _, f := range j.files {
+		if err := txn.WithTxn(ctx, j.repository.TxnManager, func(ctx context.Context) error {
+			return j.restoreMetadata(ctx, f)
+		}); err != nil {
+			logger.Warnf("Error restoring metadata of %s %q: %v", f.EntityType, f.EntityTitle, err)
+			continue
+		}
+
+		logger.Infof("Restored metadata of %s %q", f.EntityType, f.EntityTitle)
+	}
+
+	return nil
+}
+
+// restoreMetadata applies the metadata stored with the trashed file to the
+// entity that was recreated for the restored file. Files of the same entity
+// may be recreated as separate entities, so the metadata is applied using
+// the restored file only.
+func (j *RestoreTrashJob) restoreMetadata(ctx context.Context, f *models.TrashedFile) error {
+	i, err := j.metadataImporter(f)
+	if err != nil {
+		return err
+	}
+
+	if err := i.PreImport(ctx); err != nil {
+		return err
+	}
+
+	id, err := i.FindExistingID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if id == nil {
+		return fmt.Errorf("no %s was created for %q", f.EntityType, f.OriginalPath)
+	}
+
+	if err := i.Update(ctx, *id); err != nil {
+		return err
+	}
+
+	return i.PostImport(ctx, *id)
+}
+
+// metadataImporter returns an importer for the metadata stored with the
+// trashed file. Relationships are not stored with the metadata, so missing
+// references are ignored.
+func (j *RestoreTrashJob) metadataImporter(f *models.TrashedFile) (importer, error) {
+	r := j.repository
+	data := []byte(f.EntityMetadata)
+
+	switch f.EntityType {
+	case models.TrashedEntityTypeScene:
+		var input jsonschema.Scene
+		if err := json.Unmarshal(data, &input); err != nil {
+			return nil, fmt.Errorf("decoding metadata: %w", err)
+		}
+		input.Files = []string{f.OriginalPath}
+
+		return &scene.Importer{
+			ReaderWriter: r.Scene,
+			Input:        input,
+			FileFinder:   r.File,
+
+			FileNamingAlgorithm: config.GetInstance().GetVideoFileNamingAlgorithm(),
+			MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+
+			GalleryFinder:   r.Gallery,
+			GroupWriter:     r.Group,
+			PerformerWriter: r.Performer,
+			StudioWriter:    r.Studio,
+			TagWriter:       r.Tag,
+		}, nil
+	case models.TrashedEntityTypeImage:
+		var input jsonschema.Image
+		if err := json.Unmarshal(data, &input); err != nil {
+			return nil, fmt.Errorf("decoding metadata: %w", err)
+		}
+		input.Files = []string{f.OriginalPath}
+
+		return &image.Importer{
+			ReaderWriter: r.Image,
+			FileFinder:   r.File,
+			Input:        input,
+
+			MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+
+			GalleryFinder:   r.Gallery,
+			PerformerWriter: r.Performer,
+			StudioWriter:    r.Studio,
+			TagWriter:       r.Tag,
+		}, nil
+	case models.TrashedEntityTypeGallery:
+		var input jsonschema.Gallery
+		if err := json.Unmarshal(data, &input); err != nil {
+			return nil, fmt.Errorf("decoding metadata: %w", err)
+		}
+		// only zip files of galleries are trashed
+		input.FolderPath = ""
+		input.ZipFiles = []string{f.OriginalPath}
+
+		return &gallery.Importer{
+			ReaderWriter:        r.Gallery,
+			FolderFinder:        r.Folder,
+			FileFinder:          r.File,
+			PerformerWriter:     r.Performer,
+			StudioWriter:        r.Studio,
+			TagWriter:           r.Tag,
+			Input:               input,
+			MissingRefBehaviour: models.ImportMissingRefEnumIgnore,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported entity type %q", f.EntityType)
+	}
+}
@@ -88,6 +88,7 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) error {
 
 type extensionConfig struct {
 	vidExt []string
+	audExt []string
 	imgExt []string
 	zipExt []string
 }
@@ -95,6 +96,7 @@ type extensionConfig struct {
 func newExtensionConfig(c *config.Config) extensionConfig {
 	return extensionConfig{
 		vidExt: c.GetVideoExtensions(),
+		audExt: c.GetAudioExtensions(),
 		imgExt: c.GetImageExtensions(),
 		zipExt: c.GetGalleryExtensions(),
 	}
@@ -463,6 +465,26 @@ func (g *imageGenerators) Generate(ctx context.Context, i *models.Image, f model
 		}
 	}
 
+	imageFile, isImage := f.(*models.ImageFile)
+	if isImage && t.ScanGenerateImagePhashes {
+		progress.AddTotal(1)
+		phashFn := func(ctx context.Context) {
+			taskPhash := GenerateImagePhashTask{
+				repository: GetInstance().Repository,
+				File:       imageFile,
+				Overwrite:  overwrite,
+			}
+			taskPhash.Start(ctx)
+			progress.Increment()
+		}
+
+		if g.sequentialScanning {
+			phashFn(ctx)
+		} else {
+			g.taskQueue.Add(fmt.Sprintf("Generating phash for %s", path), phashFn)
+		}
+	}
+
 	return nil
 }
 
@@ -485,7 +507,10 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 
 	mgr := GetInstance()
 
-	if t.ScanGenerateSprites {
+	// sprites, phashes and previews are generated from video frames
+	isAudioFile := f.IsAudio()
+
+	if t.ScanGenerateSprites && !isAudioFile {
 		progress.AddTotal(1)
 		spriteFn := func(ctx context.Context) {
 			taskSprite := GenerateSpriteTask{
@@ -504,7 +529,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		}
 	}
 
-	if t.ScanGeneratePhashes {
+	if t.ScanGeneratePhashes && !isAudioFile {
 		progress.AddTotal(1)
 		phashFn := func(ctx context.Context) {
 			taskPhash := GeneratePhashTask{
@@ -524,7 +549,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *mode
 		}
 	}
 
-	if t.ScanGeneratePreviews {
+	if t.ScanGeneratePreviews && !isAudioFile {
 		progress.AddTotal(1)
 		previewsFn := func(ctx context.Context) {
 			options := getGeneratePreviewOptions(GeneratePreviewOptionsInput{})
@@ -10,6 +10,7 @@ import (
 	"github.com/stashapp/stash/pkg/performer"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 	"github.com/stashapp/stash/pkg/studio"
+	"github.com/stashapp/stash/pkg/tag"
 )
 
 type StashBoxTagTaskType int
@@ -17,6 +18,7 @@ type StashBoxTagTaskType int
 const (
 	Performer StashBoxTagTaskType = iota
 	Studio
+	Tag
 )
 
 type StashBoxBatchTagTask struct {
@@ -24,6 +26,7 @@ type StashBoxBatchTagTask struct {
 	name           *string
 	performer      *models.Performer
 	studio         *models.Studio
+	tag            *models.Tag
 	refresh        bool
 	createParent   bool
 	excludedFields []string
@@ -36,6 +39,8 @@ func (t *StashBoxBatchTagTask) Start(ctx context.Context) {
 		t.stashBoxPerformerTag(ctx)
 	case Studio:
 		t.stashBoxStudioTag(ctx)
+	case Tag:
+		t.stashBoxTagTag(ctx)
 	default:
 		logger.Errorf("Error starting batch task, unknown task_type %d", t.taskType)
 	}
@@ -58,6 +63,14 @@ func (t *StashBoxBatchTagTask) Description() string {
 			name = t.studio.Name
 		}
 		return fmt.Sprintf("Tagging studio %s from stash-box", name)
+	} else if t.taskType == Tag {
+		var name string
+		if t.name != nil {
+			name = *t.name
+		} else {
+			name = t.tag.Name
+		}
+		return fmt.Sprintf("Linking tag %s to stash-box", name)
 	}
 	return fmt.Sprintf("Unknown tagging task type %d from stash-box", t.taskType)
 }
@@ -461,3 +474,140 @@ func (t *StashBoxBatchTagTask) processParentStudio(ctx context.Context, parent *
 		return err
 	}
 }
+
+func (t *StashBoxBatchTagTask) stashBoxTagTag(ctx context.Context) {
+	tag, err := t.findStashBoxTag(ctx)
+	if err != nil {
+		logger.Errorf("Error fetching tag data from stash-box: %v", err)
+		return
+	}
+
+	excluded := map[string]bool{}
+	for _, field := range t.excludedFields {
+		excluded[field] = true
+	}
+
+	// tag will have a value if pulling from Stash-box by Stash ID, name or alias was successful
+	if tag != nil {
+		t.processMatchedTag(ctx, tag, excluded)
+	} else {
+		var name string
+		if t.name != nil {
+			name = *t.name
+		} else if t.tag != nil {
+			name = t.tag.Name
+		}
+		logger.Infof("No match found for %s", name)
+	}
+}
+
+func (t *StashBoxBatchTagTask) findStashBoxTag(ctx context.Context) (*models.ScrapedTag, error) {
+	r := instance.Repository
+
+	stashboxRepository := stashbox.NewRepository(r)
+	client := stashbox.NewClient(*t.box, stashboxRepository)
+
+	if t.name != nil {
+		return client.FindStashBoxTag(ctx, *t.name)
+	}
+
+	var remoteID string
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		if err := t.tag.LoadStashIDs(ctx, r.Tag); err != nil {
+			return err
+		}
+		if err := t.tag.LoadAliases(ctx, r.Tag); err != nil {
+			return err
+		}
+		for _, id := range t.tag.StashIDs.List() {
+			if id.Endpoint == t.box.Endpoint {
+				remoteID = id.StashID
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if t.refresh {
+		if remoteID == "" {
+			return nil, nil
+		}
+		return client.FindStashBoxTag(ctx, remoteID)
+	}
+
+	// try the tag name first, then each of its aliases
+	names := append([]string{t.tag.Name}, t.tag.Aliases.List()...)
+	for _, name := range names {
+		ret, err := client.FindStashBoxTag(ctx, name)
+		if err != nil || ret != nil {
+			return ret, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *StashBoxBatchTagTask) processMatchedTag(ctx context.Context, s *models.ScrapedTag, excluded map[string]bool) {
+	var tagID int
+
+	switch {
+	case t.tag != nil:
+		tagID = t.tag.ID
+
+		if s.StoredID != nil && *s.StoredID != strconv.Itoa(tagID) {
+			logger.Warnf("stash-box tag %s already matches a different tag, skipping %s", s.Name, t.tag.Name)
+			return
+		}
+	case s.StoredID != nil:
+		// the tag already exists locally, link it
+		tagID, _ = strconv.Atoi(*s.StoredID)
+	case t.name != nil && s.Name != "":
+		// Creating a new tag
+		newTag := s.ToTag(t.box.Endpoint)
+
+		r := instance.Repository
+		err := r.WithTxn(ctx, func(ctx context.Context) error {
+			qb := r.Tag
+
+			if err := tag.ValidateCreate(ctx, *newTag, qb); err != nil {
+				return err
+			}
+
+			return qb.Create(ctx, newTag)
+		})
+		if err != nil {
+			logger.Errorf("Failed to create tag %s: %v", s.Name, err)
+		} else {
+			logger.Infof("Created tag %s", s.Name)
+		}
+		return
+	default:
+		return
+	}
+
+	// Start the transaction and update the tag
+	r := instance.Repository
+	err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.Tag
+
+		existingStashIDs, err := qb.GetStashIDs(ctx, tagID)
+		if err != nil {
+			return err
+		}
+
+		partial := s.ToPartial(t.box.Endpoint, excluded, existingStashIDs)
+
+		if err := tag.ValidateUpdate(ctx, tagID, partial, qb); err != nil {
+			return err
+		}
+
+		_, err = qb.UpdatePartial(ctx, tagID, partial)
+		return err
+	})
+	if err != nil {
+		logger.Errorf("Failed to update tag %s: %v", s.Name, err)
+	} else {
+		logger.Infof("Updated tag %s", s.Name)
+	}
+}
@@ -0,0 +1,196 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// trashPurgeInterval is the interval between purges of files that have been
+// in the trash for longer than the retention period.
+const trashPurgeInterval = time.Hour
+
+// NewFileDeleter returns a file deleter that moves files deleted with
+// TrashFiles into the configured trash directory.
+func (s *Manager) NewFileDeleter() *file.Deleter {
+	ret := file.NewDeleter()
+	ret.Trash = &file.Trash{
+		Path:       s.Config.GetTrashPathOrDefault(),
+		TxnManager: s.Repository.TxnManager,
+		Repository: s.Repository.TrashedFile,
+	}
+
+	return ret
+}
+
+// RestoreTrashResult is the result of restoring trashed files.
+type RestoreTrashResult struct {
+	// JobID is the ID of the job that scans the restored files, or zero if
+	// no files were restored.
+	JobID int
+	// Errors are the errors encountered restoring individual files.
+	Errors []error
+}
+
+// RestoreTrashedFiles moves the trashed files with the provided IDs back to
+// their original paths, and queues a job that scans the restored files and
+// reapplies the metadata stored with them. Files that cannot be restored
+// are reported in the result, and do not prevent the other files from
+// being restored.
+func (s *Manager) RestoreTrashedFiles(ctx context.Context, ids []int) (*RestoreTrashResult, error) {
+	r := s.Repository
+
+	var files []*models.TrashedFile
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		files, err = r.TrashedFile.FindMany(ctx, ids)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := &RestoreTrashResult{}
+
+	var restored []*models.TrashedFile
+	for _, f := range files {
+		if err := file.RestoreTrashedFile(f); err != nil {
+			ret.Errors = append(ret.Errors, fmt.Errorf("restoring trashed file %d: %w", f.ID, err))
+			continue
+		}
+
+		logger.Infof("Restored %q from trash", f.OriginalPath)
+		restored = append(restored, f)
+
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.TrashedFile.Destroy(ctx, f.ID)
+		}); err != nil {
+			ret.Errors = append(ret.Errors, fmt.Errorf("removing trash record %d: %w", f.ID, err))
+		}
+	}
+
+	if len(restored) == 0 {
+		return ret, nil
+	}
+
+	// scan the restored files so that their scenes, images and galleries
+	// are recreated
+	input := ScanMetadataInput{}
+	for _, f := range restored {
+		input.Paths = append(input.Paths, f.OriginalPath)
+	}
+	if opts := s.Config.GetDefaultScanSettings(); opts != nil {
+		input.ScanMetadataOptions = *opts
+	}
+
+	scanJob, err := s.newScanJob(input)
+	if err != nil {
+		ret.Errors = append(ret.Errors, fmt.Errorf("scanning restored files: %w", err))
+		return ret, nil
+	}
+
+	j := &RestoreTrashJob{
+		scanJob:    scanJob,
+		repository: r,
+		files:      restored,
+	}
+
+	ret.JobID = s.JobManager.Add(ctx, "Restoring files from trash...", j)
+	return ret, nil
+}
+
+// EmptyTrash permanently deletes the trashed files with the provided IDs.
+// If ids is nil, all trashed files are deleted. Returns the number of files
+// deleted.
+func (s *Manager) EmptyTrash(ctx context.Context, ids []int) (int, error) {
+	r := s.Repository
+
+	var files []*models.TrashedFile
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		if ids == nil {
+			files, err = r.TrashedFile.All(ctx)
+		} else {
+			files, err = r.TrashedFile.FindMany(ctx, ids)
+		}
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return s.removeTrashedFiles(ctx, files)
+}
+
+// PurgeTrash permanently deletes all files that have been in the trash for
+// longer than the configured retention period.
+func (s *Manager) PurgeTrash(ctx context.Context) error {
+	days := s.Config.GetTrashRetention()
+	if days == 0 {
+		return nil
+	}
+
+	r := s.Repository
+
+	var files []*models.TrashedFile
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		files, err = r.TrashedFile.FindBefore(ctx, time.Now().AddDate(0, 0, -days))
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	n, err := s.removeTrashedFiles(ctx, files)
+	if n > 0 {
+		logger.Infof("Purged %d file(s) older than %d day(s) from trash", n, days)
+	}
+
+	return err
+}
+
+func (s *Manager) removeTrashedFiles(ctx context.Context, files []*models.TrashedFile) (int, error) {
+	r := s.Repository
+
+	removed := 0
+	for _, f := range files {
+		if err := file.RemoveTrashedFile(f); err != nil {
+			return removed, fmt.Errorf("deleting trashed file %d: %w", f.ID, err)
+		}
+
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.TrashedFile.Destroy(ctx, f.ID)
+		}); err != nil {
+			return removed, fmt.Errorf("removing trash record %d: %w", f.ID, err)
+		}
+
+		removed++
+	}
+
+	return removed, nil
+}
+
+// startTrashPurge periodically purges expired files from the trash. It
+// does nothing if the purge has already been started.
+func (s *Manager) startTrashPurge() {
+	s.trashPurgeOnce.Do(func() {
+		go func() {
+			ctx := context.Background()
+			for {
+				if err := s.Database.Ready(); err == nil {
+					if err := s.PurgeTrash(ctx); err != nil {
+						logger.Errorf("Error purging trash: %v", err)
+					}
+				}
+
+				time.Sleep(trashPurgeInterval)
+			}
+		}()
+	})
+}
@@ -0,0 +1,147 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var ErrUsersRequireCredentials = errors.New("a username and password must be configured before users can be added")
+
+// userCache caches the user accounts stored in the database, so that
+// requests can be authenticated without querying the database. It
+// implements session.UserStore.
+type userCache struct {
+	repository models.Repository
+	ready      func() error
+
+	mutex  sync.Mutex
+	users  []*models.User
+	loaded bool
+}
+
+func (c *userCache) get() []*models.User {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.loaded {
+		return c.users
+	}
+
+	if err := c.ready(); err != nil {
+		// database is not available yet, try again later
+		return nil
+	}
+
+	var users []*models.User
+	r := c.repository
+	if err := r.WithReadTxn(context.Background(), func(ctx context.Context) error {
+		var err error
+		users, err = r.User.All(ctx)
+		return err
+	}); err != nil {
+		logger.Errorf("Error loading users: %v", err)
+		return nil
+	}
+
+	c.users = users
+	c.loaded = true
+	return c.users
+}
+
+func (c *userCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.users = nil
+	c.loaded = false
+}
+
+func (c *userCache) find(username string) *models.User {
+	for _, u := range c.get() {
+		if u.Username == username {
+			return u
+		}
+	}
+
+	return nil
+}
+
+func (c *userCache) ValidateUserCredentials(username string, password string) bool {
+	u := c.find(username)
+	if u == nil {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
+
+func (c *userCache) GetUsernameByAPIKey(apiKey string) string {
+	if apiKey == "" {
+		return ""
+	}
+
+	for _, u := range c.get() {
+		if u.APIKey == apiKey {
+			return u.Username
+		}
+	}
+
+	return ""
+}
+
+// FindUser returns the database user with the provided username, or nil if
+// there is none. The user configured in the config file is not a database
+// user.
+func (s *Manager) FindUser(username string) *models.User {
+	return s.users.find(username)
+}
+
+// HasUsers returns true if any users are stored in the database.
+func (s *Manager) HasUsers() bool {
+	return len(s.users.get()) > 0
+}
+
+// RefreshUsers reloads the user accounts from the database.
+// Call this when the users change.
+func (s *Manager) RefreshUsers() {
+	s.users.invalidate()
+}
+
+// HashUserPassword returns the hash of the provided password.
+func HashUserPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// ValidateUser returns an error if the user is invalid.
+func (s *Manager) ValidateUser(u *models.User) error {
+	if !s.Config.HasCredentials() {
+		return ErrUsersRequireCredentials
+	}
+
+	if strings.TrimSpace(u.Username) == "" {
+		return errors.New("username must not be blank")
+	}
+
+	if u.Username == s.Config.GetUsername() {
+		return fmt.Errorf("username %q is already in use", u.Username)
+	}
+
+	if !u.Role.IsValid() {
+		return fmt.Errorf("invalid role %q", u.Role)
+	}
+
+	return nil
+}
@@ -0,0 +1,406 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// watchFilter determines which filesystem changes are relevant to the library.
+type watchFilter struct {
+	extensionConfig
+
+	stashPaths        config.StashConfigs
+	generatedPath     string
+	videoExcludeRegex []*regexp.Regexp
+	imageExcludeRegex []*regexp.Regexp
+	createImageClips  bool
+}
+
+func newWatchFilter(c *config.Config) *watchFilter {
+	return &watchFilter{
+		extensionConfig:   newExtensionConfig(c),
+		stashPaths:        c.GetStashPaths(),
+		generatedPath:     c.GetGeneratedPath(),
+		videoExcludeRegex: generateRegexps(c.GetExcludes()),
+		imageExcludeRegex: generateRegexps(c.GetImageExcludes()),
+		createImageClips:  c.IsCreateImageClipsFromVideos(),
+	}
+}
+
+func (f *watchFilter) stash(path string) *config.StashConfig {
+	if f.generatedPath != "" && fsutil.IsPathInDir(f.generatedPath, path) {
+		return nil
+	}
+
+	return f.stashPaths.GetStashFromDirPath(path)
+}
+
+// acceptDir returns true if the directory should be watched and scanned.
+func (f *watchFilter) acceptDir(path string) bool {
+	s := f.stash(path)
+	if s == nil {
+		return false
+	}
+
+	// skip the directory if it matches both exclusion patterns
+	// add a trailing separator so that it correctly matches against patterns like path/.*
+	pathExcludeTest := path + string(filepath.Separator)
+	excludeVideo := s.ExcludeVideo || matchFileRegex(pathExcludeTest, f.videoExcludeRegex)
+	excludeImage := s.ExcludeImage || matchFileRegex(pathExcludeTest, f.imageExcludeRegex)
+	return !excludeVideo || !excludeImage
+}
+
+// acceptFile returns true if the file is a library file that is not excluded.
+func (f *watchFilter) acceptFile(path string) bool {
+	s := f.stash(path)
+	if s == nil {
+		return false
+	}
+
+	isVideoFile := fsutil.MatchExtension(path, f.vidExt)
+	isAudioFile := fsutil.MatchExtension(path, f.audExt)
+	isImageFile := fsutil.MatchExtension(path, f.imgExt)
+	isZipFile := fsutil.MatchExtension(path, f.zipExt)
+
+	// video files are treated as images when video is excluded
+	if f.createImageClips && s.ExcludeVideo && isVideoFile {
+		isVideoFile = false
+		isImageFile = true
+	}
+
+	switch {
+	case isVideoFile || isAudioFile:
+		return !s.ExcludeVideo && !matchFileRegex(path, f.videoExcludeRegex)
+	case isImageFile || isZipFile:
+		return !s.ExcludeImage && !matchFileRegex(path, f.imageExcludeRegex)
+	}
+
+	return false
+}
+
+// watcher watches the stash paths for changes. Changes are collected until
+// no further changes have occurred for the debounce period, after which a
+// scan job is queued for the created paths and a clean job is queued for the
+// removed paths.
+type watcher struct {
+	manager  *Manager
+	fsw      *fsnotify.Watcher
+	filter   *watchFilter
+	debounce time.Duration
+
+	mutex   sync.Mutex
+	dirs    map[string]bool
+	created map[string]bool
+	removed map[string]bool
+	timer   *time.Timer
+
+	done chan struct{}
+}
+
+func newWatcher(m *Manager) (*watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return &watcher{
+		manager:  m,
+		fsw:      fsw,
+		filter:   newWatchFilter(m.Config),
+		debounce: time.Duration(m.Config.GetWatchDebounce()) * time.Second,
+		dirs:     make(map[string]bool),
+		created:  make(map[string]bool),
+		removed:  make(map[string]bool),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+func (w *watcher) start() {
+	go func() {
+		for _, s := range w.filter.stashPaths {
+			w.addDir(s.Path)
+		}
+
+		w.mutex.Lock()
+		logger.Infof("Watching %d directories for changes", len(w.dirs))
+		w.mutex.Unlock()
+
+		w.run()
+	}()
+}
+
+func (w *watcher) stopped() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *watcher) stop() {
+	close(w.done)
+
+	if err := w.fsw.Close(); err != nil {
+		logger.Warnf("error closing filesystem watcher: %v", err)
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+}
+
+// addDir adds the directory and all of its accepted subdirectories to the
+// watcher.
+func (w *watcher) addDir(path string) {
+	walkErr := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		if w.stopped() {
+			return fs.SkipAll
+		}
+
+		if err != nil {
+			logger.Warnf("error walking %s: %v", path, err)
+			return nil
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if !w.filter.acceptDir(path) {
+			return fs.SkipDir
+		}
+
+		if err := w.fsw.Add(path); err != nil {
+			logger.Warnf("error watching %s: %v", path, err)
+			return nil
+		}
+
+		w.mutex.Lock()
+		w.dirs[path] = true
+		w.mutex.Unlock()
+
+		return nil
+	})
+
+	if walkErr != nil {
+		logger.Warnf("error watching %s: %v", path, walkErr)
+	}
+}
+
+// removeDir removes the directory and all of its subdirectories from the
+// list of watched directories.
+func (w *watcher) removeDir(path string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for dir := range w.dirs {
+		if fsutil.IsPathInDir(path, dir) {
+			// the watch is removed automatically when the directory is removed
+			_ = w.fsw.Remove(dir)
+			delete(w.dirs, dir)
+		}
+	}
+}
+
+func (w *watcher) isDir(path string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.dirs[path]
+}
+
+func (w *watcher) run() {
+	for {
+		select {
+		case <-w.done:
+			return
+		case event, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(event)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			logger.Warnf("filesystem watcher error: %v", err)
+		}
+	}
+}
+
+func (w *watcher) handleEvent(event fsnotify.Event) {
+	path := event.Name
+
+	switch {
+	case event.Has(fsnotify.Create) || event.Has(fsnotify.Write):
+		info, err := os.Stat(path)
+		if err != nil {
+			// file no longer exists
+			return
+		}
+
+		if info.IsDir() {
+			if !w.filter.acceptDir(path) {
+				return
+			}
+
+			if event.Has(fsnotify.Create) {
+				w.addDir(path)
+			}
+		} else if !w.filter.acceptFile(path) {
+			return
+		}
+
+		w.addChange(path, false)
+
+	case event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename):
+		// the old name of a renamed path is removed, the new name is created
+		if w.isDir(path) {
+			w.removeDir(path)
+		} else if !w.filter.acceptFile(path) {
+			return
+		}
+
+		w.addChange(path, true)
+	}
+}
+
+// addChange records the changed path and resets the debounce timer.
+func (w *watcher) addChange(path string, removed bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.stopped() {
+		return
+	}
+
+	if removed {
+		w.removed[path] = true
+	} else {
+		w.created[path] = true
+	}
+
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.debounce, w.flush)
+}
+
+// flush queues jobs for the changes collected since the last flush.
+func (w *watcher) flush() {
+	w.mutex.Lock()
+	created := w.created
+	removed := w.removed
+	w.created = make(map[string]bool)
+	w.removed = make(map[string]bool)
+	w.timer = nil
+	w.mutex.Unlock()
+
+	var scanPaths []string
+	for path := range created {
+		// ignore paths that were created and then removed
+		if _, err := os.Stat(path); err == nil {
+			scanPaths = append(scanPaths, path)
+		}
+	}
+
+	var cleanPaths []string
+	for path := range removed {
+		// ignore paths that were removed and then recreated
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			cleanPaths = append(cleanPaths, path)
+		}
+	}
+
+	scanPaths = reducePaths(scanPaths)
+	cleanPaths = reducePaths(cleanPaths)
+
+	// run behind user-triggered jobs
+	ctx := job.WithPriority(context.Background(), job.PriorityLow)
+
+	// scan before cleaning so that moved files are detected by the scan
+	// before the old paths are cleaned
+	if len(scanPaths) > 0 {
+		logger.Infof("Detected changes in %d path(s). Queuing scan.", len(scanPaths))
+
+		input := ScanMetadataInput{
+			Paths: scanPaths,
+		}
+		if opts := w.manager.Config.GetDefaultScanSettings(); opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+
+		if _, err := w.manager.Scan(ctx, input); err != nil {
+			logger.Errorf("error queuing scan of changed paths: %v", err)
+		}
+	}
+
+	if len(cleanPaths) > 0 {
+		logger.Infof("Detected %d removed path(s). Queuing clean.", len(cleanPaths))
+
+		w.manager.Clean(ctx, CleanMetadataInput{
+			Paths: cleanPaths,
+		})
+	}
+}
+
+// reducePaths returns the paths sorted, with any paths within another path
+// in the list removed.
+func reducePaths(paths []string) []string {
+	sorted := make([]string, len(paths))
+	copy(sorted, paths)
+	sort.Strings(sorted)
+
+	var ret []string
+	for _, p := range sorted {
+		if !fsutil.IsPathInDirs(ret, p) {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
+
+// RefreshWatcher starts, stops or restarts the filesystem watcher as needed.
+// Call this when the stash paths, exclusions or watcher settings change.
+func (s *Manager) RefreshWatcher() {
+	s.watcherMutex.Lock()
+	defer s.watcherMutex.Unlock()
+
+	if s.watcher != nil {
+		s.watcher.stop()
+		s.watcher = nil
+	}
+
+	if !s.Config.IsWatchStashes() {
+		return
+	}
+
+	w, err := newWatcher(s)
+	if err != nil {
+		logger.Errorf("error creating filesystem watcher: %v", err)
+		return
+	}
+
+	w.start()
+	s.watcher = w
+}
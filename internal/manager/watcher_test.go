@@ -0,0 +1,85 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReducePaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{"empty", nil, nil},
+		{"single", []string{"/stash/a.mp4"}, []string{"/stash/a.mp4"}},
+		{"sorted", []string{"/stash/b.mp4", "/stash/a.mp4"}, []string{"/stash/a.mp4", "/stash/b.mp4"}},
+		{"child of dir", []string{"/stash/dir/a.mp4", "/stash/dir"}, []string{"/stash/dir"}},
+		{"similar prefix", []string{"/stash/dir", "/stash/dir b", "/stash/dir/a.mp4"}, []string{"/stash/dir", "/stash/dir b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, reducePaths(tt.paths))
+		})
+	}
+}
+
+func TestWatchFilter(t *testing.T) {
+	f := &watchFilter{
+		extensionConfig: extensionConfig{
+			vidExt: []string{"mp4"},
+			audExt: []string{"mp3"},
+			imgExt: []string{"jpg"},
+			zipExt: []string{"zip"},
+		},
+		stashPaths: config.StashConfigs{
+			{Path: "/stash/all"},
+			{Path: "/stash/videos", ExcludeImage: true},
+			{Path: "/stash/images", ExcludeVideo: true},
+		},
+		generatedPath:     "/stash/all/generated",
+		videoExcludeRegex: generateRegexps([]string{`sample\.mp4$`, `/skip/`}),
+		imageExcludeRegex: generateRegexps([]string{`/skip/`}),
+	}
+
+	fileTests := []struct {
+		path string
+		want bool
+	}{
+		{"/stash/all/a.mp4", true},
+		{"/stash/all/a.jpg", true},
+		{"/stash/all/a.zip", true},
+		{"/stash/all/a.mp3", true},
+		{"/stash/all/a.txt", false},
+		{"/stash/all/a sample.mp4", false},
+		{"/stash/all/generated/a.jpg", false},
+		{"/other/a.mp4", false},
+		{"/stash/videos/a.mp4", true},
+		{"/stash/videos/a.jpg", false},
+		{"/stash/images/a.mp4", false},
+		{"/stash/images/a.mp3", false},
+		{"/stash/images/a.zip", true},
+	}
+
+	for _, tt := range fileTests {
+		assert.Equal(t, tt.want, f.acceptFile(tt.path), "acceptFile(%q)", tt.path)
+	}
+
+	dirTests := []struct {
+		path string
+		want bool
+	}{
+		{"/stash/all/dir", true},
+		{"/stash/all/skip", false},
+		{"/stash/all/generated", false},
+		{"/stash/videos/skip", false},
+		{"/other", false},
+	}
+
+	for _, tt := range dirTests {
+		assert.Equal(t, tt.want, f.acceptDir(tt.path), "acceptDir(%q)", tt.path)
+	}
+}
@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/webhook"
+)
+
+// webhookDeliveryRetention is the number of days that webhook deliveries are
+// kept in the delivery log.
+const webhookDeliveryRetention = 30
+
+// ValidateWebhook returns an error if the webhook is invalid.
+func ValidateWebhook(w *webhook.Webhook) error {
+	if w.Name == "" {
+		return errors.New("name must not be blank")
+	}
+
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL %q: %w", w.URL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid URL %q: must be an absolute http or https URL", w.URL)
+	}
+
+	for _, t := range w.Triggers {
+		if !t.IsValid() {
+			return fmt.Errorf("invalid trigger %q", t)
+		}
+		if t.IsPre() {
+			return fmt.Errorf("pre hook trigger %q is not supported", t)
+		}
+	}
+
+	return nil
+}
+
+// RefreshWebhooks reloads the webhooks from the configuration.
+// Call this when the webhooks change.
+func (s *Manager) RefreshWebhooks() {
+	s.Webhooks.SetWebhooks(s.Config.GetWebhooks())
+}
+
+// recordWebhookDelivery stores a webhook delivery attempt in the delivery
+// log, removing any entries older than the retention period.
+func (s *Manager) recordWebhookDelivery(ctx context.Context, d *models.WebhookDelivery) {
+	if err := s.Database.Ready(); err != nil {
+		// nothing to record to
+		return
+	}
+
+	r := s.Repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.WebhookDelivery
+		if err := qb.Create(ctx, d); err != nil {
+			return err
+		}
+
+		return qb.DestroyBefore(ctx, time.Now().AddDate(0, 0, -webhookDeliveryRetention))
+	}); err != nil {
+		logger.Errorf("Error recording delivery for webhook %q: %v", d.WebhookID, err)
+	}
+}
@@ -3,6 +3,7 @@ package ffmpeg
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // only support H264 by default, since Safari does not support VP8/VP9
@@ -21,6 +22,9 @@ var validForHevc = []Container{Mp4}
 var validAudioForMkv = []ProbeAudioCodec{Aac, Mp3, Vorbis, Opus}
 var validAudioForWebm = []ProbeAudioCodec{Vorbis, Opus}
 var validAudioForMp4 = []ProbeAudioCodec{Aac, Mp3, Opus}
+var validAudioForMp3 = []ProbeAudioCodec{Mp3}
+var validAudioForFlac = []ProbeAudioCodec{Flac}
+var validAudioForOgg = []ProbeAudioCodec{Vorbis, Opus, Flac}
 
 var (
 	// ErrUnsupportedVideoCodecForBrowser is returned when the video codec is not supported for browser streaming.
@@ -91,6 +95,29 @@ func IsValidAudioForContainer(audio ProbeAudioCodec, format Container) bool {
 	return false
 }
 
+// IsStreamableAudio returns true if an audio-only file with the given codec
+// and container can be played directly by the browser.
+func IsStreamableAudio(audio ProbeAudioCodec, format Container) bool {
+	if audio == MissingUnsupported {
+		return false
+	}
+
+	switch format {
+	case Mp4, Webm, Matroska:
+		return IsValidAudioForContainer(audio, format)
+	case Mp3Container:
+		return isValidAudio(audio, validAudioForMp3)
+	case FlacContainer:
+		return isValidAudio(audio, validAudioForFlac)
+	case Ogg:
+		return isValidAudio(audio, validAudioForOgg)
+	case Wav:
+		// browsers support uncompressed PCM in wav files
+		return strings.HasPrefix(string(audio), "pcm_")
+	}
+	return false
+}
+
 // isValidCombo checks if a codec/container combination is valid.
 // Returns true on validity, false otherwise
 func isValidCombo(codecName string, format Container, supportedVideoCodecs []string) bool {
@@ -0,0 +1,31 @@
+package ffmpeg
+
+import "testing"
+
+func TestIsStreamableAudio(t *testing.T) {
+	tests := []struct {
+		name      string
+		audio     ProbeAudioCodec
+		container Container
+		want      bool
+	}{
+		{"mp3", Mp3, Mp3Container, true},
+		{"aac in m4a", Aac, Mp4, true},
+		{"flac", Flac, FlacContainer, true},
+		{"vorbis in ogg", Vorbis, Ogg, true},
+		{"opus in ogg", Opus, Ogg, true},
+		{"pcm in wav", ProbeAudioCodec("pcm_s16le"), Wav, true},
+		{"adpcm in wav", ProbeAudioCodec("adpcm_ms"), Wav, false},
+		{"wma", ProbeAudioCodec("wmav2"), Wmv, false},
+		{"missing codec", MissingUnsupported, Mp3Container, false},
+		{"mismatched container", Aac, Mp3Container, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsStreamableAudio(tt.audio, tt.container); got != tt.want {
+				t.Errorf("IsStreamableAudio(%q, %q) = %v, want %v", tt.audio, tt.container, got, tt.want)
+			}
+		})
+	}
+}
@@ -40,7 +40,8 @@ func (c AudioCodec) Args() []string {
 }
 
 var (
-	AudioCodecAAC     AudioCodec = "aac"
-	AudioCodecLibOpus AudioCodec = "libopus"
-	AudioCodecCopy    AudioCodec = "copy"
+	AudioCodecAAC        AudioCodec = "aac"
+	AudioCodecLibOpus    AudioCodec = "libopus"
+	AudioCodecLibMP3Lame AudioCodec = "libmp3lame"
+	AudioCodecCopy       AudioCodec = "copy"
 )
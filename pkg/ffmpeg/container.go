@@ -14,10 +14,17 @@ const (
 	Flv      Container = "flv"
 	Mpegts   Container = "mpegts"
 
+	// audio-only containers
+	Mp3Container  Container = "mp3"
+	FlacContainer Container = "flac"
+	Ogg           Container = "ogg"
+	Wav           Container = "wav"
+
 	Aac                ProbeAudioCodec = "aac"
 	Mp3                ProbeAudioCodec = "mp3"
 	Opus               ProbeAudioCodec = "opus"
 	Vorbis             ProbeAudioCodec = "vorbis"
+	Flac               ProbeAudioCodec = "flac"
 	MissingUnsupported ProbeAudioCodec = ""
 
 	Mp4Ffmpeg      string = "mov,mp4,m4a,3gp,3g2,mj2" // browsers support all of them
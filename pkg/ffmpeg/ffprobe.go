@@ -108,7 +108,8 @@ type VideoFile struct {
 	Rotation     int64
 	FrameCount   int64
 
-	AudioCodec string
+	AudioCodec    string
+	AudioChannels int
 }
 
 // TranscodeScale calculates the dimension scaling for a transcode, where maxSize is the maximum size of the longest dimension of the input video.
@@ -216,6 +217,7 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 	audioStream := result.getAudioStream()
 	if audioStream != nil {
 		result.AudioCodec = audioStream.CodecName
+		result.AudioChannels = audioStream.Channels
 		result.AudioStream = audioStream
 	}
 
@@ -271,6 +273,9 @@ func (v *VideoFile) getAudioStream() *FFProbeStream {
 	return nil
 }
 
+// getVideoStream returns the video stream of the file, ignoring attached
+// pictures such as the cover art of audio files. Returns nil if the file has
+// no video stream.
 func (v *VideoFile) getVideoStream() *FFProbeStream {
 	index := v.getStreamIndex("video", v.JSON)
 	if index != -1 {
@@ -0,0 +1,119 @@
+package ffmpeg
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// mp3WithCoverArt is the ffprobe output of an mp3 file with embedded cover art.
+const mp3WithCoverArt = `{
+	"streams": [
+		{
+			"index": 0,
+			"codec_name": "mp3",
+			"codec_type": "audio",
+			"channels": 2,
+			"duration": "183.405714",
+			"disposition": {"default": 0, "attached_pic": 0}
+		},
+		{
+			"index": 1,
+			"codec_name": "mjpeg",
+			"codec_type": "video",
+			"width": 500,
+			"height": 500,
+			"avg_frame_rate": "0/0",
+			"disposition": {"default": 0, "attached_pic": 1}
+		}
+	],
+	"format": {
+		"format_name": "mp3",
+		"duration": "183.405714",
+		"bit_rate": "320000"
+	}
+}`
+
+// mp4WithCoverArt is the ffprobe output of an mp4 file where the cover art is
+// the default video stream.
+const mp4WithCoverArt = `{
+	"streams": [
+		{
+			"index": 0,
+			"codec_name": "png",
+			"codec_type": "video",
+			"width": 320,
+			"height": 240,
+			"disposition": {"default": 1, "attached_pic": 1}
+		},
+		{
+			"index": 1,
+			"codec_name": "h264",
+			"codec_type": "video",
+			"width": 1920,
+			"height": 1080,
+			"avg_frame_rate": "30/1",
+			"disposition": {"default": 0, "attached_pic": 0}
+		},
+		{
+			"index": 2,
+			"codec_name": "aac",
+			"codec_type": "audio",
+			"channels": 2,
+			"disposition": {"default": 1, "attached_pic": 0}
+		}
+	],
+	"format": {
+		"format_name": "mov,mp4,m4a,3gp,3g2,mj2",
+		"duration": "60.000000",
+		"bit_rate": "5000000"
+	}
+}`
+
+func TestParseCoverArt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		json          string
+		videoCodec    string
+		width         int
+		height        int
+		audioCodec    string
+		audioChannels int
+	}{
+		{"mp3 with cover art", mp3WithCoverArt, "", 0, 0, "mp3", 2},
+		{"mp4 with cover art", mp4WithCoverArt, "h264", 1920, 1080, "aac", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probeJSON := &FFProbeJSON{}
+			if err := json.Unmarshal([]byte(tt.json), probeJSON); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := parse(path, probeJSON)
+			if err != nil {
+				t.Fatalf("parse() error = %v", err)
+			}
+
+			if got.VideoCodec != tt.videoCodec {
+				t.Errorf("VideoCodec = %q, want %q", got.VideoCodec, tt.videoCodec)
+			}
+			if got.Width != tt.width || got.Height != tt.height {
+				t.Errorf("size = %dx%d, want %dx%d", got.Width, got.Height, tt.width, tt.height)
+			}
+			if got.AudioCodec != tt.audioCodec {
+				t.Errorf("AudioCodec = %q, want %q", got.AudioCodec, tt.audioCodec)
+			}
+			if got.AudioChannels != tt.audioChannels {
+				t.Errorf("AudioChannels = %d, want %d", got.AudioChannels, tt.audioChannels)
+			}
+		})
+	}
+}
@@ -101,6 +101,11 @@ func (a Args) SkipAudio() Args {
 	return append(a, "-an")
 }
 
+// SkipVideo adds the skip video flag (-vn) and returns the result.
+func (a Args) SkipVideo() Args {
+	return append(a, "-vn")
+}
+
 // VideoCodec adds the given video codec and returns the result.
 func (a Args) VideoCodec(c VideoCodec) Args {
 	return append(a, c.Args()...)
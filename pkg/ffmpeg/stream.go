@@ -18,8 +18,32 @@ const (
 	MimeMkvAudio  string = "audio/x-matroska"
 	MimeMp4Video  string = "video/mp4"
 	MimeMp4Audio  string = "audio/mp4"
+	MimeMp3Audio  string = "audio/mpeg"
+	MimeFlacAudio string = "audio/flac"
+	MimeOggAudio  string = "audio/ogg"
+	MimeWavAudio  string = "audio/wav"
 )
 
+// AudioMimeType returns the mime type to use when directly streaming an
+// audio-only file with the given container.
+func AudioMimeType(container Container) string {
+	switch container {
+	case Mp3Container:
+		return MimeMp3Audio
+	case FlacContainer:
+		return MimeFlacAudio
+	case Ogg:
+		return MimeOggAudio
+	case Wav:
+		return MimeWavAudio
+	case Webm:
+		return MimeWebmAudio
+	case Matroska:
+		return MimeMkvAudio
+	}
+	return MimeMp4Audio
+}
+
 type StreamManager struct {
 	cacheDir string
 	encoder  *FFMpeg
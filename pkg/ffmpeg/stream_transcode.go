@@ -139,6 +139,20 @@ var (
 			return
 		},
 	}
+	// StreamTypeMP3 transcodes the audio stream only. It is used for
+	// audio files that cannot be played directly.
+	StreamTypeMP3 = StreamFormat{
+		MimeType: MimeMp3Audio,
+		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
+			args = args.SkipVideo()
+			args = args.AudioCodec(AudioCodecLibMP3Lame)
+			args = append(args,
+				"-b:a", "192k",
+			)
+			args = args.Format(FormatMP3)
+			return
+		},
+	}
 )
 
 type TranscodeOptions struct {
@@ -176,7 +190,7 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
-	case MimeMkvVideo:
+	case MimeMkvVideo, MimeMp3Audio:
 		codec = VideoCodecCopy
 	}
 
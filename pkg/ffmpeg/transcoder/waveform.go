@@ -0,0 +1,49 @@
+package transcoder
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+)
+
+type WaveformOptions struct {
+	OutputPath string
+	OutputType ScreenshotOutputType
+
+	Width  int
+	Height int
+
+	// Color is the colour of the waveform, as accepted by ffmpeg.
+	Color string
+
+	// Verbosity is the logging verbosity. Defaults to LogLevelError if not set.
+	Verbosity ffmpeg.LogLevel
+}
+
+func (o *WaveformOptions) setDefaults() {
+	if o.Verbosity == "" {
+		o.Verbosity = ffmpeg.LogLevelError
+	}
+	if o.Color == "" {
+		o.Color = "white"
+	}
+}
+
+// Waveform renders the audio stream of the input as a single image.
+func Waveform(input string, options WaveformOptions) ffmpeg.Args {
+	options.setDefaults()
+
+	var args ffmpeg.Args
+	args = args.LogLevel(options.Verbosity)
+	args = args.Overwrite()
+
+	args = args.Input(input)
+
+	args = append(args, "-filter_complex", fmt.Sprintf("showwavespic=s=%dx%d:split_channels=1:colors=%s", options.Width, options.Height, options.Color))
+	args = args.VideoFrames(1)
+
+	args = args.AppendArgs(options.OutputType)
+	args = args.Output(options.OutputPath)
+
+	return args
+}
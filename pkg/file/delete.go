@@ -63,10 +63,15 @@ func newRenamerRemoverImpl() renamerRemoverImpl {
 // be restored to their original state with the Abort method. If the
 // transaction is committed, the marked files are then deleted from the
 // filesystem using the Complete method.
+//
+// Files marked using the TrashFiles method are moved into the trash
+// directory on commit instead of being deleted, if Trash is set.
 type Deleter struct {
 	RenamerRemover RenamerRemover
+	Trash          *Trash
 	files          []string
 	dirs           []string
+	trashed        []*models.TrashedFile
 }
 
 func NewDeleter() *Deleter {
@@ -148,13 +153,21 @@ func (d *Deleter) Rollback() {
 		}
 	}
 
+	for _, f := range d.trashed {
+		if err := d.renameForRestore(f.OriginalPath); err != nil {
+			logger.Warnf("Error restoring %q: %v", f.OriginalPath, err)
+		}
+	}
+
 	d.files = nil
 	d.dirs = nil
+	d.trashed = nil
 }
 
 // Commit deletes all files marked for deletion and clears the marked list.
 // Any errors encountered are logged. All files will be attempted, regardless
-// of the errors encountered.
+// of the errors encountered. The trash records of files that could not be
+// moved into the trash are removed.
 func (d *Deleter) Commit() {
 	for _, f := range d.files {
 		if err := d.RenamerRemover.Remove(f + deleteFileSuffix); err != nil {
@@ -168,8 +181,21 @@ func (d *Deleter) Commit() {
 		}
 	}
 
+	var failed []*models.TrashedFile
+	for _, f := range d.trashed {
+		if err := d.moveToTrash(f); err != nil {
+			logger.Warnf("Error moving %q to trash: %v", f.OriginalPath+deleteFileSuffix, err)
+			failed = append(failed, f)
+		}
+	}
+
+	if len(failed) > 0 {
+		d.destroyTrashRecords(failed)
+	}
+
 	d.files = nil
 	d.dirs = nil
+	d.trashed = nil
 }
 
 func (d *Deleter) renameForDelete(path string) error {
@@ -195,12 +221,54 @@ func Destroy(ctx context.Context, destroyer models.FileDestroyer, f models.File,
 	return nil
 }
 
+// DestroyToTrash destroys the file in the database and marks it to be moved
+// into the trash, recording the entity that it belonged to. Files in zip
+// files are destroyed but not trashed.
+func DestroyToTrash(ctx context.Context, destroyer models.FileDestroyer, f models.File, fileDeleter *Deleter, entity models.TrashedEntity) error {
+	if err := destroyer.Destroy(ctx, f.Base().ID); err != nil {
+		return err
+	}
+
+	if f.Base().ZipFileID == nil {
+		if err := fileDeleter.TrashFiles(ctx, []string{f.Base().Path}, entity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 type ZipDestroyer struct {
 	FileDestroyer   models.FileFinderDestroyer
 	FolderDestroyer models.FolderFinderDestroyer
 }
 
 func (d *ZipDestroyer) DestroyZip(ctx context.Context, f models.File, fileDeleter *Deleter, deleteFile bool) error {
+	if err := d.destroyZip(ctx, f); err != nil {
+		return err
+	}
+
+	if deleteFile {
+		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TrashZip destroys the zip file and its contents in the database and marks
+// the zip file to be moved into the trash, recording the entity that it
+// belonged to.
+func (d *ZipDestroyer) TrashZip(ctx context.Context, f models.File, fileDeleter *Deleter, entity models.TrashedEntity) error {
+	if err := d.destroyZip(ctx, f); err != nil {
+		return err
+	}
+
+	return fileDeleter.TrashFiles(ctx, []string{f.Base().Path}, entity)
+}
+
+func (d *ZipDestroyer) destroyZip(ctx context.Context, f models.File) error {
 	// destroy contained files
 	files, err := d.FileDestroyer.FindByZipFileID(ctx, f.Base().ID)
 	if err != nil {
@@ -225,15 +293,5 @@ func (d *ZipDestroyer) DestroyZip(ctx context.Context, f models.File, fileDelete
 		}
 	}
 
-	if err := d.FileDestroyer.Destroy(ctx, f.Base().ID); err != nil {
-		return err
-	}
-
-	if deleteFile {
-		if err := fileDeleter.Files([]string{f.Base().Path}); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return d.FileDestroyer.Destroy(ctx, f.Base().ID)
 }
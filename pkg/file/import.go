@@ -69,6 +69,7 @@ func (i *Importer) fileJSONToFile(ctx context.Context, fileJSON jsonschema.DirEn
 			Duration:         ff.Duration,
 			VideoCodec:       ff.VideoCodec,
 			AudioCodec:       ff.AudioCodec,
+			AudioChannels:    ff.AudioChannels,
 			FrameRate:        ff.FrameRate,
 			BitRate:          ff.BitRate,
 			Interactive:      ff.Interactive,
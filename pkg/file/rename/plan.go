@@ -0,0 +1,66 @@
+package rename
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Rename is a planned move of a file to a new path.
+type Rename struct {
+	File    models.File
+	NewPath string
+	// Err is the reason that the file cannot be moved. The file is not
+	// moved if set.
+	Err error
+}
+
+// OldPath returns the current path of the file.
+func (r *Rename) OldPath() string {
+	return r.File.Base().Path
+}
+
+// Unchanged returns true if the file is already at the new path.
+func (r *Rename) Unchanged() bool {
+	return r.Err == nil && r.NewPath == r.OldPath()
+}
+
+// DetectCollisions sets Err on renames that would move a file to the same
+// path as another rename, or to the path of an existing file.
+func DetectCollisions(renames []*Rename, statter file.Statter) {
+	targets := make(map[string][]*Rename)
+	for _, r := range renames {
+		if r.Err != nil || r.Unchanged() {
+			continue
+		}
+
+		targets[r.NewPath] = append(targets[r.NewPath], r)
+	}
+
+	for newPath, rr := range targets {
+		if len(rr) > 1 {
+			for _, r := range rr {
+				r.Err = fmt.Errorf("%d files would be moved to %s", len(rr), newPath)
+			}
+			continue
+		}
+
+		r := rr[0]
+
+		// allow changing the case of a file name on case-insensitive
+		// filesystems, where the new path refers to the existing file
+		if strings.EqualFold(newPath, r.OldPath()) {
+			continue
+		}
+
+		if _, err := statter.Stat(newPath); err == nil {
+			r.Err = fmt.Errorf("file %s already exists", newPath)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			r.Err = fmt.Errorf("checking if %s exists: %w", newPath, err)
+		}
+	}
+}
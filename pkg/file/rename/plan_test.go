@@ -0,0 +1,55 @@
+package rename
+
+import (
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type existingFiles map[string]bool
+
+func (e existingFiles) Stat(name string) (fs.FileInfo, error) {
+	if e[name] {
+		return nil, nil
+	}
+
+	return nil, os.ErrNotExist
+}
+
+func newRename(oldPath, newPath string) *Rename {
+	return &Rename{
+		File: &models.BaseFile{
+			Path: oldPath,
+		},
+		NewPath: newPath,
+	}
+}
+
+func TestDetectCollisions(t *testing.T) {
+	var (
+		moved     = newRename("/a.mp4", "/new/a.mp4")
+		unchanged = newRename("/b.mp4", "/b.mp4")
+		caseOnly  = newRename("/c.mp4", "/C.mp4")
+		existing  = newRename("/d.mp4", "/existing.mp4")
+		same1     = newRename("/e.mp4", "/same.mp4")
+		same2     = newRename("/f.mp4", "/same.mp4")
+	)
+
+	DetectCollisions([]*Rename{moved, unchanged, caseOnly, existing, same1, same2}, existingFiles{
+		"/b.mp4":        true,
+		"/C.mp4":        true,
+		"/existing.mp4": true,
+	})
+
+	assert.NoError(t, moved.Err)
+	assert.NoError(t, unchanged.Err)
+	assert.True(t, unchanged.Unchanged())
+	assert.NoError(t, caseOnly.Err)
+	assert.Error(t, existing.Err)
+	assert.Error(t, same1.Err)
+	assert.Error(t, same2.Err)
+}
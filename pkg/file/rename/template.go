@@ -0,0 +1,221 @@
+// Package rename builds file paths from metadata templates, such as
+// "{studio}/{date} {title} [{performers}].{ext}".
+package rename
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Variables are the names of the variables that may be used in a template.
+var Variables = []string{
+	"title",
+	"code",
+	"date",
+	"year",
+	"studio",
+	"performers",
+	"basename",
+	"ext",
+}
+
+// maxNameLength is the maximum length in bytes of a file or folder name on
+// most filesystems.
+const maxNameLength = 255
+
+var (
+	variableRE = regexp.MustCompile(`{([^{}]*)}`)
+
+	// brackets and separators left behind by empty variables
+	emptyBracketsRE = regexp.MustCompile(`\[\s*\]|\(\s*\)`)
+	whitespaceRE    = regexp.MustCompile(`\s+`)
+)
+
+var ErrEmptyPath = errors.New("template produced an empty path")
+
+// Values are the values of the template variables for a file.
+type Values struct {
+	Title string
+	Code  string
+	// Date in YYYY-MM-DD form
+	Date       string
+	Studio     string
+	Performers []string
+	// Basename is the existing name of the file, without the extension
+	Basename string
+	// Ext is the file extension, without the leading dot
+	Ext string
+}
+
+func (v Values) get(name string) string {
+	switch name {
+	case "title":
+		return v.Title
+	case "code":
+		return v.Code
+	case "date":
+		return v.Date
+	case "year":
+		if len(v.Date) >= 4 {
+			return v.Date[:4]
+		}
+		return ""
+	case "studio":
+		return v.Studio
+	case "performers":
+		return strings.Join(v.Performers, ", ")
+	case "basename":
+		return v.Basename
+	case "ext":
+		return v.Ext
+	}
+
+	return ""
+}
+
+// Template is a parsed path template. Folders in the template are separated
+// with forward slashes, and variables are enclosed in braces.
+type Template struct {
+	segments []string
+}
+
+// ParseTemplate parses and validates a path template. The template must be
+// a relative path, and may only contain known variables.
+func ParseTemplate(s string) (*Template, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("template is empty")
+	}
+
+	if strings.HasPrefix(s, "/") || filepath.IsAbs(s) {
+		return nil, fmt.Errorf("template %q must be a relative path", s)
+	}
+
+	segments := strings.Split(s, "/")
+	if segments[len(segments)-1] == "" {
+		return nil, fmt.Errorf("template %q must end with a file name", s)
+	}
+
+	for _, seg := range segments {
+		if seg == ".." {
+			return nil, fmt.Errorf("template %q must not refer to a parent folder", s)
+		}
+
+		literal := variableRE.ReplaceAllString(seg, "")
+		if strings.ContainsAny(literal, "{}") {
+			return nil, fmt.Errorf("template %q has unbalanced braces", s)
+		}
+
+		if sanitize(literal) != literal {
+			return nil, fmt.Errorf("template %q contains invalid path characters", s)
+		}
+
+		for _, m := range variableRE.FindAllStringSubmatch(seg, -1) {
+			if !isVariable(m[1]) {
+				return nil, fmt.Errorf("unknown variable %q in template %q", m[1], s)
+			}
+		}
+	}
+
+	return &Template{segments: segments}, nil
+}
+
+func isVariable(name string) bool {
+	for _, v := range Variables {
+		if v == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Render returns the relative path produced by the template for the provided
+// values. Variable values are sanitized, so that they cannot introduce
+// folders or characters that are invalid in paths. Brackets and separators
+// left empty by missing values are removed, as are empty folders. If the
+// template does not contain the ext variable, then the extension is
+// appended to the file name.
+func (t *Template) Render(v Values) (string, error) {
+	var ret []string
+	for i, seg := range t.segments {
+		last := i == len(t.segments)-1
+		hasExt := false
+
+		s := variableRE.ReplaceAllStringFunc(seg, func(m string) string {
+			name := m[1 : len(m)-1]
+			if name == "ext" {
+				hasExt = true
+			}
+			return sanitize(v.get(name))
+		})
+
+		if !last {
+			s = tidy(s)
+			if s != "" {
+				ret = append(ret, truncate(s, maxNameLength))
+			}
+			continue
+		}
+
+		// tidy the file name separately from the extension
+		ext := ""
+		if v.Ext != "" {
+			ext = "." + v.Ext
+		}
+
+		stem := s
+		if hasExt {
+			stem = strings.TrimSuffix(s, ext)
+		}
+
+		stem = tidy(stem)
+		if stem == "" {
+			return "", ErrEmptyPath
+		}
+
+		ret = append(ret, truncate(stem, maxNameLength-len(ext))+ext)
+	}
+
+	return filepath.Join(ret...), nil
+}
+
+// sanitize replaces or removes characters that are not valid in file names
+// on common filesystems. Path separators and colons are replaced with
+// dashes, while other invalid and control characters are removed.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '/' || r == '\\' || r == ':':
+			return '-'
+		case r < 0x20 || r == 0x7f || strings.ContainsRune(`<>"|?*`, r):
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// tidy removes empty brackets, repeated whitespace and leading or trailing
+// separators. Leading and trailing dots are also removed, since they are
+// not valid in file names on some filesystems.
+func tidy(s string) string {
+	s = emptyBracketsRE.ReplaceAllString(s, "")
+	s = whitespaceRE.ReplaceAllString(s, " ")
+	return strings.Trim(s, " -_.")
+}
+
+// truncate shortens s to at most n bytes, without splitting a character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	return strings.TrimSpace(s[:n])
+}
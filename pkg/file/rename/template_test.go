@@ -0,0 +1,144 @@
+package rename
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		wantErr  bool
+	}{
+		{"{studio}/{date} {title} [{performers}].{ext}", false},
+		{"{title}", false},
+		{"", true},
+		{"/{title}.{ext}", true},
+		{"../{title}.{ext}", true},
+		{"{studio}/", true},
+		{"{title.{ext}", true},
+		{"{title}}.{ext}", true},
+		{"{unknown}.{ext}", true},
+		{"a:b/{title}.{ext}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			_, err := ParseTemplate(tt.template)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTemplate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTemplateRender(t *testing.T) {
+	values := Values{
+		Title:      "Title",
+		Code:       "ABC-123",
+		Date:       "2024-03-01",
+		Studio:     "Studio",
+		Performers: []string{"Performer 1", "Performer 2"},
+		Basename:   "original",
+		Ext:        "mp4",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		values   Values
+		want     string
+		wantErr  bool
+	}{
+		{
+			"all variables",
+			"{studio}/{year}/{date} {title} [{performers}] {code}.{ext}",
+			values,
+			"Studio/2024/2024-03-01 Title [Performer 1, Performer 2] ABC-123.mp4",
+			false,
+		},
+		{
+			"missing values",
+			"{studio}/{date} {title} [{performers}].{ext}",
+			Values{Title: "Title", Ext: "mp4"},
+			"Title.mp4",
+			false,
+		},
+		{
+			"separator left by missing value",
+			"{studio} - {title}.{ext}",
+			Values{Title: "Title", Ext: "mp4"},
+			"Title.mp4",
+			false,
+		},
+		{
+			"extension appended",
+			"{title}",
+			values,
+			"Title.mp4",
+			false,
+		},
+		{
+			"invalid characters",
+			"{studio}/{title}.{ext}",
+			Values{Studio: "AC/DC", Title: `What? "Why": <Me>|*`, Ext: "mp4"},
+			"AC-DC/What Why- Me.mp4",
+			false,
+		},
+		{
+			"leading and trailing dots",
+			"{title}.{ext}",
+			Values{Title: "..hidden title.", Ext: "mp4"},
+			"hidden title.mp4",
+			false,
+		},
+		{
+			"empty file name",
+			"{studio}/{title}.{ext}",
+			Values{Studio: "Studio", Ext: "mp4"},
+			"",
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := ParseTemplate(tt.template)
+			if err != nil {
+				t.Fatalf("ParseTemplate() error = %v", err)
+			}
+
+			got, err := tmpl.Render(tt.values)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Render() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if want := filepath.FromSlash(tt.want); got != want {
+				t.Errorf("Render() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestTemplateRenderTruncate(t *testing.T) {
+	tmpl, err := ParseTemplate("{title}.{ext}")
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+
+	// multi-byte characters must not be split
+	got, err := tmpl.Render(Values{Title: strings.Repeat("é", 200), Ext: "mp4"})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+
+	if len(got) > maxNameLength {
+		t.Errorf("Render() length = %d, want <= %d", len(got), maxNameLength)
+	}
+
+	if want := strings.Repeat("é", 125) + ".mp4"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -99,6 +99,20 @@ type ProgressReporter interface {
 	ExecuteTask(description string, fn func())
 }
 
+// Summary counters reported during a scan, if the ProgressReporter also
+// implements CounterReporter.
+const (
+	ScanCounterScanned = "files_scanned"
+	ScanCounterAdded   = "files_added"
+	ScanCounterUpdated = "files_updated"
+	ScanCounterErrors  = "errors"
+)
+
+// CounterReporter is used to report summary counters of the scan.
+type CounterReporter interface {
+	AddCounter(name string, n int)
+}
+
 type scanJob struct {
 	*Scanner
 
@@ -420,6 +434,12 @@ func (s *scanJob) incrementProgress(f scanFile) {
 	}
 }
 
+func (s *scanJob) addCounter(name string) {
+	if r, ok := s.ProgressReports.(CounterReporter); ok {
+		r.AddCounter(name, 1)
+	}
+}
+
 func (s *scanJob) processQueueItem(ctx context.Context, f scanFile) {
 	s.ProgressReports.ExecuteTask("Scanning "+f.Path, func() {
 		var err error
@@ -658,15 +678,26 @@ func (s *scanJob) handleFile(ctx context.Context, f scanFile) error {
 		if ff == nil {
 			// returns a file only if it is actually new
 			ff, err = s.onNewFile(ctx, f)
+			if ff != nil {
+				s.addCounter(ScanCounterAdded)
+			}
 			return err
 		}
 
 		ff, err = s.onExistingFile(ctx, f, ff)
+		if ff != nil {
+			s.addCounter(ScanCounterUpdated)
+		}
 		return err
 	}); err != nil {
+		if !errors.Is(err, context.Canceled) {
+			s.addCounter(ScanCounterErrors)
+		}
 		return err
 	}
 
+	s.addCounter(ScanCounterScanned)
+
 	if ff != nil && s.isZipFile(f.info.Name()) {
 		f.BaseFile = ff.Base()
 
@@ -0,0 +1,187 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// Trash is the destination for files deleted using Deleter.TrashFiles.
+// Each trashed file is moved into its own subdirectory of Path, so that
+// files with the same name do not collide.
+type Trash struct {
+	// Path is the trash directory.
+	Path string
+	// TxnManager is used to remove the records of files that could not be
+	// moved into the trash.
+	TxnManager txn.Manager
+	// Repository is used to record the trashed files.
+	Repository models.TrashedFileWriter
+}
+
+// TrashFiles designates files to be moved into the trash. The files are
+// renamed as per Files, and a record of each file and the provided entity
+// is created in the trash repository. The files are moved into the trash
+// directory when the Deleter is committed. If Trash is not set, then the
+// files are marked for deletion as per Files.
+func (d *Deleter) TrashFiles(ctx context.Context, paths []string, entity models.TrashedEntity) error {
+	if d.Trash == nil {
+		return d.Files(paths)
+	}
+
+	metadata, err := json.Marshal(entity.Metadata)
+	if err != nil {
+		return fmt.Errorf("encoding metadata for %s %d: %w", entity.Type, entity.ID, err)
+	}
+
+	for _, p := range paths {
+		// fail silently if the file does not exist
+		info, err := d.RenamerRemover.Stat(p)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				logger.Warnf("File %q does not exist and therefore cannot be deleted. Ignoring.", p)
+				continue
+			}
+
+			return fmt.Errorf("check file %q exists: %w", p, err)
+		}
+
+		dir, err := uuid.NewV4()
+		if err != nil {
+			return fmt.Errorf("generating trash directory name: %w", err)
+		}
+
+		f := &models.TrashedFile{
+			OriginalPath:   p,
+			TrashPath:      filepath.Join(d.Trash.Path, dir.String(), filepath.Base(p)),
+			Size:           info.Size(),
+			EntityType:     entity.Type,
+			EntityID:       entity.ID,
+			EntityTitle:    entity.Title,
+			EntityMetadata: string(metadata),
+			TrashedAt:      time.Now(),
+		}
+
+		if err := d.renameForDelete(p); err != nil {
+			return fmt.Errorf("marking file %q for deletion: %w", p, err)
+		}
+		d.trashed = append(d.trashed, f)
+
+		if err := d.Trash.Repository.Create(ctx, f); err != nil {
+			return fmt.Errorf("recording trashed file %q: %w", p, err)
+		}
+	}
+
+	return nil
+}
+
+// moveToTrash moves a file marked for deletion into the trash directory. If
+// the file cannot be moved, it is returned to its original path.
+func (d *Deleter) moveToTrash(f *models.TrashedFile) error {
+	err := d.moveFileToTrash(f)
+	if err == nil {
+		return nil
+	}
+
+	if restoreErr := d.renameForRestore(f.OriginalPath); restoreErr != nil {
+		return errors.Join(err, fmt.Errorf("restoring %q: %w", f.OriginalPath, restoreErr))
+	}
+
+	return fmt.Errorf("%w; file was restored to %q", err, f.OriginalPath)
+}
+
+// destroyTrashRecords removes the records of files that could not be moved
+// into the trash, so that no record refers to a missing trash file. Any
+// errors encountered are logged.
+func (d *Deleter) destroyTrashRecords(files []*models.TrashedFile) {
+	// the transaction that created the records has already been committed
+	ctx := context.Background()
+	if err := txn.WithTxn(ctx, d.Trash.TxnManager, func(ctx context.Context) error {
+		for _, f := range files {
+			if err := d.Trash.Repository.Destroy(ctx, f.ID); err != nil {
+				return fmt.Errorf("removing trash record for %q: %w", f.OriginalPath, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		logger.Warnf("Error removing trash records: %v", err)
+	}
+}
+
+func (d *Deleter) moveFileToTrash(f *models.TrashedFile) error {
+	src := f.OriginalPath + deleteFileSuffix
+
+	if err := fsutil.EnsureDirAll(filepath.Dir(f.TrashPath)); err != nil {
+		return err
+	}
+
+	err := d.RenamerRemover.Rename(src, f.TrashPath)
+	if err == nil {
+		return nil
+	}
+
+	// the trash directory may be on a different device
+	logger.Debugf("Renaming %q to %q failed, copying instead: %v", src, f.TrashPath, err)
+	if err := fsutil.SafeMove(src, f.TrashPath); err != nil {
+		// remove any partial copy
+		if removeErr := os.Remove(f.TrashPath); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+			logger.Warnf("Error removing %q: %v", f.TrashPath, removeErr)
+		}
+		removeTrashDir(f)
+		return err
+	}
+
+	return nil
+}
+
+// RestoreTrashedFile moves a trashed file back to its original path,
+// recreating the original directory if necessary. An error is returned if
+// a file already exists at the original path.
+func RestoreTrashedFile(f *models.TrashedFile) error {
+	if _, err := os.Stat(f.OriginalPath); err == nil {
+		return fmt.Errorf("%q already exists", f.OriginalPath)
+	}
+
+	if err := fsutil.EnsureDirAll(filepath.Dir(f.OriginalPath)); err != nil {
+		return fmt.Errorf("creating directory for %q: %w", f.OriginalPath, err)
+	}
+
+	if err := fsutil.SafeMove(f.TrashPath, f.OriginalPath); err != nil {
+		return fmt.Errorf("moving %q to %q: %w", f.TrashPath, f.OriginalPath, err)
+	}
+
+	removeTrashDir(f)
+	return nil
+}
+
+// RemoveTrashedFile permanently deletes a trashed file from the trash
+// directory. It is not an error if the file no longer exists.
+func RemoveTrashedFile(f *models.TrashedFile) error {
+	if err := os.Remove(f.TrashPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("deleting %q: %w", f.TrashPath, err)
+	}
+
+	removeTrashDir(f)
+	return nil
+}
+
+// removeTrashDir removes the directory containing the trashed file, if it
+// is empty.
+func removeTrashDir(f *models.TrashedFile) {
+	dir := filepath.Dir(f.TrashPath)
+	if err := os.Remove(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		logger.Warnf("Error removing trash directory %q: %v", dir, err)
+	}
+}
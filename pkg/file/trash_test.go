@@ -0,0 +1,172 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestDeleterTrashFiles(t *testing.T) {
+	dir := t.TempDir()
+	trashDir := filepath.Join(dir, "trash")
+
+	const contents = "contents"
+	path := filepath.Join(dir, "stash", "scene.mp4")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	entity := models.TrashedEntity{
+		Type:     models.TrashedEntityTypeScene,
+		ID:       1,
+		Title:    "title",
+		Metadata: map[string]string{"title": "title"},
+	}
+
+	const trashedFileID = 1
+
+	newDeleter := func() (*Deleter, *mocks.TrashedFileReaderWriter) {
+		db := mocks.NewDatabase()
+		rw := db.TrashedFile
+		rw.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*models.TrashedFile).ID = trashedFileID
+		}).Return(nil)
+
+		d := NewDeleter()
+		d.Trash = &Trash{
+			Path:       trashDir,
+			TxnManager: db,
+			Repository: rw,
+		}
+		return d, rw
+	}
+
+	ctx := context.Background()
+
+	t.Run("rollback", func(t *testing.T) {
+		d, _ := newDeleter()
+		if err := d.TrashFiles(ctx, []string{path}, entity); err != nil {
+			t.Fatalf("TrashFiles() error = %v", err)
+		}
+
+		assert.NoFileExists(t, path)
+
+		d.Rollback()
+
+		assert.FileExists(t, path)
+		assert.NoDirExists(t, trashDir)
+	})
+
+	t.Run("commit and restore", func(t *testing.T) {
+		d, rw := newDeleter()
+		if err := d.TrashFiles(ctx, []string{path, filepath.Join(dir, "missing.mp4")}, entity); err != nil {
+			t.Fatalf("TrashFiles() error = %v", err)
+		}
+
+		d.Commit()
+
+		rw.AssertNumberOfCalls(t, "Create", 1)
+		f := rw.Calls[0].Arguments.Get(1).(*models.TrashedFile)
+
+		assert.Equal(t, path, f.OriginalPath)
+		assert.Equal(t, trashDir, filepath.Dir(filepath.Dir(f.TrashPath)))
+		assert.Equal(t, "scene.mp4", filepath.Base(f.TrashPath))
+		assert.Equal(t, int64(len(contents)), f.Size)
+		assert.Equal(t, models.TrashedEntityTypeScene, f.EntityType)
+		assert.Equal(t, `{"title":"title"}`, f.EntityMetadata)
+
+		assert.NoFileExists(t, path)
+		assert.FileExists(t, f.TrashPath)
+
+		// remove the original directory to ensure that it is recreated
+		if err := os.Remove(filepath.Dir(path)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := RestoreTrashedFile(f); err != nil {
+			t.Fatalf("RestoreTrashedFile() error = %v", err)
+		}
+
+		assert.FileExists(t, path)
+		assert.NoDirExists(t, filepath.Dir(f.TrashPath))
+	})
+
+	t.Run("commit across devices", func(t *testing.T) {
+		d, rw := newDeleter()
+		d.RenamerRemover = renamerRemoverImpl{
+			RenameFn: func(oldpath, newpath string) error {
+				// simulate the trash directory being on another device
+				if strings.HasPrefix(newpath, trashDir) {
+					return &os.LinkError{Op: "rename", Old: oldpath, New: newpath, Err: syscall.EXDEV}
+				}
+				return os.Rename(oldpath, newpath)
+			},
+			RemoveFn:    os.Remove,
+			RemoveAllFn: os.RemoveAll,
+			StatFn:      os.Stat,
+		}
+
+		if err := d.TrashFiles(ctx, []string{path}, entity); err != nil {
+			t.Fatalf("TrashFiles() error = %v", err)
+		}
+
+		d.Commit()
+
+		f := rw.Calls[0].Arguments.Get(1).(*models.TrashedFile)
+
+		assert.NoFileExists(t, path)
+		assert.NoFileExists(t, path+deleteFileSuffix)
+		assert.FileExists(t, f.TrashPath)
+
+		if err := RestoreTrashedFile(f); err != nil {
+			t.Fatalf("RestoreTrashedFile() error = %v", err)
+		}
+	})
+
+	t.Run("commit failure", func(t *testing.T) {
+		d, rw := newDeleter()
+		rw.On("Destroy", mock.Anything, trashedFileID).Return(nil)
+
+		// the trash directory cannot be created under a file
+		d.Trash.Path = filepath.Join(dir, "trash-file")
+		if err := os.WriteFile(d.Trash.Path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := d.TrashFiles(ctx, []string{path}, entity); err != nil {
+			t.Fatalf("TrashFiles() error = %v", err)
+		}
+
+		d.Commit()
+
+		// file is returned to its original path
+		assert.FileExists(t, path)
+		assert.NoFileExists(t, path+deleteFileSuffix)
+
+		// the trash record is removed
+		rw.AssertCalled(t, "Destroy", mock.Anything, trashedFileID)
+	})
+
+	t.Run("without trash", func(t *testing.T) {
+		d := NewDeleter()
+		if err := d.TrashFiles(ctx, []string{path}, entity); err != nil {
+			t.Fatalf("TrashFiles() error = %v", err)
+		}
+
+		d.Commit()
+
+		assert.NoFileExists(t, path)
+	})
+}
@@ -10,7 +10,8 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 )
 
-// Decorator adds video specific fields to a File.
+// Decorator adds video specific fields to a File. Audio files are decorated
+// in the same way, and are identified by the absence of a video stream.
 type Decorator struct {
 	FFProbe ffmpeg.FFProbe
 }
@@ -44,16 +45,17 @@ func (d *Decorator) Decorate(ctx context.Context, fs models.FS, f models.File) (
 	}
 
 	return &models.VideoFile{
-		BaseFile:    base,
-		Format:      string(container),
-		VideoCodec:  videoFile.VideoCodec,
-		AudioCodec:  videoFile.AudioCodec,
-		Width:       videoFile.Width,
-		Height:      videoFile.Height,
-		Duration:    videoFile.FileDuration,
-		FrameRate:   videoFile.FrameRate,
-		BitRate:     videoFile.Bitrate,
-		Interactive: interactive,
+		BaseFile:      base,
+		Format:        string(container),
+		VideoCodec:    videoFile.VideoCodec,
+		AudioCodec:    videoFile.AudioCodec,
+		AudioChannels: videoFile.AudioChannels,
+		Width:         videoFile.Width,
+		Height:        videoFile.Height,
+		Duration:      videoFile.FileDuration,
+		FrameRate:     videoFile.FrameRate,
+		BitRate:       videoFile.Bitrate,
+		Interactive:   interactive,
 	}, nil
 }
 
@@ -52,6 +52,25 @@ func (s *Service) destroyZipFileImages(ctx context.Context, i *models.Gallery, f
 
 	var imgsDestroyed []*models.Image
 
+	var entity models.TrashedEntity
+	if deleteFile {
+		if err := i.LoadURLs(ctx, s.Repository); err != nil {
+			return nil, err
+		}
+
+		metadata, err := ToBasicJSON(i)
+		if err != nil {
+			return nil, err
+		}
+
+		entity = models.TrashedEntity{
+			Type:     models.TrashedEntityTypeGallery,
+			ID:       i.ID,
+			Title:    i.GetTitle(),
+			Metadata: metadata,
+		}
+	}
+
 	destroyer := &file.ZipDestroyer{
 		FileDestroyer:   s.File,
 		FolderDestroyer: s.Folder,
@@ -78,7 +97,7 @@ func (s *Service) destroyZipFileImages(ctx context.Context, i *models.Gallery, f
 		imgsDestroyed = append(imgsDestroyed, thisDestroyed...)
 
 		if deleteFile {
-			if err := destroyer.DestroyZip(ctx, f, fileDeleter.Deleter, deleteFile); err != nil {
+			if err := destroyer.TrashZip(ctx, f, fileDeleter.Deleter, entity); err != nil {
 				return nil, err
 			}
 		}
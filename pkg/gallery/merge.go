@@ -0,0 +1,379 @@
+package gallery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+var (
+	ErrMergeDestinationInSource = errors.New("destination gallery cannot be in source list")
+	ErrMergeMultipleFolders     = errors.New("cannot merge galleries from more than one folder")
+)
+
+// Merge merges the source galleries into the destination gallery.
+//
+// Images of the source galleries are added to the destination, excluding
+// images with the same checksum as an image already in the destination.
+// Excluded images that are not in any other gallery are destroyed, without
+// deleting their files or generated files.
+//
+// Chapters, files, performers, tags, scenes and URLs of the source galleries
+// are moved to the destination. If the destination is not folder-based, then
+// it takes the folder of a folder-based source gallery, otherwise it keeps its
+// own folder. Only one folder-based gallery may be included in a merge. If the
+// destination is neither folder-based nor has a primary file, then the first
+// source file is used as the primary file.
+//
+// Chapter image indexes are adjusted to the image ordering of the merged
+// gallery. Chapters of excluded images point at the image with the same
+// checksum. The source galleries are destroyed without deleting their files or
+// images.
+//
+// Values set in the provided partial are applied to the destination after the
+// relationships have been merged.
+func (s *Service) Merge(ctx context.Context, sourceIDs []int, destinationID int, fileDeleter *image.FileDeleter, values models.GalleryPartial) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	// ensure destination is not in source list
+	if sliceutil.Contains(sourceIDs, destinationID) {
+		return ErrMergeDestinationInSource
+	}
+
+	dest, err := s.Repository.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination gallery ID %d: %w", destinationID, err)
+	}
+
+	if dest == nil {
+		return fmt.Errorf("gallery with id %d not found", destinationID)
+	}
+
+	sources, err := s.Repository.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source galleries: %w", err)
+	}
+
+	if err := s.loadMergeRelationships(ctx, dest); err != nil {
+		return err
+	}
+
+	urls := dest.URLs.List()
+	sceneIDs := dest.SceneIDs.List()
+	tagIDs := dest.TagIDs.List()
+	performerIDs := dest.PerformerIDs.List()
+	folderID := dest.FolderID
+
+	var fileIDs []models.FileID
+
+	for _, src := range sources {
+		if err := s.loadMergeRelationships(ctx, src); err != nil {
+			return err
+		}
+
+		urls = sliceutil.AppendUniques(urls, src.URLs.List())
+		sceneIDs = sliceutil.AppendUniques(sceneIDs, src.SceneIDs.List())
+		tagIDs = sliceutil.AppendUniques(tagIDs, src.TagIDs.List())
+		performerIDs = sliceutil.AppendUniques(performerIDs, src.PerformerIDs.List())
+
+		for _, f := range src.Files.List() {
+			fileIDs = append(fileIDs, f.Base().ID)
+		}
+
+		if src.FolderID != nil {
+			if folderID != nil {
+				return ErrMergeMultipleFolders
+			}
+
+			folderID = src.FolderID
+		}
+	}
+
+	merged, err := s.mergeImages(ctx, sources, destinationID, fileDeleter)
+	if err != nil {
+		return err
+	}
+
+	if err := s.mergeChapters(ctx, destinationID, destinationID, merged); err != nil {
+		return err
+	}
+
+	for _, src := range sources {
+		if err := s.mergeChapters(ctx, destinationID, src.ID, merged); err != nil {
+			return err
+		}
+	}
+
+	// move files to destination gallery
+	for _, fileID := range fileIDs {
+		if err := s.Repository.AddFileID(ctx, destinationID, fileID); err != nil {
+			return fmt.Errorf("moving file %d to destination gallery: %w", fileID, err)
+		}
+	}
+
+	// if gallery didn't already have a primary file, then set it now, unless
+	// the gallery is folder-based
+	// don't allow changing primary file ID from the input values
+	values.PrimaryFileID = nil
+	if dest.PrimaryFileID == nil && folderID == nil && len(fileIDs) > 0 {
+		values.PrimaryFileID = &fileIDs[0]
+	}
+
+	values.FolderID = nil
+	if dest.FolderID == nil {
+		values.FolderID = folderID
+	}
+
+	// apply any relationship changes in the values on top of the merged values
+	values.URLs = &models.UpdateStrings{
+		Values: values.URLs.Apply(urls),
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	values.SceneIDs = &models.UpdateIDs{
+		IDs:  values.SceneIDs.Apply(sceneIDs),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	values.TagIDs = &models.UpdateIDs{
+		IDs:  values.TagIDs.Apply(tagIDs),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	values.PerformerIDs = &models.UpdateIDs{
+		IDs:  values.PerformerIDs.Apply(performerIDs),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+
+	// delete old galleries before updating, since the folder can only be
+	// associated with a single gallery
+	for _, src := range sources {
+		const deleteGenerated = false
+		const deleteFile = false
+		if _, err := s.Destroy(ctx, src, fileDeleter, deleteGenerated, deleteFile); err != nil {
+			return fmt.Errorf("deleting gallery %d: %w", src.ID, err)
+		}
+	}
+
+	if _, err := s.Repository.UpdatePartial(ctx, destinationID, values); err != nil {
+		return fmt.Errorf("updating gallery: %w", err)
+	}
+
+	return nil
+}
+
+// mergedImages describes where the images of the merged galleries are in the
+// merged gallery.
+type mergedImages struct {
+	// images of each gallery before the merge, ordered by path
+	galleryImages map[int][]*models.Image
+	// ID of the image that replaces each excluded image
+	replacements map[int]int
+	// 1-based index of each image in the merged gallery
+	indexes map[int]int
+}
+
+// imageIndex returns the index in the merged gallery of the image at the
+// provided 1-based index of the gallery with the provided ID. The second
+// return value is false if the index is out of range, in which case it is
+// clamped to the images of the gallery. The index is returned unchanged if the
+// gallery has no images.
+func (m *mergedImages) imageIndex(galleryID int, index int) (int, bool) {
+	images := m.galleryImages[galleryID]
+	if len(images) == 0 {
+		return index, true
+	}
+
+	inRange := index >= 1 && index <= len(images)
+	index = min(max(index, 1), len(images))
+
+	imageID := images[index-1].ID
+	if id, found := m.replacements[imageID]; found {
+		imageID = id
+	}
+
+	return m.indexes[imageID], inRange
+}
+
+func sortImagesByPath(images []*models.Image) {
+	sort.SliceStable(images, func(i, j int) bool {
+		return images[i].Path < images[j].Path
+	})
+}
+
+// mergeImages moves the images of the source galleries to the destination
+// gallery. Images with the same checksum as an image already in the
+// destination are not moved, and are destroyed if they are not in a gallery
+// other than the source galleries.
+func (s *Service) mergeImages(ctx context.Context, sources []*models.Gallery, destinationID int, fileDeleter *image.FileDeleter) (*mergedImages, error) {
+	destImageIDs, err := s.Repository.GetImageIDs(ctx, destinationID)
+	if err != nil {
+		return nil, fmt.Errorf("getting images of gallery %d: %w", destinationID, err)
+	}
+
+	destImages, err := s.ImageFinder.FindMany(ctx, destImageIDs)
+	if err != nil {
+		return nil, fmt.Errorf("finding images of gallery %d: %w", destinationID, err)
+	}
+
+	ret := &mergedImages{
+		galleryImages: make(map[int][]*models.Image),
+		replacements:  make(map[int]int),
+		indexes:       make(map[int]int),
+	}
+
+	ret.galleryImages[destinationID] = append([]*models.Image(nil), destImages...)
+	sortImagesByPath(ret.galleryImages[destinationID])
+
+	// image ID by checksum
+	checksums := make(map[string]int)
+	for _, img := range destImages {
+		if img.Checksum != "" {
+			checksums[img.Checksum] = img.ID
+		}
+	}
+
+	sourceIDs := make([]int, len(sources))
+	for i, src := range sources {
+		sourceIDs[i] = src.ID
+	}
+
+	// images of the merged gallery
+	images := destImages
+	var imagesToAdd []int
+	var duplicates []*models.Image
+	duplicateIDs := make(map[int]bool)
+
+	for _, src := range sources {
+		srcImageIDs, err := s.Repository.GetImageIDs(ctx, src.ID)
+		if err != nil {
+			return nil, fmt.Errorf("getting images of gallery %d: %w", src.ID, err)
+		}
+
+		srcImages, err := s.ImageFinder.FindMany(ctx, srcImageIDs)
+		if err != nil {
+			return nil, fmt.Errorf("finding images of gallery %d: %w", src.ID, err)
+		}
+
+		ret.galleryImages[src.ID] = append([]*models.Image(nil), srcImages...)
+		sortImagesByPath(ret.galleryImages[src.ID])
+
+		for _, img := range srcImages {
+			if sliceutil.Contains(destImageIDs, img.ID) || sliceutil.Contains(imagesToAdd, img.ID) {
+				continue
+			}
+
+			// skip images with the same content as one already in the gallery
+			if id, found := checksums[img.Checksum]; found && img.Checksum != "" {
+				ret.replacements[img.ID] = id
+				if !duplicateIDs[img.ID] {
+					duplicateIDs[img.ID] = true
+					duplicates = append(duplicates, img)
+				}
+				continue
+			}
+
+			if img.Checksum != "" {
+				checksums[img.Checksum] = img.ID
+			}
+
+			imagesToAdd = append(imagesToAdd, img.ID)
+			images = append(images, img)
+		}
+
+		// detach the images so that they are not destroyed with the source
+		if len(srcImageIDs) > 0 {
+			if err := s.removeImages(ctx, src.ID, srcImageIDs...); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(imagesToAdd) > 0 {
+		if err := s.addImages(ctx, destinationID, imagesToAdd...); err != nil {
+			return nil, err
+		}
+	}
+
+	sortImagesByPath(images)
+	for i, img := range images {
+		ret.indexes[img.ID] = i + 1
+	}
+
+	for _, img := range duplicates {
+		if err := img.LoadGalleryIDs(ctx, s.ImageFinder); err != nil {
+			return nil, fmt.Errorf("loading galleries of image %d: %w", img.ID, err)
+		}
+
+		// keep images that are still in another gallery
+		if len(sliceutil.Exclude(img.GalleryIDs.List(), sourceIDs)) > 0 {
+			continue
+		}
+
+		const deleteGenerated = false
+		const deleteFile = false
+		if err := s.ImageService.Destroy(ctx, img, fileDeleter, deleteGenerated, deleteFile); err != nil {
+			return nil, fmt.Errorf("destroying duplicate image %d: %w", img.ID, err)
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *Service) loadMergeRelationships(ctx context.Context, g *models.Gallery) error {
+	if err := g.LoadURLs(ctx, s.Repository); err != nil {
+		return fmt.Errorf("loading gallery URLs from %d: %w", g.ID, err)
+	}
+
+	if err := g.LoadSceneIDs(ctx, s.Repository); err != nil {
+		return fmt.Errorf("loading gallery scenes from %d: %w", g.ID, err)
+	}
+
+	if err := g.LoadTagIDs(ctx, s.Repository); err != nil {
+		return fmt.Errorf("loading gallery tags from %d: %w", g.ID, err)
+	}
+
+	if err := g.LoadPerformerIDs(ctx, s.Repository); err != nil {
+		return fmt.Errorf("loading gallery performers from %d: %w", g.ID, err)
+	}
+
+	if err := g.LoadFiles(ctx, s.Repository); err != nil {
+		return fmt.Errorf("loading gallery files from %d: %w", g.ID, err)
+	}
+
+	return nil
+}
+
+// mergeChapters moves the chapters of the source gallery to the destination
+// gallery, and adjusts their image indexes to the merged gallery. Chapters with
+// an image index outside of the source gallery are moved to its nearest image.
+func (s *Service) mergeChapters(ctx context.Context, destinationID int, sourceID int, merged *mergedImages) error {
+	chapters, err := s.Chapter.FindByGalleryID(ctx, sourceID)
+	if err != nil {
+		return fmt.Errorf("finding gallery chapters: %w", err)
+	}
+
+	for _, c := range chapters {
+		imageIndex, inRange := merged.imageIndex(sourceID, c.ImageIndex)
+		if !inRange {
+			logger.Warnf("gallery chapter %q of gallery %d has image index %d outside of the gallery, setting to %d", c.Title, sourceID, c.ImageIndex, imageIndex)
+		}
+
+		if c.GalleryID == destinationID && c.ImageIndex == imageIndex {
+			continue
+		}
+
+		c.GalleryID = destinationID
+		c.ImageIndex = imageIndex
+
+		if err := s.Chapter.Update(ctx, c); err != nil {
+			return fmt.Errorf("updating gallery chapter %d: %w", c.ID, err)
+		}
+	}
+
+	return nil
+}
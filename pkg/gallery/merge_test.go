@@ -0,0 +1,298 @@
+package gallery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeImageService records the images destroyed by the gallery service.
+type fakeImageService struct {
+	destroyed []int
+}
+
+func (s *fakeImageService) Destroy(ctx context.Context, i *models.Image, fileDeleter *image.FileDeleter, deleteGenerated, deleteFile bool) error {
+	s.destroyed = append(s.destroyed, i.ID)
+	return nil
+}
+
+func (s *fakeImageService) DestroyZipImages(ctx context.Context, zipFile models.File, fileDeleter *image.FileDeleter, deleteGenerated bool) ([]*models.Image, error) {
+	return nil, nil
+}
+
+func newTestService(db *mocks.Database, imageService ImageService) *Service {
+	return &Service{
+		Repository:   db.Gallery,
+		ImageFinder:  db.Image,
+		ImageService: imageService,
+		File:         db.File,
+		Folder:       db.Folder,
+		Chapter:      db.GalleryChapter,
+	}
+}
+
+// mockMergeRelationships returns no relationships for the provided galleries.
+func mockMergeRelationships(db *mocks.Database, ids ...int) {
+	for _, id := range ids {
+		db.Gallery.On("GetURLs", testCtx, id).Return(nil, nil)
+		db.Gallery.On("GetSceneIDs", testCtx, id).Return(nil, nil)
+		db.Gallery.On("GetPerformerIDs", testCtx, id).Return(nil, nil)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	const (
+		destID = iota + 1
+		srcID1
+		srcID2
+		otherGalleryID
+
+		tagID1
+		tagID2
+	)
+
+	const (
+		imageID1 = iota + 101
+		imageID2
+		imageID3
+		imageID4
+	)
+
+	db := mocks.NewDatabase()
+	imageService := &fakeImageService{}
+	s := newTestService(db, imageService)
+
+	dest := &models.Gallery{ID: destID}
+	src1 := &models.Gallery{ID: srcID1}
+	src2 := &models.Gallery{ID: srcID2}
+
+	// image 1 is in the destination
+	// images 3 and 4 have the same content as image 1
+	// image 2 is in both source galleries
+	// image 4 is also in another gallery
+	img1 := &models.Image{ID: imageID1, Checksum: "a"}
+	img2 := &models.Image{ID: imageID2, Checksum: "b"}
+	img3 := &models.Image{ID: imageID3, Checksum: "a"}
+	img4 := &models.Image{ID: imageID4, Checksum: "a"}
+
+	db.Gallery.On("Find", testCtx, destID).Return(dest, nil)
+	db.Gallery.On("FindMany", testCtx, []int{srcID1, srcID2}).Return([]*models.Gallery{src1, src2}, nil)
+
+	mockMergeRelationships(db, destID, srcID1, srcID2)
+	db.Gallery.On("GetTagIDs", testCtx, destID).Return([]int{tagID1}, nil)
+	db.Gallery.On("GetTagIDs", testCtx, srcID1).Return([]int{tagID2}, nil)
+	db.Gallery.On("GetTagIDs", testCtx, srcID2).Return([]int{tagID1}, nil)
+	db.Gallery.On("GetFiles", testCtx, mock.Anything).Return([]models.File{}, nil)
+
+	db.Gallery.On("GetImageIDs", testCtx, destID).Return([]int{imageID1}, nil)
+	db.Gallery.On("GetImageIDs", testCtx, srcID1).Return([]int{imageID2, imageID3}, nil)
+	db.Gallery.On("GetImageIDs", testCtx, srcID2).Return([]int{imageID4, imageID2}, nil)
+	db.Image.On("FindMany", testCtx, []int{imageID1}).Return([]*models.Image{img1}, nil)
+	db.Image.On("FindMany", testCtx, []int{imageID2, imageID3}).Return([]*models.Image{img2, img3}, nil)
+	db.Image.On("FindMany", testCtx, []int{imageID4, imageID2}).Return([]*models.Image{img4, img2}, nil)
+	db.Image.On("GetGalleryIDs", testCtx, imageID3).Return(nil, nil)
+	db.Image.On("GetGalleryIDs", testCtx, imageID4).Return([]int{otherGalleryID}, nil)
+
+	db.Gallery.On("RemoveImages", testCtx, srcID1, imageID2, imageID3).Return(nil).Once()
+	db.Gallery.On("RemoveImages", testCtx, srcID2, imageID4, imageID2).Return(nil).Once()
+	db.Gallery.On("AddImages", testCtx, destID, imageID2).Return(nil).Once()
+
+	db.GalleryChapter.On("FindByGalleryID", testCtx, mock.Anything).Return(nil, nil)
+
+	db.Gallery.On("Destroy", testCtx, srcID1).Return(nil).Once()
+	db.Gallery.On("Destroy", testCtx, srcID2).Return(nil).Once()
+
+	matchPartial := mock.MatchedBy(func(p models.GalleryPartial) bool {
+		return p.TagIDs != nil && assert.ObjectsAreEqual([]int{tagID1, tagID2}, p.TagIDs.IDs)
+	})
+	db.Gallery.On("UpdatePartial", testCtx, destID, matchPartial).Return(dest, nil).Once()
+	// updated timestamps
+	db.Gallery.On("UpdatePartial", testCtx, mock.Anything, mock.Anything).Return(nil, nil)
+
+	values := models.NewGalleryPartial()
+
+	assert.ErrorIs(t, s.Merge(testCtx, []int{srcID1, destID}, destID, nil, values), ErrMergeDestinationInSource)
+	assert.Nil(t, s.Merge(testCtx, []int{srcID1, srcID2, srcID1}, destID, nil, values))
+
+	// image 3 is only in a source gallery, image 4 is kept in the other gallery
+	assert.Equal(t, []int{imageID3}, imageService.destroyed)
+
+	db.AssertExpectations(t)
+}
+
+func TestMergeFolderAndZip(t *testing.T) {
+	const (
+		folderGalleryID = iota + 1
+		zipGalleryID
+	)
+
+	const (
+		imageID1 = iota + 101
+		imageID2
+		imageID3
+		imageID4
+	)
+
+	const (
+		folderID  models.FolderID = 10
+		zipFileID models.FileID   = 20
+	)
+
+	newFolderGallery := func() *models.Gallery {
+		fID := folderID
+		return &models.Gallery{ID: folderGalleryID, FolderID: &fID}
+	}
+
+	newZipGallery := func() *models.Gallery {
+		primaryFileID := zipFileID
+		return &models.Gallery{ID: zipGalleryID, PrimaryFileID: &primaryFileID}
+	}
+
+	// images 1 and 3 have the same content
+	// merged ordering by path is 4, 1, 2 or 3, 4, 2
+	img1 := &models.Image{ID: imageID1, Path: "/gallery/a.jpg", Checksum: "a"}
+	img2 := &models.Image{ID: imageID2, Path: "/gallery/b.jpg", Checksum: "b"}
+	img3 := &models.Image{ID: imageID3, Path: "/gallery.zip/a.jpg", Checksum: "a"}
+	img4 := &models.Image{ID: imageID4, Path: "/gallery.zip/c.jpg", Checksum: "c"}
+
+	mockGalleries := func(db *mocks.Database, dest, src *models.Gallery) {
+		zipFile := &models.BaseFile{ID: zipFileID}
+
+		db.Gallery.On("Find", testCtx, dest.ID).Return(dest, nil)
+		db.Gallery.On("FindMany", testCtx, []int{src.ID}).Return([]*models.Gallery{src}, nil)
+
+		mockMergeRelationships(db, folderGalleryID, zipGalleryID)
+		db.Gallery.On("GetTagIDs", testCtx, mock.Anything).Return(nil, nil)
+		db.Gallery.On("GetFiles", testCtx, folderGalleryID).Return([]models.File{}, nil)
+		db.Gallery.On("GetFiles", testCtx, zipGalleryID).Return([]models.File{zipFile}, nil)
+
+		db.Gallery.On("GetImageIDs", testCtx, folderGalleryID).Return([]int{imageID1, imageID2}, nil)
+		db.Gallery.On("GetImageIDs", testCtx, zipGalleryID).Return([]int{imageID3, imageID4}, nil)
+		db.Image.On("FindMany", testCtx, []int{imageID1, imageID2}).Return([]*models.Image{img1, img2}, nil)
+		db.Image.On("FindMany", testCtx, []int{imageID3, imageID4}).Return([]*models.Image{img3, img4}, nil)
+
+		db.Gallery.On("Destroy", testCtx, src.ID).Return(nil).Once()
+	}
+
+	t.Run("zip into folder", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		imageService := &fakeImageService{}
+		s := newTestService(db, imageService)
+
+		dest := newFolderGallery()
+		src := newZipGallery()
+		mockGalleries(db, dest, src)
+
+		destChapter := &models.GalleryChapter{ID: 1, GalleryID: folderGalleryID, ImageIndex: 2}
+		srcChapter := &models.GalleryChapter{ID: 2, GalleryID: zipGalleryID, ImageIndex: 1}
+		outOfRangeChapter := &models.GalleryChapter{ID: 3, GalleryID: zipGalleryID, ImageIndex: 5}
+
+		db.GalleryChapter.On("FindByGalleryID", testCtx, folderGalleryID).Return([]*models.GalleryChapter{destChapter}, nil)
+		db.GalleryChapter.On("FindByGalleryID", testCtx, zipGalleryID).Return([]*models.GalleryChapter{srcChapter, outOfRangeChapter}, nil)
+		db.GalleryChapter.On("Update", testCtx, mock.Anything).Return(nil).Times(3)
+
+		db.Gallery.On("RemoveImages", testCtx, zipGalleryID, imageID3, imageID4).Return(nil).Once()
+		db.Gallery.On("AddImages", testCtx, folderGalleryID, imageID4).Return(nil).Once()
+		db.Image.On("GetGalleryIDs", testCtx, imageID3).Return(nil, nil)
+
+		// the zip file is moved to the destination, so its images are kept
+		db.Gallery.On("AddFileID", testCtx, folderGalleryID, zipFileID).Return(nil).Once()
+		db.Gallery.On("FindByFileID", testCtx, zipFileID).Return([]*models.Gallery{src, dest}, nil)
+
+		matchPartial := mock.MatchedBy(func(p models.GalleryPartial) bool {
+			return p.TagIDs != nil && p.FolderID == nil && p.PrimaryFileID == nil
+		})
+		db.Gallery.On("UpdatePartial", testCtx, folderGalleryID, matchPartial).Return(dest, nil).Once()
+		// updated timestamps
+		db.Gallery.On("UpdatePartial", testCtx, mock.Anything, mock.Anything).Return(nil, nil)
+
+		assert.Nil(t, s.Merge(testCtx, []int{zipGalleryID}, folderGalleryID, nil, models.NewGalleryPartial()))
+
+		// image 3 has the same content as image 1
+		assert.Equal(t, []int{imageID3}, imageService.destroyed)
+
+		// merged ordering is 4, 1, 2
+		assert.Equal(t, 3, destChapter.ImageIndex)
+		assert.Equal(t, folderGalleryID, srcChapter.GalleryID)
+		assert.Equal(t, 2, srcChapter.ImageIndex)
+		// clamped to image 4
+		assert.Equal(t, folderGalleryID, outOfRangeChapter.GalleryID)
+		assert.Equal(t, 1, outOfRangeChapter.ImageIndex)
+
+		db.AssertExpectations(t)
+	})
+
+	t.Run("folder into zip", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		imageService := &fakeImageService{}
+		s := newTestService(db, imageService)
+
+		dest := newZipGallery()
+		src := newFolderGallery()
+		mockGalleries(db, dest, src)
+
+		srcChapter := &models.GalleryChapter{ID: 1, GalleryID: folderGalleryID, ImageIndex: 2}
+
+		db.GalleryChapter.On("FindByGalleryID", testCtx, zipGalleryID).Return(nil, nil)
+		db.GalleryChapter.On("FindByGalleryID", testCtx, folderGalleryID).Return([]*models.GalleryChapter{srcChapter}, nil)
+		db.GalleryChapter.On("Update", testCtx, srcChapter).Return(nil).Once()
+
+		db.Gallery.On("RemoveImages", testCtx, folderGalleryID, imageID1, imageID2).Return(nil).Once()
+		db.Gallery.On("AddImages", testCtx, zipGalleryID, imageID2).Return(nil).Once()
+		db.Image.On("GetGalleryIDs", testCtx, imageID1).Return(nil, nil)
+
+		// the destination takes the folder of the source
+		matchPartial := mock.MatchedBy(func(p models.GalleryPartial) bool {
+			return p.TagIDs != nil && p.FolderID != nil && *p.FolderID == folderID && p.PrimaryFileID == nil
+		})
+		db.Gallery.On("UpdatePartial", testCtx, zipGalleryID, matchPartial).Return(dest, nil).Once()
+		// updated timestamps
+		db.Gallery.On("UpdatePartial", testCtx, mock.Anything, mock.Anything).Return(nil, nil)
+
+		assert.Nil(t, s.Merge(testCtx, []int{folderGalleryID}, zipGalleryID, nil, models.NewGalleryPartial()))
+
+		// image 1 has the same content as image 3
+		assert.Equal(t, []int{imageID1}, imageService.destroyed)
+
+		// merged ordering is 3, 4, 2
+		assert.Equal(t, zipGalleryID, srcChapter.GalleryID)
+		assert.Equal(t, 3, srcChapter.ImageIndex)
+
+		db.AssertExpectations(t)
+	})
+}
+
+func TestMergeMultipleFolders(t *testing.T) {
+	const (
+		folderGalleryID = iota + 1
+		otherFolderGalleryID
+	)
+
+	const (
+		folderID      models.FolderID = 10
+		otherFolderID models.FolderID = 11
+	)
+
+	db := mocks.NewDatabase()
+	s := newTestService(db, &fakeImageService{})
+
+	fID := folderID
+	otherFID := otherFolderID
+	folderGallery := &models.Gallery{ID: folderGalleryID, FolderID: &fID}
+	otherFolderGallery := &models.Gallery{ID: otherFolderGalleryID, FolderID: &otherFID}
+
+	db.Gallery.On("Find", testCtx, folderGalleryID).Return(folderGallery, nil)
+	db.Gallery.On("FindMany", testCtx, []int{otherFolderGalleryID}).Return([]*models.Gallery{otherFolderGallery}, nil)
+
+	mockMergeRelationships(db, folderGalleryID, otherFolderGalleryID)
+	db.Gallery.On("GetTagIDs", testCtx, mock.Anything).Return(nil, nil)
+	db.Gallery.On("GetFiles", testCtx, mock.Anything).Return([]models.File{}, nil)
+
+	err := s.Merge(testCtx, []int{otherFolderGalleryID}, folderGalleryID, nil, models.NewGalleryPartial())
+	assert.ErrorIs(t, err, ErrMergeMultipleFolders)
+}
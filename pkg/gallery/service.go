@@ -10,6 +10,7 @@ import (
 )
 
 type ImageFinder interface {
+	models.ImageGetter
 	FindByFolderID(ctx context.Context, folder models.FolderID) ([]*models.Image, error)
 	FindByZipFileID(ctx context.Context, zipFileID models.FileID) ([]*models.Image, error)
 	models.GalleryIDLoader
@@ -26,4 +27,5 @@ type Service struct {
 	ImageService ImageService
 	File         models.FileReaderWriter
 	Folder       models.FolderReaderWriter
+	Chapter      models.GalleryChapterReaderWriter
 }
@@ -27,12 +27,18 @@ func (s *Service) AddImages(ctx context.Context, g *models.Gallery, toAdd ...int
 		return err
 	}
 
-	if err := s.Repository.AddImages(ctx, g.ID, toAdd...); err != nil {
+	return s.addImages(ctx, g.ID, toAdd...)
+}
+
+// addImages adds images to the gallery without validating whether the
+// gallery supports it.
+func (s *Service) addImages(ctx context.Context, galleryID int, toAdd ...int) error {
+	if err := s.Repository.AddImages(ctx, galleryID, toAdd...); err != nil {
 		return fmt.Errorf("failed to add images to gallery: %w", err)
 	}
 
 	// #3759 - update the gallery's UpdatedAt timestamp
-	return s.Updated(ctx, g.ID)
+	return s.Updated(ctx, galleryID)
 }
 
 // RemoveImages removes images from the provided gallery.
@@ -44,12 +50,18 @@ func (s *Service) RemoveImages(ctx context.Context, g *models.Gallery, toRemove
 		return err
 	}
 
-	if err := s.Repository.RemoveImages(ctx, g.ID, toRemove...); err != nil {
+	return s.removeImages(ctx, g.ID, toRemove...)
+}
+
+// removeImages removes images from the gallery without validating whether
+// the gallery supports it.
+func (s *Service) removeImages(ctx context.Context, galleryID int, toRemove ...int) error {
+	if err := s.Repository.RemoveImages(ctx, galleryID, toRemove...); err != nil {
 		return fmt.Errorf("failed to remove images from gallery: %w", err)
 	}
 
 	// #3759 - update the gallery's UpdatedAt timestamp
-	return s.Updated(ctx, g.ID)
+	return s.Updated(ctx, galleryID)
 }
 
 func (s *Service) SetCover(ctx context.Context, g *models.Gallery, coverImageID int) error {
@@ -0,0 +1,49 @@
+package gallery
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddRemoveImages(t *testing.T) {
+	const (
+		manualGalleryID = iota + 1
+		folderGalleryID
+		zipGalleryID
+
+		imageID1
+		imageID2
+	)
+
+	var (
+		folderID models.FolderID = 10
+		fileID   models.FileID   = 20
+	)
+
+	manualGallery := &models.Gallery{ID: manualGalleryID}
+	folderGallery := &models.Gallery{ID: folderGalleryID, FolderID: &folderID}
+	zipGallery := &models.Gallery{ID: zipGalleryID, PrimaryFileID: &fileID}
+
+	db := mocks.NewDatabase()
+	s := newTestService(db, &fakeImageService{})
+
+	db.Gallery.On("AddImages", testCtx, manualGalleryID, imageID1, imageID2).Return(nil).Once()
+	db.Gallery.On("RemoveImages", testCtx, manualGalleryID, imageID1).Return(nil).Once()
+	// updated timestamp
+	db.Gallery.On("UpdatePartial", testCtx, manualGalleryID, mock.Anything).Return(manualGallery, nil).Twice()
+
+	assert.Nil(t, s.AddImages(testCtx, manualGallery, imageID1, imageID2))
+	assert.Nil(t, s.RemoveImages(testCtx, manualGallery, imageID1))
+
+	for _, g := range []*models.Gallery{folderGallery, zipGallery} {
+		want := &ContentsChangedError{Gallery: g}
+		assert.Equal(t, want, s.AddImages(testCtx, g, imageID1))
+		assert.Equal(t, want, s.RemoveImages(testCtx, g, imageID1))
+	}
+
+	db.AssertExpectations(t)
+}
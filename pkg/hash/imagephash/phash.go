@@ -0,0 +1,87 @@
+package imagephash
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/corona10/goimagehash"
+	_ "golang.org/x/image/webp"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// decodeSize is the maximum dimension of images converted with ffmpeg. The
+// perceptual hash is computed from a much smaller image, so there is no need
+// to convert at full size.
+const decodeSize = 160
+
+// Generate returns the perceptual hash of an image file. Images in formats
+// that cannot be decoded natively are converted with ffmpeg.
+func Generate(encoder *ffmpeg.FFMpeg, imageFile *models.ImageFile) (*uint64, error) {
+	img, err := decodeImage(encoder, imageFile)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return nil, fmt.Errorf("computing phash from image: %w", err)
+	}
+	hashValue := hash.GetHash()
+	return &hashValue, nil
+}
+
+func decodeImage(encoder *ffmpeg.FFMpeg, imageFile *models.ImageFile) (image.Image, error) {
+	reader, err := imageFile.Open(&file.OsFS{})
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("reading image: %w", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err == nil {
+		return img, nil
+	}
+
+	if !errors.Is(err, image.ErrFormat) || encoder == nil {
+		return nil, fmt.Errorf("decoding image: %w", err)
+	}
+
+	return decodeWithFFMpeg(encoder, data)
+}
+
+func decodeWithFFMpeg(encoder *ffmpeg.FFMpeg, data []byte) (image.Image, error) {
+	args := transcoder.ImageThumbnail("-", transcoder.ImageThumbnailOptions{
+		OutputFormat:  ffmpeg.ImageFormatJpeg,
+		OutputPath:    "-",
+		MaxDimensions: decodeSize,
+	})
+
+	out, err := encoder.GenerateOutput(context.Background(), args, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("converting image: %w", err)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(out))
+	if err != nil {
+		return nil, fmt.Errorf("decoding converted image: %w", err)
+	}
+
+	return img, nil
+}
@@ -0,0 +1,185 @@
+package history
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func decode[V any](v json.RawMessage) (*V, error) {
+	var ret *V
+	if err := json.Unmarshal(v, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func stringField[T, P any](name string, get func(*T) *string, dest func(*P) *models.OptionalString) field[T, P] {
+	return field[T, P]{
+		name:  name,
+		value: func(o *T) interface{} { return get(o) },
+		isSet: func(p *P) bool { return dest(p).Set },
+		set: func(p *P, v json.RawMessage) error {
+			s, err := decode[string](v)
+			if err != nil {
+				return err
+			}
+
+			*dest(p) = models.NewOptionalStringPtr(s)
+			return nil
+		},
+	}
+}
+
+func intField[T, P any](name string, get func(*T) *int, dest func(*P) *models.OptionalInt) field[T, P] {
+	return field[T, P]{
+		name:  name,
+		value: func(o *T) interface{} { return get(o) },
+		isSet: func(p *P) bool { return dest(p).Set },
+		set: func(p *P, v json.RawMessage) error {
+			i, err := decode[int](v)
+			if err != nil {
+				return err
+			}
+
+			*dest(p) = models.NewOptionalIntPtr(i)
+			return nil
+		},
+	}
+}
+
+func floatField[T, P any](name string, get func(*T) *float64, dest func(*P) *models.OptionalFloat64) field[T, P] {
+	return field[T, P]{
+		name:  name,
+		value: func(o *T) interface{} { return get(o) },
+		isSet: func(p *P) bool { return dest(p).Set },
+		set: func(p *P, v json.RawMessage) error {
+			f, err := decode[float64](v)
+			if err != nil {
+				return err
+			}
+
+			*dest(p) = models.NewOptionalFloat64Ptr(f)
+			return nil
+		},
+	}
+}
+
+func boolField[T, P any](name string, get func(*T) *bool, dest func(*P) *models.OptionalBool) field[T, P] {
+	return field[T, P]{
+		name:  name,
+		value: func(o *T) interface{} { return get(o) },
+		isSet: func(p *P) bool { return dest(p).Set },
+		set: func(p *P, v json.RawMessage) error {
+			b, err := decode[bool](v)
+			if err != nil {
+				return err
+			}
+
+			*dest(p) = models.NewOptionalBoolPtr(b)
+			return nil
+		},
+	}
+}
+
+// dateField stores dates in their string form.
+func dateField[T, P any](name string, get func(*T) *models.Date, dest func(*P) *models.OptionalDate) field[T, P] {
+	return field[T, P]{
+		name: name,
+		value: func(o *T) interface{} {
+			d := get(o)
+			if d == nil {
+				return nil
+			}
+			return d.String()
+		},
+		isSet: func(p *P) bool { return dest(p).Set },
+		set: func(p *P, v json.RawMessage) error {
+			s, err := decode[string](v)
+			if err != nil {
+				return err
+			}
+
+			if s == nil {
+				*dest(p) = models.NewOptionalDatePtr(nil)
+				return nil
+			}
+
+			d, err := models.ParseDate(*s)
+			if err != nil {
+				return err
+			}
+
+			*dest(p) = models.NewOptionalDate(d)
+			return nil
+		},
+	}
+}
+
+// idsField stores IDs in ascending order, since the order of related IDs
+// is not significant.
+func idsField[T, P any](name string, get func(*T) []int, dest func(*P) **models.UpdateIDs) field[T, P] {
+	return field[T, P]{
+		name: name,
+		value: func(o *T) interface{} {
+			ret := append([]int{}, get(o)...)
+			sort.Ints(ret)
+			return ret
+		},
+		isSet: func(p *P) bool { return *dest(p) != nil },
+		set: func(p *P, v json.RawMessage) error {
+			var ids []int
+			if err := json.Unmarshal(v, &ids); err != nil {
+				return err
+			}
+
+			*dest(p) = &models.UpdateIDs{
+				IDs:  ids,
+				Mode: models.RelationshipUpdateModeSet,
+			}
+			return nil
+		},
+	}
+}
+
+func stringsField[T, P any](name string, get func(*T) []string, dest func(*P) **models.UpdateStrings) field[T, P] {
+	return field[T, P]{
+		name:  name,
+		value: func(o *T) interface{} { return append([]string{}, get(o)...) },
+		isSet: func(p *P) bool { return *dest(p) != nil },
+		set: func(p *P, v json.RawMessage) error {
+			var values []string
+			if err := json.Unmarshal(v, &values); err != nil {
+				return err
+			}
+
+			*dest(p) = &models.UpdateStrings{
+				Values: values,
+				Mode:   models.RelationshipUpdateModeSet,
+			}
+			return nil
+		},
+	}
+}
+
+func stashIDsField[T, P any](name string, get func(*T) []models.StashID, dest func(*P) **models.UpdateStashIDs) field[T, P] {
+	return field[T, P]{
+		name:  name,
+		value: func(o *T) interface{} { return append([]models.StashID{}, get(o)...) },
+		isSet: func(p *P) bool { return *dest(p) != nil },
+		set: func(p *P, v json.RawMessage) error {
+			var stashIDs []models.StashID
+			if err := json.Unmarshal(v, &stashIDs); err != nil {
+				return err
+			}
+
+			*dest(p) = &models.UpdateStashIDs{
+				StashIDs: stashIDs,
+				Mode:     models.RelationshipUpdateModeSet,
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,99 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type (
+	galleryField   = field[models.Gallery, models.GalleryPartial]
+	galleryTracker = tracker[models.Gallery, models.GalleryPartial]
+)
+
+var galleryFields = []galleryField{
+	stringField("title",
+		func(s *models.Gallery) *string { return &s.Title },
+		func(p *models.GalleryPartial) *models.OptionalString { return &p.Title }),
+	stringField("code",
+		func(s *models.Gallery) *string { return &s.Code },
+		func(p *models.GalleryPartial) *models.OptionalString { return &p.Code }),
+	stringsField("urls",
+		func(s *models.Gallery) []string { return s.URLs.List() },
+		func(p *models.GalleryPartial) **models.UpdateStrings { return &p.URLs }),
+	dateField("date",
+		func(s *models.Gallery) *models.Date { return s.Date },
+		func(p *models.GalleryPartial) *models.OptionalDate { return &p.Date }),
+	stringField("details",
+		func(s *models.Gallery) *string { return &s.Details },
+		func(p *models.GalleryPartial) *models.OptionalString { return &p.Details }),
+	stringField("photographer",
+		func(s *models.Gallery) *string { return &s.Photographer },
+		func(p *models.GalleryPartial) *models.OptionalString { return &p.Photographer }),
+	intField("rating100",
+		func(s *models.Gallery) *int { return s.Rating },
+		func(p *models.GalleryPartial) *models.OptionalInt { return &p.Rating }),
+	boolField("organized",
+		func(s *models.Gallery) *bool { return &s.Organized },
+		func(p *models.GalleryPartial) *models.OptionalBool { return &p.Organized }),
+	intField("studio_id",
+		func(s *models.Gallery) *int { return s.StudioID },
+		func(p *models.GalleryPartial) *models.OptionalInt { return &p.StudioID }),
+	idsField("scene_ids",
+		func(s *models.Gallery) []int { return s.SceneIDs.List() },
+		func(p *models.GalleryPartial) **models.UpdateIDs { return &p.SceneIDs }),
+	idsField("tag_ids",
+		func(s *models.Gallery) []int { return s.TagIDs.List() },
+		func(p *models.GalleryPartial) **models.UpdateIDs { return &p.TagIDs }),
+	idsField("performer_ids",
+		func(s *models.Gallery) []int { return s.PerformerIDs.List() },
+		func(p *models.GalleryPartial) **models.UpdateIDs { return &p.PerformerIDs }),
+}
+
+func newGalleryTracker(r models.GalleryReader, history models.EntityHistoryCreator) *galleryTracker {
+	return &galleryTracker{
+		entityType: models.HistoryEntityTypeGallery,
+		fields:     galleryFields,
+		load: func(ctx context.Context, id int) (*models.Gallery, error) {
+			g, err := r.Find(ctx, id)
+			if err != nil || g == nil {
+				return nil, err
+			}
+
+			if err := utils.Do([]func() error{
+				func() error { return g.LoadURLs(ctx, r) },
+				func() error { return g.LoadSceneIDs(ctx, r) },
+				func() error { return g.LoadTagIDs(ctx, r) },
+				func() error { return g.LoadPerformerIDs(ctx, r) },
+			}); err != nil {
+				return nil, err
+			}
+
+			return g, nil
+		},
+		history: history,
+	}
+}
+
+type galleryRecorder struct {
+	models.GalleryReaderWriter
+	tracker *galleryTracker
+}
+
+func (r *galleryRecorder) Update(ctx context.Context, updatedGallery *models.Gallery) error {
+	return r.tracker.record(ctx, updatedGallery.ID, func() error {
+		return r.GalleryReaderWriter.Update(ctx, updatedGallery)
+	})
+}
+
+func (r *galleryRecorder) UpdatePartial(ctx context.Context, id int, updatedGallery models.GalleryPartial) (*models.Gallery, error) {
+	var ret *models.Gallery
+	err := r.tracker.recordPartial(ctx, id, updatedGallery, func() error {
+		var err error
+		ret, err = r.GalleryReaderWriter.UpdatePartial(ctx, id, updatedGallery)
+		return err
+	})
+
+	return ret, err
+}
@@ -0,0 +1,269 @@
+// Package history records field-level changes made to scenes, performers,
+// studios, tags and galleries, and reverts them.
+//
+// Only updates to existing entities are recorded. Creating and destroying
+// entities is not recorded, and merging entities is recorded only as the
+// update to the merge destination. Relationships are recorded against the
+// entity that was updated, and not against the related entities. Partial
+// updates that do not set any tracked field are not recorded, and do not
+// incur the cost of loading the entity before and after the update.
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// ErrChangedSince is returned by Revert when a field has been changed again
+// since the change being reverted.
+var ErrChangedSince = errors.New("fields have been changed since")
+
+// Wrap returns a copy of the provided repository in which updates to
+// scenes, performers, studios, tags and galleries are recorded in the
+// entity history.
+func Wrap(r models.Repository) models.Repository {
+	r.Scene = &sceneRecorder{
+		SceneReaderWriter: r.Scene,
+		tracker:           newSceneTracker(r.Scene, r.EntityHistory),
+	}
+	r.Performer = &performerRecorder{
+		PerformerReaderWriter: r.Performer,
+		tracker:               newPerformerTracker(r.Performer, r.EntityHistory),
+	}
+	r.Studio = &studioRecorder{
+		StudioReaderWriter: r.Studio,
+		tracker:            newStudioTracker(r.Studio, r.EntityHistory),
+	}
+	r.Tag = &tagRecorder{
+		TagReaderWriter: r.Tag,
+		tracker:         newTagTracker(r.Tag, r.EntityHistory),
+	}
+	r.Gallery = &galleryRecorder{
+		GalleryReaderWriter: r.Gallery,
+		tracker:             newGalleryTracker(r.Gallery, r.EntityHistory),
+	}
+
+	return r
+}
+
+// Revert sets the fields changed by the provided history entry back to
+// their previous values. The update is made through the provided
+// repository, so that it is itself recorded if the repository was wrapped.
+// ErrChangedSince is returned if any of the fields no longer have the value
+// set by the change.
+func Revert(ctx context.Context, r models.Repository, h *models.EntityHistory) error {
+	switch h.EntityType {
+	case models.HistoryEntityTypeScene:
+		return newSceneTracker(r.Scene, nil).revert(ctx, h, func(id int, partial models.ScenePartial) error {
+			_, err := r.Scene.UpdatePartial(ctx, id, partial)
+			return err
+		})
+	case models.HistoryEntityTypePerformer:
+		return newPerformerTracker(r.Performer, nil).revert(ctx, h, func(id int, partial models.PerformerPartial) error {
+			_, err := r.Performer.UpdatePartial(ctx, id, partial)
+			return err
+		})
+	case models.HistoryEntityTypeStudio:
+		return newStudioTracker(r.Studio, nil).revert(ctx, h, func(id int, partial models.StudioPartial) error {
+			partial.ID = id
+			_, err := r.Studio.UpdatePartial(ctx, partial)
+			return err
+		})
+	case models.HistoryEntityTypeTag:
+		return newTagTracker(r.Tag, nil).revert(ctx, h, func(id int, partial models.TagPartial) error {
+			_, err := r.Tag.UpdatePartial(ctx, id, partial)
+			return err
+		})
+	case models.HistoryEntityTypeGallery:
+		return newGalleryTracker(r.Gallery, nil).revert(ctx, h, func(id int, partial models.GalleryPartial) error {
+			_, err := r.Gallery.UpdatePartial(ctx, id, partial)
+			return err
+		})
+	}
+
+	return fmt.Errorf("unsupported entity type %q", h.EntityType)
+}
+
+// snapshot is the JSON-encoded value of each tracked field of an entity.
+type snapshot map[string]json.RawMessage
+
+// field is a tracked field of an entity of type T, which is updated using a
+// partial of type P.
+type field[T any, P any] struct {
+	name string
+	// value returns the JSON-encodable value of the field
+	value func(o *T) interface{}
+	// isSet returns true if the partial sets the field
+	isSet func(p *P) bool
+	// set sets the field in the partial to the JSON-encoded value
+	set func(p *P, v json.RawMessage) error
+}
+
+// tracker records changes to entities of type T.
+type tracker[T any, P any] struct {
+	entityType models.HistoryEntityType
+	fields     []field[T, P]
+	// load returns the entity with the tracked relationships loaded.
+	// Returns nil if the entity does not exist.
+	load    func(ctx context.Context, id int) (*T, error)
+	history models.EntityHistoryCreator
+}
+
+func (t *tracker[T, P]) snapshot(ctx context.Context, id int) (snapshot, error) {
+	o, err := t.load(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("loading %s %d: %w", t.entityType, id, err)
+	}
+
+	if o == nil {
+		return nil, nil
+	}
+
+	ret := make(snapshot)
+	for _, f := range t.fields {
+		v, err := json.Marshal(f.value(o))
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", f.name, err)
+		}
+		ret[f.name] = v
+	}
+
+	return ret, nil
+}
+
+func (t *tracker[T, P]) diff(before, after snapshot) []models.FieldChange {
+	var ret []models.FieldChange
+	for _, f := range t.fields {
+		oldValue, newValue := before[f.name], after[f.name]
+		if !bytes.Equal(oldValue, newValue) {
+			ret = append(ret, models.FieldChange{
+				Field:    f.name,
+				OldValue: oldValue,
+				NewValue: newValue,
+			})
+		}
+	}
+
+	return ret
+}
+
+// record calls fn and records the changes it makes to the entity with the
+// provided id. Nothing is recorded if the entity does not exist before and
+// after the update, or if none of the tracked fields changed.
+func (t *tracker[T, P]) record(ctx context.Context, id int, fn func() error) error {
+	before, err := t.snapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	after, err := t.snapshot(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if before == nil || after == nil {
+		return nil
+	}
+
+	changes := t.diff(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	h := &models.EntityHistory{
+		EntityType: t.entityType,
+		EntityID:   id,
+		Source:     models.HistorySourceFromContext(ctx),
+		Changes:    changes,
+		CreatedAt:  time.Now(),
+	}
+
+	if username := session.GetCurrentUserID(ctx); username != nil && *username != "" {
+		h.Username = username
+	}
+
+	if err := t.history.Create(ctx, h); err != nil {
+		return fmt.Errorf("recording history of %s %d: %w", t.entityType, id, err)
+	}
+
+	return nil
+}
+
+// recordPartial calls fn and records the changes it makes to the entity with
+// the provided id, if the partial sets any of the tracked fields. Otherwise,
+// fn is called without loading the entity.
+func (t *tracker[T, P]) recordPartial(ctx context.Context, id int, partial P, fn func() error) error {
+	if !t.isSet(&partial) {
+		return fn()
+	}
+
+	return t.record(ctx, id, fn)
+}
+
+// isSet returns true if the partial sets any of the tracked fields.
+func (t *tracker[T, P]) isSet(p *P) bool {
+	for _, f := range t.fields {
+		if f.isSet(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *tracker[T, P]) revert(ctx context.Context, h *models.EntityHistory, update func(id int, partial P) error) error {
+	current, err := t.snapshot(ctx, h.EntityID)
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		return fmt.Errorf("%s %d not found", strings.ToLower(t.entityType.String()), h.EntityID)
+	}
+
+	var partial P
+	var changed []string
+	for _, c := range h.Changes {
+		f := t.field(c.Field)
+		if f == nil {
+			return fmt.Errorf("unknown field %q", c.Field)
+		}
+
+		if !bytes.Equal(current[c.Field], c.NewValue) {
+			changed = append(changed, c.Field)
+			continue
+		}
+
+		if err := f.set(&partial, c.OldValue); err != nil {
+			return fmt.Errorf("setting %s: %w", c.Field, err)
+		}
+	}
+
+	if len(changed) > 0 {
+		return fmt.Errorf("%w: %s", ErrChangedSince, strings.Join(changed, ", "))
+	}
+
+	return update(h.EntityID, partial)
+}
+
+func (t *tracker[T, P]) field(name string) *field[T, P] {
+	for i := range t.fields {
+		if t.fields[i].name == name {
+			return &t.fields[i]
+		}
+	}
+
+	return nil
+}
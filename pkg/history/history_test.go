@@ -0,0 +1,197 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+const sceneID = 1
+
+func testSceneTracker(history models.EntityHistoryCreator, scenes ...*models.Scene) *sceneTracker {
+	return &sceneTracker{
+		entityType: models.HistoryEntityTypeScene,
+		fields:     sceneFields,
+		load: func(ctx context.Context, id int) (*models.Scene, error) {
+			ret := scenes[0]
+			if len(scenes) > 1 {
+				scenes = scenes[1:]
+			}
+			return ret, nil
+		},
+		history: history,
+	}
+}
+
+func testScene(title string, rating *int, tagIDs []int) *models.Scene {
+	return &models.Scene{
+		ID:           sceneID,
+		Title:        title,
+		Rating:       rating,
+		URLs:         models.NewRelatedStrings([]string{}),
+		GalleryIDs:   models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs(tagIDs),
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		Groups:       models.NewRelatedGroups([]models.GroupsScenes{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+	}
+}
+
+func TestTrackerRecord(t *testing.T) {
+	rating := 60
+	before := testScene("old", nil, []int{2, 1})
+	after := testScene("new", &rating, []int{1, 2})
+
+	ctx := models.WithHistorySource(context.Background(), models.HistorySourceIdentify)
+
+	t.Run("changed", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		db.EntityHistory.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		called := false
+		err := testSceneTracker(db.EntityHistory, before, after).record(ctx, sceneID, func() error {
+			called = true
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+		db.AssertExpectations(t)
+
+		h := db.EntityHistory.Calls[0].Arguments.Get(1).(*models.EntityHistory)
+		assert.Equal(t, models.HistoryEntityTypeScene, h.EntityType)
+		assert.Equal(t, sceneID, h.EntityID)
+		assert.Equal(t, models.HistorySourceIdentify, h.Source)
+		assert.Nil(t, h.Username)
+
+		// the order of tag IDs is not significant
+		assert.Equal(t, []models.FieldChange{
+			{Field: "title", OldValue: json.RawMessage(`"old"`), NewValue: json.RawMessage(`"new"`)},
+			{Field: "rating100", OldValue: json.RawMessage(`null`), NewValue: json.RawMessage(`60`)},
+		}, h.Changes)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		db := mocks.NewDatabase()
+
+		err := testSceneTracker(db.EntityHistory, before, before).record(ctx, sceneID, func() error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+		db.EntityHistory.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update error", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		updateErr := errors.New("update error")
+
+		err := testSceneTracker(db.EntityHistory, before, after).record(ctx, sceneID, func() error {
+			return updateErr
+		})
+
+		assert.ErrorIs(t, err, updateErr)
+		db.EntityHistory.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTrackerRecordPartial(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("untracked fields", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		tracker := testSceneTracker(db.EntityHistory)
+		tracker.load = func(ctx context.Context, id int) (*models.Scene, error) {
+			t.Error("scene should not be loaded")
+			return nil, nil
+		}
+
+		partial := models.NewScenePartial()
+		partial.PlayDuration = models.NewOptionalFloat64(10)
+
+		called := false
+		err := tracker.recordPartial(ctx, sceneID, partial, func() error {
+			called = true
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.True(t, called)
+		db.EntityHistory.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("tracked fields", func(t *testing.T) {
+		db := mocks.NewDatabase()
+		db.EntityHistory.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		partial := models.NewScenePartial()
+		partial.Title = models.NewOptionalString("new")
+
+		err := testSceneTracker(db.EntityHistory, testScene("old", nil, []int{}), testScene("new", nil, []int{})).recordPartial(ctx, sceneID, partial, func() error {
+			return nil
+		})
+
+		assert.NoError(t, err)
+		db.AssertExpectations(t)
+	})
+}
+
+func TestTrackerRevert(t *testing.T) {
+	rating := 60
+	h := &models.EntityHistory{
+		EntityType: models.HistoryEntityTypeScene,
+		EntityID:   sceneID,
+		Changes: []models.FieldChange{
+			{Field: "title", OldValue: json.RawMessage(`"old"`), NewValue: json.RawMessage(`"new"`)},
+			{Field: "rating100", OldValue: json.RawMessage(`null`), NewValue: json.RawMessage(`60`)},
+			{Field: "tag_ids", OldValue: json.RawMessage(`[1]`), NewValue: json.RawMessage(`[1,2]`)},
+		},
+	}
+
+	ctx := context.Background()
+
+	t.Run("revert", func(t *testing.T) {
+		var got models.ScenePartial
+		err := testSceneTracker(nil, testScene("new", &rating, []int{2, 1})).revert(ctx, h, func(id int, partial models.ScenePartial) error {
+			assert.Equal(t, sceneID, id)
+			got = partial
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.NewOptionalString("old"), got.Title)
+		assert.Equal(t, models.NewOptionalIntPtr(nil), got.Rating)
+		assert.Equal(t, &models.UpdateIDs{
+			IDs:  []int{1},
+			Mode: models.RelationshipUpdateModeSet,
+		}, got.TagIDs)
+		assert.False(t, got.Details.Set)
+		assert.Nil(t, got.PerformerIDs)
+	})
+
+	t.Run("changed since", func(t *testing.T) {
+		err := testSceneTracker(nil, testScene("newer", &rating, []int{1, 2})).revert(ctx, h, func(id int, partial models.ScenePartial) error {
+			t.Error("update should not be called")
+			return nil
+		})
+
+		assert.ErrorIs(t, err, ErrChangedSince)
+		assert.ErrorContains(t, err, "title")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		err := testSceneTracker(nil, nil).revert(ctx, h, func(id int, partial models.ScenePartial) error {
+			t.Error("update should not be called")
+			return nil
+		})
+
+		assert.Error(t, err)
+	})
+}
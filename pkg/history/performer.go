@@ -0,0 +1,153 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type (
+	performerField   = field[models.Performer, models.PerformerPartial]
+	performerTracker = tracker[models.Performer, models.PerformerPartial]
+)
+
+var performerFields = []performerField{
+	stringField("name",
+		func(s *models.Performer) *string { return &s.Name },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Name }),
+	stringField("disambiguation",
+		func(s *models.Performer) *string { return &s.Disambiguation },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Disambiguation }),
+	stringField("gender",
+		func(s *models.Performer) *string {
+			if s.Gender == nil {
+				return nil
+			}
+			v := s.Gender.String()
+			return &v
+		},
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Gender }),
+	dateField("birthdate",
+		func(s *models.Performer) *models.Date { return s.Birthdate },
+		func(p *models.PerformerPartial) *models.OptionalDate { return &p.Birthdate }),
+	stringField("ethnicity",
+		func(s *models.Performer) *string { return &s.Ethnicity },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Ethnicity }),
+	stringField("country",
+		func(s *models.Performer) *string { return &s.Country },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Country }),
+	stringField("eye_color",
+		func(s *models.Performer) *string { return &s.EyeColor },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.EyeColor }),
+	intField("height_cm",
+		func(s *models.Performer) *int { return s.Height },
+		func(p *models.PerformerPartial) *models.OptionalInt { return &p.Height }),
+	stringField("measurements",
+		func(s *models.Performer) *string { return &s.Measurements },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Measurements }),
+	stringField("fake_tits",
+		func(s *models.Performer) *string { return &s.FakeTits },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.FakeTits }),
+	floatField("penis_length",
+		func(s *models.Performer) *float64 { return s.PenisLength },
+		func(p *models.PerformerPartial) *models.OptionalFloat64 { return &p.PenisLength }),
+	stringField("circumcised",
+		func(s *models.Performer) *string {
+			if s.Circumcised == nil {
+				return nil
+			}
+			v := s.Circumcised.String()
+			return &v
+		},
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Circumcised }),
+	stringField("career_length",
+		func(s *models.Performer) *string { return &s.CareerLength },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.CareerLength }),
+	stringField("tattoos",
+		func(s *models.Performer) *string { return &s.Tattoos },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Tattoos }),
+	stringField("piercings",
+		func(s *models.Performer) *string { return &s.Piercings },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Piercings }),
+	boolField("favorite",
+		func(s *models.Performer) *bool { return &s.Favorite },
+		func(p *models.PerformerPartial) *models.OptionalBool { return &p.Favorite }),
+	intField("rating100",
+		func(s *models.Performer) *int { return s.Rating },
+		func(p *models.PerformerPartial) *models.OptionalInt { return &p.Rating }),
+	stringField("details",
+		func(s *models.Performer) *string { return &s.Details },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.Details }),
+	dateField("death_date",
+		func(s *models.Performer) *models.Date { return s.DeathDate },
+		func(p *models.PerformerPartial) *models.OptionalDate { return &p.DeathDate }),
+	stringField("hair_color",
+		func(s *models.Performer) *string { return &s.HairColor },
+		func(p *models.PerformerPartial) *models.OptionalString { return &p.HairColor }),
+	intField("weight",
+		func(s *models.Performer) *int { return s.Weight },
+		func(p *models.PerformerPartial) *models.OptionalInt { return &p.Weight }),
+	boolField("ignore_auto_tag",
+		func(s *models.Performer) *bool { return &s.IgnoreAutoTag },
+		func(p *models.PerformerPartial) *models.OptionalBool { return &p.IgnoreAutoTag }),
+	stringsField("alias_list",
+		func(s *models.Performer) []string { return s.Aliases.List() },
+		func(p *models.PerformerPartial) **models.UpdateStrings { return &p.Aliases }),
+	stringsField("urls",
+		func(s *models.Performer) []string { return s.URLs.List() },
+		func(p *models.PerformerPartial) **models.UpdateStrings { return &p.URLs }),
+	idsField("tag_ids",
+		func(s *models.Performer) []int { return s.TagIDs.List() },
+		func(p *models.PerformerPartial) **models.UpdateIDs { return &p.TagIDs }),
+	stashIDsField("stash_ids",
+		func(s *models.Performer) []models.StashID { return s.StashIDs.List() },
+		func(p *models.PerformerPartial) **models.UpdateStashIDs { return &p.StashIDs }),
+}
+
+func newPerformerTracker(r models.PerformerReader, history models.EntityHistoryCreator) *performerTracker {
+	return &performerTracker{
+		entityType: models.HistoryEntityTypePerformer,
+		fields:     performerFields,
+		load: func(ctx context.Context, id int) (*models.Performer, error) {
+			p, err := r.Find(ctx, id)
+			if err != nil || p == nil {
+				return nil, err
+			}
+
+			if err := utils.Do([]func() error{
+				func() error { return p.LoadAliases(ctx, r) },
+				func() error { return p.LoadURLs(ctx, r) },
+				func() error { return p.LoadTagIDs(ctx, r) },
+				func() error { return p.LoadStashIDs(ctx, r) },
+			}); err != nil {
+				return nil, err
+			}
+
+			return p, nil
+		},
+		history: history,
+	}
+}
+
+type performerRecorder struct {
+	models.PerformerReaderWriter
+	tracker *performerTracker
+}
+
+func (r *performerRecorder) Update(ctx context.Context, updatedPerformer *models.Performer) error {
+	return r.tracker.record(ctx, updatedPerformer.ID, func() error {
+		return r.PerformerReaderWriter.Update(ctx, updatedPerformer)
+	})
+}
+
+func (r *performerRecorder) UpdatePartial(ctx context.Context, id int, updatedPerformer models.PerformerPartial) (*models.Performer, error) {
+	var ret *models.Performer
+	err := r.tracker.recordPartial(ctx, id, updatedPerformer, func() error {
+		var err error
+		ret, err = r.PerformerReaderWriter.UpdatePartial(ctx, id, updatedPerformer)
+		return err
+	})
+
+	return ret, err
+}
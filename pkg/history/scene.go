@@ -0,0 +1,130 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type (
+	sceneField   = field[models.Scene, models.ScenePartial]
+	sceneTracker = tracker[models.Scene, models.ScenePartial]
+)
+
+var sceneFields = []sceneField{
+	stringField("title",
+		func(s *models.Scene) *string { return &s.Title },
+		func(p *models.ScenePartial) *models.OptionalString { return &p.Title }),
+	stringField("code",
+		func(s *models.Scene) *string { return &s.Code },
+		func(p *models.ScenePartial) *models.OptionalString { return &p.Code }),
+	stringField("details",
+		func(s *models.Scene) *string { return &s.Details },
+		func(p *models.ScenePartial) *models.OptionalString { return &p.Details }),
+	stringField("director",
+		func(s *models.Scene) *string { return &s.Director },
+		func(p *models.ScenePartial) *models.OptionalString { return &p.Director }),
+	dateField("date",
+		func(s *models.Scene) *models.Date { return s.Date },
+		func(p *models.ScenePartial) *models.OptionalDate { return &p.Date }),
+	intField("rating100",
+		func(s *models.Scene) *int { return s.Rating },
+		func(p *models.ScenePartial) *models.OptionalInt { return &p.Rating }),
+	boolField("organized",
+		func(s *models.Scene) *bool { return &s.Organized },
+		func(p *models.ScenePartial) *models.OptionalBool { return &p.Organized }),
+	intField("studio_id",
+		func(s *models.Scene) *int { return s.StudioID },
+		func(p *models.ScenePartial) *models.OptionalInt { return &p.StudioID }),
+	stringsField("urls",
+		func(s *models.Scene) []string { return s.URLs.List() },
+		func(p *models.ScenePartial) **models.UpdateStrings { return &p.URLs }),
+	idsField("gallery_ids",
+		func(s *models.Scene) []int { return s.GalleryIDs.List() },
+		func(p *models.ScenePartial) **models.UpdateIDs { return &p.GalleryIDs }),
+	idsField("tag_ids",
+		func(s *models.Scene) []int { return s.TagIDs.List() },
+		func(p *models.ScenePartial) **models.UpdateIDs { return &p.TagIDs }),
+	idsField("performer_ids",
+		func(s *models.Scene) []int { return s.PerformerIDs.List() },
+		func(p *models.ScenePartial) **models.UpdateIDs { return &p.PerformerIDs }),
+	{
+		name: "groups",
+		value: func(s *models.Scene) interface{} {
+			return append([]models.GroupsScenes{}, s.Groups.List()...)
+		},
+		isSet: func(p *models.ScenePartial) bool { return p.GroupIDs != nil },
+		set: func(p *models.ScenePartial, v json.RawMessage) error {
+			var groups []models.GroupsScenes
+			if err := json.Unmarshal(v, &groups); err != nil {
+				return err
+			}
+
+			p.GroupIDs = &models.UpdateGroupIDs{
+				Groups: groups,
+				Mode:   models.RelationshipUpdateModeSet,
+			}
+			return nil
+		},
+	},
+	stashIDsField("stash_ids",
+		func(s *models.Scene) []models.StashID { return s.StashIDs.List() },
+		func(p *models.ScenePartial) **models.UpdateStashIDs { return &p.StashIDs }),
+}
+
+func newSceneTracker(r models.SceneReader, history models.EntityHistoryCreator) *sceneTracker {
+	return &sceneTracker{
+		entityType: models.HistoryEntityTypeScene,
+		fields:     sceneFields,
+		load: func(ctx context.Context, id int) (*models.Scene, error) {
+			s, err := r.Find(ctx, id)
+			if err != nil || s == nil {
+				return nil, err
+			}
+
+			if err := utils.Do([]func() error{
+				func() error { return s.LoadURLs(ctx, r) },
+				func() error { return s.LoadGalleryIDs(ctx, r) },
+				func() error { return s.LoadTagIDs(ctx, r) },
+				func() error { return s.LoadPerformerIDs(ctx, r) },
+				func() error { return s.LoadGroups(ctx, r) },
+				func() error { return s.LoadStashIDs(ctx, r) },
+			}); err != nil {
+				return nil, err
+			}
+
+			return s, nil
+		},
+		history: history,
+	}
+}
+
+type sceneRecorder struct {
+	models.SceneReaderWriter
+	tracker *sceneTracker
+}
+
+func (r *sceneRecorder) Update(ctx context.Context, updatedScene *models.Scene) error {
+	return r.tracker.record(ctx, updatedScene.ID, func() error {
+		return r.SceneReaderWriter.Update(ctx, updatedScene)
+	})
+}
+
+func (r *sceneRecorder) UpdatePartial(ctx context.Context, id int, updatedScene models.ScenePartial) (*models.Scene, error) {
+	var ret *models.Scene
+	err := r.tracker.recordPartial(ctx, id, updatedScene, func() error {
+		var err error
+		ret, err = r.SceneReaderWriter.UpdatePartial(ctx, id, updatedScene)
+		return err
+	})
+
+	return ret, err
+}
+
+func (r *sceneRecorder) AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs []int) error {
+	return r.tracker.record(ctx, sceneID, func() error {
+		return r.SceneReaderWriter.AddGalleryIDs(ctx, sceneID, galleryIDs)
+	})
+}
@@ -0,0 +1,92 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type (
+	studioField   = field[models.Studio, models.StudioPartial]
+	studioTracker = tracker[models.Studio, models.StudioPartial]
+)
+
+var studioFields = []studioField{
+	stringField("name",
+		func(s *models.Studio) *string { return &s.Name },
+		func(p *models.StudioPartial) *models.OptionalString { return &p.Name }),
+	stringField("url",
+		func(s *models.Studio) *string { return &s.URL },
+		func(p *models.StudioPartial) *models.OptionalString { return &p.URL }),
+	intField("parent_id",
+		func(s *models.Studio) *int { return s.ParentID },
+		func(p *models.StudioPartial) *models.OptionalInt { return &p.ParentID }),
+	intField("rating100",
+		func(s *models.Studio) *int { return s.Rating },
+		func(p *models.StudioPartial) *models.OptionalInt { return &p.Rating }),
+	boolField("favorite",
+		func(s *models.Studio) *bool { return &s.Favorite },
+		func(p *models.StudioPartial) *models.OptionalBool { return &p.Favorite }),
+	stringField("details",
+		func(s *models.Studio) *string { return &s.Details },
+		func(p *models.StudioPartial) *models.OptionalString { return &p.Details }),
+	boolField("ignore_auto_tag",
+		func(s *models.Studio) *bool { return &s.IgnoreAutoTag },
+		func(p *models.StudioPartial) *models.OptionalBool { return &p.IgnoreAutoTag }),
+	stringsField("aliases",
+		func(s *models.Studio) []string { return s.Aliases.List() },
+		func(p *models.StudioPartial) **models.UpdateStrings { return &p.Aliases }),
+	idsField("tag_ids",
+		func(s *models.Studio) []int { return s.TagIDs.List() },
+		func(p *models.StudioPartial) **models.UpdateIDs { return &p.TagIDs }),
+	stashIDsField("stash_ids",
+		func(s *models.Studio) []models.StashID { return s.StashIDs.List() },
+		func(p *models.StudioPartial) **models.UpdateStashIDs { return &p.StashIDs }),
+}
+
+func newStudioTracker(r models.StudioReader, history models.EntityHistoryCreator) *studioTracker {
+	return &studioTracker{
+		entityType: models.HistoryEntityTypeStudio,
+		fields:     studioFields,
+		load: func(ctx context.Context, id int) (*models.Studio, error) {
+			s, err := r.Find(ctx, id)
+			if err != nil || s == nil {
+				return nil, err
+			}
+
+			if err := utils.Do([]func() error{
+				func() error { return s.LoadAliases(ctx, r) },
+				func() error { return s.LoadTagIDs(ctx, r) },
+				func() error { return s.LoadStashIDs(ctx, r) },
+			}); err != nil {
+				return nil, err
+			}
+
+			return s, nil
+		},
+		history: history,
+	}
+}
+
+type studioRecorder struct {
+	models.StudioReaderWriter
+	tracker *studioTracker
+}
+
+func (r *studioRecorder) Update(ctx context.Context, updatedStudio *models.Studio) error {
+	return r.tracker.record(ctx, updatedStudio.ID, func() error {
+		return r.StudioReaderWriter.Update(ctx, updatedStudio)
+	})
+}
+
+func (r *studioRecorder) UpdatePartial(ctx context.Context, updatedStudio models.StudioPartial) (*models.Studio, error) {
+	var ret *models.Studio
+	err := r.tracker.recordPartial(ctx, updatedStudio.ID, updatedStudio, func() error {
+		var err error
+		ret, err = r.StudioReaderWriter.UpdatePartial(ctx, updatedStudio)
+		return err
+	})
+
+	return ret, err
+}
@@ -0,0 +1,105 @@
+package history
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type (
+	tagField   = field[models.Tag, models.TagPartial]
+	tagTracker = tracker[models.Tag, models.TagPartial]
+)
+
+var tagFields = []tagField{
+	stringField("name",
+		func(s *models.Tag) *string { return &s.Name },
+		func(p *models.TagPartial) *models.OptionalString { return &p.Name }),
+	stringField("description",
+		func(s *models.Tag) *string { return &s.Description },
+		func(p *models.TagPartial) *models.OptionalString { return &p.Description }),
+	boolField("favorite",
+		func(s *models.Tag) *bool { return &s.Favorite },
+		func(p *models.TagPartial) *models.OptionalBool { return &p.Favorite }),
+	boolField("ignore_auto_tag",
+		func(s *models.Tag) *bool { return &s.IgnoreAutoTag },
+		func(p *models.TagPartial) *models.OptionalBool { return &p.IgnoreAutoTag }),
+	stringsField("aliases",
+		func(s *models.Tag) []string { return s.Aliases.List() },
+		func(p *models.TagPartial) **models.UpdateStrings { return &p.Aliases }),
+	idsField("parent_ids",
+		func(s *models.Tag) []int { return s.ParentIDs.List() },
+		func(p *models.TagPartial) **models.UpdateIDs { return &p.ParentIDs }),
+	idsField("child_ids",
+		func(s *models.Tag) []int { return s.ChildIDs.List() },
+		func(p *models.TagPartial) **models.UpdateIDs { return &p.ChildIDs }),
+	stashIDsField("stash_ids",
+		func(s *models.Tag) []models.StashID { return s.StashIDs.List() },
+		func(p *models.TagPartial) **models.UpdateStashIDs { return &p.StashIDs }),
+}
+
+func newTagTracker(r models.TagReader, history models.EntityHistoryCreator) *tagTracker {
+	return &tagTracker{
+		entityType: models.HistoryEntityTypeTag,
+		fields:     tagFields,
+		load: func(ctx context.Context, id int) (*models.Tag, error) {
+			t, err := r.Find(ctx, id)
+			if err != nil || t == nil {
+				return nil, err
+			}
+
+			if err := utils.Do([]func() error{
+				func() error { return t.LoadAliases(ctx, r) },
+				func() error { return t.LoadParentIDs(ctx, r) },
+				func() error { return t.LoadChildIDs(ctx, r) },
+				func() error { return t.LoadStashIDs(ctx, r) },
+			}); err != nil {
+				return nil, err
+			}
+
+			return t, nil
+		},
+		history: history,
+	}
+}
+
+type tagRecorder struct {
+	models.TagReaderWriter
+	tracker *tagTracker
+}
+
+func (r *tagRecorder) Update(ctx context.Context, updatedTag *models.Tag) error {
+	return r.tracker.record(ctx, updatedTag.ID, func() error {
+		return r.TagReaderWriter.Update(ctx, updatedTag)
+	})
+}
+
+func (r *tagRecorder) UpdatePartial(ctx context.Context, id int, updateTag models.TagPartial) (*models.Tag, error) {
+	var ret *models.Tag
+	err := r.tracker.recordPartial(ctx, id, updateTag, func() error {
+		var err error
+		ret, err = r.TagReaderWriter.UpdatePartial(ctx, id, updateTag)
+		return err
+	})
+
+	return ret, err
+}
+
+func (r *tagRecorder) UpdateAliases(ctx context.Context, tagID int, aliases []string) error {
+	return r.tracker.record(ctx, tagID, func() error {
+		return r.TagReaderWriter.UpdateAliases(ctx, tagID, aliases)
+	})
+}
+
+func (r *tagRecorder) UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error {
+	return r.tracker.record(ctx, tagID, func() error {
+		return r.TagReaderWriter.UpdateParentTags(ctx, tagID, parentIDs)
+	})
+}
+
+func (r *tagRecorder) UpdateChildTags(ctx context.Context, tagID int, childIDs []int) error {
+	return r.tracker.record(ctx, tagID, func() error {
+		return r.TagReaderWriter.UpdateChildTags(ctx, tagID, childIDs)
+	})
+}
@@ -88,6 +88,17 @@ func (s *Service) deleteFiles(ctx context.Context, i *models.Image, fileDeleter
 		return err
 	}
 
+	if err := i.LoadURLs(ctx, s.Repository); err != nil {
+		return err
+	}
+
+	entity := models.TrashedEntity{
+		Type:     models.TrashedEntityTypeImage,
+		ID:       i.ID,
+		Title:    i.GetTitle(),
+		Metadata: ToBasicJSON(i),
+	}
+
 	for _, f := range i.Files.List() {
 		// only delete files where there is no other associated image
 		otherImages, err := s.Repository.FindByFileID(ctx, f.Base().ID)
@@ -101,10 +112,9 @@ func (s *Service) deleteFiles(ctx context.Context, i *models.Image, fileDeleter
 		}
 
 		// don't delete files in zip archives
-		const deleteFile = true
 		if f.Base().ZipFileID == nil {
 			logger.Info("Deleting image file: ", f.Base().Path)
-			if err := file.Destroy(ctx, s.File, f, fileDeleter.Deleter, deleteFile); err != nil {
+			if err := file.DestroyToTrash(ctx, s.File, f, fileDeleter.Deleter, entity); err != nil {
 				return err
 			}
 		}
@@ -3,6 +3,7 @@ package job
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 )
 
@@ -29,6 +30,17 @@ func MakeJobExec(fn JobExecFn) JobExec {
 	}
 }
 
+// Spec describes how to re-create a job, so that jobs that had not finished
+// when the application was stopped can be queued again.
+type Spec struct {
+	// Type identifies the kind of job to create.
+	Type string `json:"type"`
+	// Input is the job-specific input used to create the job.
+	Input       json.RawMessage `json:"input,omitempty"`
+	Description string          `json:"description"`
+	Priority    int             `json:"priority"`
+}
+
 // Status is the status of a Job
 type Status string
 
@@ -45,6 +57,9 @@ const (
 	StatusCancelled Status = "CANCELLED"
 	// StatusFailed means that the job failed.
 	StatusFailed Status = "FAILED"
+	// StatusPaused means that the job is paused. A paused job that has been
+	// started is blocked the next time it reports progress.
+	StatusPaused Status = "PAUSED"
 )
 
 // Job represents the status of a queued or running job.
@@ -60,10 +75,22 @@ type Job struct {
 	EndTime   *time.Time
 	AddTime   time.Time
 	Error     *string
+	// Jobs with a higher priority are started before jobs with a lower
+	// priority. Jobs with the same priority are started in the order that
+	// they were added.
+	Priority int
+	// ID of the scheduled task that queued the job. Empty if the job was not
+	// queued by a scheduled task.
+	ScheduledTaskID string
+	// summary counters reported by the job, such as the number of files
+	// scanned. Populated once the job has finished.
+	Counters map[string]int
 
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
+	progress   *Progress
+	spec       *Spec
 }
 
 // TimeElapsed returns the total time elapsed for the job.
@@ -80,9 +107,10 @@ func (j *Job) TimeElapsed() time.Duration {
 }
 
 func (j *Job) cancel() {
-	if j.Status == StatusReady {
+	switch {
+	case j.Status == StatusReady, j.Status == StatusPaused && j.StartTime == nil:
 		j.Status = StatusCancelled
-	} else if j.Status == StatusRunning {
+	case j.Status == StatusRunning, j.Status == StatusPaused:
 		j.Status = StatusStopping
 	}
 
@@ -26,8 +26,14 @@ type Manager struct {
 
 	subscriptions       []*ManagerSubscription
 	updateThrottleLimit time.Duration
+
+	recorder Recorder
 }
 
+// Recorder is called with a copy of each job once it has been removed from
+// the queue.
+type Recorder func(j Job)
+
 // NewManager initialises and returns a new Manager.
 func NewManager() *Manager {
 	ret := &Manager{
@@ -49,28 +55,50 @@ func (m *Manager) Stop() {
 	close(m.stop)
 }
 
-// Add queues a job.
+// SetRecorder sets the function that is called when a job is removed from
+// the queue. The function is called in a separate goroutine.
+func (m *Manager) SetRecorder(r Recorder) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.recorder = r
+}
+
+// Add queues a job. The priority of the job is set using WithPriority on
+// the provided context, defaulting to PriorityNormal.
 func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
+	return m.add(ctx, description, e, nil)
+}
+
+// AddResumable queues a job in the same way as Add. If the job has not
+// finished when GetResumable is called, then the provided spec is returned
+// so that the job can be re-created later.
+func (m *Manager) AddResumable(ctx context.Context, description string, e JobExec, spec Spec) int {
+	return m.add(ctx, description, e, &spec)
+}
+
+func (m *Manager) add(ctx context.Context, description string, e JobExec, spec *Spec) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	t := time.Now()
 
 	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+		ID:              m.nextID(),
+		Status:          StatusReady,
+		Description:     description,
+		AddTime:         t,
+		Priority:        priorityFromContext(ctx),
+		ScheduledTaskID: scheduledTaskFromContext(ctx),
+		exec:            e,
+		outerCtx:        ctx,
+		spec:            spec,
 	}
 
 	m.queue = append(m.queue, &j)
 
-	if len(m.queue) == 1 {
-		// notify that there is now a job in the queue
-		m.notEmpty.Broadcast()
-	}
+	// notify that there is now a job ready in the queue
+	m.notEmpty.Broadcast()
 
 	m.notifyNewJob(&j)
 
@@ -86,17 +114,27 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 	t := time.Now()
 
 	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+		ID:              m.nextID(),
+		Status:          StatusReady,
+		Description:     description,
+		AddTime:         t,
+		Priority:        priorityFromContext(ctx),
+		ScheduledTaskID: scheduledTaskFromContext(ctx),
+		exec:            e,
+		outerCtx:        ctx,
 	}
 
 	m.queue = append(m.queue, &j)
 
-	m.dispatch(ctx, &j)
+	done := m.dispatch(ctx, &j)
+
+	go func() {
+		<-done
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.removeJob(&j)
+	}()
 
 	return j.ID
 }
@@ -119,13 +157,15 @@ func (m *Manager) nextID() int {
 
 func (m *Manager) getReadyJob() *Job {
 	// assumes lock held
+	// returns the first ready job with the highest priority
+	var ret *Job
 	for _, j := range m.queue {
-		if j.Status == StatusReady {
-			return j
+		if j.Status == StatusReady && (ret == nil || j.Priority > ret.Priority) {
+			ret = j
 		}
 	}
 
-	return nil
+	return ret
 }
 
 func (m *Manager) dispatcher() {
@@ -163,13 +203,14 @@ func (m *Manager) dispatcher() {
 	}
 }
 
-func (m *Manager) newProgress(j *Job) *Progress {
+func (m *Manager) newProgress(ctx context.Context, j *Job) *Progress {
 	return &Progress{
 		updater: &updater{
 			m:   m,
 			job: j,
 		},
 		percent: ProgressIndefinite,
+		done:    ctx.Done(),
 	}
 }
 
@@ -181,18 +222,19 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 
 	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
 	j.cancelFunc = cancelFunc
+	j.progress = m.newProgress(ctx, j)
 
 	done = make(chan struct{})
-	go m.executeJob(ctx, j, done)
+	go m.executeJob(ctx, j, j.progress, done)
 
 	m.notifyJobUpdate(j)
 
 	return
 }
 
-func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
+func (m *Manager) executeJob(ctx context.Context, j *Job, progress *Progress, done chan struct{}) {
 	defer close(done)
-	defer m.onJobFinish(j)
+	defer m.onJobFinish(j, progress)
 	defer func() {
 		if p := recover(); p != nil {
 			// a panic occurred, log and mark the job as failed
@@ -205,20 +247,27 @@ func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 		}
 	}()
 
-	progress := m.newProgress(j)
 	if err := j.exec.Execute(ctx, progress); err != nil {
 		logger.Errorf("task failed due to error: %v", err)
+
+		m.mutex.Lock()
 		j.error(err)
+		m.mutex.Unlock()
 	}
 }
 
-func (m *Manager) onJobFinish(job *Job) {
+func (m *Manager) onJobFinish(job *Job, progress *Progress) {
+	counters := progress.getCounters()
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	job.Counters = counters
+
 	if job.Status == StatusStopping {
 		job.Status = StatusCancelled
 	} else if job.Status != StatusFailed {
+		// includes jobs that finished while paused
 		job.Status = StatusFinished
 	}
 	t := time.Now()
@@ -250,6 +299,10 @@ func (m *Manager) removeJob(job *Job) {
 		default:
 		}
 	}
+
+	if m.recorder != nil {
+		go m.recorder(*job)
+	}
 }
 
 func (m *Manager) getJob(list []*Job, id int) (index int, job *Job) {
@@ -284,6 +337,89 @@ func (m *Manager) CancelJob(id int) {
 	}
 }
 
+// PauseJob pauses the job with the provided id. Jobs that have not yet
+// started are not started until resumed. Jobs that have been started are
+// blocked the next time they report progress. There is no effect if the job
+// does not exist or is not ready or running.
+func (m *Manager) PauseJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil || (j.Status != StatusReady && j.Status != StatusRunning) {
+		return
+	}
+
+	if j.progress != nil {
+		j.progress.pause()
+	}
+
+	j.Status = StatusPaused
+	m.notifyJobUpdate(j)
+}
+
+// ResumeJob resumes the paused job with the provided id. There is no effect
+// if the job does not exist or is not paused.
+func (m *Manager) ResumeJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil || j.Status != StatusPaused {
+		return
+	}
+
+	if j.StartTime == nil {
+		j.Status = StatusReady
+		m.notEmpty.Broadcast()
+	} else {
+		j.Status = StatusRunning
+		j.progress.resume()
+	}
+
+	m.notifyJobUpdate(j)
+}
+
+// SetPriority sets the priority of the job with the provided id. This only
+// affects jobs that have not yet been started. There is no effect if the job
+// does not exist.
+func (m *Manager) SetPriority(id int, priority int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return
+	}
+
+	j.Priority = priority
+	m.notifyJobUpdate(j)
+}
+
+// GetResumable returns the specs of the resumable jobs in the queue that have
+// not finished or been cancelled.
+func (m *Manager) GetResumable() []Spec {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var ret []Spec
+	for _, j := range m.queue {
+		if j.spec == nil {
+			continue
+		}
+
+		switch j.Status {
+		case StatusReady, StatusRunning, StatusPaused:
+			spec := *j.spec
+			spec.Description = j.Description
+			spec.Priority = j.Priority
+			ret = append(ret, spec)
+		}
+	}
+
+	return ret
+}
+
 // CancelAll cancels all of the jobs in the queue. This is the same as
 // calling CancelJob on all jobs in the queue.
 func (m *Manager) CancelAll() {
@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -236,6 +237,194 @@ func TestCancelAll(t *testing.T) {
 	}
 }
 
+func TestPriority(t *testing.T) {
+	m := NewManager()
+
+	// block the queue while adding jobs
+	exec1 := newTestExec(make(chan struct{}))
+	m.Add(context.Background(), "blocking job", exec1)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	lowExec := newTestExec(nil)
+	lowID := m.Add(WithPriority(context.Background(), PriorityLow), "low job", lowExec)
+
+	normalExec := newTestExec(make(chan struct{}))
+	normalID := m.Add(context.Background(), "normal job", normalExec)
+
+	highExec := newTestExec(make(chan struct{}))
+	m.Add(WithPriority(context.Background(), PriorityHigh), "high job", highExec)
+
+	assert := assert.New(t)
+	assert.Equal(PriorityLow, m.GetJob(lowID).Priority)
+	assert.Equal(PriorityNormal, m.GetJob(normalID).Priority)
+
+	// allow first job to finish
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect high priority job to have started
+	select {
+	case <-highExec.started:
+		// ok
+	default:
+		t.Error("high priority exec was not started")
+	}
+
+	close(highExec.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect normal priority job to have started before the low priority job
+	select {
+	case <-normalExec.started:
+		// ok
+	default:
+		t.Error("normal priority exec was not started")
+	}
+
+	select {
+	case <-lowExec.started:
+		t.Error("low priority exec was started")
+	default:
+	}
+
+	close(normalExec.finish)
+}
+
+func TestPauseResume(t *testing.T) {
+	m := NewManager()
+
+	// add two jobs
+	exec1 := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	exec2 := newTestExec(nil)
+	job2ID := m.Add(context.Background(), "other job", exec2)
+
+	<-exec1.started
+
+	m.PauseJob(jobID)
+	m.PauseJob(job2ID)
+
+	assert := assert.New(t)
+	assert.Equal(StatusPaused, m.GetJob(jobID).Status)
+	assert.Equal(StatusPaused, m.GetJob(job2ID).Status)
+
+	// reporting progress should block while paused
+	incremented := make(chan struct{})
+	go func() {
+		exec1.progress.Increment()
+		close(incremented)
+	}()
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	select {
+	case <-incremented:
+		t.Error("progress was reported while paused")
+	default:
+	}
+
+	m.ResumeJob(jobID)
+
+	select {
+	case <-incremented:
+		// ok
+	case <-time.After(time.Second):
+		t.Error("progress was not reported after resume")
+	}
+
+	assert.Equal(StatusRunning, m.GetJob(jobID).Status)
+
+	// allow first job to finish
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect paused job to not have been started
+	select {
+	case <-exec2.started:
+		t.Error("paused exec was started")
+	default:
+	}
+
+	m.ResumeJob(job2ID)
+
+	select {
+	case <-exec2.started:
+		// ok
+	case <-time.After(time.Second):
+		t.Error("resumed exec was not started")
+	}
+}
+
+func TestPauseCancel(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	<-exec1.started
+
+	m.PauseJob(jobID)
+
+	incremented := make(chan struct{})
+	go func() {
+		exec1.progress.Increment()
+		close(incremented)
+	}()
+
+	// cancelling should unblock the paused job
+	m.CancelJob(jobID)
+
+	select {
+	case <-incremented:
+		// ok
+	case <-time.After(time.Second):
+		t.Error("paused job was not unblocked by cancel")
+	}
+
+	assert.Equal(t, StatusStopping, m.GetJob(jobID).Status)
+
+	close(exec1.finish)
+}
+
+func TestGetResumable(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	m.AddResumable(context.Background(), "test job", exec1, Spec{Type: "test"})
+
+	m.Add(context.Background(), "other job", newTestExec(nil))
+
+	exec3 := newTestExec(nil)
+	job3ID := m.AddResumable(WithPriority(context.Background(), PriorityLow), "low job", exec3, Spec{Type: "low"})
+
+	cancelledID := m.AddResumable(context.Background(), "cancelled job", newTestExec(nil), Spec{Type: "cancelled"})
+	m.CancelJob(cancelledID)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+	assert.Equal([]Spec{
+		{Type: "test", Description: "test job", Priority: PriorityNormal},
+		{Type: "low", Description: "low job", Priority: PriorityLow},
+	}, m.GetResumable())
+
+	m.SetPriority(job3ID, PriorityHigh)
+	assert.Equal(PriorityHigh, m.GetResumable()[1].Priority)
+
+	close(exec1.finish)
+}
+
 func TestSubscribe(t *testing.T) {
 	m := NewManager()
 
@@ -343,3 +532,67 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+func TestRecorder(t *testing.T) {
+	m := NewManager()
+
+	recorded := make(chan Job, 1)
+	m.SetRecorder(func(j Job) {
+		recorded <- j
+	})
+
+	const jobName = "test job"
+	const scheduledTaskID = "nightly-scan"
+	ctx := WithScheduledTask(context.Background(), scheduledTaskID)
+	jobID := m.Add(ctx, jobName, MakeJobExec(func(ctx context.Context, progress *Progress) error {
+		progress.AddCounter("scanned", 2)
+		progress.AddCounter("scanned", 1)
+		progress.AddCounter("added", 1)
+		return errors.New("test error")
+	}))
+
+	assert := assert.New(t)
+
+	select {
+	case j := <-recorded:
+		assert.Equal(jobID, j.ID)
+		assert.Equal(jobName, j.Description)
+		assert.Equal(scheduledTaskID, j.ScheduledTaskID)
+		assert.Equal(StatusFailed, j.Status)
+		assert.NotNil(j.StartTime)
+		assert.NotNil(j.EndTime)
+		if assert.NotNil(j.Error) {
+			assert.Equal("test error", *j.Error)
+		}
+		assert.Equal(map[string]int{"scanned": 3, "added": 1}, j.Counters)
+	case <-time.After(time.Second):
+		t.Error("job was not recorded")
+	}
+}
+
+func TestStartRemovesFinishedJob(t *testing.T) {
+	m := NewManager()
+
+	recorded := make(chan Job, 1)
+	m.SetRecorder(func(j Job) {
+		recorded <- j
+	})
+
+	jobID := m.Start(context.Background(), "started job", MakeJobExec(func(ctx context.Context, progress *Progress) error {
+		return nil
+	}))
+
+	select {
+	case j := <-recorded:
+		assert.Equal(t, jobID, j.ID)
+		assert.Equal(t, StatusFinished, j.Status)
+	case <-time.After(time.Second):
+		t.Error("job was not recorded")
+	}
+
+	// the job is moved from the queue to the graveyard
+	assert.Empty(t, m.GetQueue())
+	if j := m.GetJob(jobID); assert.NotNil(t, j) {
+		assert.Equal(t, StatusFinished, j.Status)
+	}
+}
@@ -0,0 +1,26 @@
+package job
+
+import "context"
+
+// Standard job priorities. Any integer value may be used.
+const (
+	PriorityLow    = -10
+	PriorityNormal = 0
+	PriorityHigh   = 10
+)
+
+type priorityCtxKey struct{}
+
+// WithPriority returns a context that causes jobs added with it to be queued
+// with the provided priority.
+func WithPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, priorityCtxKey{}, priority)
+}
+
+func priorityFromContext(ctx context.Context) int {
+	if v, ok := ctx.Value(priorityCtxKey{}).(int); ok {
+		return v
+	}
+
+	return PriorityNormal
+}
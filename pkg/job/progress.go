@@ -7,16 +7,24 @@ import "sync"
 const ProgressIndefinite float64 = -1
 
 // Progress is used by JobExec to communicate updates to the job's progress to
-// the JobManager.
+// the JobManager. If the job is paused, then calls that report work done block
+// until the job is resumed or cancelled.
 type Progress struct {
 	defined      bool
 	processed    int
 	total        int
 	percent      float64
 	currentTasks []*task
+	counters     map[string]int
 
 	mutex   sync.Mutex
 	updater *updater
+
+	pauseMutex sync.Mutex
+	// closed when the job is resumed. nil if the job is not paused.
+	resumed chan struct{}
+	// closed when the job is cancelled
+	done <-chan struct{}
 }
 
 type task struct {
@@ -32,6 +40,42 @@ func (p *Progress) updated() {
 	p.updater.updateProgress(p.percent, details)
 }
 
+func (p *Progress) pause() {
+	p.pauseMutex.Lock()
+	defer p.pauseMutex.Unlock()
+
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+	}
+}
+
+func (p *Progress) resume() {
+	p.pauseMutex.Lock()
+	defer p.pauseMutex.Unlock()
+
+	if p.resumed != nil {
+		close(p.resumed)
+		p.resumed = nil
+	}
+}
+
+// waitIfPaused blocks while the job is paused, returning once the job is
+// resumed or cancelled.
+func (p *Progress) waitIfPaused() {
+	p.pauseMutex.Lock()
+	resumed := p.resumed
+	p.pauseMutex.Unlock()
+
+	if resumed == nil {
+		return
+	}
+
+	select {
+	case <-resumed:
+	case <-p.done:
+	}
+}
+
 // Indefinite sets the progress to an indefinite amount.
 func (p *Progress) Indefinite() {
 	p.mutex.Lock()
@@ -75,6 +119,8 @@ func (p *Progress) AddTotal(total int) {
 // SetProcessed sets the number of work units completed. This is used to
 // calculate the progress percentage.
 func (p *Progress) SetProcessed(processed int) {
+	p.waitIfPaused()
+
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -102,6 +148,8 @@ func (p *Progress) calculatePercent() {
 // overwritten if Indefinite, SetTotal, Increment or SetProcessed is called.
 // Constrains the percent value between 0 and 1, inclusive.
 func (p *Progress) SetPercent(percent float64) {
+	p.waitIfPaused()
+
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -118,6 +166,8 @@ func (p *Progress) SetPercent(percent float64) {
 // Increment increments the number of processed work units. This is used to calculate the percentage.
 // If total is set already, then the number of processed work units will not exceed the total.
 func (p *Progress) Increment() {
+	p.waitIfPaused()
+
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -130,6 +180,8 @@ func (p *Progress) Increment() {
 // AddProcessed increments the number of processed work units by the provided
 // amount. This is used to calculate the percentage.
 func (p *Progress) AddProcessed(v int) {
+	p.waitIfPaused()
+
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
@@ -142,6 +194,35 @@ func (p *Progress) AddProcessed(v int) {
 	p.calculatePercent()
 }
 
+// AddCounter adds n to the named summary counter. Counters are stored with
+// the job once it has finished.
+func (p *Progress) AddCounter(name string, n int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.counters == nil {
+		p.counters = make(map[string]int)
+	}
+
+	p.counters[name] += n
+}
+
+func (p *Progress) getCounters() map[string]int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if len(p.counters) == 0 {
+		return nil
+	}
+
+	ret := make(map[string]int, len(p.counters))
+	for k, v := range p.counters {
+		ret[k] = v
+	}
+
+	return ret
+}
+
 func (p *Progress) addTask(t *task) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -166,6 +247,8 @@ func (p *Progress) removeTask(t *task) {
 // ExecuteTask executes a task as part of a job. The description is used to
 // populate the Details slice in the parent Job.
 func (p *Progress) ExecuteTask(description string, fn func()) {
+	p.waitIfPaused()
+
 	t := &task{
 		description: description,
 	}
@@ -0,0 +1,16 @@
+package job
+
+import "context"
+
+type scheduledTaskCtxKey struct{}
+
+// WithScheduledTask returns a context that causes jobs added with it to be
+// recorded as queued by the scheduled task with the provided ID.
+func WithScheduledTask(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, scheduledTaskCtxKey{}, id)
+}
+
+func scheduledTaskFromContext(ctx context.Context) string {
+	v, _ := ctx.Value(scheduledTaskCtxKey{}).(string)
+	return v
+}
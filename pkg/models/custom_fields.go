@@ -0,0 +1,201 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxCustomFieldNameLength is the maximum length of the name of a custom field.
+const MaxCustomFieldNameLength = 64
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeString CustomFieldType = "STRING"
+	CustomFieldTypeInt    CustomFieldType = "INT"
+	CustomFieldTypeFloat  CustomFieldType = "FLOAT"
+	CustomFieldTypeBool   CustomFieldType = "BOOL"
+	CustomFieldTypeDate   CustomFieldType = "DATE"
+)
+
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeString,
+	CustomFieldTypeInt,
+	CustomFieldTypeFloat,
+	CustomFieldTypeBool,
+	CustomFieldTypeDate,
+}
+
+func (e CustomFieldType) IsValid() bool {
+	switch e {
+	case CustomFieldTypeString, CustomFieldTypeInt, CustomFieldTypeFloat, CustomFieldTypeBool, CustomFieldTypeDate:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// CustomField is a user-defined field of an entity.
+type CustomField struct {
+	Field string          `json:"field"`
+	Type  CustomFieldType `json:"type"`
+	// Value is a string, int64, float64 or bool, depending on Type.
+	// Dates are strings in YYYY-MM-DD format.
+	Value interface{} `json:"value"`
+}
+
+var (
+	ErrCustomFieldName  = errors.New("custom field name must not be empty")
+	ErrCustomFieldValue = errors.New("custom field value does not match its type")
+)
+
+// Normalize validates the custom field, and returns a copy with the value
+// converted to the Go type of the field type. Numbers may be provided as
+// any integer or float type, or as a json.Number.
+func (f CustomField) Normalize() (CustomField, error) {
+	f.Field = strings.TrimSpace(f.Field)
+	if f.Field == "" {
+		return f, ErrCustomFieldName
+	}
+	if len(f.Field) > MaxCustomFieldNameLength {
+		return f, fmt.Errorf("custom field name %q is longer than %d characters", f.Field, MaxCustomFieldNameLength)
+	}
+
+	if !f.Type.IsValid() {
+		return f, fmt.Errorf("custom field %q: invalid type %q", f.Field, f.Type)
+	}
+
+	v, err := normalizeCustomFieldValue(f.Type, f.Value)
+	if err != nil {
+		return f, fmt.Errorf("custom field %q: %w", f.Field, err)
+	}
+	f.Value = v
+
+	return f, nil
+}
+
+func normalizeCustomFieldValue(t CustomFieldType, v interface{}) (interface{}, error) {
+	switch t {
+	case CustomFieldTypeString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case CustomFieldTypeInt:
+		if i, ok := customFieldInt(v); ok {
+			return i, nil
+		}
+	case CustomFieldTypeFloat:
+		if f, ok := customFieldNumber(v); ok {
+			return f, nil
+		}
+	case CustomFieldTypeBool:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case CustomFieldTypeDate:
+		if s, ok := v.(string); ok {
+			d, err := ParseDate(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid date %q: %w", s, err)
+			}
+			return d.String(), nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: expected %s, got %v", ErrCustomFieldValue, t, v)
+}
+
+func customFieldInt(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i, true
+		}
+	}
+
+	// allow integral floats, since JSON numbers are decoded as float64
+	f, ok := customFieldNumber(v)
+	if ok && f == math.Trunc(f) && math.Abs(f) <= 1<<53 {
+		return int64(f), true
+	}
+
+	return 0, false
+}
+
+func customFieldNumber(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+type CustomFieldsInput struct {
+	// Full replaces all custom fields of the entity. Applied before the
+	// other fields.
+	Full []CustomField `json:"full"`
+	// Partial sets the provided custom fields, leaving the others unchanged.
+	Partial []CustomField `json:"partial"`
+	// Remove removes the custom fields with the provided names.
+	Remove []string `json:"remove"`
+}
+
+type CustomFieldCriterionInput struct {
+	Field    string            `json:"field"`
+	Value    interface{}       `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
+
+// CustomFieldsReader provides methods to get the custom fields of an entity.
+type CustomFieldsReader interface {
+	GetCustomFields(ctx context.Context, id int) ([]CustomField, error)
+}
+
+// CustomFieldsWriter provides methods to set the custom fields of an entity.
+type CustomFieldsWriter interface {
+	SetCustomFields(ctx context.Context, id int, input CustomFieldsInput) error
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCustomFieldNormalize(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   CustomField
+		want    interface{}
+		wantErr bool
+	}{
+		{"string", CustomField{" name ", CustomFieldTypeString, "value"}, "value", false},
+		{"string from int", CustomField{"name", CustomFieldTypeString, 1}, nil, true},
+		{"int", CustomField{"name", CustomFieldTypeInt, 1}, int64(1), false},
+		{"int from integral float", CustomField{"name", CustomFieldTypeInt, 2.0}, int64(2), false},
+		{"int from json number", CustomField{"name", CustomFieldTypeInt, json.Number("3")}, int64(3), false},
+		{"int from fractional float", CustomField{"name", CustomFieldTypeInt, 2.5}, nil, true},
+		{"float", CustomField{"name", CustomFieldTypeFloat, 2.5}, 2.5, false},
+		{"float from int", CustomField{"name", CustomFieldTypeFloat, 2}, 2.0, false},
+		{"bool", CustomField{"name", CustomFieldTypeBool, true}, true, false},
+		{"bool from string", CustomField{"name", CustomFieldTypeBool, "true"}, nil, true},
+		{"date", CustomField{"name", CustomFieldTypeDate, "2024-01-02"}, "2024-01-02", false},
+		{"invalid date", CustomField{"name", CustomFieldTypeDate, "yesterday"}, nil, true},
+		{"empty name", CustomField{" ", CustomFieldTypeString, "value"}, nil, true},
+		{"long name", CustomField{strings.Repeat("a", MaxCustomFieldNameLength+1), CustomFieldTypeString, "value"}, nil, true},
+		{"invalid type", CustomField{"name", CustomFieldType("LIST"), "value"}, nil, true},
+		{"nil value", CustomField{"name", CustomFieldTypeString, nil}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CustomField.Normalize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Field != "name" {
+				t.Errorf("CustomField.Normalize() field = %q, want %q", got.Field, "name")
+			}
+			if got.Value != tt.want {
+				t.Errorf("CustomField.Normalize() value = %#v, want %#v", got.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomFieldNormalizeValueError(t *testing.T) {
+	_, err := CustomField{"name", CustomFieldTypeInt, "one"}.Normalize()
+	if !errors.Is(err, ErrCustomFieldValue) {
+		t.Errorf("CustomField.Normalize() error = %v, want %v", err, ErrCustomFieldValue)
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import "context"
+
+type EntityHistoryReader interface {
+	Find(ctx context.Context, id int) (*EntityHistory, error)
+	// FindByEntity returns the history of the provided entity, most recent
+	// change first.
+	FindByEntity(ctx context.Context, entityType HistoryEntityType, entityID int) ([]*EntityHistory, error)
+}
+
+type EntityHistoryCreator interface {
+	Create(ctx context.Context, obj *EntityHistory) error
+}
+
+type EntityHistoryReaderWriter interface {
+	EntityHistoryReader
+	EntityHistoryCreator
+}
+
+type historySourceCtxKey struct{}
+
+// WithHistorySource returns a context that causes changes to be recorded in
+// the entity history with the provided source.
+func WithHistorySource(ctx context.Context, source HistorySource) context.Context {
+	return context.WithValue(ctx, historySourceCtxKey{}, source)
+}
+
+// HistorySourceFromContext returns the source set by WithHistorySource.
+// Returns HistorySourceSystem if no source is set.
+func HistorySourceFromContext(ctx context.Context) HistorySource {
+	if v, ok := ctx.Value(historySourceCtxKey{}).(HistorySource); ok {
+		return v
+	}
+
+	return HistorySourceSystem
+}
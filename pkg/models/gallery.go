@@ -63,6 +63,8 @@ type GalleryFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type GalleryUpdateInput struct {
@@ -84,6 +86,8 @@ type GalleryUpdateInput struct {
 
 	// deprecated
 	URL *string `json:"url"`
+
+	CustomFields *CustomFieldsInput `json:"custom_fields"`
 }
 
 type GalleryDestroyInput struct {
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type JobHistoryFilter struct {
+	// filter by job status
+	Status []string `json:"status"`
+}
+
+type JobHistoryReader interface {
+	Query(ctx context.Context, jobFilter *JobHistoryFilter, findFilter *FindFilterType) ([]*JobHistory, int, error)
+	// LastScheduledTaskRuns returns the most recent time that each scheduled
+	// task queued a job, keyed by scheduled task ID.
+	LastScheduledTaskRuns(ctx context.Context) (map[string]time.Time, error)
+}
+
+type JobHistoryWriter interface {
+	Create(ctx context.Context, obj *JobHistory) error
+	// DestroyBefore removes all records that finished before the provided time.
+	DestroyBefore(ctx context.Context, t time.Time) error
+}
+
+type JobHistoryReaderWriter interface {
+	JobHistoryReader
+	JobHistoryWriter
+}
@@ -77,14 +77,15 @@ type Fingerprint struct {
 
 type VideoFile struct {
 	*BaseFile
-	Format     string  `json:"format,omitempty"`
-	Width      int     `json:"width,omitempty"`
-	Height     int     `json:"height,omitempty"`
-	Duration   float64 `json:"duration,omitempty"`
-	VideoCodec string  `json:"video_codec,omitempty"`
-	AudioCodec string  `json:"audio_codec,omitempty"`
-	FrameRate  float64 `json:"frame_rate,omitempty"`
-	BitRate    int64   `json:"bitrate,omitempty"`
+	Format        string  `json:"format,omitempty"`
+	Width         int     `json:"width,omitempty"`
+	Height        int     `json:"height,omitempty"`
+	Duration      float64 `json:"duration,omitempty"`
+	VideoCodec    string  `json:"video_codec,omitempty"`
+	AudioCodec    string  `json:"audio_codec,omitempty"`
+	AudioChannels int     `json:"audio_channels,omitempty"`
+	FrameRate     float64 `json:"frame_rate,omitempty"`
+	BitRate       int64   `json:"bitrate,omitempty"`
 
 	Interactive      bool `json:"interactive,omitempty"`
 	InteractiveSpeed *int `json:"interactive_speed,omitempty"`
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
@@ -33,6 +34,8 @@ type Group struct {
 	CreatedAt  json.JSONTime         `json:"created_at,omitempty"`
 	UpdatedAt  json.JSONTime         `json:"updated_at,omitempty"`
 
+	CustomFields []models.CustomField `json:"custom_fields,omitempty"`
+
 	// deprecated - for import only
 	URL string `json:"url,omitempty"`
 }
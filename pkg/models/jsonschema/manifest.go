@@ -0,0 +1,95 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models/json"
+)
+
+// Manifest describes an export of the metadata directory. The maps of the
+// manifest are keyed by the name of the directory containing the JSON files
+// of the object type, for example "scenes" or "movies".
+type Manifest struct {
+	// ExportedAt is the time that the export was started.
+	ExportedAt json.JSONTime `json:"exported_at"`
+	// Since is the start time of the export that an incremental export is
+	// based on. It is not set for full exports.
+	Since *json.JSONTime `json:"since,omitempty"`
+
+	// Changed contains the names of the JSON files written by an
+	// incremental export.
+	Changed map[string][]string `json:"changed,omitempty"`
+	// Deleted contains the objects deleted since the previous export.
+	Deleted map[string][]ManifestRef `json:"deleted,omitempty"`
+	// Renamed contains the objects whose name changed since the previous
+	// export.
+	Renamed map[string][]ManifestRename `json:"renamed,omitempty"`
+
+	// Objects contains the exported objects by id. It is used to find the
+	// changed and deleted objects in the next incremental export.
+	Objects map[string]map[int]ManifestObject `json:"objects,omitempty"`
+}
+
+// IsIncremental returns true if the manifest describes an incremental export.
+func (m Manifest) IsIncremental() bool {
+	return m.Since != nil
+}
+
+type ManifestObject struct {
+	// Filename is the name of the JSON file of the object.
+	Filename string      `json:"filename"`
+	Ref      ManifestRef `json:"ref"`
+}
+
+// ManifestRef identifies an object in the same way as the importer of the
+// object type. Performers, studios, tags and groups are identified by name,
+// scenes and images by file paths.
+type ManifestRef struct {
+	Name           string      `json:"name,omitempty"`
+	Disambiguation string      `json:"disambiguation,omitempty"`
+	Files          []string    `json:"files,omitempty"`
+	Gallery        *GalleryRef `json:"gallery,omitempty"`
+}
+
+func (r ManifestRef) String() string {
+	switch {
+	case r.Gallery != nil:
+		return r.Gallery.String()
+	case len(r.Files) > 0:
+		return fmt.Sprint(r.Files)
+	case r.Disambiguation != "":
+		return r.Name + " (" + r.Disambiguation + ")"
+	default:
+		return r.Name
+	}
+}
+
+type ManifestRename struct {
+	From ManifestRef `json:"from"`
+	To   ManifestRef `json:"to"`
+}
+
+func LoadManifestFile(filePath string) (*Manifest, error) {
+	var manifest Manifest
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&manifest)
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func SaveManifestFile(filePath string, manifest *Manifest) error {
+	if manifest == nil {
+		return fmt.Errorf("manifest must not be nil")
+	}
+	return marshalToFile(filePath, manifest)
+}
@@ -24,6 +24,8 @@ type Studio struct {
 	StashIDs      []models.StashID `json:"stash_ids,omitempty"`
 	Tags          []string         `json:"tags,omitempty"`
 	IgnoreAutoTag bool             `json:"ignore_auto_tag,omitempty"`
+
+	CustomFields []models.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (s Studio) Filename() string {
@@ -6,19 +6,23 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
 type Tag struct {
-	Name          string        `json:"name,omitempty"`
-	Description   string        `json:"description,omitempty"`
-	Favorite      bool          `json:"favorite,omitempty"`
-	Aliases       []string      `json:"aliases,omitempty"`
-	Image         string        `json:"image,omitempty"`
-	Parents       []string      `json:"parents,omitempty"`
-	IgnoreAutoTag bool          `json:"ignore_auto_tag,omitempty"`
-	CreatedAt     json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt     json.JSONTime `json:"updated_at,omitempty"`
+	Name          string           `json:"name,omitempty"`
+	Description   string           `json:"description,omitempty"`
+	Favorite      bool             `json:"favorite,omitempty"`
+	Aliases       []string         `json:"aliases,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	Parents       []string         `json:"parents,omitempty"`
+	StashIDs      []models.StashID `json:"stash_ids,omitempty"`
+	IgnoreAutoTag bool             `json:"ignore_auto_tag,omitempty"`
+	CreatedAt     json.JSONTime    `json:"created_at,omitempty"`
+	UpdatedAt     json.JSONTime    `json:"updated_at,omitempty"`
+
+	CustomFields []models.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (s Tag) Filename() string {
@@ -10,18 +10,23 @@ import (
 )
 
 type Database struct {
-	File           *FileReaderWriter
-	Folder         *FolderReaderWriter
-	Gallery        *GalleryReaderWriter
-	GalleryChapter *GalleryChapterReaderWriter
-	Image          *ImageReaderWriter
-	Group          *GroupReaderWriter
-	Performer      *PerformerReaderWriter
-	Scene          *SceneReaderWriter
-	SceneMarker    *SceneMarkerReaderWriter
-	Studio         *StudioReaderWriter
-	Tag            *TagReaderWriter
-	SavedFilter    *SavedFilterReaderWriter
+	File            *FileReaderWriter
+	Folder          *FolderReaderWriter
+	Gallery         *GalleryReaderWriter
+	GalleryChapter  *GalleryChapterReaderWriter
+	Image           *ImageReaderWriter
+	Group           *GroupReaderWriter
+	Performer       *PerformerReaderWriter
+	Scene           *SceneReaderWriter
+	SceneMarker     *SceneMarkerReaderWriter
+	Studio          *StudioReaderWriter
+	Tag             *TagReaderWriter
+	SavedFilter     *SavedFilterReaderWriter
+	JobHistory      *JobHistoryReaderWriter
+	WebhookDelivery *WebhookDeliveryReaderWriter
+	User            *UserReaderWriter
+	TrashedFile     *TrashedFileReaderWriter
+	EntityHistory   *EntityHistoryReaderWriter
 }
 
 func (*Database) Begin(ctx context.Context, exclusive bool) (context.Context, error) {
@@ -59,18 +64,23 @@ func (*Database) Reset() error {
 
 func NewDatabase() *Database {
 	return &Database{
-		File:           &FileReaderWriter{},
-		Folder:         &FolderReaderWriter{},
-		Gallery:        &GalleryReaderWriter{},
-		GalleryChapter: &GalleryChapterReaderWriter{},
-		Image:          &ImageReaderWriter{},
-		Group:          &GroupReaderWriter{},
-		Performer:      &PerformerReaderWriter{},
-		Scene:          &SceneReaderWriter{},
-		SceneMarker:    &SceneMarkerReaderWriter{},
-		Studio:         &StudioReaderWriter{},
-		Tag:            &TagReaderWriter{},
-		SavedFilter:    &SavedFilterReaderWriter{},
+		File:            &FileReaderWriter{},
+		Folder:          &FolderReaderWriter{},
+		Gallery:         &GalleryReaderWriter{},
+		GalleryChapter:  &GalleryChapterReaderWriter{},
+		Image:           &ImageReaderWriter{},
+		Group:           &GroupReaderWriter{},
+		Performer:       &PerformerReaderWriter{},
+		Scene:           &SceneReaderWriter{},
+		SceneMarker:     &SceneMarkerReaderWriter{},
+		Studio:          &StudioReaderWriter{},
+		Tag:             &TagReaderWriter{},
+		SavedFilter:     &SavedFilterReaderWriter{},
+		JobHistory:      &JobHistoryReaderWriter{},
+		WebhookDelivery: &WebhookDeliveryReaderWriter{},
+		User:            &UserReaderWriter{},
+		TrashedFile:     &TrashedFileReaderWriter{},
+		EntityHistory:   &EntityHistoryReaderWriter{},
 	}
 }
 
@@ -87,22 +97,32 @@ func (db *Database) AssertExpectations(t mock.TestingT) {
 	db.Studio.AssertExpectations(t)
 	db.Tag.AssertExpectations(t)
 	db.SavedFilter.AssertExpectations(t)
+	db.JobHistory.AssertExpectations(t)
+	db.WebhookDelivery.AssertExpectations(t)
+	db.User.AssertExpectations(t)
+	db.TrashedFile.AssertExpectations(t)
+	db.EntityHistory.AssertExpectations(t)
 }
 
 func (db *Database) Repository() models.Repository {
 	return models.Repository{
-		TxnManager:     db,
-		File:           db.File,
-		Folder:         db.Folder,
-		Gallery:        db.Gallery,
-		GalleryChapter: db.GalleryChapter,
-		Image:          db.Image,
-		Group:          db.Group,
-		Performer:      db.Performer,
-		Scene:          db.Scene,
-		SceneMarker:    db.SceneMarker,
-		Studio:         db.Studio,
-		Tag:            db.Tag,
-		SavedFilter:    db.SavedFilter,
+		TxnManager:      db,
+		File:            db.File,
+		Folder:          db.Folder,
+		Gallery:         db.Gallery,
+		GalleryChapter:  db.GalleryChapter,
+		Image:           db.Image,
+		Group:           db.Group,
+		Performer:       db.Performer,
+		Scene:           db.Scene,
+		SceneMarker:     db.SceneMarker,
+		Studio:          db.Studio,
+		Tag:             db.Tag,
+		SavedFilter:     db.SavedFilter,
+		JobHistory:      db.JobHistory,
+		WebhookDelivery: db.WebhookDelivery,
+		User:            db.User,
+		TrashedFile:     db.TrashedFile,
+		EntityHistory:   db.EntityHistory,
 	}
 }
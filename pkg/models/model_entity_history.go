@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type HistoryEntityType string
+
+const (
+	HistoryEntityTypeScene     HistoryEntityType = "SCENE"
+	HistoryEntityTypePerformer HistoryEntityType = "PERFORMER"
+	HistoryEntityTypeStudio    HistoryEntityType = "STUDIO"
+	HistoryEntityTypeTag       HistoryEntityType = "TAG"
+	HistoryEntityTypeGallery   HistoryEntityType = "GALLERY"
+)
+
+var AllHistoryEntityType = []HistoryEntityType{
+	HistoryEntityTypeScene,
+	HistoryEntityTypePerformer,
+	HistoryEntityTypeStudio,
+	HistoryEntityTypeTag,
+	HistoryEntityTypeGallery,
+}
+
+func (e HistoryEntityType) IsValid() bool {
+	switch e {
+	case HistoryEntityTypeScene, HistoryEntityTypePerformer, HistoryEntityTypeStudio, HistoryEntityTypeTag, HistoryEntityTypeGallery:
+		return true
+	}
+	return false
+}
+
+func (e HistoryEntityType) String() string {
+	return string(e)
+}
+
+func (e *HistoryEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistoryEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistoryEntityType", str)
+	}
+	return nil
+}
+
+func (e HistoryEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// HistorySource is the origin of a change to an entity.
+type HistorySource string
+
+const (
+	// HistorySourceUI is a change made through a browser session.
+	HistorySourceUI HistorySource = "UI"
+	// HistorySourceAPIKey is a change made through a request authenticated
+	// with an API key.
+	HistorySourceAPIKey HistorySource = "API_KEY"
+	// HistorySourcePlugin is a change made by a plugin.
+	HistorySourcePlugin HistorySource = "PLUGIN"
+	// HistorySourceIdentify is a change made by the identify task.
+	HistorySourceIdentify HistorySource = "IDENTIFY"
+	// HistorySourceAutoTag is a change made by the auto tag task.
+	HistorySourceAutoTag HistorySource = "AUTOTAG"
+	// HistorySourceSystem is a change made internally, without a request or
+	// one of the above tasks.
+	HistorySourceSystem HistorySource = "SYSTEM"
+)
+
+var AllHistorySource = []HistorySource{
+	HistorySourceUI,
+	HistorySourceAPIKey,
+	HistorySourcePlugin,
+	HistorySourceIdentify,
+	HistorySourceAutoTag,
+	HistorySourceSystem,
+}
+
+func (e HistorySource) IsValid() bool {
+	switch e {
+	case HistorySourceUI, HistorySourceAPIKey, HistorySourcePlugin, HistorySourceIdentify, HistorySourceAutoTag, HistorySourceSystem:
+		return true
+	}
+	return false
+}
+
+func (e HistorySource) String() string {
+	return string(e)
+}
+
+func (e *HistorySource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = HistorySource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid HistorySource", str)
+	}
+	return nil
+}
+
+func (e HistorySource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// FieldChange is the change to a single field of an entity. The values are
+// stored in JSON form. A null value indicates that the field was unset.
+type FieldChange struct {
+	Field    string          `json:"field"`
+	OldValue json.RawMessage `json:"old_value"`
+	NewValue json.RawMessage `json:"new_value"`
+}
+
+// EntityHistory is a record of the fields changed by an update to a scene,
+// performer, studio, tag or gallery.
+type EntityHistory struct {
+	ID         int               `json:"id"`
+	EntityType HistoryEntityType `json:"entity_type"`
+	EntityID   int               `json:"entity_id"`
+	Source     HistorySource     `json:"source"`
+	// Username is the user that made the change, if known
+	Username  *string       `json:"username"`
+	Changes   []FieldChange `json:"changes"`
+	CreatedAt time.Time     `json:"created_at"`
+}
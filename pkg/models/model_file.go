@@ -266,14 +266,15 @@ func (f ImageFile) Clone() (ret File) {
 // VideoFile is an extension of BaseFile to represent video files.
 type VideoFile struct {
 	*BaseFile
-	Format     string  `json:"format"`
-	Width      int     `json:"width"`
-	Height     int     `json:"height"`
-	Duration   float64 `json:"duration"`
-	VideoCodec string  `json:"video_codec"`
-	AudioCodec string  `json:"audio_codec"`
-	FrameRate  float64 `json:"frame_rate"`
-	BitRate    int64   `json:"bitrate"`
+	Format        string  `json:"format"`
+	Width         int     `json:"width"`
+	Height        int     `json:"height"`
+	Duration      float64 `json:"duration"`
+	VideoCodec    string  `json:"video_codec"`
+	AudioCodec    string  `json:"audio_codec"`
+	AudioChannels int     `json:"audio_channels"`
+	FrameRate     float64 `json:"frame_rate"`
+	BitRate       int64   `json:"bitrate"`
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
@@ -291,6 +292,11 @@ func (f VideoFile) GetFormat() string {
 	return f.Format
 }
 
+// IsAudio returns true if the file has no video stream.
+func (f VideoFile) IsAudio() bool {
+	return f.VideoCodec == "" && f.Width == 0 && f.Height == 0
+}
+
 func (f VideoFile) Clone() (ret File) {
 	clone := f
 	clone.BaseFile = f.BaseFile.Clone().(*BaseFile)
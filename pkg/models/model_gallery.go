@@ -70,6 +70,7 @@ type GalleryPartial struct {
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
 	PrimaryFileID *FileID
+	FolderID      *FolderID
 }
 
 func NewGalleryPartial() GalleryPartial {
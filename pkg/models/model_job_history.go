@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// JobHistory is a record of a job that has been removed from the job queue.
+type JobHistory struct {
+	ID          int        `json:"id"`
+	Description string     `json:"description"`
+	Status      string     `json:"status"`
+	Error       *string    `json:"error"`
+	AddTime     time.Time  `json:"add_time"`
+	StartTime   *time.Time `json:"start_time"`
+	EndTime     *time.Time `json:"end_time"`
+	// summary counters reported by the job, keyed by name
+	Counters map[string]int `json:"counters"`
+	// ID of the scheduled task that queued the job, if any
+	ScheduledTaskID *string `json:"scheduled_task_id"`
+}
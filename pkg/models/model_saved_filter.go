@@ -68,4 +68,6 @@ type SavedFilter struct {
 	FindFilter   *FindFilterType        `json:"find_filter"`
 	ObjectFilter map[string]interface{} `json:"object_filter"`
 	UIOptions    map[string]interface{} `json:"ui_options"`
+	// DLNAEnabled exposes the filter as a container over DLNA.
+	DLNAEnabled bool `db:"dlna_enabled" json:"dlna_enabled"`
 }
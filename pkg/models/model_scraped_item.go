@@ -385,12 +385,51 @@ func (p *ScrapedPerformer) ToPartial(endpoint string, excluded map[string]bool,
 
 type ScrapedTag struct {
 	// Set if tag matched
-	StoredID *string `json:"stored_id"`
-	Name     string  `json:"name"`
+	StoredID     *string `json:"stored_id"`
+	Name         string  `json:"name"`
+	RemoteSiteID *string `json:"remote_site_id"`
 }
 
 func (ScrapedTag) IsScrapedContent() {}
 
+func (t *ScrapedTag) ToTag(endpoint string) *Tag {
+	// Populate a new tag from the input
+	ret := NewTag()
+	ret.Name = t.Name
+
+	if t.RemoteSiteID != nil && endpoint != "" {
+		ret.StashIDs = NewRelatedStashIDs([]StashID{
+			{
+				Endpoint: endpoint,
+				StashID:  *t.RemoteSiteID,
+			},
+		})
+	}
+
+	return &ret
+}
+
+func (t *ScrapedTag) ToPartial(endpoint string, excluded map[string]bool, existingStashIDs []StashID) TagPartial {
+	ret := NewTagPartial()
+
+	if t.Name != "" && !excluded["name"] {
+		ret.Name = NewOptionalString(t.Name)
+	}
+
+	if t.RemoteSiteID != nil && endpoint != "" {
+		ret.StashIDs = &UpdateStashIDs{
+			StashIDs: existingStashIDs,
+			Mode:     RelationshipUpdateModeSet,
+		}
+		ret.StashIDs.Set(StashID{
+			Endpoint: endpoint,
+			StashID:  *t.RemoteSiteID,
+		})
+	}
+
+	return ret
+}
+
 // A movie from a scraping operation...
 type ScrapedMovie struct {
 	StoredID *string        `json:"stored_id"`
@@ -367,3 +367,90 @@ func TestScrapedStudio_ToPartial(t *testing.T) {
 		})
 	}
 }
+
+func TestScrapedTag_ToPartial(t *testing.T) {
+	var (
+		name         = "name"
+		remoteSiteID = "remoteSiteID"
+		endpoint     = "endpoint"
+
+		existingEndpoint = "existingEndpoint"
+		existingStashID  = StashID{"existingStashID", existingEndpoint}
+		existingStashIDs = []StashID{existingStashID}
+	)
+
+	fullTag := ScrapedTag{
+		Name:         name,
+		RemoteSiteID: &remoteSiteID,
+	}
+
+	type args struct {
+		endpoint         string
+		excluded         map[string]bool
+		existingStashIDs []StashID
+	}
+
+	tests := []struct {
+		name string
+		o    ScrapedTag
+		args args
+		want TagPartial
+	}{
+		{
+			"full no exclusions",
+			fullTag,
+			args{
+				endpoint:         endpoint,
+				excluded:         map[string]bool{},
+				existingStashIDs: existingStashIDs,
+			},
+			TagPartial{
+				Name: NewOptionalString(name),
+				StashIDs: &UpdateStashIDs{
+					StashIDs: append(existingStashIDs, StashID{
+						Endpoint: endpoint,
+						StashID:  remoteSiteID,
+					}),
+					Mode: RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"exclude name no endpoint",
+			fullTag,
+			args{
+				excluded: map[string]bool{"name": true},
+			},
+			TagPartial{},
+		},
+		{
+			"overwrite stash id",
+			fullTag,
+			args{
+				excluded:         map[string]bool{"name": true},
+				endpoint:         existingEndpoint,
+				existingStashIDs: existingStashIDs,
+			},
+			TagPartial{
+				StashIDs: &UpdateStashIDs{
+					StashIDs: []StashID{{
+						Endpoint: existingEndpoint,
+						StashID:  remoteSiteID,
+					}},
+					Mode: RelationshipUpdateModeSet,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.o
+			got := s.ToPartial(tt.args.endpoint, tt.args.excluded, tt.args.existingStashIDs)
+
+			// unset updatedAt - we don't need to compare it
+			got.UpdatedAt = OptionalTime{}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,9 +14,10 @@ type Tag struct {
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
 
-	Aliases   RelatedStrings `json:"aliases"`
-	ParentIDs RelatedIDs     `json:"parent_ids"`
-	ChildIDs  RelatedIDs     `json:"tag_ids"`
+	Aliases   RelatedStrings  `json:"aliases"`
+	ParentIDs RelatedIDs      `json:"parent_ids"`
+	ChildIDs  RelatedIDs      `json:"tag_ids"`
+	StashIDs  RelatedStashIDs `json:"stash_ids"`
 }
 
 func NewTag() Tag {
@@ -33,6 +34,12 @@ func (s *Tag) LoadAliases(ctx context.Context, l AliasLoader) error {
 	})
 }
 
+func (s *Tag) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
+	return s.StashIDs.load(func() ([]StashID, error) {
+		return l.GetStashIDs(ctx, s.ID)
+	})
+}
+
 func (s *Tag) LoadParentIDs(ctx context.Context, l TagRelationLoader) error {
 	return s.ParentIDs.load(func() ([]int, error) {
 		return l.GetParentIDs(ctx, s.ID)
@@ -56,6 +63,7 @@ type TagPartial struct {
 	Aliases   *UpdateStrings
 	ParentIDs *UpdateIDs
 	ChildIDs  *UpdateIDs
+	StashIDs  *UpdateStashIDs
 }
 
 func NewTagPartial() TagPartial {
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type TrashedEntityType string
+
+const (
+	TrashedEntityTypeScene   TrashedEntityType = "SCENE"
+	TrashedEntityTypeImage   TrashedEntityType = "IMAGE"
+	TrashedEntityTypeGallery TrashedEntityType = "GALLERY"
+)
+
+var AllTrashedEntityType = []TrashedEntityType{
+	TrashedEntityTypeScene,
+	TrashedEntityTypeImage,
+	TrashedEntityTypeGallery,
+}
+
+func (e TrashedEntityType) IsValid() bool {
+	switch e {
+	case TrashedEntityTypeScene, TrashedEntityTypeImage, TrashedEntityTypeGallery:
+		return true
+	}
+	return false
+}
+
+func (e TrashedEntityType) String() string {
+	return string(e)
+}
+
+func (e *TrashedEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TrashedEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TrashedEntityType", str)
+	}
+	return nil
+}
+
+func (e TrashedEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// TrashedEntity describes the scene, image or gallery that a trashed file
+// belonged to.
+type TrashedEntity struct {
+	Type  TrashedEntityType
+	ID    int
+	Title string
+	// Metadata is stored with the trashed file in JSON form
+	Metadata interface{}
+}
+
+// TrashedFile is a file that was moved into the trash directory when its
+// scene, image or gallery was destroyed.
+type TrashedFile struct {
+	ID int `json:"id"`
+	// Path of the file before it was trashed
+	OriginalPath string `json:"original_path"`
+	// Path of the file in the trash directory
+	TrashPath      string            `json:"trash_path"`
+	Size           int64             `json:"size"`
+	EntityType     TrashedEntityType `json:"entity_type"`
+	EntityID       int               `json:"entity_id"`
+	EntityTitle    string            `json:"entity_title"`
+	EntityMetadata string            `json:"entity_metadata"`
+	TrashedAt      time.Time         `json:"trashed_at"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type UserRole string
+
+const (
+	// UserRoleAdmin users may perform all operations, including changing
+	// the system configuration and managing users.
+	UserRoleAdmin UserRole = "ADMIN"
+	// UserRoleEditor users may modify library content, but may not change
+	// the system configuration.
+	UserRoleEditor UserRole = "EDITOR"
+	// UserRoleViewer users may only browse the library and record their
+	// own activity and ratings.
+	UserRoleViewer UserRole = "VIEWER"
+)
+
+var AllUserRole = []UserRole{
+	UserRoleAdmin,
+	UserRoleEditor,
+	UserRoleViewer,
+}
+
+func (e UserRole) IsValid() bool {
+	switch e {
+	case UserRoleAdmin, UserRoleEditor, UserRoleViewer:
+		return true
+	}
+	return false
+}
+
+func (e UserRole) String() string {
+	return string(e)
+}
+
+func (e *UserRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserRole", str)
+	}
+	return nil
+}
+
+func (e UserRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// User is a user account stored in the database. The user configured in the
+// config file is not stored in the database.
+type User struct {
+	ID           int       `json:"id"`
+	Username     string    `json:"username"`
+	PasswordHash string    `json:"password_hash"`
+	Role         UserRole  `json:"role"`
+	APIKey       string    `json:"api_key"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// WebhookDelivery is a record of an attempt to deliver a webhook payload.
+type WebhookDelivery struct {
+	ID        int    `json:"id"`
+	WebhookID string `json:"webhook_id"`
+	URL       string `json:"url"`
+	Trigger   string `json:"trigger"`
+	// ID of the entity that the trigger applies to
+	EntityID int  `json:"entity_id"`
+	Attempt  int  `json:"attempt"`
+	Success  bool `json:"success"`
+	// HTTP status code of the response. Nil if no response was received.
+	StatusCode *int          `json:"status_code"`
+	Error      *string       `json:"error"`
+	Duration   time.Duration `json:"duration"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
@@ -11,6 +11,7 @@ type JSONPaths struct {
 	Metadata string
 
 	ScrapedFile string
+	Manifest    string
 
 	Performers string
 	Scenes     string
@@ -26,6 +27,7 @@ func newJSONPaths(baseDir string) *JSONPaths {
 	jp := JSONPaths{}
 	jp.Metadata = baseDir
 	jp.ScrapedFile = filepath.Join(baseDir, "scraped.json")
+	jp.Manifest = filepath.Join(baseDir, "manifest.json")
 	jp.Performers = filepath.Join(baseDir, "performers")
 	jp.Scenes = filepath.Join(baseDir, "scenes")
 	jp.Images = filepath.Join(baseDir, "images")
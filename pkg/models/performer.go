@@ -198,6 +198,8 @@ type PerformerFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type PerformerCreateInput struct {
@@ -234,6 +236,8 @@ type PerformerCreateInput struct {
 	HairColor     *string   `json:"hair_color"`
 	Weight        *int      `json:"weight"`
 	IgnoreAutoTag *bool     `json:"ignore_auto_tag"`
+
+	CustomFields []CustomField `json:"custom_fields"`
 }
 
 type PerformerUpdateInput struct {
@@ -271,4 +275,6 @@ type PerformerUpdateInput struct {
 	HairColor     *string   `json:"hair_color"`
 	Weight        *int      `json:"weight"`
 	IgnoreAutoTag *bool     `json:"ignore_auto_tag"`
+
+	CustomFields *CustomFieldsInput `json:"custom_fields"`
 }
@@ -14,19 +14,24 @@ type TxnManager interface {
 type Repository struct {
 	TxnManager TxnManager
 
-	Blob           BlobReader
-	File           FileReaderWriter
-	Folder         FolderReaderWriter
-	Gallery        GalleryReaderWriter
-	GalleryChapter GalleryChapterReaderWriter
-	Image          ImageReaderWriter
-	Group          GroupReaderWriter
-	Performer      PerformerReaderWriter
-	Scene          SceneReaderWriter
-	SceneMarker    SceneMarkerReaderWriter
-	Studio         StudioReaderWriter
-	Tag            TagReaderWriter
-	SavedFilter    SavedFilterReaderWriter
+	Blob            BlobReader
+	File            FileReaderWriter
+	Folder          FolderReaderWriter
+	Gallery         GalleryReaderWriter
+	GalleryChapter  GalleryChapterReaderWriter
+	Image           ImageReaderWriter
+	Group           GroupReaderWriter
+	Performer       PerformerReaderWriter
+	Scene           SceneReaderWriter
+	SceneMarker     SceneMarkerReaderWriter
+	Studio          StudioReaderWriter
+	Tag             TagReaderWriter
+	SavedFilter     SavedFilterReaderWriter
+	JobHistory      JobHistoryReaderWriter
+	WebhookDelivery WebhookDeliveryReaderWriter
+	User            UserReaderWriter
+	TrashedFile     TrashedFileReaderWriter
+	EntityHistory   EntityHistoryReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
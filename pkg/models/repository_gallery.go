@@ -62,6 +62,7 @@ type GalleryReader interface {
 	GalleryFinder
 	GalleryQueryer
 	GalleryCounter
+	CustomFieldsReader
 
 	URLLoader
 	FileIDLoader
@@ -79,6 +80,7 @@ type GalleryWriter interface {
 	GalleryCreator
 	GalleryUpdater
 	GalleryDestroyer
+	CustomFieldsWriter
 
 	AddFileID(ctx context.Context, id int, fileID FileID) error
 	AddImages(ctx context.Context, galleryID int, imageIDs ...int) error
@@ -88,6 +88,7 @@ type SceneReader interface {
 	SceneFinder
 	SceneQueryer
 	SceneCounter
+	CustomFieldsReader
 
 	URLLoader
 	ViewDateReader
@@ -126,6 +127,7 @@ type SceneWriter interface {
 	SceneCreator
 	SceneUpdater
 	SceneDestroyer
+	CustomFieldsWriter
 
 	AddFileID(ctx context.Context, id int, fileID FileID) error
 	AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs []int) error
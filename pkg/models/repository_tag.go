@@ -25,6 +25,8 @@ type TagFinder interface {
 	FindByStudioID(ctx context.Context, studioID int) ([]*Tag, error)
 	FindByName(ctx context.Context, name string, nocase bool) (*Tag, error)
 	FindByNames(ctx context.Context, names []string, nocase bool) ([]*Tag, error)
+	FindByStashID(ctx context.Context, stashID StashID) ([]*Tag, error)
+	FindByStashIDStatus(ctx context.Context, hasStashID bool, stashboxEndpoint string) ([]*Tag, error)
 }
 
 // TagQueryer provides methods to query tags.
@@ -84,9 +86,11 @@ type TagReader interface {
 	TagQueryer
 	TagAutoTagQueryer
 	TagCounter
+	CustomFieldsReader
 
 	AliasLoader
 	TagRelationLoader
+	StashIDLoader
 
 	All(ctx context.Context) ([]*Tag, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
@@ -98,6 +102,7 @@ type TagWriter interface {
 	TagCreator
 	TagUpdater
 	TagDestroyer
+	CustomFieldsWriter
 
 	Merge(ctx context.Context, source []int, destination int) error
 }
@@ -115,6 +115,8 @@ type SceneFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type SceneQueryOptions struct {
@@ -169,6 +171,8 @@ type SceneCreateInput struct {
 	// Files will be reassigned from existing scenes if applicable.
 	// Files must not already be primary for another scene.
 	FileIds []string `json:"file_ids"`
+
+	CustomFields []CustomField `json:"custom_fields"`
 }
 
 type SceneUpdateInput struct {
@@ -197,6 +201,8 @@ type SceneUpdateInput struct {
 	PlayDuration  *float64  `json:"play_duration"`
 	PlayCount     *int      `json:"play_count"`
 	PrimaryFileID *string   `json:"primary_file_id"`
+
+	CustomFields *CustomFieldsInput `json:"custom_fields"`
 }
 
 type SceneDestroyInput struct {
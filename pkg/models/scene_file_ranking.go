@@ -0,0 +1,90 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type SceneFileRankingCriterion string
+
+const (
+	// Files with more pixels rank higher
+	SceneFileRankingCriterionResolution SceneFileRankingCriterion = "RESOLUTION"
+	// Files with a video codec earlier in the preferred codecs rank higher
+	SceneFileRankingCriterionCodec SceneFileRankingCriterion = "CODEC"
+	// Files with a higher bitrate rank higher
+	SceneFileRankingCriterionBitrate SceneFileRankingCriterion = "BITRATE"
+	// Files with a container format earlier in the preferred containers rank higher
+	SceneFileRankingCriterionContainer SceneFileRankingCriterion = "CONTAINER"
+	// Files in a directory earlier in the path priority rank higher
+	SceneFileRankingCriterionPath SceneFileRankingCriterion = "PATH"
+)
+
+var AllSceneFileRankingCriterion = []SceneFileRankingCriterion{
+	SceneFileRankingCriterionResolution,
+	SceneFileRankingCriterionCodec,
+	SceneFileRankingCriterionBitrate,
+	SceneFileRankingCriterionContainer,
+	SceneFileRankingCriterionPath,
+}
+
+func (e SceneFileRankingCriterion) IsValid() bool {
+	switch e {
+	case SceneFileRankingCriterionResolution, SceneFileRankingCriterionCodec, SceneFileRankingCriterionBitrate, SceneFileRankingCriterionContainer, SceneFileRankingCriterionPath:
+		return true
+	}
+	return false
+}
+
+func (e SceneFileRankingCriterion) String() string {
+	return string(e)
+}
+
+func (e *SceneFileRankingCriterion) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SceneFileRankingCriterion(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SceneFileRankingCriterion", str)
+	}
+	return nil
+}
+
+func (e SceneFileRankingCriterion) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneFileRanking are the rules used to select the primary file of scenes
+// with multiple files.
+type SceneFileRanking struct {
+	// Criteria are applied in order. Later criteria are only used when
+	// files are equal by the earlier criteria.
+	Criteria []SceneFileRankingCriterion `json:"criteria"`
+	// Video codecs in order of preference
+	PreferredCodecs []string `json:"preferredCodecs"`
+	// Container formats in order of preference
+	PreferredContainers []string `json:"preferredContainers"`
+	// Directories in order of preference
+	PathPriority []string `json:"pathPriority"`
+}
+
+// DefaultSceneFileRanking applies all criteria. The preference lists are
+// empty, so files are effectively ranked by resolution, then by bitrate.
+func DefaultSceneFileRanking() SceneFileRanking {
+	return SceneFileRanking{
+		Criteria: []SceneFileRankingCriterion{
+			SceneFileRankingCriterionResolution,
+			SceneFileRankingCriterionCodec,
+			SceneFileRankingCriterionBitrate,
+			SceneFileRankingCriterionContainer,
+			SceneFileRankingCriterionPath,
+		},
+		PreferredCodecs:     []string{},
+		PreferredContainers: []string{},
+		PathPriority:        []string{},
+	}
+}
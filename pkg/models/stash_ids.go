@@ -33,6 +33,15 @@ func (u *UpdateStashIDs) Set(v StashID) {
 	u.StashIDs = append(u.StashIDs, v)
 }
 
+// Apply applies the update to a list of existing stash ids, returning the result.
+func (u *UpdateStashIDs) Apply(existing []StashID) []StashID {
+	if u == nil {
+		return existing
+	}
+
+	return applyUpdate(u.StashIDs, u.Mode, existing)
+}
+
 type StashIDCriterionInput struct {
 	// If present, this value is treated as a predicate.
 	// That is, it will filter based on stash_ids with the matching endpoint
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type TrashedFileFilter struct {
+	// filter by the type of the entity that the file belonged to
+	EntityType *TrashedEntityType `json:"entity_type"`
+}
+
+type TrashedFileReader interface {
+	Find(ctx context.Context, id int) (*TrashedFile, error)
+	FindMany(ctx context.Context, ids []int) ([]*TrashedFile, error)
+	// FindBefore returns all files that were trashed before the provided time.
+	FindBefore(ctx context.Context, t time.Time) ([]*TrashedFile, error)
+	All(ctx context.Context) ([]*TrashedFile, error)
+	Query(ctx context.Context, trashedFilter *TrashedFileFilter, findFilter *FindFilterType) ([]*TrashedFile, int, error)
+}
+
+type TrashedFileCreator interface {
+	Create(ctx context.Context, obj *TrashedFile) error
+}
+
+type TrashedFileWriter interface {
+	TrashedFileCreator
+	Destroy(ctx context.Context, id int) error
+}
+
+type TrashedFileReaderWriter interface {
+	TrashedFileReader
+	TrashedFileWriter
+}
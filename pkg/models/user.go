@@ -0,0 +1,36 @@
+package models
+
+import "context"
+
+type UserReader interface {
+	Find(ctx context.Context, id int) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	All(ctx context.Context) ([]*User, error)
+}
+
+type UserWriter interface {
+	Create(ctx context.Context, newUser *User) error
+	Update(ctx context.Context, updatedUser *User) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type UserReaderWriter interface {
+	UserReader
+	UserWriter
+}
+
+type userIDCtxKey struct{}
+
+// WithUserID returns a context that causes per-user data, such as scene
+// ratings and activity, to be read and written for the provided user.
+// Contexts without a user ID read and write the shared data, which belongs
+// to the user configured in the config file.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDCtxKey{}, userID)
+}
+
+// UserIDFromContext returns the user ID set by WithUserID.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	v, ok := ctx.Value(userIDCtxKey{}).(int)
+	return v, ok
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type WebhookDeliveryFilter struct {
+	// filter by webhook ID
+	WebhookID *string `json:"webhook_id"`
+	// filter by success
+	Success *bool `json:"success"`
+}
+
+type WebhookDeliveryReader interface {
+	Query(ctx context.Context, deliveryFilter *WebhookDeliveryFilter, findFilter *FindFilterType) ([]*WebhookDelivery, int, error)
+}
+
+type WebhookDeliveryWriter interface {
+	Create(ctx context.Context, obj *WebhookDelivery) error
+	// DestroyBefore removes all records created before the provided time.
+	DestroyBefore(ctx context.Context, t time.Time) error
+}
+
+type WebhookDeliveryReaderWriter interface {
+	WebhookDeliveryReader
+	WebhookDeliveryWriter
+}
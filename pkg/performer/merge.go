@@ -0,0 +1,118 @@
+package performer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+var ErrMergeDestinationInSource = errors.New("destination performer cannot be in source list")
+
+// Merge merges the source performers into the destination performer.
+// Scenes, images and galleries of the source performers are reassigned to the
+// destination. The names and aliases of the source performers are added as
+// aliases of the destination, and their URLs, tags and stash ids are added to
+// the destination. The source performers are destroyed.
+//
+// Values set in the provided partial are applied to the destination after the
+// relationships have been merged.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, values models.PerformerPartial, qb models.PerformerReaderWriter) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	// ensure destination is not in source list
+	if sliceutil.Contains(sourceIDs, destinationID) {
+		return ErrMergeDestinationInSource
+	}
+
+	dest, err := qb.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination performer ID %d: %w", destinationID, err)
+	}
+
+	if dest == nil {
+		return &NotFoundError{destinationID}
+	}
+
+	sources, err := qb.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source performers: %w", err)
+	}
+
+	if err := loadMergeRelationships(ctx, dest, qb); err != nil {
+		return err
+	}
+
+	aliases := dest.Aliases.List()
+	urls := dest.URLs.List()
+	tagIDs := dest.TagIDs.List()
+	stashIDs := dest.StashIDs.List()
+
+	for _, src := range sources {
+		if err := loadMergeRelationships(ctx, src, qb); err != nil {
+			return err
+		}
+
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, src.Aliases.List()...)
+		urls = sliceutil.AppendUniques(urls, src.URLs.List())
+		tagIDs = sliceutil.AppendUniques(tagIDs, src.TagIDs.List())
+		stashIDs = sliceutil.AppendUniques(stashIDs, src.StashIDs.List())
+	}
+
+	name := dest.Name
+	if values.Name.Set {
+		name = values.Name.Value
+	}
+
+	// apply any relationship changes in the values on top of the merged values
+	values.Aliases = &models.UpdateStrings{
+		Values: stringslice.UniqueFoldExcept(values.Aliases.Apply(aliases), name),
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	values.URLs = &models.UpdateStrings{
+		Values: values.URLs.Apply(urls),
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	values.TagIDs = &models.UpdateIDs{
+		IDs:  values.TagIDs.Apply(tagIDs),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	values.StashIDs = &models.UpdateStashIDs{
+		StashIDs: values.StashIDs.Apply(stashIDs),
+		Mode:     models.RelationshipUpdateModeSet,
+	}
+
+	// reassign related objects and destroy the source performers
+	if err := qb.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return fmt.Errorf("merging performers: %w", err)
+	}
+
+	// validate after the source performers have been removed, so that the
+	// destination may take the name of one of the sources
+	if err := ValidateUpdate(ctx, destinationID, values, qb); err != nil {
+		return err
+	}
+
+	if _, err := qb.UpdatePartial(ctx, destinationID, values); err != nil {
+		return fmt.Errorf("updating performer: %w", err)
+	}
+
+	return nil
+}
+
+func loadMergeRelationships(ctx context.Context, p *models.Performer, qb models.PerformerReader) error {
+	if err := p.LoadRelationships(ctx, qb); err != nil {
+		return fmt.Errorf("loading performer relationships from %d: %w", p.ID, err)
+	}
+
+	if err := p.LoadURLs(ctx, qb); err != nil {
+		return fmt.Errorf("loading performer URLs from %d: %w", p.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMerge(t *testing.T) {
+	const (
+		destID = iota + 1
+		srcID1
+		srcID2
+		missingID
+
+		tagID1
+		tagID2
+		tagID3
+	)
+
+	const (
+		destName = "dest"
+		srcName1 = "src1"
+		srcName2 = "src2"
+
+		endpoint = "endpoint"
+	)
+
+	db := mocks.NewDatabase()
+
+	dest := &models.Performer{ID: destID, Name: destName}
+	src1 := &models.Performer{ID: srcID1, Name: srcName1}
+	src2 := &models.Performer{ID: srcID2, Name: srcName2}
+
+	db.Performer.On("Find", testCtx, destID).Return(dest, nil)
+	db.Performer.On("Find", testCtx, missingID).Return(nil, nil)
+	db.Performer.On("FindMany", testCtx, []int{srcID1, srcID2}).Return([]*models.Performer{src1, src2}, nil)
+
+	db.Performer.On("GetAliases", testCtx, destID).Return([]string{"alias"}, nil)
+	db.Performer.On("GetAliases", testCtx, srcID1).Return([]string{"Alias", "src alias"}, nil)
+	db.Performer.On("GetAliases", testCtx, srcID2).Return([]string{destName}, nil)
+
+	db.Performer.On("GetURLs", testCtx, destID).Return([]string{"url1"}, nil)
+	db.Performer.On("GetURLs", testCtx, srcID1).Return([]string{"url1", "url2"}, nil)
+	db.Performer.On("GetURLs", testCtx, srcID2).Return(nil, nil)
+
+	db.Performer.On("GetTagIDs", testCtx, destID).Return([]int{tagID1}, nil)
+	db.Performer.On("GetTagIDs", testCtx, srcID1).Return([]int{tagID2}, nil)
+	db.Performer.On("GetTagIDs", testCtx, srcID2).Return([]int{tagID1, tagID3}, nil)
+
+	db.Performer.On("GetStashIDs", testCtx, destID).Return(nil, nil)
+	db.Performer.On("GetStashIDs", testCtx, srcID1).Return([]models.StashID{{StashID: "a", Endpoint: endpoint}}, nil)
+	db.Performer.On("GetStashIDs", testCtx, srcID2).Return(nil, nil)
+
+	db.Performer.On("Merge", testCtx, []int{srcID1, srcID2}, destID).Return(nil).Once()
+
+	matchPartial := mock.MatchedBy(func(p models.PerformerPartial) bool {
+		return assert.ObjectsAreEqual([]string{"alias", srcName1, "src alias", srcName2}, p.Aliases.Values) &&
+			assert.ObjectsAreEqual([]string{"url1", "url2"}, p.URLs.Values) &&
+			assert.ObjectsAreEqual([]int{tagID1, tagID2, tagID3}, p.TagIDs.IDs) &&
+			assert.ObjectsAreEqual([]models.StashID{{StashID: "a", Endpoint: endpoint}}, p.StashIDs.StashIDs)
+	})
+	db.Performer.On("UpdatePartial", testCtx, destID, matchPartial).Return(dest, nil).Once()
+
+	values := models.NewPerformerPartial()
+
+	assert.ErrorIs(t, Merge(testCtx, []int{srcID1, destID}, destID, values, db.Performer), ErrMergeDestinationInSource)
+	assert.Equal(t, &NotFoundError{missingID}, Merge(testCtx, []int{srcID1}, missingID, values, db.Performer))
+	assert.Nil(t, Merge(testCtx, []int{srcID1, srcID2, srcID1}, destID, values, db.Performer))
+
+	db.AssertExpectations(t)
+}
@@ -404,6 +404,15 @@ type HookConfig struct {
 
 	// A list of stash operations that will be used to trigger this hook operation.
 	TriggeredBy []hook.TriggerEnum `yaml:"triggeredBy"`
+
+	// Determines the order that pre hooks are executed. Hooks with a lower
+	// order are executed first. Hooks with the same order are executed in
+	// order of plugin ID, then in the order they are declared.
+	Order int `yaml:"order"`
+
+	// The maximum number of seconds that a pre hook may run before the
+	// operation is aborted. Defaults to 10 seconds if not set.
+	Timeout int `yaml:"timeout"`
 }
 
 func loadPluginFromYAML(reader io.Reader) (*Config, error) {
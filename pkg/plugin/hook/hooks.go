@@ -1,5 +1,7 @@
 package hook
 
+import "strings"
+
 type TriggerEnum string
 
 // Scan-related hooks are current disabled until post-hook execution is
@@ -20,6 +22,7 @@ const (
 
 	GalleryCreatePost  TriggerEnum = "Gallery.Create.Post"
 	GalleryUpdatePost  TriggerEnum = "Gallery.Update.Post"
+	GalleryMergePost   TriggerEnum = "Gallery.Merge.Post"
 	GalleryDestroyPost TriggerEnum = "Gallery.Destroy.Post"
 
 	GalleryChapterCreatePost  TriggerEnum = "GalleryChapter.Create.Post"
@@ -38,10 +41,12 @@ const (
 
 	PerformerCreatePost  TriggerEnum = "Performer.Create.Post"
 	PerformerUpdatePost  TriggerEnum = "Performer.Update.Post"
+	PerformerMergePost   TriggerEnum = "Performer.Merge.Post"
 	PerformerDestroyPost TriggerEnum = "Performer.Destroy.Post"
 
 	StudioCreatePost  TriggerEnum = "Studio.Create.Post"
 	StudioUpdatePost  TriggerEnum = "Studio.Update.Post"
+	StudioMergePost   TriggerEnum = "Studio.Merge.Post"
 	StudioDestroyPost TriggerEnum = "Studio.Destroy.Post"
 
 	TagCreatePost  TriggerEnum = "Tag.Create.Post"
@@ -50,6 +55,49 @@ const (
 	TagDestroyPost TriggerEnum = "Tag.Destroy.Post"
 )
 
+// Pre hooks are executed synchronously before the operation is performed.
+// Plugins may modify the input or return an error to abort the operation.
+const (
+	SceneMarkerCreatePre  TriggerEnum = "SceneMarker.Create.Pre"
+	SceneMarkerUpdatePre  TriggerEnum = "SceneMarker.Update.Pre"
+	SceneMarkerDestroyPre TriggerEnum = "SceneMarker.Destroy.Pre"
+
+	SceneCreatePre  TriggerEnum = "Scene.Create.Pre"
+	SceneUpdatePre  TriggerEnum = "Scene.Update.Pre"
+	SceneDestroyPre TriggerEnum = "Scene.Destroy.Pre"
+
+	ImageUpdatePre  TriggerEnum = "Image.Update.Pre"
+	ImageDestroyPre TriggerEnum = "Image.Destroy.Pre"
+
+	GalleryCreatePre  TriggerEnum = "Gallery.Create.Pre"
+	GalleryUpdatePre  TriggerEnum = "Gallery.Update.Pre"
+	GalleryDestroyPre TriggerEnum = "Gallery.Destroy.Pre"
+	GalleryMergePre   TriggerEnum = "Gallery.Merge.Pre"
+
+	GalleryChapterCreatePre  TriggerEnum = "GalleryChapter.Create.Pre"
+	GalleryChapterUpdatePre  TriggerEnum = "GalleryChapter.Update.Pre"
+	GalleryChapterDestroyPre TriggerEnum = "GalleryChapter.Destroy.Pre"
+
+	GroupCreatePre  TriggerEnum = "Group.Create.Pre"
+	GroupUpdatePre  TriggerEnum = "Group.Update.Pre"
+	GroupDestroyPre TriggerEnum = "Group.Destroy.Pre"
+
+	PerformerCreatePre  TriggerEnum = "Performer.Create.Pre"
+	PerformerUpdatePre  TriggerEnum = "Performer.Update.Pre"
+	PerformerDestroyPre TriggerEnum = "Performer.Destroy.Pre"
+	PerformerMergePre   TriggerEnum = "Performer.Merge.Pre"
+
+	StudioCreatePre  TriggerEnum = "Studio.Create.Pre"
+	StudioUpdatePre  TriggerEnum = "Studio.Update.Pre"
+	StudioDestroyPre TriggerEnum = "Studio.Destroy.Pre"
+	StudioMergePre   TriggerEnum = "Studio.Merge.Pre"
+
+	TagCreatePre  TriggerEnum = "Tag.Create.Pre"
+	TagUpdatePre  TriggerEnum = "Tag.Update.Pre"
+	TagDestroyPre TriggerEnum = "Tag.Destroy.Pre"
+	TagMergePre   TriggerEnum = "Tag.Merge.Pre"
+)
+
 var AllHookTriggerEnum = []TriggerEnum{
 	SceneMarkerCreatePost,
 	SceneMarkerUpdatePost,
@@ -65,6 +113,7 @@ var AllHookTriggerEnum = []TriggerEnum{
 
 	GalleryCreatePost,
 	GalleryUpdatePost,
+	GalleryMergePost,
 	GalleryDestroyPost,
 
 	GalleryChapterCreatePost,
@@ -75,18 +124,63 @@ var AllHookTriggerEnum = []TriggerEnum{
 	MovieUpdatePost,
 	MovieDestroyPost,
 
+	GroupCreatePost,
+	GroupUpdatePost,
+	GroupDestroyPost,
+
 	PerformerCreatePost,
 	PerformerUpdatePost,
+	PerformerMergePost,
 	PerformerDestroyPost,
 
 	StudioCreatePost,
 	StudioUpdatePost,
+	StudioMergePost,
 	StudioDestroyPost,
 
 	TagCreatePost,
 	TagUpdatePost,
 	TagMergePost,
 	TagDestroyPost,
+
+	SceneMarkerCreatePre,
+	SceneMarkerUpdatePre,
+	SceneMarkerDestroyPre,
+
+	SceneCreatePre,
+	SceneUpdatePre,
+	SceneDestroyPre,
+
+	ImageUpdatePre,
+	ImageDestroyPre,
+
+	GalleryCreatePre,
+	GalleryUpdatePre,
+	GalleryDestroyPre,
+	GalleryMergePre,
+
+	GalleryChapterCreatePre,
+	GalleryChapterUpdatePre,
+	GalleryChapterDestroyPre,
+
+	GroupCreatePre,
+	GroupUpdatePre,
+	GroupDestroyPre,
+
+	PerformerCreatePre,
+	PerformerUpdatePre,
+	PerformerDestroyPre,
+	PerformerMergePre,
+
+	StudioCreatePre,
+	StudioUpdatePre,
+	StudioDestroyPre,
+	StudioMergePre,
+
+	TagCreatePre,
+	TagUpdatePre,
+	TagDestroyPre,
+	TagMergePre,
 }
 
 func (e TriggerEnum) IsValid() bool {
@@ -106,6 +200,7 @@ func (e TriggerEnum) IsValid() bool {
 
 		GalleryCreatePost,
 		GalleryUpdatePost,
+		GalleryMergePost,
 		GalleryDestroyPost,
 
 		GalleryChapterCreatePost,
@@ -116,22 +211,73 @@ func (e TriggerEnum) IsValid() bool {
 		MovieUpdatePost,
 		MovieDestroyPost,
 
+		GroupCreatePost,
+		GroupUpdatePost,
+		GroupDestroyPost,
+
 		PerformerCreatePost,
 		PerformerUpdatePost,
+		PerformerMergePost,
 		PerformerDestroyPost,
 
 		StudioCreatePost,
 		StudioUpdatePost,
+		StudioMergePost,
 		StudioDestroyPost,
 
 		TagCreatePost,
 		TagUpdatePost,
-		TagDestroyPost:
+		TagMergePost,
+		TagDestroyPost,
+
+		SceneMarkerCreatePre,
+		SceneMarkerUpdatePre,
+		SceneMarkerDestroyPre,
+
+		SceneCreatePre,
+		SceneUpdatePre,
+		SceneDestroyPre,
+
+		ImageUpdatePre,
+		ImageDestroyPre,
+
+		GalleryCreatePre,
+		GalleryUpdatePre,
+		GalleryDestroyPre,
+		GalleryMergePre,
+
+		GalleryChapterCreatePre,
+		GalleryChapterUpdatePre,
+		GalleryChapterDestroyPre,
+
+		GroupCreatePre,
+		GroupUpdatePre,
+		GroupDestroyPre,
+
+		PerformerCreatePre,
+		PerformerUpdatePre,
+		PerformerDestroyPre,
+		PerformerMergePre,
+
+		StudioCreatePre,
+		StudioUpdatePre,
+		StudioDestroyPre,
+		StudioMergePre,
+
+		TagCreatePre,
+		TagUpdatePre,
+		TagDestroyPre,
+		TagMergePre:
 		return true
 	}
 	return false
 }
 
+// IsPre returns true if the trigger is executed before the operation.
+func (e TriggerEnum) IsPre() bool {
+	return strings.HasSuffix(string(e), ".Pre")
+}
+
 func (e TriggerEnum) String() string {
 	return string(e)
 }
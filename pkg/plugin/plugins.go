@@ -104,6 +104,13 @@ type Cache struct {
 	plugins      []Config
 	sessionStore *session.Store
 	gqlHandler   http.Handler
+
+	postHookListeners []PostHookListener
+}
+
+// PostHookListener is notified whenever post hooks are executed.
+type PostHookListener interface {
+	OnPostHook(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
 }
 
 // NewCache returns a new Cache.
@@ -123,6 +130,13 @@ func (c *Cache) RegisterGQLHandler(handler http.Handler) {
 	c.gqlHandler = handler
 }
 
+// RegisterPostHookListener adds a listener that is notified when post hooks
+// are executed. Listeners are notified regardless of whether any plugins
+// handle the hook.
+func (c *Cache) RegisterPostHookListener(l PostHookListener) {
+	c.postHookListeners = append(c.postHookListeners, l)
+}
+
 func (c *Cache) RegisterSessionStore(sessionStore *session.Store) {
 	c.sessionStore = sessionStore
 }
@@ -358,6 +372,10 @@ func waitForTask(ctx context.Context, task Task) error {
 }
 
 func (c Cache) ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+	for _, l := range c.postHookListeners {
+		l.OnPostHook(ctx, id, hookType, input, inputFields)
+	}
+
 	if err := c.executePostHooks(ctx, hookType, common.HookContext{
 		ID:          id,
 		Type:        hookType.String(),
@@ -0,0 +1,204 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// ErrPreHookRejected is returned when a pre hook rejects an operation.
+var ErrPreHookRejected = errors.New("operation rejected by plugin")
+
+const defaultPreHookTimeout = 10 * time.Second
+
+// PreHookOutput is the output expected from a pre hook operation. To reject
+// the operation, the plugin should return an error instead.
+type PreHookOutput struct {
+	// Input fields to set before the operation is performed. Fields that are
+	// not included are left unchanged.
+	Input map[string]interface{} `json:"input"`
+}
+
+func (h *HookConfig) getTimeout() time.Duration {
+	if h.Timeout <= 0 {
+		return defaultPreHookTimeout
+	}
+
+	return time.Duration(h.Timeout) * time.Second
+}
+
+type orderedHook struct {
+	plugin Config
+	hook   *HookConfig
+	index  int
+}
+
+// getOrderedHooks returns the hooks for the trigger from all enabled plugins,
+// in the order that they should be executed.
+func (c Cache) getOrderedHooks(hookType hook.TriggerEnum) []orderedHook {
+	var ret []orderedHook
+	for _, p := range c.enabledPlugins() {
+		for i, h := range p.getHooks(hookType) {
+			ret = append(ret, orderedHook{
+				plugin: p,
+				hook:   h,
+				index:  i,
+			})
+		}
+	}
+
+	sortHooks(ret)
+	return ret
+}
+
+// sortHooks sorts by order, then plugin ID, then declaration order.
+func sortHooks(hooks []orderedHook) {
+	sort.SliceStable(hooks, func(i, j int) bool {
+		a, b := hooks[i], hooks[j]
+		if a.hook.Order != b.hook.Order {
+			return a.hook.Order < b.hook.Order
+		}
+		if a.plugin.id != b.plugin.id {
+			return a.plugin.id < b.plugin.id
+		}
+		return a.index < b.index
+	})
+}
+
+// ExecutePreHooks synchronously executes the pre hooks for an operation.
+// Hooks are executed in order of their configured order, then plugin ID.
+//
+// input must be a pointer to the operation input. Plugins may set input
+// fields by returning a PreHookOutput. Changes are applied to input before
+// the next hook is executed. Returns the fields that were set by plugins,
+// keyed by input field name.
+//
+// Returns an error wrapping ErrPreHookRejected if a plugin rejects the
+// operation. An error is also returned if a plugin fails to execute or times
+// out. In either case, the operation should be aborted.
+func (c Cache) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) (map[string]interface{}, error) {
+	visitedPluginHookCounts := getVisitedPluginHookCounts(ctx)
+
+	hookContext := common.HookContext{
+		ID:          id,
+		Type:        hookType.String(),
+		Input:       input,
+		InputFields: append([]string{}, inputFields...),
+	}
+
+	changed := make(map[string]interface{})
+
+	for _, h := range c.getOrderedHooks(hookType) {
+		p := h.plugin
+		if visitedPluginHookCounts.For(p.id, hookType) >= maxCyclicLoopDepth {
+			logger.Debugf("cyclic loop detected: plugin ID '%s' hook %s, not re-triggering", p.id, hookType)
+			continue
+		}
+
+		output, err := c.executePreHook(ctx, &p, h.hook, hookType, hookContext)
+		if err != nil {
+			return nil, fmt.Errorf("%s [%s]: %w", hookType.String(), p.Name, err)
+		}
+
+		if output == nil {
+			logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
+			continue
+		}
+
+		if output.Error != nil {
+			return nil, fmt.Errorf("%w: %s [%s]: %s", ErrPreHookRejected, hookType.String(), p.Name, *output.Error)
+		}
+
+		fields, err := applyPreHookOutput(output.Output, input)
+		if err != nil {
+			return nil, fmt.Errorf("%s [%s]: %w", hookType.String(), p.Name, err)
+		}
+
+		for k, v := range fields {
+			changed[k] = v
+			if !sliceutil.Contains(hookContext.InputFields, k) {
+				hookContext.InputFields = append(hookContext.InputFields, k)
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+func (c Cache) executePreHook(ctx context.Context, p *Config, h *HookConfig, hookType hook.TriggerEnum, hookContext common.HookContext) (*common.PluginOutput, error) {
+	timeout := h.getTimeout()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
+	serverConnection := c.makeServerConnection(newCtx)
+
+	pluginInput := buildPluginInput(p, &h.OperationConfig, serverConnection, nil)
+	addHookContext(pluginInput.Args, hookContext)
+
+	pt := pluginTask{
+		plugin:       p,
+		operation:    &h.OperationConfig,
+		input:        pluginInput,
+		gqlHandler:   c.gqlHandler,
+		serverConfig: c.config,
+	}
+
+	task := pt.createTask()
+	if err := task.Start(); err != nil {
+		return nil, err
+	}
+
+	if err := waitForTask(ctx, task); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("timed out after %s", timeout)
+		}
+		return nil, err
+	}
+
+	return task.GetResult(), nil
+}
+
+// applyPreHookOutput sets the input fields returned by a pre hook on input.
+// Returns the fields that were set.
+func applyPreHookOutput(output interface{}, input interface{}) (map[string]interface{}, error) {
+	if output == nil {
+		return nil, nil
+	}
+
+	// output is decoded from the plugin's JSON output, so re-encode it
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("encoding output: %w", err)
+	}
+
+	var o PreHookOutput
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("invalid output: %w", err)
+	}
+
+	if len(o.Input) == 0 {
+		return nil, nil
+	}
+
+	data, err = json.Marshal(o.Input)
+	if err != nil {
+		return nil, fmt.Errorf("encoding input: %w", err)
+	}
+
+	// fields not present in the output are left unchanged
+	if err := json.Unmarshal(data, input); err != nil {
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	return o.Input, nil
+}
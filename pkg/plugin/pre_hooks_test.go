@@ -0,0 +1,101 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortHooks(t *testing.T) {
+	first := &HookConfig{Order: -1}
+	a1 := &HookConfig{}
+	a2 := &HookConfig{}
+	b := &HookConfig{}
+
+	hooks := []orderedHook{
+		{plugin: Config{id: "b"}, hook: b, index: 0},
+		{plugin: Config{id: "a"}, hook: a2, index: 1},
+		{plugin: Config{id: "c"}, hook: first, index: 0},
+		{plugin: Config{id: "a"}, hook: a1, index: 0},
+	}
+
+	sortHooks(hooks)
+
+	want := []*HookConfig{first, a1, a2, b}
+	for i, h := range hooks {
+		if h.hook != want[i] {
+			t.Errorf("hook %d = plugin %q index %d", i, h.plugin.id, h.index)
+		}
+	}
+}
+
+func TestApplyPreHookOutput(t *testing.T) {
+	type testInput struct {
+		ID    string  `json:"id"`
+		Name  *string `json:"name"`
+		Notes *string `json:"notes"`
+	}
+
+	notes := "notes"
+
+	tests := []struct {
+		name       string
+		output     interface{}
+		wantName   string
+		wantFields map[string]interface{}
+		wantErr    bool
+	}{
+		{
+			name:     "no output",
+			output:   nil,
+			wantName: "original",
+		},
+		{
+			name:     "no input",
+			output:   map[string]interface{}{},
+			wantName: "original",
+		},
+		{
+			name: "modified",
+			output: map[string]interface{}{
+				"input": map[string]interface{}{"name": "modified"},
+			},
+			wantName:   "modified",
+			wantFields: map[string]interface{}{"name": "modified"},
+		},
+		{
+			name:    "invalid output",
+			output:  "ok",
+			wantErr: true,
+		},
+		{
+			name: "invalid input",
+			output: map[string]interface{}{
+				"input": map[string]interface{}{"name": 1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := "original"
+			input := testInput{ID: "1", Name: &name, Notes: &notes}
+
+			got, err := applyPreHookOutput(tt.output, &input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("applyPreHookOutput() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			assert.Equal(t, tt.wantFields, got)
+			assert.Equal(t, tt.wantName, *input.Name)
+			// fields not in the output are unchanged
+			assert.Equal(t, "1", input.ID)
+			assert.Equal(t, notes, *input.Notes)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package scene
 
 import (
 	"context"
+	"fmt"
 	"path/filepath"
 
 	"github.com/stashapp/stash/pkg/file"
@@ -145,6 +146,11 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 		return err
 	}
 
+	entity, err := s.trashedEntity(ctx, scene)
+	if err != nil {
+		return err
+	}
+
 	for _, f := range scene.Files.List() {
 		// only delete files where there is no other associated scene
 		otherScenes, err := s.Repository.FindByFileID(ctx, f.ID)
@@ -157,9 +163,8 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 			continue
 		}
 
-		const deleteFile = true
 		logger.Info("Deleting scene file: ", f.Path)
-		if err := file.Destroy(ctx, s.File, f, fileDeleter.Deleter, deleteFile); err != nil {
+		if err := file.DestroyToTrash(ctx, s.File, f, fileDeleter.Deleter, entity); err != nil {
 			return err
 		}
 
@@ -168,7 +173,7 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 			funscriptPath := video.GetFunscriptPath(f.Path)
 			funscriptExists, _ := fsutil.FileExists(funscriptPath)
 			if funscriptExists {
-				if err := fileDeleter.Files([]string{funscriptPath}); err != nil {
+				if err := fileDeleter.TrashFiles(ctx, []string{funscriptPath}, entity); err != nil {
 					return err
 				}
 			}
@@ -178,6 +183,80 @@ func (s *Service) deleteFiles(ctx context.Context, scene *models.Scene, fileDele
 	return nil
 }
 
+// DestroyFiles destroys files of the scene other than its primary file.
+// Files associated with other scenes are not destroyed. The files are moved
+// into the trash if trash is true, otherwise they are deleted. Returns the
+// destroyed files.
+func (s *Service) DestroyFiles(ctx context.Context, scene *models.Scene, files []*models.VideoFile, fileDeleter *file.Deleter, trash bool) ([]*models.VideoFile, error) {
+	var entity models.TrashedEntity
+	if trash {
+		var err error
+		entity, err = s.trashedEntity(ctx, scene)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var ret []*models.VideoFile
+	for _, f := range files {
+		if scene.PrimaryFileID != nil && f.ID == *scene.PrimaryFileID {
+			return nil, fmt.Errorf("cannot destroy primary file %s", f.Path)
+		}
+
+		otherScenes, err := s.Repository.FindByFileID(ctx, f.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(otherScenes) > 1 {
+			// other scenes associated, don't remove
+			continue
+		}
+
+		if trash {
+			logger.Info("Trashing scene file: ", f.Path)
+			err = file.DestroyToTrash(ctx, s.File, f, fileDeleter, entity)
+		} else {
+			logger.Info("Deleting scene file: ", f.Path)
+			const deleteFile = true
+			err = file.Destroy(ctx, s.File, f, fileDeleter, deleteFile)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, f)
+	}
+
+	return ret, nil
+}
+
+// trashedEntity returns the scene details to record with its trashed files.
+func (s *Service) trashedEntity(ctx context.Context, scene *models.Scene) (models.TrashedEntity, error) {
+	if err := scene.LoadURLs(ctx, s.Repository); err != nil {
+		return models.TrashedEntity{}, err
+	}
+
+	if err := scene.LoadStashIDs(ctx, s.Repository); err != nil {
+		return models.TrashedEntity{}, err
+	}
+
+	metadata, err := ToBasicJSON(ctx, s.Repository, scene)
+	if err != nil {
+		return models.TrashedEntity{}, err
+	}
+
+	// don't store the cover image with the trashed file
+	metadata.Cover = ""
+
+	return models.TrashedEntity{
+		Type:     models.TrashedEntityTypeScene,
+		ID:       scene.ID,
+		Title:    scene.GetTitle(),
+		Metadata: metadata,
+	}, nil
+}
+
 // DestroyMarker deletes the scene marker from the database and returns a
 // function that removes the generated files, to be executed after the
 // transaction is successfully committed.
@@ -443,6 +443,9 @@ const (
 
 	markerSeconds1Str = "1.0"
 	markerSeconds2Str = "2.3"
+
+	markerEndSeconds2    = 4.5
+	markerEndSeconds2Str = "4.5"
 )
 
 type sceneMarkersTestScenario struct {
@@ -474,6 +477,7 @@ var getSceneMarkersJSONScenarios = []sceneMarkersTestScenario{
 				Title:      markerTitle2,
 				PrimaryTag: validTagName2,
 				Seconds:    markerSeconds2Str,
+				EndSeconds: markerEndSeconds2Str,
 				Tags: []string{
 					validTagName2,
 				},
@@ -509,6 +513,8 @@ var getSceneMarkersJSONScenarios = []sceneMarkersTestScenario{
 	},
 }
 
+var endSeconds2 = markerEndSeconds2
+
 var validMarkers = []*models.SceneMarker{
 	{
 		ID:           validMarkerID1,
@@ -523,6 +529,7 @@ var validMarkers = []*models.SceneMarker{
 		Title:        markerTitle2,
 		PrimaryTagID: validTagID2,
 		Seconds:      markerSeconds2,
+		EndSeconds:   &endSeconds2,
 		CreatedAt:    createTime,
 		UpdatedAt:    updateTime,
 	},
@@ -0,0 +1,83 @@
+package scene
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// RankFiles returns the files ordered from best to worst according to the
+// ranking rules. Files that rank equally keep their order, so a primary
+// file that is first in files is kept unless another file ranks higher.
+func RankFiles(files []*models.VideoFile, ranking models.SceneFileRanking) []*models.VideoFile {
+	ret := make([]*models.VideoFile, len(files))
+	copy(ret, files)
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return CompareFiles(ret[i], ret[j], ranking) < 0
+	})
+
+	return ret
+}
+
+// CompareFiles returns a negative number if a ranks higher than b, a
+// positive number if b ranks higher than a, and zero if they rank equally.
+func CompareFiles(a, b *models.VideoFile, ranking models.SceneFileRanking) int {
+	for _, c := range ranking.Criteria {
+		var v int
+		switch c {
+		case models.SceneFileRankingCriterionResolution:
+			v = compareDesc(int64(a.Width)*int64(a.Height), int64(b.Width)*int64(b.Height))
+		case models.SceneFileRankingCriterionCodec:
+			v = preferenceIndex(ranking.PreferredCodecs, a.VideoCodec) - preferenceIndex(ranking.PreferredCodecs, b.VideoCodec)
+		case models.SceneFileRankingCriterionBitrate:
+			v = compareDesc(a.BitRate, b.BitRate)
+		case models.SceneFileRankingCriterionContainer:
+			v = preferenceIndex(ranking.PreferredContainers, a.Format) - preferenceIndex(ranking.PreferredContainers, b.Format)
+		case models.SceneFileRankingCriterionPath:
+			v = pathIndex(ranking.PathPriority, a.Path) - pathIndex(ranking.PathPriority, b.Path)
+		}
+
+		if v != 0 {
+			return v
+		}
+	}
+
+	return 0
+}
+
+func compareDesc(a, b int64) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	}
+	return 0
+}
+
+// preferenceIndex returns the index of v in preferred, ignoring case.
+// Values that are not preferred have the lowest preference.
+func preferenceIndex(preferred []string, v string) int {
+	for i, p := range preferred {
+		if strings.EqualFold(p, v) {
+			return i
+		}
+	}
+
+	return len(preferred)
+}
+
+// pathIndex returns the index of the first directory in dirs that contains
+// path. Paths outside of all directories have the lowest preference.
+func pathIndex(dirs []string, path string) int {
+	for i, dir := range dirs {
+		if fsutil.IsPathInDir(dir, path) {
+			return i
+		}
+	}
+
+	return len(dirs)
+}
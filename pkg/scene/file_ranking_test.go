@@ -0,0 +1,104 @@
+package scene
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeRankingFile(id int, path string, width, height int, codec string, bitrate int64, format string) *models.VideoFile {
+	return &models.VideoFile{
+		BaseFile: &models.BaseFile{
+			ID:   models.FileID(id),
+			Path: path,
+		},
+		Width:      width,
+		Height:     height,
+		VideoCodec: codec,
+		BitRate:    bitrate,
+		Format:     format,
+	}
+}
+
+func rankedIDs(files []*models.VideoFile) []models.FileID {
+	var ret []models.FileID
+	for _, f := range files {
+		ret = append(ret, f.ID)
+	}
+	return ret
+}
+
+func TestRankFiles(t *testing.T) {
+	preferred := filepath.Join("stash", "preferred")
+	other := filepath.Join("stash", "other")
+
+	sd := makeRankingFile(1, filepath.Join(other, "sd.mp4"), 640, 480, "h264", 2000000, "mp4")
+	hd := makeRankingFile(2, filepath.Join(other, "hd.mkv"), 1920, 1080, "h264", 8000000, "matroska")
+	hdHevc := makeRankingFile(3, filepath.Join(preferred, "hd.mp4"), 1920, 1080, "HEVC", 4000000, "mp4")
+
+	files := []*models.VideoFile{sd, hd, hdHevc}
+
+	tests := []struct {
+		name    string
+		ranking models.SceneFileRanking
+		want    []models.FileID
+	}{
+		{
+			"default",
+			models.DefaultSceneFileRanking(),
+			[]models.FileID{2, 3, 1},
+		},
+		{
+			"no criteria keeps order",
+			models.SceneFileRanking{},
+			[]models.FileID{1, 2, 3},
+		},
+		{
+			"preferred codec",
+			models.SceneFileRanking{
+				Criteria:        []models.SceneFileRankingCriterion{models.SceneFileRankingCriterionResolution, models.SceneFileRankingCriterionCodec},
+				PreferredCodecs: []string{"hevc"},
+			},
+			[]models.FileID{3, 2, 1},
+		},
+		{
+			"preferred container",
+			models.SceneFileRanking{
+				Criteria:            []models.SceneFileRankingCriterion{models.SceneFileRankingCriterionContainer, models.SceneFileRankingCriterionBitrate},
+				PreferredContainers: []string{"matroska", "mp4"},
+			},
+			[]models.FileID{2, 3, 1},
+		},
+		{
+			"path priority before resolution",
+			models.SceneFileRanking{
+				Criteria:     []models.SceneFileRankingCriterion{models.SceneFileRankingCriterionPath, models.SceneFileRankingCriterionResolution},
+				PathPriority: []string{preferred},
+			},
+			[]models.FileID{3, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankFiles(files, tt.ranking)
+			assert.Equal(t, tt.want, rankedIDs(got))
+		})
+	}
+
+	// input is not modified
+	assert.Equal(t, []models.FileID{1, 2, 3}, rankedIDs(files))
+}
+
+func TestCompareFilesEqual(t *testing.T) {
+	a := makeRankingFile(1, "a.mp4", 1920, 1080, "h264", 8000000, "mp4")
+	b := makeRankingFile(2, "b.mp4", 1080, 1920, "H264", 8000000, "MP4")
+
+	ranking := models.DefaultSceneFileRanking()
+	ranking.PreferredCodecs = []string{"hevc", "h264"}
+	ranking.PreferredContainers = []string{"mp4"}
+
+	assert.Equal(t, 0, CompareFiles(a, b, ranking))
+}
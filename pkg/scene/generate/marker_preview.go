@@ -2,6 +2,7 @@ package generate
 
 import (
 	"context"
+	"math"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
@@ -20,7 +21,9 @@ const (
 	markerScreenshotQuality = 2
 )
 
-func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds int, includeAudio bool) error {
+// MarkerPreviewVideo generates the video that is streamed for a marker. If
+// endSeconds is set, the video covers the marker range.
+func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds int, endSeconds *float64, includeAudio bool) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -32,8 +35,9 @@ func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash st
 	}
 
 	if err := g.generateFile(lockCtx, g.MarkerPaths, mp4Pattern, output, g.markerPreviewVideo(input, sceneMarkerOptions{
-		Seconds: seconds,
-		Audio:   includeAudio,
+		Seconds:  seconds,
+		Duration: markerDuration(seconds, endSeconds, markerPreviewDuration),
+		Audio:    includeAudio,
 	})); err != nil {
 		return err
 	}
@@ -44,8 +48,24 @@ func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash st
 }
 
 type sceneMarkerOptions struct {
-	Seconds int
-	Audio   bool
+	Seconds  int
+	Duration float64
+	Audio    bool
+}
+
+// markerDuration returns the duration of a marker starting at seconds. Returns
+// defaultDuration if the marker does not have an end time.
+func markerDuration(seconds int, endSeconds *float64, defaultDuration float64) float64 {
+	if endSeconds == nil {
+		return defaultDuration
+	}
+
+	d := *endSeconds - float64(seconds)
+	if d <= 0 {
+		return defaultDuration
+	}
+
+	return d
 }
 
 func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions) generateFn {
@@ -69,7 +89,7 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 		)
 
 		trimOptions := transcoder.TranscodeOptions{
-			Duration:   markerPreviewDuration,
+			Duration:   options.Duration,
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibX264,
@@ -90,7 +110,9 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 	}
 }
 
-func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash string, seconds int) error {
+// SceneMarkerWebp generates the animated preview image for a marker. If
+// endSeconds is set, the preview is limited to the marker range.
+func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash string, seconds int, endSeconds *float64) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -102,7 +124,8 @@ func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash strin
 	}
 
 	if err := g.generateFile(lockCtx, g.MarkerPaths, webpPattern, output, g.sceneMarkerWebp(input, sceneMarkerOptions{
-		Seconds: seconds,
+		Seconds:  seconds,
+		Duration: math.Min(markerDuration(seconds, endSeconds, markerImageDuration), markerImageDuration),
 	})); err != nil {
 		return err
 	}
@@ -130,7 +153,7 @@ func (g Generator) sceneMarkerWebp(input string, options sceneMarkerOptions) gen
 		)
 
 		trimOptions := transcoder.TranscodeOptions{
-			Duration:   markerImageDuration,
+			Duration:   options.Duration,
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibWebP,
@@ -64,3 +64,37 @@ func (g Generator) screenshot(input string, options screenshotOptions) generateF
 		return g.generate(lockCtx, args)
 	}
 }
+
+const (
+	waveformWidth  = 1280
+	waveformHeight = 720
+)
+
+// Waveform generates an image of the audio waveform of the input file. It is
+// used in place of a screenshot for files without a video stream.
+func (g Generator) Waveform(ctx context.Context, input string) ([]byte, error) {
+	lockCtx := g.LockManager.ReadLock(ctx, input)
+	defer lockCtx.Cancel()
+
+	logger.Infof("Creating waveform for %s", input)
+
+	ret, err := g.generateBytes(lockCtx, g.ScenePaths, jpgPattern, g.waveform(input))
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (g Generator) waveform(input string) generateFn {
+	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
+		args := transcoder.Waveform(input, transcoder.WaveformOptions{
+			OutputPath: tmpFn,
+			OutputType: transcoder.ScreenshotOutputTypeImage2,
+			Width:      waveformWidth,
+			Height:     waveformHeight,
+		})
+
+		return g.generate(lockCtx, args)
+	}
+}
@@ -35,6 +35,18 @@ func (i *MarkerImporter) PreImport(ctx context.Context) error {
 		UpdatedAt: i.Input.UpdatedAt.GetTime(),
 	}
 
+	if i.Input.EndSeconds != "" {
+		endSeconds, err := strconv.ParseFloat(i.Input.EndSeconds, 64)
+		if err != nil {
+			return fmt.Errorf("invalid end_seconds %q: %w", i.Input.EndSeconds, err)
+		}
+		i.marker.EndSeconds = &endSeconds
+	}
+
+	if err := ValidateMarkerEndSeconds(i.marker.Seconds, i.marker.EndSeconds); err != nil {
+		return err
+	}
+
 	if err := i.populateTags(ctx); err != nil {
 		return err
 	}
@@ -0,0 +1,49 @@
+package scene
+
+import (
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type MarkerEndSecondsError struct {
+	Seconds    float64
+	EndSeconds float64
+}
+
+func (e *MarkerEndSecondsError) Error() string {
+	return fmt.Sprintf("marker end time %v must be after start time %v", e.EndSeconds, e.Seconds)
+}
+
+// ValidateMarkerEndSeconds returns an error if the end time is not after the start time.
+func ValidateMarkerEndSeconds(seconds float64, endSeconds *float64) error {
+	if endSeconds == nil {
+		return nil
+	}
+
+	if *endSeconds <= seconds {
+		return &MarkerEndSecondsError{Seconds: seconds, EndSeconds: *endSeconds}
+	}
+
+	return nil
+}
+
+// ValidateUpdateMarkerEndSeconds performs the same check as ValidateMarkerEndSeconds, but is used when modifying an existing marker.
+func ValidateUpdateMarkerEndSeconds(existing models.SceneMarker, seconds models.OptionalFloat64, endSeconds models.OptionalFloat64) error {
+	// if neither seconds nor endSeconds is set, don't check anything
+	if !seconds.Set && !endSeconds.Set {
+		return nil
+	}
+
+	newSeconds := existing.Seconds
+	if seconds.Set {
+		newSeconds = seconds.Value
+	}
+
+	newEndSeconds := existing.EndSeconds
+	if endSeconds.Set {
+		newEndSeconds = endSeconds.Ptr()
+	}
+
+	return ValidateMarkerEndSeconds(newSeconds, newEndSeconds)
+}
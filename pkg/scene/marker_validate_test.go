@@ -0,0 +1,72 @@
+package scene
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMarkerEndSeconds(t *testing.T) {
+	var (
+		end5  = 5.0
+		end10 = 10.0
+	)
+
+	tests := []struct {
+		name       string
+		seconds    float64
+		endSeconds *float64
+		want       error
+	}{
+		{"end nil", 10, nil, nil},
+		{"valid", 5, &end10, nil},
+		{"invalid", 10, &end5, &MarkerEndSecondsError{10, 5}},
+		{"same time", 10, &end10, &MarkerEndSecondsError{10, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateMarkerEndSeconds(tt.seconds, tt.endSeconds)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateUpdateMarkerEndSeconds(t *testing.T) {
+	end20 := 20.0
+
+	existing := models.SceneMarker{
+		Seconds:    10,
+		EndSeconds: &end20,
+	}
+
+	ofUnset := models.OptionalFloat64{}
+	ofNull := models.OptionalFloat64{Set: true, Null: true}
+	of5 := models.NewOptionalFloat64(5)
+	of15 := models.NewOptionalFloat64(15)
+	of25 := models.NewOptionalFloat64(25)
+
+	tests := []struct {
+		name       string
+		seconds    models.OptionalFloat64
+		endSeconds models.OptionalFloat64
+		want       error
+	}{
+		{"both unset", ofUnset, ofUnset, nil},
+		{"valid seconds set", of15, ofUnset, nil},
+		{"invalid seconds set", of25, ofUnset, &MarkerEndSecondsError{25, 20}},
+		{"valid end set", ofUnset, of15, nil},
+		{"invalid end set", ofUnset, of5, &MarkerEndSecondsError{10, 5}},
+		{"end set null", of25, ofNull, nil},
+		{"invalid both set", of15, of5, &MarkerEndSecondsError{15, 5}},
+		{"valid both set", of5, of15, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateUpdateMarkerEndSeconds(existing, tt.seconds, tt.endSeconds)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Package scheduler provides cron expression parsing and a scheduler that
+// runs functions according to cron schedules.
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as an alias for Sunday
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Cron is a parsed cron expression.
+type Cron struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// if either day field is unrestricted, then both must match
+	// otherwise, either may match
+	domStar bool
+	dowStar bool
+}
+
+// ParseCron parses a standard five field cron expression, consisting of
+// minute, hour, day of month, month and day of week. Fields may contain
+// wildcards, lists, ranges and steps. Month and day of week fields accept
+// three letter names. The descriptors @yearly, @annually, @monthly, @weekly,
+// @daily, @midnight and @hourly are also accepted.
+func ParseCron(spec string) (*Cron, error) {
+	spec = strings.TrimSpace(spec)
+	if d, found := cronDescriptors[strings.ToLower(spec)]; found {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, found %d", ErrInvalidCron, len(fields))
+	}
+
+	var ret Cron
+	var err error
+
+	if ret.minute, err = parseCronField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if ret.hour, err = parseCronField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if ret.dom, err = parseCronField(fields[2], domField); err != nil {
+		return nil, err
+	}
+	if ret.month, err = parseCronField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if ret.dow, err = parseCronField(fields[4], dowField); err != nil {
+		return nil, err
+	}
+
+	// treat 7 as Sunday
+	if ret.dow&(1<<7) != 0 {
+		ret.dow |= 1
+		ret.dow &^= 1 << 7
+	}
+
+	ret.domStar = strings.HasPrefix(fields[2], "*")
+	ret.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return &ret, nil
+}
+
+func parseCronField(s string, f cronField) (uint64, error) {
+	var ret uint64
+	for _, part := range strings.Split(s, ",") {
+		bits, err := parseCronRange(part, f)
+		if err != nil {
+			return 0, err
+		}
+		ret |= bits
+	}
+
+	return ret, nil
+}
+
+func parseCronRange(s string, f cronField) (uint64, error) {
+	rangeStr, stepStr, hasStep := strings.Cut(s, "/")
+
+	start, end := f.min, f.max
+	step := 1
+
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepStr)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("%w: invalid step %q in %s field", ErrInvalidCron, stepStr, f.name)
+		}
+	}
+
+	if rangeStr != "*" {
+		startStr, endStr, isRange := strings.Cut(rangeStr, "-")
+
+		var err error
+		start, err = parseCronValue(startStr, f)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case isRange:
+			end, err = parseCronValue(endStr, f)
+			if err != nil {
+				return 0, err
+			}
+		case !hasStep:
+			// single value
+			end = start
+		}
+
+		if start > end {
+			return 0, fmt.Errorf("%w: invalid range %q in %s field", ErrInvalidCron, rangeStr, f.name)
+		}
+	}
+
+	var ret uint64
+	for i := start; i <= end; i += step {
+		ret |= 1 << uint(i)
+	}
+
+	return ret, nil
+}
+
+func parseCronValue(s string, f cronField) (int, error) {
+	if v, found := f.names[strings.ToLower(s)]; found {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: invalid value %q in %s field", ErrInvalidCron, s, f.name)
+	}
+
+	return v, nil
+}
+
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// Next returns the first time matching the cron expression that is after
+// the provided time. Returns the zero time if no matching time is found
+// within the next five years.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// start at the beginning of the next minute
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
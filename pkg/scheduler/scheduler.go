@@ -0,0 +1,210 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry is a function that is run according to a cron schedule.
+type Entry struct {
+	ID       string
+	Schedule *Cron
+	Run      func()
+}
+
+type entryState struct {
+	Entry
+	next time.Time
+}
+
+// Scheduler runs entries according to their schedules.
+// The last run time of each entry is retained while the scheduler is running,
+// including when the entries are replaced. Use LastRuns and SetLastRun to
+// retain the last run times across restarts.
+type Scheduler struct {
+	mutex   sync.Mutex
+	entries map[string]*entryState
+	lastRun map[string]time.Time
+
+	wake chan struct{}
+	stop chan struct{}
+
+	// now returns the current time. Overridden in tests.
+	now func() time.Time
+}
+
+func New() *Scheduler {
+	return &Scheduler{
+		entries: make(map[string]*entryState),
+		lastRun: make(map[string]time.Time),
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+// Set replaces the scheduled entries.
+func (s *Scheduler) Set(entries []Entry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.entries = make(map[string]*entryState)
+	for _, e := range entries {
+		s.entries[e.ID] = &entryState{
+			Entry: e,
+			next:  e.Schedule.Next(now),
+		}
+	}
+
+	s.notify()
+}
+
+// Start starts running the scheduled entries in a separate goroutine.
+// Does nothing if the scheduler is already running.
+func (s *Scheduler) Start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	go s.run(s.stop)
+}
+
+// Stop stops the scheduler. Entries that are already running are not
+// interrupted.
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// NextRun returns the next time the entry with the provided ID will be run.
+// Returns nil if the entry is not scheduled.
+func (s *Scheduler) NextRun(id string) *time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e := s.entries[id]
+	if e == nil || e.next.IsZero() {
+		return nil
+	}
+
+	ret := e.next
+	return &ret
+}
+
+// LastRun returns the last time the entry with the provided ID was run.
+// Returns nil if the entry has not been run.
+func (s *Scheduler) LastRun(id string) *time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t, found := s.lastRun[id]
+	if !found {
+		return nil
+	}
+
+	return &t
+}
+
+// LastRuns returns the last run times of all entries that have been run,
+// keyed by entry ID.
+func (s *Scheduler) LastRuns() map[string]time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := make(map[string]time.Time, len(s.lastRun))
+	for id, t := range s.lastRun {
+		ret[id] = t
+	}
+
+	return ret
+}
+
+// SetLastRun sets the last run time of the entry with the provided ID.
+// Used to restore last run times from a previous session.
+func (s *Scheduler) SetLastRun(id string, t time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastRun[id] = t
+}
+
+// notify wakes the run loop so that the next run time is recalculated.
+// Assumes the mutex is held.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// nextWake returns the earliest next run time of the entries.
+func (s *Scheduler) nextWake() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret time.Time
+	for _, e := range s.entries {
+		if e.next.IsZero() {
+			continue
+		}
+
+		if ret.IsZero() || e.next.Before(ret) {
+			ret = e.next
+		}
+	}
+
+	return ret
+}
+
+func (s *Scheduler) run(stop chan struct{}) {
+	for {
+		var timer <-chan time.Time
+		var t *time.Timer
+
+		if next := s.nextWake(); !next.IsZero() {
+			t = time.NewTimer(next.Sub(s.now()))
+			timer = t.C
+		}
+
+		select {
+		case <-stop:
+			if t != nil {
+				t.Stop()
+			}
+			return
+		case <-s.wake:
+			if t != nil {
+				t.Stop()
+			}
+		case <-timer:
+			s.runDue()
+		}
+	}
+}
+
+// runDue runs all entries that are due and calculates their next run time.
+func (s *Scheduler) runDue() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	for _, e := range s.entries {
+		if e.next.IsZero() || e.next.After(now) {
+			continue
+		}
+
+		s.lastRun[e.ID] = now
+		e.next = e.Schedule.Next(now)
+
+		go e.Run()
+	}
+}
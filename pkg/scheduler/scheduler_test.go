@@ -0,0 +1,82 @@
+package scheduler
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunDue(t *testing.T) {
+	now := time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC)
+
+	s := New()
+	s.now = func() time.Time { return now }
+
+	hourly, _ := ParseCron("@hourly")
+	daily, _ := ParseCron("@daily")
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	ran := make(map[string]int)
+	run := func(id string) func() {
+		return func() {
+			mutex.Lock()
+			ran[id]++
+			mutex.Unlock()
+			wg.Done()
+		}
+	}
+
+	s.Set([]Entry{
+		{ID: "hourly", Schedule: hourly, Run: run("hourly")},
+		{ID: "daily", Schedule: daily, Run: run("daily")},
+	})
+
+	assert := assert.New(t)
+
+	if got := s.NextRun("hourly"); assert.NotNil(got) {
+		assert.Equal(time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC), *got)
+	}
+	assert.Nil(s.NextRun("missing"))
+
+	// nothing is due yet
+	s.runDue()
+	assert.Nil(s.LastRun("hourly"))
+
+	now = time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC)
+	wg.Add(1)
+	s.runDue()
+	wg.Wait()
+
+	assert.Equal(map[string]int{"hourly": 1}, ran)
+	if got := s.LastRun("hourly"); assert.NotNil(got) {
+		assert.Equal(now, *got)
+	}
+	if got := s.NextRun("hourly"); assert.NotNil(got) {
+		assert.Equal(time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC), *got)
+	}
+
+	// last run is retained when entries are replaced
+	s.Set([]Entry{
+		{ID: "hourly", Schedule: hourly, Run: run("hourly")},
+	})
+	if got := s.LastRun("hourly"); assert.NotNil(got) {
+		assert.Equal(now, *got)
+	}
+	assert.Nil(s.NextRun("daily"))
+}
+
+func TestSchedulerSetLastRun(t *testing.T) {
+	s := New()
+
+	last := time.Date(2024, 1, 9, 10, 0, 0, 0, time.UTC)
+	s.SetLastRun("hourly", last)
+
+	if got := s.LastRun("hourly"); assert.NotNil(t, got) {
+		assert.Equal(t, last, *got)
+	}
+
+	assert.Equal(t, map[string]time.Time{"hourly": last}, s.LastRuns())
+}
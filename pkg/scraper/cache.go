@@ -69,6 +69,7 @@ type StudioFinder interface {
 type TagFinder interface {
 	models.TagGetter
 	models.TagAutoTagQueryer
+	FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Tag, error)
 }
 
 type GalleryFinder interface {
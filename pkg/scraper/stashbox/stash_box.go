@@ -55,6 +55,7 @@ type StudioReader interface {
 type TagFinder interface {
 	models.TagQueryer
 	FindBySceneID(ctx context.Context, sceneID int) ([]*models.Tag, error)
+	FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Tag, error)
 }
 
 type Repository struct {
@@ -817,10 +818,11 @@ func (c Client) sceneFragmentToScrapedScene(ctx context.Context, s *graphql.Scen
 
 		for _, t := range s.Tags {
 			st := &models.ScrapedTag{
-				Name: t.Name,
+				Name:         t.Name,
+				RemoteSiteID: &t.ID,
 			}
 
-			err := match.ScrapedTag(ctx, tqb, st)
+			err := match.ScrapedTag(ctx, tqb, st, &c.box.Endpoint)
 			if err != nil {
 				return err
 			}
@@ -938,6 +940,43 @@ func (c Client) FindStashBoxStudio(ctx context.Context, query string) (*models.S
 	return ret, nil
 }
 
+// FindStashBoxTag finds a tag on the stash-box by stash ID, or by name or
+// alias. Returns nil if no tag was found.
+func (c Client) FindStashBoxTag(ctx context.Context, query string) (*models.ScrapedTag, error) {
+	var tag *graphql.FindTag
+
+	_, err := uuid.FromString(query)
+	if err == nil {
+		// Confirmed the user passed in a Stash ID
+		tag, err = c.client.FindTag(ctx, &query, nil)
+	} else {
+		// Otherwise assume they're searching on a name
+		tag, err = c.client.FindTag(ctx, nil, &query)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tag.FindTag == nil {
+		return nil, nil
+	}
+
+	ret := &models.ScrapedTag{
+		Name:         tag.FindTag.Name,
+		RemoteSiteID: &tag.FindTag.ID,
+	}
+
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		return match.ScrapedTag(ctx, r.Tag, ret, &c.box.Endpoint)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (c Client) GetUser(ctx context.Context) (*graphql.Me, error) {
 	return c.client.Me(ctx)
 }
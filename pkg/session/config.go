@@ -15,3 +15,14 @@ type SessionConfig interface {
 	GetMaxSessionAge() int
 	ValidateCredentials(username string, password string) bool
 }
+
+// UserStore provides access to user accounts other than the user configured
+// in SessionConfig.
+type UserStore interface {
+	// ValidateUserCredentials returns true if the username and password
+	// match a user account.
+	ValidateUserCredentials(username string, password string) bool
+	// GetUsernameByAPIKey returns the username of the user account with the
+	// provided API key, or an empty string if there is none.
+	GetUsernameByAPIKey(apiKey string) string
+}
@@ -28,11 +28,18 @@ func (s *Store) VisitedPluginHandler() func(http.Handler) http.Handler {
 
 			// ignore errors
 			if err == nil {
-				val := session.Values[visitedPluginHooksKey]
+				val, isPlugin := session.Values[visitedPluginHooksKey]
 
 				visitedPlugins, _ := val.([]VisitedPluginHook)
 
 				ctx := setVisitedPluginHooks(r.Context(), visitedPlugins)
+
+				// only cookies created by MakePluginCookie contain the
+				// visited plugin hooks
+				if isPlugin {
+					ctx = context.WithValue(ctx, contextPluginSession, true)
+				}
+
 				r = r.WithContext(ctx)
 			}
 
@@ -50,6 +57,13 @@ func GetVisitedPluginHooks(ctx context.Context) []VisitedPluginHook {
 	return nil
 }
 
+// IsPluginSession returns true if the request was made by a plugin, using
+// the session cookie created by MakePluginCookie.
+func IsPluginSession(ctx context.Context) bool {
+	v, _ := ctx.Value(contextPluginSession).(bool)
+	return v
+}
+
 func AddVisitedPluginHook(ctx context.Context, pluginID string, hookType hook.TriggerEnum) context.Context {
 	curVal := GetVisitedPluginHooks(ctx)
 	curVal = append(curVal, VisitedPluginHook{PluginID: pluginID, HookType: hookType})
@@ -15,6 +15,7 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextPluginSession
 )
 
 const (
@@ -47,6 +48,7 @@ var ErrUnauthorized = errors.New("unauthorized")
 type Store struct {
 	sessionStore *sessions.CookieStore
 	config       SessionConfig
+	users        UserStore
 }
 
 func NewStore(c SessionConfig) *Store {
@@ -61,6 +63,20 @@ func NewStore(c SessionConfig) *Store {
 	return ret
 }
 
+// SetUserStore sets the store used to authenticate users other than the
+// configured user.
+func (s *Store) SetUserStore(users UserStore) {
+	s.users = users
+}
+
+func (s *Store) validateCredentials(username string, password string) bool {
+	if s.config.ValidateCredentials(username, password) {
+		return true
+	}
+
+	return s.users != nil && s.users.ValidateUserCredentials(username, password)
+}
+
 func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	// ignore error - we want a new session regardless
 	newSession, _ := s.sessionStore.Get(r, cookieName)
@@ -69,11 +85,11 @@ func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	password := r.FormValue(passwordFormKey)
 
 	// authenticate the user
-	if !s.config.ValidateCredentials(username, password) {
+	if !s.validateCredentials(username, password) {
 		return &InvalidCredentialsError{Username: username}
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Info("User logged in")
 
 	newSession.Values[userIDKey] = username
@@ -100,7 +116,7 @@ func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Infof("User logged out")
 
 	return nil
@@ -158,14 +174,19 @@ func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID str
 	}
 
 	if apiKey != "" {
-		// match against configured API and set userID to the
-		// configured username. In future, we'll want to
-		// get the username from the key.
-		if c.GetAPIKey() != apiKey {
-			return "", ErrUnauthorized
+		// match against the configured API key first, then against the
+		// API keys of the other users
+		if c.GetAPIKey() == apiKey {
+			userID = c.GetUsername()
+		} else {
+			if s.users != nil {
+				userID = s.users.GetUsernameByAPIKey(apiKey)
+			}
+
+			if userID == "" {
+				return "", ErrUnauthorized
+			}
 		}
-
-		userID = c.GetUsername()
 	} else {
 		// handle session
 		userID, err = s.GetSessionUserID(w, r)
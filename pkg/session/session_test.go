@@ -0,0 +1,91 @@
+package session
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type sessionConfig struct {
+	username string
+	password string
+	apiKey   string
+}
+
+func (c *sessionConfig) GetUsername() string {
+	return c.username
+}
+
+func (c *sessionConfig) GetAPIKey() string {
+	return c.apiKey
+}
+
+func (c *sessionConfig) GetSessionStoreKey() []byte {
+	return []byte("session-store-key")
+}
+
+func (c *sessionConfig) GetMaxSessionAge() int {
+	return 0
+}
+
+func (c *sessionConfig) ValidateCredentials(username string, password string) bool {
+	return username == c.username && password == c.password
+}
+
+type userStore map[string]string
+
+func (s userStore) ValidateUserCredentials(username string, password string) bool {
+	return false
+}
+
+func (s userStore) GetUsernameByAPIKey(apiKey string) string {
+	return s[apiKey]
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	const (
+		configUser   = "admin"
+		configAPIKey = "config-key"
+		user         = "viewer"
+		userAPIKey   = "user-key"
+	)
+
+	store := NewStore(&sessionConfig{
+		username: configUser,
+		password: "password",
+		apiKey:   configAPIKey,
+	})
+
+	tests := []struct {
+		name    string
+		users   UserStore
+		apiKey  string
+		want    string
+		wantErr error
+	}{
+		{"config key", nil, configAPIKey, configUser, nil},
+		{"config key with users", userStore{userAPIKey: user}, configAPIKey, configUser, nil},
+		{"user key", userStore{userAPIKey: user}, userAPIKey, user, nil},
+		{"user key without users", nil, userAPIKey, "", ErrUnauthorized},
+		{"invalid key", userStore{userAPIKey: user}, "invalid", "", ErrUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store.SetUserStore(tt.users)
+
+			r := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+			r.Header.Set(ApiKeyHeader, tt.apiKey)
+
+			got, err := store.Authenticate(httptest.NewRecorder(), r)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Authenticate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -44,3 +44,26 @@ func UniqueFold(s []string) []string {
 	}
 	return ret
 }
+
+// UniqueFoldExcept returns the trimmed, non-blank values from the provided
+// slice with case-insensitive duplicates and values matching except removed.
+// Returns an empty, non-nil slice if no values remain.
+func UniqueFoldExcept(s []string, except string) []string {
+	seen := map[string]bool{
+		strings.ToLower(except): true,
+	}
+
+	ret := []string{}
+	for _, v := range s {
+		v = strings.TrimSpace(v)
+		vL := strings.ToLower(v)
+		if v == "" || seen[vL] {
+			continue
+		}
+
+		seen[vL] = true
+		ret = append(ret, v)
+	}
+
+	return ret
+}
@@ -0,0 +1,29 @@
+package stringslice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueFoldExcept(t *testing.T) {
+	tests := []struct {
+		name   string
+		s      []string
+		except string
+		want   []string
+	}{
+		{"empty", nil, "name", []string{}},
+		{"unique", []string{"a", "b"}, "name", []string{"a", "b"}},
+		{"same as except", []string{"a", "Name"}, "name", []string{"a"}},
+		{"case duplicates", []string{"a", "A", "b"}, "name", []string{"a", "b"}},
+		{"blank", []string{" ", "a "}, "name", []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UniqueFoldExcept(tt.s, tt.except)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -49,6 +49,11 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 			func() error { return db.deleteStashIDs() },
 			func() error { return db.clearOHistory() },
 			func() error { return db.clearWatchHistory() },
+			func() error { return db.clearJobHistory() },
+			func() error { return db.clearWebhookDeliveries() },
+			func() error { return db.clearUsers() },
+			func() error { return db.clearTrashedFiles() },
+			func() error { return db.clearEntityHistory() },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
 			func() error { return db.anonymiseFingerprints(ctx) },
@@ -100,6 +105,7 @@ func (db *Anonymiser) deleteStashIDs() error {
 		func() error { return db.truncateTable("scene_stash_ids") },
 		func() error { return db.truncateTable("studio_stash_ids") },
 		func() error { return db.truncateTable("performer_stash_ids") },
+		func() error { return db.truncateTable("tag_stash_ids") },
 	})
 }
 
@@ -115,6 +121,33 @@ func (db *Anonymiser) clearWatchHistory() error {
 	})
 }
 
+func (db *Anonymiser) clearJobHistory() error {
+	// job descriptions and errors may contain paths
+	return db.truncateTable(jobHistoryTable)
+}
+
+func (db *Anonymiser) clearWebhookDeliveries() error {
+	return db.truncateTable(webhookDeliveryTable)
+}
+
+func (db *Anonymiser) clearUsers() error {
+	// user accounts contain credentials
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(scenesUsersTable) },
+		func() error { return db.truncateTable(userTable) },
+	})
+}
+
+func (db *Anonymiser) clearTrashedFiles() error {
+	// trash records contain paths and entity metadata
+	return db.truncateTable(trashedFileTable)
+}
+
+func (db *Anonymiser) clearEntityHistory() error {
+	// history records contain the previous values of anonymised fields
+	return db.truncateTable(entityHistoryTable)
+}
+
 func (db *Anonymiser) anonymiseFolders(ctx context.Context) error {
 	logger.Infof("Anonymising folders")
 	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
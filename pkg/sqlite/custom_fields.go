@@ -0,0 +1,231 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	customFieldsFieldColumn = "field"
+	customFieldsTypeColumn  = "type"
+	customFieldsValueColumn = "value"
+)
+
+// customFieldsTable manages a table of custom fields of an entity type.
+// Values are stored with their native SQLite type, so that they can be
+// compared with typed criterion values.
+type customFieldsTable struct {
+	table
+}
+
+type customFieldRow struct {
+	Field string      `db:"field"`
+	Type  string      `db:"type"`
+	Value interface{} `db:"value"`
+}
+
+func (r *customFieldRow) resolve() models.CustomField {
+	t := models.CustomFieldType(r.Type)
+	v := r.Value
+
+	switch t {
+	case models.CustomFieldTypeBool:
+		i, _ := v.(int64)
+		v = i != 0
+	case models.CustomFieldTypeFloat:
+		if i, ok := v.(int64); ok {
+			v = float64(i)
+		}
+	case models.CustomFieldTypeString, models.CustomFieldTypeDate:
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+	}
+
+	return models.CustomField{
+		Field: r.Field,
+		Type:  t,
+		Value: v,
+	}
+}
+
+func (t *customFieldsTable) get(ctx context.Context, id int) ([]models.CustomField, error) {
+	table := t.table.table
+	q := dialect.Select(
+		customFieldsFieldColumn,
+		customFieldsTypeColumn,
+		customFieldsValueColumn,
+	).From(table).Where(t.idColumn.Eq(id)).Order(table.Col(customFieldsFieldColumn).Asc())
+
+	const single = false
+	var ret []models.CustomField
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v customFieldRow
+		if err := rows.StructScan(&v); err != nil {
+			return err
+		}
+
+		ret = append(ret, v.resolve())
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting custom fields from %s: %w", table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+func (t *customFieldsTable) set(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	if input.Full != nil {
+		if err := t.destroy(ctx, []int{id}); err != nil {
+			return err
+		}
+
+		if err := t.setFields(ctx, id, input.Full); err != nil {
+			return err
+		}
+	}
+
+	if err := t.setFields(ctx, id, input.Partial); err != nil {
+		return err
+	}
+
+	if len(input.Remove) > 0 {
+		table := t.table.table
+		q := dialect.Delete(table).Where(
+			t.idColumn.Eq(id),
+			table.Col(customFieldsFieldColumn).In(input.Remove),
+		)
+
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("removing custom fields from %s: %w", table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) setFields(ctx context.Context, id int, fields []models.CustomField) error {
+	table := t.table.table
+	for _, f := range fields {
+		f, err := f.Normalize()
+		if err != nil {
+			return err
+		}
+
+		q := dialect.Delete(table).Where(
+			t.idColumn.Eq(id),
+			table.Col(customFieldsFieldColumn).Eq(f.Field),
+		)
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("removing custom field from %s: %w", table.GetTable(), err)
+		}
+
+		iq := dialect.Insert(table).Cols(
+			t.idColumn.GetCol(),
+			customFieldsFieldColumn,
+			customFieldsTypeColumn,
+			customFieldsValueColumn,
+		).Vals(
+			goqu.Vals{id, f.Field, f.Type.String(), customFieldDBValue(f.Value)},
+		)
+		if _, err := exec(ctx, iq); err != nil {
+			return fmt.Errorf("inserting into %s: %w", table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+// customFieldDBValue returns the value to store or compare against in the
+// database. Booleans are stored as integers.
+func customFieldDBValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return int64(1)
+		}
+		return int64(0)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+
+	return v
+}
+
+// customFieldsStore implements models.CustomFieldsReader and
+// models.CustomFieldsWriter for stores.
+type customFieldsStore struct {
+	customFieldsTable *customFieldsTable
+}
+
+func (s *customFieldsStore) GetCustomFields(ctx context.Context, id int) ([]models.CustomField, error) {
+	return s.customFieldsTable.get(ctx, id)
+}
+
+func (s *customFieldsStore) SetCustomFields(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	return s.customFieldsTable.set(ctx, id, input)
+}
+
+type customFieldsCriterionHandler struct {
+	c           []models.CustomFieldCriterionInput
+	table       *customFieldsTable
+	parentIDCol string
+}
+
+func (h *customFieldsCriterionHandler) handle(ctx context.Context, f *filterBuilder) {
+	for _, c := range h.c {
+		h.handleCriterion(f, c)
+	}
+}
+
+func (h *customFieldsCriterionHandler) handleCriterion(f *filterBuilder, c models.CustomFieldCriterionInput) {
+	t := h.table.table.table.GetTable()
+	fk := h.table.idColumn.GetCol()
+
+	exists := fmt.Sprintf("EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.%[2]s = %[3]s AND %[1]s.%[4]s = ?", t, fk, h.parentIDCol, customFieldsFieldColumn)
+	notExists := "NOT " + exists
+
+	switch c.Modifier {
+	case models.CriterionModifierIsNull:
+		f.addWhere(notExists+")", c.Field)
+		return
+	case models.CriterionModifierNotNull:
+		f.addWhere(exists+")", c.Field)
+		return
+	}
+
+	if c.Value == nil {
+		f.setError(fmt.Errorf("custom field %q: value is required for modifier %s", c.Field, c.Modifier))
+		return
+	}
+
+	v := customFieldDBValue(c.Value)
+	valueCol := t + "." + customFieldsValueColumn
+
+	switch c.Modifier {
+	case models.CriterionModifierEquals:
+		f.addWhere(exists+" AND "+valueCol+" = ?)", c.Field, v)
+	case models.CriterionModifierNotEquals:
+		f.addWhere(notExists+" AND "+valueCol+" = ?)", c.Field, v)
+	case models.CriterionModifierIncludes:
+		f.addWhere(exists+" AND "+valueCol+" LIKE ?)", c.Field, "%"+fmt.Sprint(v)+"%")
+	case models.CriterionModifierExcludes:
+		f.addWhere(notExists+" AND "+valueCol+" LIKE ?)", c.Field, "%"+fmt.Sprint(v)+"%")
+	case models.CriterionModifierGreaterThan:
+		f.addWhere(exists+" AND "+valueCol+" > ?)", c.Field, v)
+	case models.CriterionModifierLessThan:
+		f.addWhere(exists+" AND "+valueCol+" < ?)", c.Field, v)
+	default:
+		f.setError(fmt.Errorf("custom field %q: unsupported modifier %s", c.Field, c.Modifier))
+	}
+}
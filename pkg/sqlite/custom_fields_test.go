@@ -0,0 +1,157 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCustomFields(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		id := sceneIDs[sceneIdxWithGallery]
+
+		if err := qb.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: []models.CustomField{
+				{Field: "batch", Type: models.CustomFieldTypeString, Value: "b1"},
+				{Field: "score", Type: models.CustomFieldTypeInt, Value: 3.0},
+				{Field: "licensed", Type: models.CustomFieldTypeBool, Value: true},
+			},
+		}); err != nil {
+			t.Errorf("SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		if err := qb.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Partial: []models.CustomField{
+				{Field: "ratio", Type: models.CustomFieldTypeFloat, Value: 1.5},
+				{Field: "score", Type: models.CustomFieldTypeInt, Value: 4},
+			},
+			Remove: []string{"batch"},
+		}); err != nil {
+			t.Errorf("SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.GetCustomFields(ctx, id)
+		if err != nil {
+			t.Errorf("GetCustomFields() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, []models.CustomField{
+			{Field: "licensed", Type: models.CustomFieldTypeBool, Value: true},
+			{Field: "ratio", Type: models.CustomFieldTypeFloat, Value: 1.5},
+			{Field: "score", Type: models.CustomFieldTypeInt, Value: int64(4)},
+		}, got)
+
+		// invalid values must be rejected
+		err = qb.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Partial: []models.CustomField{
+				{Field: "score", Type: models.CustomFieldTypeInt, Value: "four"},
+			},
+		})
+		assert.ErrorIs(t, err, models.ErrCustomFieldValue)
+
+		return nil
+	})
+}
+
+func TestPerformerQueryCustomFields(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+		id1 := performerIDs[performerIdx1WithScene]
+		id2 := performerIDs[performerIdx2WithScene]
+
+		if err := qb.SetCustomFields(ctx, id1, models.CustomFieldsInput{
+			Full: []models.CustomField{
+				{Field: "source", Type: models.CustomFieldTypeString, Value: "batch one"},
+				{Field: "height", Type: models.CustomFieldTypeInt, Value: 170},
+				{Field: "checked", Type: models.CustomFieldTypeDate, Value: "2024-01-02"},
+			},
+		}); err != nil {
+			t.Errorf("SetCustomFields() error = %v", err)
+			return nil
+		}
+		if err := qb.SetCustomFields(ctx, id2, models.CustomFieldsInput{
+			Full: []models.CustomField{
+				{Field: "source", Type: models.CustomFieldTypeString, Value: "batch two"},
+				{Field: "height", Type: models.CustomFieldTypeInt, Value: 180},
+			},
+		}); err != nil {
+			t.Errorf("SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		tests := []struct {
+			name      string
+			criterion models.CustomFieldCriterionInput
+			included  []int
+			excluded  []int
+		}{
+			{
+				"equals",
+				models.CustomFieldCriterionInput{Field: "source", Value: "batch one", Modifier: models.CriterionModifierEquals},
+				[]int{id1},
+				[]int{id2},
+			},
+			{
+				"contains",
+				models.CustomFieldCriterionInput{Field: "source", Value: "batch", Modifier: models.CriterionModifierIncludes},
+				[]int{id1, id2},
+				nil,
+			},
+			{
+				"greater than",
+				models.CustomFieldCriterionInput{Field: "height", Value: 175, Modifier: models.CriterionModifierGreaterThan},
+				[]int{id2},
+				[]int{id1},
+			},
+			{
+				"less than date",
+				models.CustomFieldCriterionInput{Field: "checked", Value: "2024-02-01", Modifier: models.CriterionModifierLessThan},
+				[]int{id1},
+				[]int{id2},
+			},
+			{
+				"is null",
+				models.CustomFieldCriterionInput{Field: "checked", Modifier: models.CriterionModifierIsNull},
+				[]int{id2},
+				[]int{id1},
+			},
+			{
+				"not null",
+				models.CustomFieldCriterionInput{Field: "checked", Modifier: models.CriterionModifierNotNull},
+				[]int{id1},
+				[]int{id2},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				performers := queryPerformers(ctx, t, &models.PerformerFilterType{
+					CustomFields: []models.CustomFieldCriterionInput{tt.criterion},
+				}, nil)
+
+				var ids []int
+				for _, p := range performers {
+					ids = append(ids, p.ID)
+				}
+
+				for _, id := range tt.included {
+					assert.Contains(t, ids, id)
+				}
+				for _, id := range tt.excluded {
+					assert.NotContains(t, ids, id)
+				}
+			})
+		}
+
+		return nil
+	})
+}
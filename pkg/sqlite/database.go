@@ -30,7 +30,7 @@ const (
 	dbConnTimeout = 30
 )
 
-var appSchemaVersion uint = 67
+var appSchemaVersion uint = 79
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -39,6 +39,10 @@ var (
 	// ErrDatabaseNotInitialized indicates that the database is not
 	// initialized, usually due to an incomplete configuration.
 	ErrDatabaseNotInitialized = errors.New("database not initialized")
+
+	// ErrFTS5NotAvailable indicates that the SQLite library was built
+	// without the FTS5 extension, which is required for the search index.
+	ErrFTS5NotAvailable = errors.New("SQLite FTS5 extension is not available - stash must be built with the sqlite_fts5 build tag")
 )
 
 // ErrMigrationNeeded indicates that a database migration is needed
@@ -62,19 +66,24 @@ func (e *MismatchedSchemaVersionError) Error() string {
 }
 
 type storeRepository struct {
-	Blobs          *BlobStore
-	File           *FileStore
-	Folder         *FolderStore
-	Image          *ImageStore
-	Gallery        *GalleryStore
-	GalleryChapter *GalleryChapterStore
-	Scene          *SceneStore
-	SceneMarker    *SceneMarkerStore
-	Performer      *PerformerStore
-	SavedFilter    *SavedFilterStore
-	Studio         *StudioStore
-	Tag            *TagStore
-	Group          *GroupStore
+	Blobs           *BlobStore
+	File            *FileStore
+	Folder          *FolderStore
+	Image           *ImageStore
+	Gallery         *GalleryStore
+	GalleryChapter  *GalleryChapterStore
+	Scene           *SceneStore
+	SceneMarker     *SceneMarkerStore
+	Performer       *PerformerStore
+	SavedFilter     *SavedFilterStore
+	Studio          *StudioStore
+	Tag             *TagStore
+	Group           *GroupStore
+	JobHistory      *JobHistoryStore
+	WebhookDelivery *WebhookDeliveryStore
+	User            *UserStore
+	TrashedFile     *TrashedFileStore
+	EntityHistory   *EntityHistoryStore
 }
 
 type Database struct {
@@ -99,19 +108,24 @@ func NewDatabase() *Database {
 
 	r := &storeRepository{}
 	*r = storeRepository{
-		Blobs:          blobStore,
-		File:           fileStore,
-		Folder:         folderStore,
-		Scene:          NewSceneStore(r, blobStore),
-		SceneMarker:    NewSceneMarkerStore(),
-		Image:          NewImageStore(r),
-		Gallery:        galleryStore,
-		GalleryChapter: NewGalleryChapterStore(),
-		Performer:      performerStore,
-		Studio:         studioStore,
-		Tag:            tagStore,
-		Group:          NewGroupStore(blobStore),
-		SavedFilter:    NewSavedFilterStore(),
+		Blobs:           blobStore,
+		File:            fileStore,
+		Folder:          folderStore,
+		Scene:           NewSceneStore(r, blobStore),
+		SceneMarker:     NewSceneMarkerStore(),
+		Image:           NewImageStore(r),
+		Gallery:         galleryStore,
+		GalleryChapter:  NewGalleryChapterStore(),
+		Performer:       performerStore,
+		Studio:          studioStore,
+		Tag:             tagStore,
+		Group:           NewGroupStore(blobStore),
+		SavedFilter:     NewSavedFilterStore(),
+		JobHistory:      NewJobHistoryStore(),
+		WebhookDelivery: NewWebhookDeliveryStore(),
+		User:            NewUserStore(),
+		TrashedFile:     NewTrashedFileStore(),
+		EntityHistory:   NewEntityHistoryStore(),
 	}
 
 	ret := &Database{
@@ -145,6 +159,10 @@ func (db *Database) Open(dbPath string) error {
 
 	db.dbPath = dbPath
 
+	if err := checkFTS5(); err != nil {
+		return err
+	}
+
 	databaseSchemaVersion, err := db.getDatabaseSchemaVersion()
 	if err != nil {
 		return fmt.Errorf("getting database schema version: %w", err)
@@ -246,6 +264,27 @@ func (db *Database) open(disableForeignKeys bool) (*sqlx.DB, error) {
 	return conn, nil
 }
 
+// checkFTS5 returns ErrFTS5NotAvailable if the SQLite library does not
+// support FTS5 tables.
+func checkFTS5() error {
+	conn, err := sqlx.Open(sqlite3Driver, ":memory:")
+	if err != nil {
+		return fmt.Errorf("db.Open(): %w", err)
+	}
+	defer conn.Close()
+
+	var enabled bool
+	if err := conn.QueryRow("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return fmt.Errorf("checking for FTS5 support: %w", err)
+	}
+
+	if !enabled {
+		return ErrFTS5NotAvailable
+	}
+
+	return nil
+}
+
 func (db *Database) Remove() error {
 	databasePath := db.dbPath
 	err := db.Close()
@@ -307,6 +346,26 @@ func (db *Database) Backup(backupPath string) (err error) {
 	return nil
 }
 
+// Copy copies the database into outPath and opens the copy. Blobs written
+// to the copy are stored in the copy, so that the blobs of this database
+// are not affected.
+func (db *Database) Copy(outPath string) (*Database, error) {
+	if _, err := db.db.Exec(fmt.Sprintf(`VACUUM INTO "%s"`, outPath)); err != nil {
+		return nil, fmt.Errorf("vacuuming into %s: %w", outPath, err)
+	}
+
+	ret := NewDatabase()
+	ret.SetBlobStoreOptions(BlobStoreOptions{
+		UseDatabase: true,
+	})
+
+	if err := ret.Open(outPath); err != nil {
+		return nil, fmt.Errorf("opening %s: %w", outPath, err)
+	}
+
+	return ret, nil
+}
+
 func (db *Database) Anonymise(outPath string) error {
 	anon, err := NewAnonymiser(db, outPath)
 
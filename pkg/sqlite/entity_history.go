@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	entityHistoryTable = "entity_history"
+)
+
+type entityHistoryRow struct {
+	ID         int          `db:"id" goqu:"skipinsert"`
+	EntityType string       `db:"entity_type"`
+	EntityID   int          `db:"entity_id"`
+	Source     string       `db:"source"`
+	Username   null.String  `db:"username"`
+	Changes    string       `db:"changes"`
+	CreatedAt  UTCTimestamp `db:"created_at"`
+}
+
+func (r *entityHistoryRow) fromEntityHistory(o models.EntityHistory) error {
+	changes, err := json.Marshal(o.Changes)
+	if err != nil {
+		return fmt.Errorf("encoding changes: %w", err)
+	}
+
+	r.ID = o.ID
+	r.EntityType = o.EntityType.String()
+	r.EntityID = o.EntityID
+	r.Source = o.Source.String()
+	r.Username = null.StringFromPtr(o.Username)
+	r.Changes = string(changes)
+	r.CreatedAt = UTCTimestamp{Timestamp{Timestamp: o.CreatedAt}}
+
+	return nil
+}
+
+func (r *entityHistoryRow) resolve() (*models.EntityHistory, error) {
+	ret := &models.EntityHistory{
+		ID:         r.ID,
+		EntityType: models.HistoryEntityType(r.EntityType),
+		EntityID:   r.EntityID,
+		Source:     models.HistorySource(r.Source),
+		Username:   r.Username.Ptr(),
+		CreatedAt:  r.CreatedAt.Timestamp.Timestamp,
+	}
+
+	if err := json.Unmarshal([]byte(r.Changes), &ret.Changes); err != nil {
+		return nil, fmt.Errorf("decoding changes of history %d: %w", r.ID, err)
+	}
+
+	return ret, nil
+}
+
+type EntityHistoryStore struct {
+	tableMgr *table
+}
+
+func NewEntityHistoryStore() *EntityHistoryStore {
+	return &EntityHistoryStore{
+		tableMgr: entityHistoryTableMgr,
+	}
+}
+
+func (qb *EntityHistoryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *EntityHistoryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *EntityHistoryStore) Create(ctx context.Context, newObject *models.EntityHistory) error {
+	var r entityHistoryRow
+	if err := r.fromEntityHistory(*newObject); err != nil {
+		return err
+	}
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+// returns nil, nil if not found
+func (qb *EntityHistoryStore) Find(ctx context.Context, id int) (*models.EntityHistory, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *EntityHistoryStore) FindByEntity(ctx context.Context, entityType models.HistoryEntityType, entityID int) ([]*models.EntityHistory, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("entity_type").Eq(entityType.String()),
+		table.Col("entity_id").Eq(entityID),
+	).Order(table.Col("created_at").Desc(), table.Col(idColumn).Desc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *EntityHistoryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.EntityHistory, error) {
+	const single = false
+	var ret []*models.EntityHistory
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f entityHistoryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		h, err := f.resolve()
+		if err != nil {
+			return err
+		}
+
+		ret = append(ret, h)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,233 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/history"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityHistoryFindByEntity(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.EntityHistory
+
+		username := "user"
+		oldTime := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		newTime := time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)
+
+		oldChange := models.EntityHistory{
+			EntityType: models.HistoryEntityTypeScene,
+			EntityID:   sceneIDs[sceneIdx1WithPerformer],
+			Source:     models.HistorySourceUI,
+			Username:   &username,
+			Changes: []models.FieldChange{
+				{
+					Field:    "title",
+					OldValue: json.RawMessage(`"old"`),
+					NewValue: json.RawMessage(`"new"`),
+				},
+			},
+			CreatedAt: oldTime,
+		}
+		newChange := models.EntityHistory{
+			EntityType: models.HistoryEntityTypeScene,
+			EntityID:   sceneIDs[sceneIdx1WithPerformer],
+			Source:     models.HistorySourceIdentify,
+			Changes: []models.FieldChange{
+				{
+					Field:    "rating100",
+					OldValue: json.RawMessage(`null`),
+					NewValue: json.RawMessage(`60`),
+				},
+			},
+			CreatedAt: newTime,
+		}
+		otherEntity := models.EntityHistory{
+			EntityType: models.HistoryEntityTypePerformer,
+			EntityID:   sceneIDs[sceneIdx1WithPerformer],
+			Source:     models.HistorySourceAPIKey,
+			Changes:    []models.FieldChange{},
+			CreatedAt:  newTime,
+		}
+
+		for _, o := range []*models.EntityHistory{&oldChange, &newChange, &otherEntity} {
+			if err := qb.Create(ctx, o); err != nil {
+				t.Errorf("Error creating entity history: %v", err)
+				return nil
+			}
+		}
+
+		assert := assert.New(t)
+
+		got, err := qb.FindByEntity(ctx, models.HistoryEntityTypeScene, sceneIDs[sceneIdx1WithPerformer])
+		if err != nil {
+			t.Errorf("Error finding entity history: %v", err)
+			return nil
+		}
+
+		// most recent change first
+		if assert.Len(got, 2) {
+			assert.Equal(newChange.ID, got[0].ID)
+			assert.Nil(got[0].Username)
+			assert.Equal(oldChange.ID, got[1].ID)
+			assert.Equal(models.HistorySourceUI, got[1].Source)
+			assert.Equal(&username, got[1].Username)
+			assert.Equal(oldChange.Changes, got[1].Changes)
+			assert.True(oldTime.Equal(got[1].CreatedAt))
+		}
+
+		found, err := qb.Find(ctx, otherEntity.ID)
+		if err != nil {
+			t.Errorf("Error finding entity history: %v", err)
+			return nil
+		}
+
+		if assert.NotNil(found) {
+			assert.Equal(models.HistoryEntityTypePerformer, found.EntityType)
+		}
+
+		found, err = qb.Find(ctx, otherEntity.ID+1)
+		if err != nil {
+			t.Errorf("Error finding entity history: %v", err)
+			return nil
+		}
+
+		assert.Nil(found)
+
+		return nil
+	})
+}
+
+func TestEntityHistoryRecordAndRevert(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		r := history.Wrap(db.Repository())
+		ctx = models.WithHistorySource(ctx, models.HistorySourceAPIKey)
+
+		id := sceneIDs[sceneIdxWithTwoTags]
+		before, err := r.Scene.Find(ctx, id)
+		if err != nil {
+			t.Errorf("Error finding scene: %v", err)
+			return nil
+		}
+
+		if err := before.LoadTagIDs(ctx, r.Scene); err != nil {
+			t.Errorf("Error loading tag IDs: %v", err)
+			return nil
+		}
+
+		if _, err := r.Scene.UpdatePartial(ctx, id, models.ScenePartial{
+			Title: models.NewOptionalString("changed title"),
+			TagIDs: &models.UpdateIDs{
+				IDs:  []int{tagIDs[tagIdx1WithScene]},
+				Mode: models.RelationshipUpdateModeSet,
+			},
+		}); err != nil {
+			t.Errorf("Error updating scene: %v", err)
+			return nil
+		}
+
+		assert := assert.New(t)
+
+		got, err := r.EntityHistory.FindByEntity(ctx, models.HistoryEntityTypeScene, id)
+		if err != nil {
+			t.Errorf("Error finding entity history: %v", err)
+			return nil
+		}
+
+		if !assert.Len(got, 1) {
+			return nil
+		}
+
+		assert.Equal(models.HistorySourceAPIKey, got[0].Source)
+		if assert.Len(got[0].Changes, 2) {
+			assert.Equal("title", got[0].Changes[0].Field)
+			assert.Equal("tag_ids", got[0].Changes[1].Field)
+		}
+
+		if err := history.Revert(ctx, r, got[0]); err != nil {
+			t.Errorf("Error reverting change: %v", err)
+			return nil
+		}
+
+		after, err := r.Scene.Find(ctx, id)
+		if err != nil {
+			t.Errorf("Error finding scene: %v", err)
+			return nil
+		}
+
+		if err := after.LoadTagIDs(ctx, r.Scene); err != nil {
+			t.Errorf("Error loading tag IDs: %v", err)
+			return nil
+		}
+
+		assert.Equal(before.Title, after.Title)
+		assert.ElementsMatch(before.TagIDs.List(), after.TagIDs.List())
+
+		// the revert is itself recorded, and the original change can no
+		// longer be reverted
+		got, err = r.EntityHistory.FindByEntity(ctx, models.HistoryEntityTypeScene, id)
+		if err != nil {
+			t.Errorf("Error finding entity history: %v", err)
+			return nil
+		}
+
+		if assert.Len(got, 2) {
+			assert.ErrorIs(history.Revert(ctx, r, got[1]), history.ErrChangedSince)
+		}
+
+		return nil
+	})
+}
+
+// BenchmarkEntityHistoryBulkSceneUpdate measures the cost of recording the
+// history of a bulk scene update, which loads each scene before and after
+// it is updated.
+func BenchmarkEntityHistoryBulkSceneUpdate(b *testing.B) {
+	tagged := models.ScenePartial{
+		TagIDs: &models.UpdateIDs{
+			IDs:  []int{tagIDs[tagIdx1WithScene]},
+			Mode: models.RelationshipUpdateModeAdd,
+		},
+	}
+	untracked := models.ScenePartial{
+		PlayDuration: models.NewOptionalFloat64(10),
+	}
+
+	benchmarks := []struct {
+		name    string
+		wrap    bool
+		partial models.ScenePartial
+	}{
+		{"without history", false, tagged},
+		{"with history", true, tagged},
+		{"untracked field with history", true, untracked},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			r := db.Repository()
+			if bm.wrap {
+				r = history.Wrap(r)
+			}
+
+			for i := 0; i < b.N; i++ {
+				withRollbackTxn(func(ctx context.Context) error {
+					for _, id := range sceneIDs {
+						if _, err := r.Scene.UpdatePartial(ctx, id, bm.partial); err != nil {
+							b.Errorf("Error updating scene: %v", err)
+							return nil
+						}
+					}
+					return nil
+				})
+			}
+		})
+	}
+}
@@ -59,6 +59,7 @@ type videoFileRow struct {
 	Duration         float64       `db:"duration"`
 	VideoCodec       string        `db:"video_codec"`
 	AudioCodec       string        `db:"audio_codec"`
+	AudioChannels    int           `db:"audio_channels"`
 	FrameRate        float64       `db:"frame_rate"`
 	BitRate          int64         `db:"bit_rate"`
 	Interactive      bool          `db:"interactive"`
@@ -73,6 +74,7 @@ func (f *videoFileRow) fromVideoFile(ff models.VideoFile) {
 	f.Duration = ff.Duration
 	f.VideoCodec = ff.VideoCodec
 	f.AudioCodec = ff.AudioCodec
+	f.AudioChannels = ff.AudioChannels
 	f.FrameRate = ff.FrameRate
 	f.BitRate = ff.BitRate
 	f.Interactive = ff.Interactive
@@ -103,6 +105,7 @@ type videoFileQueryRow struct {
 	Duration         null.Float  `db:"duration"`
 	VideoCodec       null.String `db:"video_codec"`
 	AudioCodec       null.String `db:"audio_codec"`
+	AudioChannels    null.Int    `db:"audio_channels"`
 	FrameRate        null.Float  `db:"frame_rate"`
 	BitRate          null.Int    `db:"bit_rate"`
 	Interactive      null.Bool   `db:"interactive"`
@@ -117,6 +120,7 @@ func (f *videoFileQueryRow) resolve() *models.VideoFile {
 		Duration:         f.Duration.Float64,
 		VideoCodec:       f.VideoCodec.String,
 		AudioCodec:       f.AudioCodec.String,
+		AudioChannels:    int(f.AudioChannels.Int64),
 		FrameRate:        f.FrameRate.Float64,
 		BitRate:          f.BitRate.Int64,
 		Interactive:      f.Interactive.Bool,
@@ -134,6 +138,7 @@ func videoFileQueryColumns() []interface{} {
 		table.Col("duration"),
 		table.Col("video_codec"),
 		table.Col("audio_codec"),
+		table.Col("audio_channels"),
 		table.Col("frame_rate"),
 		table.Col("bit_rate"),
 		table.Col("interactive"),
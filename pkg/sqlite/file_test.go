@@ -44,6 +44,7 @@ func Test_fileFileStore_Create(t *testing.T) {
 		bitrate    int64 = 234
 		videoCodec       = "videoCodec"
 		audioCodec       = "audioCodec"
+		channels         = 2
 		format           = "format"
 	)
 
@@ -108,6 +109,28 @@ func Test_fileFileStore_Create(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"audio file",
+			&models.VideoFile{
+				BaseFile: &models.BaseFile{
+					DirEntry: models.DirEntry{
+						ModTime: fileModTime,
+					},
+					Path:           getFilePath(folderIdxWithFiles, basename),
+					ParentFolderID: folderIDs[folderIdxWithFiles],
+					Basename:       basename,
+					Size:           size,
+					CreatedAt:      createdAt,
+					UpdatedAt:      updatedAt,
+				},
+				Duration:      duration,
+				AudioCodec:    audioCodec,
+				AudioChannels: channels,
+				Format:        format,
+				BitRate:       bitrate,
+			},
+			false,
+		},
 		{
 			"image file",
 			&models.ImageFile{
@@ -110,6 +110,10 @@ func (r *galleryRowRecord) fromPartial(o models.GalleryPartial) {
 	r.setNullInt("studio_id", o.StudioID)
 	r.setTimestamp("created_at", o.CreatedAt)
 	r.setTimestamp("updated_at", o.UpdatedAt)
+
+	if o.FolderID != nil {
+		r.set("folder_id", int(*o.FolderID))
+	}
 }
 
 type galleryRepositoryType struct {
@@ -181,6 +185,8 @@ var (
 )
 
 type GalleryStore struct {
+	customFieldsStore
+
 	tableMgr *table
 
 	fileStore   *FileStore
@@ -189,6 +195,9 @@ type GalleryStore struct {
 
 func NewGalleryStore(fileStore *FileStore, folderStore *FolderStore) *GalleryStore {
 	return &GalleryStore{
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: galleriesCustomFieldsTableMgr,
+		},
 		tableMgr:    galleryTableMgr,
 		fileStore:   fileStore,
 		folderStore: folderStore,
@@ -690,38 +699,40 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 	distinctIDs(&query, galleryTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    galleriesFilesTable,
-				onClause: "galleries_files.gallery_id = galleries.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "galleries_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = galleries_files.file_id",
-			},
-			join{
-				table:    folderTable,
-				as:       "gallery_folder",
-				onClause: "galleries.folder_id = gallery_folder.id",
-			},
-			join{
-				table:    galleriesChaptersTable,
-				onClause: "galleries_chapters.gallery_id = galleries.id",
-			},
-		)
-
-		// add joins for files and checksum
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"galleries.title", "gallery_folder.path", filepathColumn, "files_fingerprints.fingerprint", "galleries_chapters.title"}
-		query.parseQueryString(searchColumns, *q)
+		if !query.addSearch(gallerySearchIndex, "galleries.id", *q) {
+			query.addJoins(
+				join{
+					table:    galleriesFilesTable,
+					onClause: "galleries_files.gallery_id = galleries.id",
+				},
+				join{
+					table:    fileTable,
+					onClause: "galleries_files.file_id = files.id",
+				},
+				join{
+					table:    folderTable,
+					onClause: "files.parent_folder_id = folders.id",
+				},
+				join{
+					table:    fingerprintTable,
+					onClause: "files_fingerprints.file_id = galleries_files.file_id",
+				},
+				join{
+					table:    folderTable,
+					as:       "gallery_folder",
+					onClause: "galleries.folder_id = gallery_folder.id",
+				},
+				join{
+					table:    galleriesChaptersTable,
+					onClause: "galleries_chapters.gallery_id = galleries.id",
+				},
+			)
+
+			// add joins for files and checksum
+			filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
+			searchColumns := []string{"galleries.title", "gallery_folder.path", filepathColumn, "files_fingerprints.fingerprint", "galleries_chapters.title"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &galleryFilterHandler{
@@ -785,6 +796,11 @@ var gallerySortOptions = sortOptions{
 }
 
 func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.FindFilterType) error {
+	if sortClause, ok := query.searchSort(findFilter, "galleries.id"); ok {
+		query.sortAndPagination += sortClause
+		return nil
+	}
+
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -128,6 +128,7 @@ var (
 )
 
 type GroupStore struct {
+	customFieldsStore
 	blobJoinQueryBuilder
 	tagRelationshipStore
 	groupRelationshipStore
@@ -137,6 +138,9 @@ type GroupStore struct {
 
 func NewGroupStore(blobStore *BlobStore) *GroupStore {
 	return &GroupStore{
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: groupsCustomFieldsTableMgr,
+		},
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
 			joinTable: groupTable,
@@ -81,6 +81,12 @@ func (qb *groupFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{groupFilter.CreatedAt, "groups.created_at", nil},
 		&timestampCriterionHandler{groupFilter.UpdatedAt, "groups.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			c:           groupFilter.CustomFields,
+			table:       groupsCustomFieldsTableMgr,
+			parentIDCol: "groups.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "groups_scenes.scene_id",
 			relatedRepo:    sceneRepository.repository,
@@ -6,10 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/guregu/null.v4"
 	"gopkg.in/guregu/null.v4/zero"
 
@@ -28,6 +32,27 @@ const (
 	imageURLColumn        = "url"
 )
 
+var findExactImageDuplicateQuery = `
+SELECT GROUP_CONCAT(DISTINCT images.id) as ids
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+GROUP BY files_fingerprints.fingerprint
+HAVING COUNT(DISTINCT images.id) > 1
+ORDER BY SUM(files.size) DESC;
+`
+
+var findAllImagePhashesQuery = `
+SELECT images.id as id
+    , files_fingerprints.fingerprint as phash
+FROM images
+INNER JOIN images_files ON (images.id = images_files.image_id)
+INNER JOIN files ON (images_files.file_id = files.id)
+INNER JOIN files_fingerprints ON (images_files.file_id = files_fingerprints.file_id AND files_fingerprints.type = 'phash')
+ORDER BY files.size DESC;
+`
+
 type imageRow struct {
 	ID    int         `db:"id" goqu:"skipinsert"`
 	Title zero.String `db:"title"`
@@ -655,6 +680,81 @@ func (qb *ImageStore) FindByGalleryIDIndex(ctx context.Context, galleryID int, i
 	return ret[0], nil
 }
 
+func (qb *ImageStore) FindDuplicates(ctx context.Context, distance int) ([][]*models.Image, error) {
+	var dupeIds [][]int
+	if distance == 0 {
+		var ids []string
+		if err := dbWrapper.Select(ctx, &ids, findExactImageDuplicateQuery); err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			strIds := strings.Split(id, ",")
+			var imageIds []int
+			for _, strId := range strIds {
+				if intId, err := strconv.Atoi(strId); err == nil {
+					imageIds = sliceutil.AppendUnique(imageIds, intId)
+				}
+			}
+			// filter out
+			if len(imageIds) > 1 {
+				dupeIds = append(dupeIds, imageIds)
+			}
+		}
+	} else {
+		var hashes []*utils.Phash
+
+		if err := imageRepository.queryFunc(ctx, findAllImagePhashesQuery, nil, false, func(rows *sqlx.Rows) error {
+			phash := utils.Phash{
+				Bucket:   -1,
+				Duration: -1,
+			}
+			if err := rows.StructScan(&phash); err != nil {
+				return err
+			}
+
+			hashes = append(hashes, &phash)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		// images have no duration, so don't compare durations
+		const durationDiff = -1
+		dupeIds = utils.FindDuplicates(hashes, distance, durationDiff)
+	}
+
+	var duplicates [][]*models.Image
+	for _, imageIds := range dupeIds {
+		if images, err := qb.FindMany(ctx, imageIds); err == nil {
+			duplicates = append(duplicates, images)
+		}
+	}
+
+	sortImagesByPath(duplicates)
+
+	return duplicates, nil
+}
+
+func sortImagesByPath(images [][]*models.Image) {
+	lessFunc := func(i int, j int) bool {
+		firstPathI := getFirstImagePath(images[i])
+		firstPathJ := getFirstImagePath(images[j])
+		return firstPathI < firstPathJ
+	}
+	sort.SliceStable(images, lessFunc)
+}
+
+func getFirstImagePath(images []*models.Image) string {
+	var firstPath string
+	for i, image := range images {
+		if i == 0 || image.Path < firstPath {
+			firstPath = image.Path
+		}
+	}
+	return firstPath
+}
+
 func (qb *ImageStore) CountByGalleryID(ctx context.Context, galleryID int) (int, error) {
 	joinTable := goqu.T(galleriesImagesTable)
 
@@ -778,28 +878,30 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 	distinctIDs(&query, imageTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    imagesFilesTable,
-				onClause: "images_files.image_id = images.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "images_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = images_files.file_id",
-			},
-		)
+		if !query.addSearch(imageSearchIndex, "images.id", *q) {
+			query.addJoins(
+				join{
+					table:    imagesFilesTable,
+					onClause: "images_files.image_id = images.id",
+				},
+				join{
+					table:    fileTable,
+					onClause: "images_files.file_id = files.id",
+				},
+				join{
+					table:    folderTable,
+					onClause: "files.parent_folder_id = folders.id",
+				},
+				join{
+					table:    fingerprintTable,
+					onClause: "files_fingerprints.file_id = images_files.file_id",
+				},
+			)
 
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"images.title", filepathColumn, "files_fingerprints.fingerprint"}
-		query.parseQueryString(searchColumns, *q)
+			filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
+			searchColumns := []string{"images.title", filepathColumn, "files_fingerprints.fingerprint"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &imageFilterHandler{
@@ -927,7 +1029,9 @@ var imageSortOptions = sortOptions{
 func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *models.FindFilterType) error {
 	sortClause := ""
 
-	if findFilter != nil && findFilter.Sort != nil && *findFilter.Sort != "" {
+	if searchSort, ok := q.searchSort(findFilter, "images.id"); ok {
+		sortClause = searchSort
+	} else if findFilter != nil && findFilter.Sort != nil && *findFilter.Sort != "" {
 		sort := findFilter.GetSort("title")
 		direction := findFilter.GetDirection()
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -3018,6 +3019,110 @@ func TestImageQueryPagination(t *testing.T) {
 	})
 }
 
+func setImagePhashes(ctx context.Context, phashes map[int]int64) error {
+	for imageIdx, phash := range phashes {
+		f, err := db.File.Find(ctx, imageFileIDs[imageIdx])
+		if err != nil {
+			return err
+		}
+
+		ff := f[0]
+		ff.Base().Fingerprints = ff.Base().Fingerprints.AppendUnique(models.Fingerprint{
+			Type:        models.FingerprintTypePhash,
+			Fingerprint: phash,
+		})
+
+		if err := db.File.Update(ctx, ff); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestImageStore_FindDuplicates(t *testing.T) {
+	qb := db.Image
+
+	withRollbackTxn(func(ctx context.Context) error {
+		const phash = int64(0x7f3e1c0f0f1c3e7f)
+
+		if err := setImagePhashes(ctx, map[int]int64{
+			imageIdxWithGallery:  phash,
+			imageIdx1WithGallery: phash,
+			// one bit different
+			imageIdx2WithGallery: phash ^ 1,
+		}); err != nil {
+			t.Errorf("setting phashes: %v", err)
+			return nil
+		}
+
+		imageIDsOf := func(images []*models.Image) []int {
+			var ret []int
+			for _, i := range images {
+				ret = append(ret, i.ID)
+			}
+			return ret
+		}
+
+		got, err := qb.FindDuplicates(ctx, 0)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.ElementsMatch(t, []int{imageIDs[imageIdxWithGallery], imageIDs[imageIdx1WithGallery]}, imageIDsOf(got[0]))
+		}
+
+		got, err = qb.FindDuplicates(ctx, 1)
+		if err != nil {
+			t.Errorf("ImageStore.FindDuplicates() error = %v", err)
+			return nil
+		}
+
+		if assert.Len(t, got, 1) {
+			assert.ElementsMatch(t, []int{imageIDs[imageIdxWithGallery], imageIDs[imageIdx1WithGallery], imageIDs[imageIdx2WithGallery]}, imageIDsOf(got[0]))
+		}
+
+		return nil
+	})
+}
+
+func TestImageQueryPhashDistance(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		const phash = int64(0x7f3e1c0f0f1c3e7f)
+
+		if err := setImagePhashes(ctx, map[int]int64{
+			imageIdxWithGallery: phash,
+			// three bits different
+			imageIdx1WithGallery: phash ^ 7,
+		}); err != nil {
+			t.Errorf("setting phashes: %v", err)
+			return nil
+		}
+
+		distance := 0
+		imageFilter := models.ImageFilterType{
+			PhashDistance: &models.PhashDistanceCriterionInput{
+				Value:    utils.PhashToString(phash),
+				Modifier: models.CriterionModifierEquals,
+				Distance: &distance,
+			},
+		}
+
+		images := queryImages(ctx, t, db.Image, &imageFilter, nil)
+		if assert.Len(t, images, 1) {
+			assert.Equal(t, imageIDs[imageIdxWithGallery], images[0].ID)
+		}
+
+		distance = 4
+		images = queryImages(ctx, t, db.Image, &imageFilter, nil)
+		assert.Len(t, images, 2)
+
+		return nil
+	})
+}
+
 // TODO Count
 // TODO SizeCount
 // TODO All
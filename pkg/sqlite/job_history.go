@@ -0,0 +1,234 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	jobHistoryTable = "job_history"
+)
+
+type jobHistoryRow struct {
+	ID          int           `db:"id" goqu:"skipinsert"`
+	Description string        `db:"description"`
+	Status      string        `db:"status"`
+	Error       null.String   `db:"error"`
+	AddTime     UTCTimestamp  `db:"add_time"`
+	StartTime   NullTimestamp `db:"start_time"`
+	EndTime     NullTimestamp `db:"end_time"`
+	Counters    string        `db:"counters"`
+
+	ScheduledTaskID null.String `db:"scheduled_task_id"`
+}
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	ret := t.UTC()
+	return &ret
+}
+
+func (r *jobHistoryRow) fromJobHistory(o models.JobHistory) {
+	r.ID = o.ID
+	r.Description = o.Description
+	r.Status = o.Status
+	r.Error = null.StringFromPtr(o.Error)
+	r.AddTime = UTCTimestamp{Timestamp{Timestamp: o.AddTime}}
+	// times are stored in UTC so that they can be compared as strings
+	r.StartTime = NullTimestampFromTimePtr(utcTimePtr(o.StartTime))
+	r.EndTime = NullTimestampFromTimePtr(utcTimePtr(o.EndTime))
+	r.ScheduledTaskID = null.StringFromPtr(o.ScheduledTaskID)
+
+	if len(o.Counters) > 0 {
+		r.Counters = encodeJSONOrEmpty(o.Counters)
+	}
+}
+
+func (r *jobHistoryRow) resolve() *models.JobHistory {
+	ret := &models.JobHistory{
+		ID:          r.ID,
+		Description: r.Description,
+		Status:      r.Status,
+		Error:       r.Error.Ptr(),
+		AddTime:     r.AddTime.Timestamp.Timestamp,
+		StartTime:   r.StartTime.TimePtr(),
+		EndTime:     r.EndTime.TimePtr(),
+
+		ScheduledTaskID: r.ScheduledTaskID.Ptr(),
+	}
+
+	if r.Counters != "" {
+		ret.Counters = make(map[string]int)
+		decodeJSON(r.Counters, &ret.Counters)
+	}
+
+	return ret
+}
+
+type JobHistoryStore struct {
+	tableMgr *table
+}
+
+func NewJobHistoryStore() *JobHistoryStore {
+	return &JobHistoryStore{
+		tableMgr: jobHistoryTableMgr,
+	}
+}
+
+func (qb *JobHistoryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *JobHistoryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *JobHistoryStore) Create(ctx context.Context, newObject *models.JobHistory) error {
+	var r jobHistoryRow
+	r.fromJobHistory(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *JobHistoryStore) DestroyBefore(ctx context.Context, t time.Time) error {
+	table := qb.table()
+	q := dialect.Delete(table).Prepared(true).Where(
+		table.Col("end_time").Lt(UTCTimestamp{Timestamp{Timestamp: t}}),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying job history before %v: %w", t, err)
+	}
+
+	return nil
+}
+
+func (qb *JobHistoryStore) LastScheduledTaskRuns(ctx context.Context) (map[string]time.Time, error) {
+	table := qb.table()
+	q := dialect.From(table).Prepared(true).Select(
+		table.Col("scheduled_task_id"),
+		goqu.MAX(table.Col("add_time")),
+	).Where(table.Col("scheduled_task_id").IsNotNull()).GroupBy(table.Col("scheduled_task_id"))
+
+	ret := make(map[string]time.Time)
+	const single = false
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		// the aggregate is returned as a string rather than a time
+		var id, addTime string
+		if err := r.Scan(&id, &addTime); err != nil {
+			return err
+		}
+
+		t, err := time.Parse(TimestampFormat, addTime)
+		if err != nil {
+			return fmt.Errorf("parsing add time %q of scheduled task %s: %w", addTime, id, err)
+		}
+
+		ret[id] = t
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting last scheduled task runs: %w", err)
+	}
+
+	return ret, nil
+}
+
+var jobHistorySortOptions = []string{
+	"add_time",
+	"description",
+	"end_time",
+	"id",
+	"start_time",
+	"status",
+}
+
+func (qb *JobHistoryStore) Query(ctx context.Context, jobFilter *models.JobHistoryFilter, findFilter *models.FindFilterType) ([]*models.JobHistory, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	table := qb.table()
+
+	var where []exp.Expression
+	if jobFilter != nil && len(jobFilter.Status) > 0 {
+		where = append(where, table.Col("status").In(jobFilter.Status))
+	}
+
+	countQuery := dialect.From(table).Prepared(true).Select(goqu.COUNT("*")).Where(where...)
+	var count int
+	if err := querySimple(ctx, countQuery, &count); err != nil {
+		return nil, 0, err
+	}
+
+	// most recent first by default
+	sort := "end_time"
+	direction := "DESC"
+	if findFilter.Sort != nil && *findFilter.Sort != "" {
+		sort = findFilter.GetSort(sort)
+		direction = findFilter.GetDirection()
+	}
+
+	// ensure sort is in the list of allowed sorts
+	if !sliceutil.Contains(jobHistorySortOptions, sort) {
+		return nil, 0, fmt.Errorf("invalid sort: %s", sort)
+	}
+
+	sortCol := table.Col(sort)
+	var order exp.OrderedExpression
+	if direction == "DESC" {
+		order = sortCol.Desc()
+	} else {
+		order = sortCol.Asc()
+	}
+
+	q := qb.selectDataset().Prepared(true).Where(where...).Order(order, table.Col(idColumn).Desc())
+
+	if !findFilter.IsGetAll() {
+		pageSize := findFilter.GetPageSize()
+		q = q.Limit(uint(pageSize)).Offset(uint((findFilter.GetPage() - 1) * pageSize))
+	}
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ret, count, nil
+}
+
+func (qb *JobHistoryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.JobHistory, error) {
+	const single = false
+	var ret []*models.JobHistory
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f jobHistoryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
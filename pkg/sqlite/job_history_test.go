@@ -0,0 +1,133 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHistoryQuery(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.JobHistory
+
+		errStr := "failed"
+		startTime := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		oldEnd := time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)
+		newEnd := time.Date(2024, 2, 1, 4, 0, 0, 0, time.UTC)
+
+		old := models.JobHistory{
+			Description: "Scanning...",
+			Status:      "FINISHED",
+			AddTime:     startTime,
+			StartTime:   &startTime,
+			EndTime:     &oldEnd,
+			Counters:    map[string]int{"scanned": 10},
+		}
+		failed := models.JobHistory{
+			Description: "Identifying...",
+			Status:      "FAILED",
+			Error:       &errStr,
+			AddTime:     startTime,
+			StartTime:   &startTime,
+			EndTime:     &newEnd,
+		}
+
+		for _, o := range []*models.JobHistory{&old, &failed} {
+			if err := qb.Create(ctx, o); err != nil {
+				t.Errorf("Error creating job history: %v", err)
+				return nil
+			}
+		}
+
+		assert := assert.New(t)
+
+		got, count, err := qb.Query(ctx, nil, nil)
+		if err != nil {
+			t.Errorf("Error querying job history: %v", err)
+			return nil
+		}
+
+		// most recent first
+		assert.Equal(2, count)
+		if assert.Len(got, 2) {
+			assert.Equal(failed.ID, got[0].ID)
+			assert.Equal(errStr, *got[0].Error)
+			assert.Equal(old.ID, got[1].ID)
+			assert.Equal(old.Counters, got[1].Counters)
+			assert.True(oldEnd.Equal(*got[1].EndTime))
+		}
+
+		got, count, err = qb.Query(ctx, &models.JobHistoryFilter{Status: []string{"FAILED"}}, nil)
+		if err != nil {
+			t.Errorf("Error querying job history: %v", err)
+			return nil
+		}
+
+		assert.Equal(1, count)
+		if assert.Len(got, 1) {
+			assert.Equal(failed.ID, got[0].ID)
+		}
+
+		if err := qb.DestroyBefore(ctx, newEnd); err != nil {
+			t.Errorf("Error destroying job history: %v", err)
+			return nil
+		}
+
+		got, _, err = qb.Query(ctx, nil, nil)
+		if err != nil {
+			t.Errorf("Error querying job history: %v", err)
+			return nil
+		}
+
+		if assert.Len(got, 1) {
+			assert.Equal(failed.ID, got[0].ID)
+		}
+
+		return nil
+	})
+}
+
+func TestJobHistoryLastScheduledTaskRuns(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.JobHistory
+
+		taskID := "nightly-scan"
+		otherTaskID := "weekly-backup"
+		first := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		second := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+		other := time.Date(2024, 1, 3, 3, 0, 0, 0, time.UTC)
+		unscheduled := time.Date(2024, 1, 4, 3, 0, 0, 0, time.UTC)
+
+		for _, o := range []*models.JobHistory{
+			{Description: "Scanning...", Status: "FINISHED", AddTime: first, EndTime: &first, ScheduledTaskID: &taskID},
+			{Description: "Scanning...", Status: "FAILED", AddTime: second, EndTime: &second, ScheduledTaskID: &taskID},
+			{Description: "Backing up database...", Status: "FINISHED", AddTime: other, EndTime: &other, ScheduledTaskID: &otherTaskID},
+			{Description: "Scanning...", Status: "FINISHED", AddTime: unscheduled, EndTime: &unscheduled},
+		} {
+			if err := qb.Create(ctx, o); err != nil {
+				t.Errorf("Error creating job history: %v", err)
+				return nil
+			}
+		}
+
+		got, err := qb.LastScheduledTaskRuns(ctx)
+		if err != nil {
+			t.Errorf("Error getting last scheduled task runs: %v", err)
+			return nil
+		}
+
+		assert := assert.New(t)
+		if assert.Len(got, 2) {
+			assert.True(second.Equal(got[taskID]))
+			assert.True(other.Equal(got[otherTaskID]))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,12 @@
+CREATE TABLE `job_history` (
+  `id` integer not null primary key autoincrement,
+  `description` text not null,
+  `status` varchar(255) not null,
+  `error` text,
+  `add_time` datetime not null,
+  `start_time` datetime,
+  `end_time` datetime,
+  `counters` text
+);
+
+CREATE INDEX `index_job_history_end_time` ON `job_history` (`end_time`);
@@ -0,0 +1,16 @@
+CREATE TABLE `webhook_deliveries` (
+  `id` integer not null primary key autoincrement,
+  `webhook_id` varchar(255) not null,
+  `url` text not null,
+  `trigger` varchar(255) not null,
+  `entity_id` integer not null,
+  `attempt` integer not null,
+  `success` boolean not null,
+  `status_code` integer,
+  `error` text,
+  `duration` integer not null,
+  `created_at` datetime not null
+);
+
+CREATE INDEX `index_webhook_deliveries_webhook_id` ON `webhook_deliveries` (`webhook_id`);
+CREATE INDEX `index_webhook_deliveries_created_at` ON `webhook_deliveries` (`created_at`);
@@ -0,0 +1,33 @@
+CREATE TABLE `users` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `password_hash` varchar(255) not null,
+  `role` varchar(255) not null,
+  `api_key` varchar(255),
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_users_username_unique` ON `users` (`username`);
+
+-- per-user scene data. Data for the user configured in the config file
+-- remains in the scenes table.
+CREATE TABLE `scenes_users` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint,
+  `resume_time` float not null default 0,
+  `play_duration` float not null default 0,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `user_id`)
+);
+
+CREATE INDEX `index_scenes_users_user_id` ON `scenes_users` (`user_id`);
+
+-- history rows without a user belong to the user configured in the config file
+ALTER TABLE `scenes_view_dates` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+ALTER TABLE `scenes_o_dates` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+
+CREATE INDEX `index_scenes_view_dates_user_id` ON `scenes_view_dates` (`user_id`);
+CREATE INDEX `index_scenes_o_dates_user_id` ON `scenes_o_dates` (`user_id`);
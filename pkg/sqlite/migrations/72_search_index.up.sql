@@ -0,0 +1,367 @@
+-- Full-text search indexes. The *_search_text views define the text that is
+-- indexed for each object. The triggers keep the indexes up to date.
+
+CREATE VIRTUAL TABLE `scenes_fts` USING fts5(`title`, `details`, `code`, `markers`, `paths`, `fingerprints`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `scenes_search_text` AS
+SELECT
+  `scenes`.`id` AS `id`,
+  `scenes`.`title` AS `title`,
+  `scenes`.`details` AS `details`,
+  `scenes`.`code` AS `code`,
+  (SELECT group_concat(`scene_markers`.`title`, ' ') FROM `scene_markers` WHERE `scene_markers`.`scene_id` = `scenes`.`id`) AS `markers`,
+  (SELECT group_concat(`folders`.`path` || ' ' || `files`.`basename`, ' ') FROM `scenes_files`
+    INNER JOIN `files` ON `files`.`id` = `scenes_files`.`file_id`
+    INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`
+    WHERE `scenes_files`.`scene_id` = `scenes`.`id`) AS `paths`,
+  (SELECT group_concat(`files_fingerprints`.`fingerprint`, ' ') FROM `scenes_files`
+    INNER JOIN `files_fingerprints` ON `files_fingerprints`.`file_id` = `scenes_files`.`file_id`
+    WHERE `scenes_files`.`scene_id` = `scenes`.`id`) AS `fingerprints`
+FROM `scenes`;
+
+CREATE VIRTUAL TABLE `images_fts` USING fts5(`title`, `details`, `code`, `paths`, `fingerprints`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `images_search_text` AS
+SELECT
+  `images`.`id` AS `id`,
+  `images`.`title` AS `title`,
+  `images`.`details` AS `details`,
+  `images`.`code` AS `code`,
+  (SELECT group_concat(`folders`.`path` || ' ' || `files`.`basename`, ' ') FROM `images_files`
+    INNER JOIN `files` ON `files`.`id` = `images_files`.`file_id`
+    INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`
+    WHERE `images_files`.`image_id` = `images`.`id`) AS `paths`,
+  (SELECT group_concat(`files_fingerprints`.`fingerprint`, ' ') FROM `images_files`
+    INNER JOIN `files_fingerprints` ON `files_fingerprints`.`file_id` = `images_files`.`file_id`
+    WHERE `images_files`.`image_id` = `images`.`id`) AS `fingerprints`
+FROM `images`;
+
+CREATE VIRTUAL TABLE `galleries_fts` USING fts5(`title`, `details`, `code`, `chapters`, `paths`, `fingerprints`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `galleries_search_text` AS
+SELECT
+  `galleries`.`id` AS `id`,
+  `galleries`.`title` AS `title`,
+  `galleries`.`details` AS `details`,
+  `galleries`.`code` AS `code`,
+  (SELECT group_concat(`galleries_chapters`.`title`, ' ') FROM `galleries_chapters` WHERE `galleries_chapters`.`gallery_id` = `galleries`.`id`) AS `chapters`,
+  trim(
+    coalesce((SELECT `folders`.`path` FROM `folders` WHERE `folders`.`id` = `galleries`.`folder_id`), '') || ' ' ||
+    coalesce((SELECT group_concat(`folders`.`path` || ' ' || `files`.`basename`, ' ') FROM `galleries_files`
+      INNER JOIN `files` ON `files`.`id` = `galleries_files`.`file_id`
+      INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`
+      WHERE `galleries_files`.`gallery_id` = `galleries`.`id`), '')
+  ) AS `paths`,
+  (SELECT group_concat(`files_fingerprints`.`fingerprint`, ' ') FROM `galleries_files`
+    INNER JOIN `files_fingerprints` ON `files_fingerprints`.`file_id` = `galleries_files`.`file_id`
+    WHERE `galleries_files`.`gallery_id` = `galleries`.`id`) AS `fingerprints`
+FROM `galleries`;
+
+CREATE VIRTUAL TABLE `performers_fts` USING fts5(`name`, `disambiguation`, `aliases`, `details`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `performers_search_text` AS
+SELECT
+  `performers`.`id` AS `id`,
+  `performers`.`name` AS `name`,
+  `performers`.`disambiguation` AS `disambiguation`,
+  (SELECT group_concat(`performer_aliases`.`alias`, ' ') FROM `performer_aliases` WHERE `performer_aliases`.`performer_id` = `performers`.`id`) AS `aliases`,
+  `performers`.`details` AS `details`
+FROM `performers`;
+
+CREATE VIRTUAL TABLE `studios_fts` USING fts5(`name`, `aliases`, `details`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `studios_search_text` AS
+SELECT
+  `studios`.`id` AS `id`,
+  `studios`.`name` AS `name`,
+  (SELECT group_concat(`studio_aliases`.`alias`, ' ') FROM `studio_aliases` WHERE `studio_aliases`.`studio_id` = `studios`.`id`) AS `aliases`,
+  `studios`.`details` AS `details`
+FROM `studios`;
+
+CREATE VIRTUAL TABLE `tags_fts` USING fts5(`name`, `aliases`, `description`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `tags_search_text` AS
+SELECT
+  `tags`.`id` AS `id`,
+  `tags`.`name` AS `name`,
+  (SELECT group_concat(`tag_aliases`.`alias`, ' ') FROM `tag_aliases` WHERE `tag_aliases`.`tag_id` = `tags`.`id`) AS `aliases`,
+  `tags`.`description` AS `description`
+FROM `tags`;
+
+CREATE VIRTUAL TABLE `scene_markers_fts` USING fts5(`title`, `scene_title`, `primary_tag`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+CREATE VIEW `scene_markers_search_text` AS
+SELECT
+  `scene_markers`.`id` AS `id`,
+  `scene_markers`.`title` AS `title`,
+  (SELECT `scenes`.`title` FROM `scenes` WHERE `scenes`.`id` = `scene_markers`.`scene_id`) AS `scene_title`,
+  (SELECT `tags`.`name` FROM `tags` WHERE `tags`.`id` = `scene_markers`.`primary_tag_id`) AS `primary_tag`
+FROM `scene_markers`;
+
+-- scenes
+CREATE TRIGGER `scenes_fts_insert` AFTER INSERT ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `scenes_fts_update` AFTER UPDATE OF `title`, `details`, `code` ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `scenes_fts_delete` AFTER DELETE ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- images
+CREATE TRIGGER `images_fts_insert` AFTER INSERT ON `images` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `images_fts_update` AFTER UPDATE OF `title`, `details`, `code` ON `images` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `images_fts_delete` AFTER DELETE ON `images` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- galleries
+CREATE TRIGGER `galleries_fts_insert` AFTER INSERT ON `galleries` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `galleries_fts_update` AFTER UPDATE OF `title`, `details`, `code`, `folder_id` ON `galleries` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `galleries_fts_delete` AFTER DELETE ON `galleries` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- performers
+CREATE TRIGGER `performers_fts_insert` AFTER INSERT ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `disambiguation`, `aliases`, `details`) SELECT * FROM `performers_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `performers_fts_update` AFTER UPDATE OF `name`, `disambiguation`, `details` ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `disambiguation`, `aliases`, `details`) SELECT * FROM `performers_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `performers_fts_delete` AFTER DELETE ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- studios
+CREATE TRIGGER `studios_fts_insert` AFTER INSERT ON `studios` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`, `details`) SELECT * FROM `studios_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `studios_fts_update` AFTER UPDATE OF `name`, `details` ON `studios` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`, `details`) SELECT * FROM `studios_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `studios_fts_delete` AFTER DELETE ON `studios` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- tags
+CREATE TRIGGER `tags_fts_insert` AFTER INSERT ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`, `description`) SELECT * FROM `tags_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `tags_fts_update` AFTER UPDATE OF `name`, `description` ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`, `description`) SELECT * FROM `tags_search_text` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `tags_fts_delete` AFTER DELETE ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- scene_markers
+CREATE TRIGGER `scene_markers_fts_insert` AFTER INSERT ON `scene_markers` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `scene_title`, `primary_tag`) SELECT * FROM `scene_markers_search_text` WHERE `id` = NEW.`id`;
+  DELETE FROM `scenes_fts` WHERE `rowid` = NEW.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` = NEW.`scene_id`;
+END;
+
+CREATE TRIGGER `scene_markers_fts_update` AFTER UPDATE OF `title`, `scene_id`, `primary_tag_id` ON `scene_markers` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `scene_title`, `primary_tag`) SELECT * FROM `scene_markers_search_text` WHERE `id` = NEW.`id`;
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (OLD.`scene_id`, NEW.`scene_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` IN (OLD.`scene_id`, NEW.`scene_id`);
+END;
+
+CREATE TRIGGER `scene_markers_fts_delete` AFTER DELETE ON `scene_markers` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` = OLD.`id`;
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` = OLD.`scene_id`;
+END;
+
+CREATE TRIGGER `scene_markers_fts_scene_update` AFTER UPDATE OF `title` ON `scenes` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` IN (SELECT `id` FROM `scene_markers` WHERE `scene_id` = NEW.`id`);
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `scene_title`, `primary_tag`) SELECT * FROM `scene_markers_search_text` WHERE `id` IN (SELECT `id` FROM `scene_markers` WHERE `scene_id` = NEW.`id`);
+END;
+
+CREATE TRIGGER `scene_markers_fts_tag_update` AFTER UPDATE OF `name` ON `tags` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` IN (SELECT `id` FROM `scene_markers` WHERE `primary_tag_id` = NEW.`id`);
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `scene_title`, `primary_tag`) SELECT * FROM `scene_markers_search_text` WHERE `id` IN (SELECT `id` FROM `scene_markers` WHERE `primary_tag_id` = NEW.`id`);
+END;
+
+-- gallery chapters
+CREATE TRIGGER `galleries_chapters_fts_insert` AFTER INSERT ON `galleries_chapters` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = NEW.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` = NEW.`gallery_id`;
+END;
+
+CREATE TRIGGER `galleries_chapters_fts_update` AFTER UPDATE OF `title`, `gallery_id` ON `galleries_chapters` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (OLD.`gallery_id`, NEW.`gallery_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` IN (OLD.`gallery_id`, NEW.`gallery_id`);
+END;
+
+CREATE TRIGGER `galleries_chapters_fts_delete` AFTER DELETE ON `galleries_chapters` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` = OLD.`gallery_id`;
+END;
+
+-- aliases
+CREATE TRIGGER `performer_aliases_fts_insert` AFTER INSERT ON `performer_aliases` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = NEW.`performer_id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `disambiguation`, `aliases`, `details`) SELECT * FROM `performers_search_text` WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER `performer_aliases_fts_update` AFTER UPDATE ON `performer_aliases` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` IN (OLD.`performer_id`, NEW.`performer_id`);
+  INSERT INTO `performers_fts` (`rowid`, `name`, `disambiguation`, `aliases`, `details`) SELECT * FROM `performers_search_text` WHERE `id` IN (OLD.`performer_id`, NEW.`performer_id`);
+END;
+
+CREATE TRIGGER `performer_aliases_fts_delete` AFTER DELETE ON `performer_aliases` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = OLD.`performer_id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `disambiguation`, `aliases`, `details`) SELECT * FROM `performers_search_text` WHERE `id` = OLD.`performer_id`;
+END;
+
+CREATE TRIGGER `studio_aliases_fts_insert` AFTER INSERT ON `studio_aliases` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = NEW.`studio_id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`, `details`) SELECT * FROM `studios_search_text` WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER `studio_aliases_fts_update` AFTER UPDATE ON `studio_aliases` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` IN (OLD.`studio_id`, NEW.`studio_id`);
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`, `details`) SELECT * FROM `studios_search_text` WHERE `id` IN (OLD.`studio_id`, NEW.`studio_id`);
+END;
+
+CREATE TRIGGER `studio_aliases_fts_delete` AFTER DELETE ON `studio_aliases` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = OLD.`studio_id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`, `details`) SELECT * FROM `studios_search_text` WHERE `id` = OLD.`studio_id`;
+END;
+
+CREATE TRIGGER `tag_aliases_fts_insert` AFTER INSERT ON `tag_aliases` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = NEW.`tag_id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`, `description`) SELECT * FROM `tags_search_text` WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER `tag_aliases_fts_update` AFTER UPDATE ON `tag_aliases` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` IN (OLD.`tag_id`, NEW.`tag_id`);
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`, `description`) SELECT * FROM `tags_search_text` WHERE `id` IN (OLD.`tag_id`, NEW.`tag_id`);
+END;
+
+CREATE TRIGGER `tag_aliases_fts_delete` AFTER DELETE ON `tag_aliases` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = OLD.`tag_id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`, `description`) SELECT * FROM `tags_search_text` WHERE `id` = OLD.`tag_id`;
+END;
+
+-- files
+CREATE TRIGGER `scenes_files_fts_insert` AFTER INSERT ON `scenes_files` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = NEW.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` = NEW.`scene_id`;
+END;
+
+CREATE TRIGGER `scenes_files_fts_delete` AFTER DELETE ON `scenes_files` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` = OLD.`scene_id`;
+END;
+
+CREATE TRIGGER `images_files_fts_insert` AFTER INSERT ON `images_files` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = NEW.`image_id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` = NEW.`image_id`;
+END;
+
+CREATE TRIGGER `images_files_fts_delete` AFTER DELETE ON `images_files` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = OLD.`image_id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` = OLD.`image_id`;
+END;
+
+CREATE TRIGGER `galleries_files_fts_insert` AFTER INSERT ON `galleries_files` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = NEW.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` = NEW.`gallery_id`;
+END;
+
+CREATE TRIGGER `galleries_files_fts_delete` AFTER DELETE ON `galleries_files` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` = OLD.`gallery_id`;
+END;
+
+CREATE TRIGGER `files_fts_update` AFTER UPDATE OF `basename`, `parent_folder_id` ON `files` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`id`);
+END;
+
+CREATE TRIGGER `folders_fts_update` AFTER UPDATE OF `path` ON `folders` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scenes_files`.`scene_id` FROM `scenes_files` INNER JOIN `files` ON `files`.`id` = `scenes_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` IN (SELECT `scenes_files`.`scene_id` FROM `scenes_files` INNER JOIN `files` ON `files`.`id` = `scenes_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `images_files`.`image_id` FROM `images_files` INNER JOIN `files` ON `files`.`id` = `images_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` IN (SELECT `images_files`.`image_id` FROM `images_files` INNER JOIN `files` ON `files`.`id` = `images_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `galleries_files`.`gallery_id` FROM `galleries_files` INNER JOIN `files` ON `files`.`id` = `galleries_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id` UNION SELECT `id` FROM `galleries` WHERE `folder_id` = NEW.`id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` IN (SELECT `galleries_files`.`gallery_id` FROM `galleries_files` INNER JOIN `files` ON `files`.`id` = `galleries_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id` UNION SELECT `id` FROM `galleries` WHERE `folder_id` = NEW.`id`);
+END;
+
+CREATE TRIGGER `files_fingerprints_fts_insert` AFTER INSERT ON `files_fingerprints` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`file_id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`file_id`);
+END;
+
+CREATE TRIGGER `files_fingerprints_fts_update` AFTER UPDATE ON `files_fingerprints` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`file_id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`file_id`);
+END;
+
+CREATE TRIGGER `files_fingerprints_fts_delete` AFTER DELETE ON `files_fingerprints` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = OLD.`file_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = OLD.`file_id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = OLD.`file_id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = OLD.`file_id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = OLD.`file_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = OLD.`file_id`);
+END;
+
+-- populate the indexes
+INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `code`, `markers`, `paths`, `fingerprints`) SELECT * FROM `scenes_search_text`;
+INSERT INTO `images_fts` (`rowid`, `title`, `details`, `code`, `paths`, `fingerprints`) SELECT * FROM `images_search_text`;
+INSERT INTO `galleries_fts` (`rowid`, `title`, `details`, `code`, `chapters`, `paths`, `fingerprints`) SELECT * FROM `galleries_search_text`;
+INSERT INTO `performers_fts` (`rowid`, `name`, `disambiguation`, `aliases`, `details`) SELECT * FROM `performers_search_text`;
+INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`, `details`) SELECT * FROM `studios_search_text`;
+INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`, `description`) SELECT * FROM `tags_search_text`;
+INSERT INTO `scene_markers_fts` (`rowid`, `title`, `scene_title`, `primary_tag`) SELECT * FROM `scene_markers_search_text`;
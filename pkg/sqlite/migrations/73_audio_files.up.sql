@@ -0,0 +1 @@
+ALTER TABLE `video_files` ADD COLUMN `audio_channels` INTEGER NOT NULL DEFAULT 0;
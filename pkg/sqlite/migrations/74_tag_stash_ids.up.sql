@@ -0,0 +1,8 @@
+CREATE TABLE `tag_stash_ids` (
+  `tag_id` integer NOT NULL,
+  `endpoint` varchar(255) NOT NULL,
+  `stash_id` varchar(36) NOT NULL,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_tag_stash_ids_on_tag_id` ON `tag_stash_ids` (`tag_id`);
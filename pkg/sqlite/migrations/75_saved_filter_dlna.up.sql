@@ -0,0 +1 @@
+ALTER TABLE `saved_filters` ADD COLUMN `dlna_enabled` boolean not null default '0';
@@ -0,0 +1,13 @@
+CREATE TABLE `trashed_files` (
+  `id` integer not null primary key autoincrement,
+  `original_path` text not null,
+  `trash_path` text not null,
+  `size` integer not null,
+  `entity_type` varchar(255) not null,
+  `entity_id` integer not null,
+  `entity_title` text not null,
+  `entity_metadata` text not null,
+  `trashed_at` datetime not null
+);
+
+CREATE INDEX `index_trashed_files_trashed_at` ON `trashed_files` (`trashed_at`);
@@ -0,0 +1,11 @@
+CREATE TABLE `entity_history` (
+  `id` integer not null primary key autoincrement,
+  `entity_type` varchar(255) not null,
+  `entity_id` integer not null,
+  `source` varchar(255) not null,
+  `username` varchar(255),
+  `changes` text not null,
+  `created_at` datetime not null
+);
+
+CREATE INDEX `index_entity_history_on_entity` ON `entity_history` (`entity_type`, `entity_id`);
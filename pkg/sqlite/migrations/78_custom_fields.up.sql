@@ -0,0 +1,65 @@
+CREATE TABLE `scene_custom_fields` (
+  `scene_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  PRIMARY KEY (`scene_id`, `field`),
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_custom_fields_field_value` ON `scene_custom_fields` (`field`, `value`);
+
+CREATE TABLE `performer_custom_fields` (
+  `performer_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  PRIMARY KEY (`performer_id`, `field`),
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_performer_custom_fields_field_value` ON `performer_custom_fields` (`field`, `value`);
+
+CREATE TABLE `studio_custom_fields` (
+  `studio_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  PRIMARY KEY (`studio_id`, `field`),
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_studio_custom_fields_field_value` ON `studio_custom_fields` (`field`, `value`);
+
+CREATE TABLE `tag_custom_fields` (
+  `tag_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  PRIMARY KEY (`tag_id`, `field`),
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_tag_custom_fields_field_value` ON `tag_custom_fields` (`field`, `value`);
+
+CREATE TABLE `gallery_custom_fields` (
+  `gallery_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  PRIMARY KEY (`gallery_id`, `field`),
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_gallery_custom_fields_field_value` ON `gallery_custom_fields` (`field`, `value`);
+
+CREATE TABLE `group_custom_fields` (
+  `group_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  PRIMARY KEY (`group_id`, `field`),
+  foreign key(`group_id`) references `groups`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_group_custom_fields_field_value` ON `group_custom_fields` (`field`, `value`);
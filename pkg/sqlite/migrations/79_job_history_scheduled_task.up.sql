@@ -0,0 +1,3 @@
+ALTER TABLE `job_history` ADD COLUMN `scheduled_task_id` varchar(255);
+
+CREATE INDEX `index_job_history_scheduled_task_id` ON `job_history` (`scheduled_task_id`);
@@ -225,6 +225,7 @@ var (
 )
 
 type PerformerStore struct {
+	customFieldsStore
 	blobJoinQueryBuilder
 
 	tableMgr *table
@@ -232,6 +233,9 @@ type PerformerStore struct {
 
 func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
 	return &PerformerStore{
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: performersCustomFieldsTableMgr,
+		},
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
 			joinTable: performerTable,
@@ -377,6 +381,59 @@ func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
 	return performerRepository.destroyExisting(ctx, []int{id})
 }
 
+// Merge reassigns the scenes, images and galleries of the source performers
+// to the destination performer, and then destroys the source performers.
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+	args = append(args, destination)
+
+	joinTables := map[string]string{
+		performersScenesTable:    sceneIDColumn,
+		performersImagesTable:    imageIDColumn,
+		performersGalleriesTable: galleryIDColumn,
+	}
+
+	for table, idColumn := range joinTables {
+		_, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.performer_id = ?)`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// delete source performer ids from the table where they couldn't be set
+		if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // returns nil, nil if not found
 func (qb *PerformerStore) Find(ctx context.Context, id int) (*models.Performer, error) {
 	ret, err := qb.find(ctx, id)
@@ -599,9 +656,11 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(performersAliasesTable, "", "performer_aliases.performer_id = performers.id")
-		searchColumns := []string{"performers.name", "performer_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		if !query.addSearch(performerSearchIndex, "performers.id", *q) {
+			query.join(performersAliasesTable, "", "performer_aliases.performer_id = performers.id")
+			searchColumns := []string{"performers.name", "performer_aliases.alias"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &performerFilterHandler{
@@ -613,7 +672,7 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	}
 
 	var err error
-	query.sortAndPagination, err = qb.getPerformerSort(findFilter)
+	query.sortAndPagination, err = qb.getPerformerSort(&query, findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -731,7 +790,11 @@ var performerSortOptions = sortOptions{
 	"weight",
 }
 
-func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (string, error) {
+func (qb *PerformerStore) getPerformerSort(query *queryBuilder, findFilter *models.FindFilterType) (string, error) {
+	if sortClause, ok := query.searchSort(findFilter, "performers.id"); ok {
+		return sortClause, nil
+	}
+
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -168,6 +168,12 @@ func (qb *performerFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{filter.CreatedAt, tableName + ".created_at", nil},
 		&timestampCriterionHandler{filter.UpdatedAt, tableName + ".updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			c:           filter.CustomFields,
+			table:       performersCustomFieldsTableMgr,
+			parentIDCol: tableName + ".id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "performers_scenes.scene_id",
 			relatedRepo:    sceneRepository.repository,
@@ -1,6 +1,13 @@
 package sqlite
 
-import "github.com/corona10/goimagehash"
+import (
+	"context"
+
+	"github.com/corona10/goimagehash"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
 
 func phashDistanceFn(phash1 int64, phash2 int64) (int64, error) {
 	hash1 := goimagehash.NewImageHash(uint64(phash1), goimagehash.PHash)
@@ -8,3 +15,45 @@ func phashDistanceFn(phash1 int64, phash2 int64) (int64, error) {
 	distance, _ := hash1.Distance(hash2)
 	return int64(distance), nil
 }
+
+// phashDistanceCriterionHandler filters by the phash of the files of an
+// object. fileIDColumn is the file id column of the join table added by
+// addJoinFn.
+func phashDistanceCriterionHandler(phashDistance *models.PhashDistanceCriterionInput, fileIDColumn string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if phashDistance != nil {
+			addJoinFn(f)
+			f.addLeftJoin(fingerprintTable, "fingerprints_phash", fileIDColumn+" = fingerprints_phash.file_id AND fingerprints_phash.type = 'phash'")
+
+			value, _ := utils.StringToPhash(phashDistance.Value)
+			distance := 0
+			if phashDistance.Distance != nil {
+				distance = *phashDistance.Distance
+			}
+
+			if distance == 0 {
+				// use the default handler
+				intCriterionHandler(&models.IntCriterionInput{
+					Value:    int(value),
+					Modifier: phashDistance.Modifier,
+				}, "fingerprints_phash.fingerprint", nil)(ctx, f)
+			}
+
+			switch {
+			case phashDistance.Modifier == models.CriterionModifierEquals && distance > 0:
+				// needed to avoid a type mismatch
+				f.addWhere("typeof(fingerprints_phash.fingerprint) = 'integer'")
+				f.addWhere("phash_distance(fingerprints_phash.fingerprint, ?) < ?", value, distance)
+			case phashDistance.Modifier == models.CriterionModifierNotEquals && distance > 0:
+				// needed to avoid a type mismatch
+				f.addWhere("typeof(fingerprints_phash.fingerprint) = 'integer'")
+				f.addWhere("phash_distance(fingerprints_phash.fingerprint, ?) > ?", value, distance)
+			default:
+				intCriterionHandler(&models.IntCriterionInput{
+					Value:    int(value),
+					Modifier: phashDistance.Modifier,
+				}, "fingerprints_phash.fingerprint", nil)(ctx, f)
+			}
+		}
+	}
+}
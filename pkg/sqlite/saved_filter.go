@@ -28,6 +28,7 @@ type savedFilterRow struct {
 	FindFilter   string            `db:"find_filter"`
 	ObjectFilter string            `db:"object_filter"`
 	UIOptions    string            `db:"ui_options"`
+	DLNAEnabled  bool              `db:"dlna_enabled"`
 }
 
 func encodeJSONOrEmpty(v interface{}) string {
@@ -57,6 +58,7 @@ func (r *savedFilterRow) fromSavedFilter(o models.SavedFilter) {
 	r.ID = o.ID
 	r.Mode = o.Mode
 	r.Name = o.Name
+	r.DLNAEnabled = o.DLNAEnabled
 
 	// encode the filters as json
 	r.FindFilter = encodeJSONOrEmpty(o.FindFilter)
@@ -66,9 +68,10 @@ func (r *savedFilterRow) fromSavedFilter(o models.SavedFilter) {
 
 func (r *savedFilterRow) resolve() *models.SavedFilter {
 	ret := &models.SavedFilter{
-		ID:   r.ID,
-		Mode: r.Mode,
-		Name: r.Name,
+		ID:          r.ID,
+		Mode:        r.Mode,
+		Name:        r.Name,
+		DLNAEnabled: r.DLNAEnabled,
 	}
 
 	// decode the filters from json
@@ -96,6 +96,43 @@ func TestSavedFilterDestroy(t *testing.T) {
 	})
 }
 
+func TestSavedFilterDLNAEnabled(t *testing.T) {
+	newFilter := models.SavedFilter{
+		Name:        "dlnaFilter",
+		Mode:        models.FilterModeScenes,
+		DLNAEnabled: true,
+	}
+
+	if err := withTxn(func(ctx context.Context) error {
+		if err := db.SavedFilter.Create(ctx, &newFilter); err != nil {
+			return err
+		}
+
+		found, err := db.SavedFilter.Find(ctx, newFilter.ID)
+		if err != nil {
+			return err
+		}
+
+		assert.True(t, found.DLNAEnabled)
+
+		newFilter.DLNAEnabled = false
+		if err := db.SavedFilter.Update(ctx, &newFilter); err != nil {
+			return err
+		}
+
+		found, err = db.SavedFilter.Find(ctx, newFilter.ID)
+		if err != nil {
+			return err
+		}
+
+		assert.False(t, found.DLNAEnabled)
+
+		return db.SavedFilter.Destroy(ctx, newFilter.ID)
+	}); err != nil {
+		t.Error(err)
+	}
+}
+
 // TODO Update
 // TODO Destroy
 // TODO Find
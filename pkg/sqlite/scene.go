@@ -229,6 +229,7 @@ var (
 )
 
 type SceneStore struct {
+	customFieldsStore
 	blobJoinQueryBuilder
 
 	tableMgr *table
@@ -240,6 +241,9 @@ type SceneStore struct {
 
 func NewSceneStore(r *storeRepository, blobStore *BlobStore) *SceneStore {
 	return &SceneStore{
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: scenesCustomFieldsTableMgr,
+		},
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
 			joinTable: sceneTable,
@@ -301,11 +305,27 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 	var r sceneRow
 	r.fromScene(*newObject)
 
+	// database users' values are not written to the scenes table
+	userID, hasUser := models.UserIDFromContext(ctx)
+	var userRecord exp.Record
+	if hasUser {
+		userRecord = r.userRecord()
+		r.Rating = null.Int{}
+		r.ResumeTime = 0
+		r.PlayDuration = 0
+	}
+
 	id, err := qb.tableMgr.insertID(ctx, r)
 	if err != nil {
 		return err
 	}
 
+	if hasUser {
+		if err := setSceneUserData(ctx, id, userID, userRecord); err != nil {
+			return err
+		}
+	}
+
 	if len(fileIDs) > 0 {
 		const firstPrimary = true
 		if err := scenesFilesTableMgr.insertJoins(ctx, id, firstPrimary, fileIDs); err != nil {
@@ -368,6 +388,15 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 
 	r.fromPartial(partial)
 
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		userRecord := splitSceneUserRecord(r.Record)
+		if len(userRecord) > 0 {
+			if err := setSceneUserData(ctx, id, userID, userRecord); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
@@ -417,6 +446,17 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 	var r sceneRow
 	r.fromScene(*updatedObject)
 
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		if err := setSceneUserData(ctx, updatedObject.ID, userID, r.userRecord()); err != nil {
+			return err
+		}
+
+		// retain the values of the scenes table
+		if err := qb.loadSharedUserData(ctx, updatedObject.ID, &r); err != nil {
+			return err
+		}
+	}
+
 	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
 		return err
 	}
@@ -582,6 +622,10 @@ func (qb *SceneStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*mo
 		return nil, err
 	}
 
+	if err := applySceneUserData(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
@@ -918,32 +962,34 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 	distinctIDs(&query, sceneTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    scenesFilesTable,
-				onClause: "scenes_files.scene_id = scenes.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "scenes_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = scenes_files.file_id",
-			},
-			join{
-				table:    sceneMarkerTable,
-				onClause: "scene_markers.scene_id = scenes.id",
-			},
-		)
-
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
-		query.parseQueryString(searchColumns, *q)
+		if !query.addSearch(sceneSearchIndex, "scenes.id", *q) {
+			query.addJoins(
+				join{
+					table:    scenesFilesTable,
+					onClause: "scenes_files.scene_id = scenes.id",
+				},
+				join{
+					table:    fileTable,
+					onClause: "scenes_files.file_id = files.id",
+				},
+				join{
+					table:    folderTable,
+					onClause: "files.parent_folder_id = folders.id",
+				},
+				join{
+					table:    fingerprintTable,
+					onClause: "files_fingerprints.file_id = scenes_files.file_id",
+				},
+				join{
+					table:    sceneMarkerTable,
+					onClause: "scene_markers.scene_id = scenes.id",
+				},
+			)
+
+			filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
+			searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &sceneFilterHandler{
@@ -954,7 +1000,7 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		return nil, err
 	}
 
-	if err := qb.setSceneSort(&query, findFilter); err != nil {
+	if err := qb.setSceneSort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -1083,7 +1129,12 @@ var sceneSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *SceneStore) setSceneSort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
+	if sortClause, ok := query.searchSort(findFilter, "scenes.id"); ok {
+		query.sortAndPagination += sortClause
+		return nil
+	}
+
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -1180,13 +1231,19 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 	case "play_count":
-		query.sortAndPagination += getCountSort(sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += " ORDER BY " + sceneHistoryCountSubquery(ctx, scenesViewDatesTable) + " " + getSortDirection(direction)
 	case "last_played_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesViewDatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += " ORDER BY " + sceneHistoryMaxSubquery(ctx, scenesViewDatesTable, sceneViewDateColumn) + " " + getSortDirection(direction)
 	case "last_o_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesODatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += " ORDER BY " + sceneHistoryMaxSubquery(ctx, scenesODatesTable, sceneODateColumn) + " " + getSortDirection(direction)
 	case "o_counter":
-		query.sortAndPagination += getCountSort(sceneTable, scenesODatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += " ORDER BY " + sceneHistoryCountSubquery(ctx, scenesODatesTable) + " " + getSortDirection(direction)
+	case "rating", "resume_time", "play_duration":
+		col, j := sceneUserColumn(ctx, sort)
+		if j != nil {
+			query.addJoins(*j)
+		}
+		query.sortAndPagination += getSort(col, direction, sceneTable)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
@@ -1212,6 +1269,14 @@ func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *floa
 		record["play_duration"] = goqu.L("play_duration + ?", playDuration)
 	}
 
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		if err := setSceneUserData(ctx, id, userID, record); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	if len(record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, record); err != nil {
 			return false, err
@@ -1236,6 +1301,14 @@ func (qb *SceneStore) ResetActivity(ctx context.Context, id int, resetResume boo
 		record["play_duration"] = 0.0
 	}
 
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		if err := setSceneUserData(ctx, id, userID, record); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
 	if len(record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, record); err != nil {
 			return false, err
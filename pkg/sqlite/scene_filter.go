@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
-	"github.com/stashapp/stash/pkg/utils"
 )
 
 type sceneFilterHandler struct {
@@ -92,7 +91,9 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.phashDistanceCriterionHandler(sceneFilter.PhashDistance),
 
-		intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil),
+		qb.sceneUserCriterionHandler("rating", func(column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+			return intCriterionHandler(sceneFilter.Rating100, column, addJoinFn)
+		}),
 		qb.oCountCriterionHandler(sceneFilter.OCounter),
 		boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil),
 
@@ -127,13 +128,17 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.captionCriterionHandler(sceneFilter.Captions),
 
-		floatIntCriterionHandler(sceneFilter.ResumeTime, "scenes.resume_time", nil),
-		floatIntCriterionHandler(sceneFilter.PlayDuration, "scenes.play_duration", nil),
+		qb.sceneUserCriterionHandler("resume_time", func(column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+			return floatIntCriterionHandler(sceneFilter.ResumeTime, column, addJoinFn)
+		}),
+		qb.sceneUserCriterionHandler("play_duration", func(column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc {
+			return floatIntCriterionHandler(sceneFilter.PlayDuration, column, addJoinFn)
+		}),
 		qb.playCountCriterionHandler(sceneFilter.PlayCount),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.LastPlayedAt != nil {
 				f.addLeftJoin(
-					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, scenesViewDatesTable, sceneIDColumn),
+					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s WHERE %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, scenesViewDatesTable, sceneHistoryUserClause(ctx, scenesViewDatesTable), sceneIDColumn),
 					"scene_last_view",
 					fmt.Sprintf("scene_last_view.%s = scenes.id", sceneIDColumn),
 				)
@@ -160,6 +165,12 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{sceneFilter.CreatedAt, "scenes.created_at", nil},
 		&timestampCriterionHandler{sceneFilter.UpdatedAt, "scenes.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			c:           sceneFilter.CustomFields,
+			table:       scenesCustomFieldsTableMgr,
+			parentIDCol: "scenes.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_galleries.gallery_id",
 			relatedRepo:    galleryRepository.repository,
@@ -232,24 +243,40 @@ func (qb *sceneFilterHandler) addVideoFilesTable(f *filterBuilder) {
 	f.addLeftJoin(videoFileTable, "", "video_files.file_id = scenes_files.file_id")
 }
 
-func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesViewDatesTable,
-		primaryFK:    sceneIDColumn,
+// sceneUserCriterionHandler returns the handler built by fn for a per-user
+// scenes column, using the values of the user in the context.
+func (qb *sceneFilterHandler) sceneUserCriterionHandler(column string, fn func(column string, addJoinFn func(f *filterBuilder)) criterionHandlerFunc) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		col, j := sceneUserColumn(ctx, column)
+
+		var addJoinFn func(f *filterBuilder)
+		if j != nil {
+			addJoinFn = func(f *filterBuilder) {
+				f.addLeftJoin(j.table, j.as, j.onClause)
+			}
+		}
+
+		fn(col, addJoinFn)(ctx, f)
 	}
+}
 
-	return h.handler(count)
+func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
+	return qb.historyCountCriterionHandler(scenesViewDatesTable, count)
 }
 
 func (qb *sceneFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesODatesTable,
-		primaryFK:    sceneIDColumn,
-	}
+	return qb.historyCountCriterionHandler(scenesODatesTable, count)
+}
 
-	return h.handler(count)
+// historyCountCriterionHandler filters by the number of rows in the scene view
+// or o date table for the user in the context.
+func (qb *sceneFilterHandler) historyCountCriterionHandler(table string, count *models.IntCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if count != nil {
+			clause, args := getIntCriterionWhereClause(sceneHistoryCountSubquery(ctx, table), *count)
+			f.addWhere(clause, args...)
+		}
+	}
 }
 
 func (qb *sceneFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
@@ -529,40 +556,5 @@ func (qb *sceneFilterHandler) performerTagsCriterionHandler(tags *models.Hierarc
 }
 
 func (qb *sceneFilterHandler) phashDistanceCriterionHandler(phashDistance *models.PhashDistanceCriterionInput) criterionHandlerFunc {
-	return func(ctx context.Context, f *filterBuilder) {
-		if phashDistance != nil {
-			qb.addSceneFilesTable(f)
-			f.addLeftJoin(fingerprintTable, "fingerprints_phash", "scenes_files.file_id = fingerprints_phash.file_id AND fingerprints_phash.type = 'phash'")
-
-			value, _ := utils.StringToPhash(phashDistance.Value)
-			distance := 0
-			if phashDistance.Distance != nil {
-				distance = *phashDistance.Distance
-			}
-
-			if distance == 0 {
-				// use the default handler
-				intCriterionHandler(&models.IntCriterionInput{
-					Value:    int(value),
-					Modifier: phashDistance.Modifier,
-				}, "fingerprints_phash.fingerprint", nil)(ctx, f)
-			}
-
-			switch {
-			case phashDistance.Modifier == models.CriterionModifierEquals && distance > 0:
-				// needed to avoid a type mismatch
-				f.addWhere("typeof(fingerprints_phash.fingerprint) = 'integer'")
-				f.addWhere("phash_distance(fingerprints_phash.fingerprint, ?) < ?", value, distance)
-			case phashDistance.Modifier == models.CriterionModifierNotEquals && distance > 0:
-				// needed to avoid a type mismatch
-				f.addWhere("typeof(fingerprints_phash.fingerprint) = 'integer'")
-				f.addWhere("phash_distance(fingerprints_phash.fingerprint, ?) > ?", value, distance)
-			default:
-				intCriterionHandler(&models.IntCriterionInput{
-					Value:    int(value),
-					Modifier: phashDistance.Modifier,
-				}, "fingerprints_phash.fingerprint", nil)(ctx, f)
-			}
-		}
-	}
+	return phashDistanceCriterionHandler(phashDistance, "scenes_files.file_id", qb.addSceneFilesTable)
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil"
@@ -24,19 +25,21 @@ GROUP BY scene_markers.id
 `
 
 type sceneMarkerRow struct {
-	ID           int       `db:"id" goqu:"skipinsert"`
-	Title        string    `db:"title"` // TODO: make db schema (and gql schema) nullable
-	Seconds      float64   `db:"seconds"`
-	PrimaryTagID int       `db:"primary_tag_id"`
-	SceneID      int       `db:"scene_id"`
-	CreatedAt    Timestamp `db:"created_at"`
-	UpdatedAt    Timestamp `db:"updated_at"`
+	ID           int        `db:"id" goqu:"skipinsert"`
+	Title        string     `db:"title"` // TODO: make db schema (and gql schema) nullable
+	Seconds      float64    `db:"seconds"`
+	EndSeconds   null.Float `db:"end_seconds"`
+	PrimaryTagID int        `db:"primary_tag_id"`
+	SceneID      int        `db:"scene_id"`
+	CreatedAt    Timestamp  `db:"created_at"`
+	UpdatedAt    Timestamp  `db:"updated_at"`
 }
 
 func (r *sceneMarkerRow) fromSceneMarker(o models.SceneMarker) {
 	r.ID = o.ID
 	r.Title = o.Title
 	r.Seconds = o.Seconds
+	r.EndSeconds = null.FloatFromPtr(o.EndSeconds)
 	r.PrimaryTagID = o.PrimaryTagID
 	r.SceneID = o.SceneID
 	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
@@ -48,6 +51,7 @@ func (r *sceneMarkerRow) resolve() *models.SceneMarker {
 		ID:           r.ID,
 		Title:        r.Title,
 		Seconds:      r.Seconds,
+		EndSeconds:   nullFloatPtr(r.EndSeconds),
 		PrimaryTagID: r.PrimaryTagID,
 		SceneID:      r.SceneID,
 		CreatedAt:    r.CreatedAt.Timestamp,
@@ -69,6 +73,7 @@ func (r *sceneMarkerRowRecord) fromPartial(o models.SceneMarkerPartial) {
 		r.set("title", o.Title.Value)
 	}
 	r.setFloat64("seconds", o.Seconds)
+	r.setNullFloat64("end_seconds", o.EndSeconds)
 	r.setInt("primary_tag_id", o.PrimaryTagID)
 	r.setInt("scene_id", o.SceneID)
 	r.setTimestamp("created_at", o.CreatedAt)
@@ -301,10 +306,12 @@ func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *mo
 	distinctIDs(&query, sceneMarkerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(sceneTable, "", "scenes.id = scene_markers.scene_id")
-		query.join(tagTable, "", "scene_markers.primary_tag_id = tags.id")
-		searchColumns := []string{"scene_markers.title", "scenes.title", "tags.name"}
-		query.parseQueryString(searchColumns, *q)
+		if !query.addSearch(sceneMarkerSearchIndex, "scene_markers.id", *q) {
+			query.join(sceneTable, "", "scenes.id = scene_markers.scene_id")
+			query.join(tagTable, "", "scene_markers.primary_tag_id = tags.id")
+			searchColumns := []string{"scene_markers.title", "scenes.title", "tags.name"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &sceneMarkerFilterHandler{
@@ -363,6 +370,11 @@ var sceneMarkerSortOptions = sortOptions{
 }
 
 func (qb *SceneMarkerStore) setSceneMarkerSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+	if sortClause, ok := query.searchSort(findFilter, "scene_markers.id"); ok {
+		query.sortAndPagination += sortClause
+		return nil
+	}
+
 	sort := findFilter.GetSort("title")
 	direction := findFilter.GetDirection()
 
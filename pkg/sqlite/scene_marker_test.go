@@ -409,3 +409,73 @@ func queryMarkers(ctx context.Context, t *testing.T, sqb models.SceneMarkerReade
 // TODO Count
 // TODO All
 // TODO Query
+
+func TestMarkerQueryDuration(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		mqb := db.SceneMarker
+
+		endSeconds := 40.0
+		marker := models.SceneMarker{
+			SceneID:      sceneIDs[sceneIdxWithMarkers],
+			PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers],
+			Seconds:      10,
+			EndSeconds:   &endSeconds,
+		}
+
+		if err := mqb.Create(ctx, &marker); err != nil {
+			t.Errorf("error creating marker: %v", err)
+			return nil
+		}
+
+		got, err := mqb.Find(ctx, marker.ID)
+		if err != nil {
+			t.Errorf("error finding marker: %v", err)
+			return nil
+		}
+		assert.Equal(t, &endSeconds, got.EndSeconds)
+
+		queryIDs := func(c models.FloatCriterionInput) []int {
+			markers := queryMarkers(ctx, t, mqb, &models.SceneMarkerFilterType{
+				Duration: &c,
+			}, nil)
+
+			var ids []int
+			for _, m := range markers {
+				ids = append(ids, m.ID)
+			}
+			return ids
+		}
+
+		assert.Contains(t, queryIDs(models.FloatCriterionInput{
+			Value:    30,
+			Modifier: models.CriterionModifierEquals,
+		}), marker.ID)
+		assert.NotContains(t, queryIDs(models.FloatCriterionInput{
+			Value:    30,
+			Modifier: models.CriterionModifierGreaterThan,
+		}), marker.ID)
+
+		// markers without an end time have no duration
+		ids := queryIDs(models.FloatCriterionInput{
+			Modifier: models.CriterionModifierNotNull,
+		})
+		assert.Equal(t, []int{marker.ID}, ids)
+
+		// clear the end time
+		if _, err := mqb.UpdatePartial(ctx, marker.ID, models.SceneMarkerPartial{
+			EndSeconds: models.OptionalFloat64{Set: true, Null: true},
+		}); err != nil {
+			t.Errorf("error updating marker: %v", err)
+			return nil
+		}
+
+		got, err = mqb.Find(ctx, marker.ID)
+		if err != nil {
+			t.Errorf("error finding marker: %v", err)
+			return nil
+		}
+		assert.Nil(t, got.EndSeconds)
+
+		return nil
+	})
+}
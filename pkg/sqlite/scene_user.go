@@ -0,0 +1,201 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	scenesUsersTable = "scenes_users"
+)
+
+// sceneUserColumns are the scenes columns that are stored per user. Values
+// for the user configured in the config file are stored in the scenes table,
+// values for database users are stored in the scenes_users table.
+var sceneUserColumns = []string{
+	"rating",
+	"resume_time",
+	"play_duration",
+}
+
+type sceneUserRow struct {
+	SceneID      int      `db:"scene_id"`
+	Rating       null.Int `db:"rating"`
+	ResumeTime   float64  `db:"resume_time"`
+	PlayDuration float64  `db:"play_duration"`
+}
+
+// userRecord returns the per-user values of the row.
+func (r *sceneRow) userRecord() exp.Record {
+	return exp.Record{
+		"rating":        r.Rating,
+		"resume_time":   r.ResumeTime,
+		"play_duration": r.PlayDuration,
+	}
+}
+
+// loadSharedUserData sets the per-user fields of r to the values stored in
+// the scenes table.
+func (qb *SceneStore) loadSharedUserData(ctx context.Context, id int, r *sceneRow) error {
+	table := qb.table()
+	q := dialect.From(table).Select(
+		table.Col("rating"),
+		table.Col("resume_time"),
+		table.Col("play_duration"),
+	).Where(qb.tableMgr.byID(id))
+
+	const single = true
+	return queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		return rows.Scan(&r.Rating, &r.ResumeTime, &r.PlayDuration)
+	})
+}
+
+// splitSceneUserRecord removes the per-user columns from record and returns
+// them in a new record.
+func splitSceneUserRecord(record exp.Record) exp.Record {
+	ret := make(exp.Record)
+	for _, c := range sceneUserColumns {
+		if v, ok := record[c]; ok {
+			ret[c] = v
+			delete(record, c)
+		}
+	}
+
+	return ret
+}
+
+// setSceneUserData sets the per-user columns in record for the scene and user.
+func setSceneUserData(ctx context.Context, sceneID int, userID int, record exp.Record) error {
+	table := scenesUsersTableMgr.table
+
+	// ensure the row exists so that relative updates apply to the defaults
+	q := dialect.Insert(table).Rows(
+		goqu.Record{sceneIDColumn: sceneID, userIDColumn: userID},
+	).OnConflict(goqu.DoNothing())
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("inserting into %s: %w", table.GetTable(), err)
+	}
+
+	if len(record) == 0 {
+		return nil
+	}
+
+	uq := dialect.Update(table).Prepared(true).Set(record).Where(
+		table.Col(sceneIDColumn).Eq(sceneID),
+		table.Col(userIDColumn).Eq(userID),
+	)
+	if _, err := exec(ctx, uq); err != nil {
+		return fmt.Errorf("updating %s: %w", table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// applySceneUserData replaces the per-user fields of the provided scenes with
+// the values of the user in the context. Does nothing if the context does not
+// have a user.
+func applySceneUserData(ctx context.Context, scenes []*models.Scene) error {
+	userID, ok := models.UserIDFromContext(ctx)
+	if !ok || len(scenes) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(scenes))
+	for i, s := range scenes {
+		ids[i] = s.ID
+	}
+
+	rows := make(map[int]sceneUserRow)
+	table := scenesUsersTableMgr.table
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.From(table).Prepared(true).Select(
+			table.Col(sceneIDColumn),
+			table.Col("rating"),
+			table.Col("resume_time"),
+			table.Col("play_duration"),
+		).Where(
+			table.Col(userIDColumn).Eq(userID),
+			table.Col(sceneIDColumn).In(batch),
+		)
+
+		const single = false
+		return queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+			var row sceneUserRow
+			if err := r.StructScan(&row); err != nil {
+				return err
+			}
+
+			rows[row.SceneID] = row
+			return nil
+		})
+	}); err != nil {
+		return fmt.Errorf("getting user scene data: %w", err)
+	}
+
+	// scenes without a row have not been rated or played by the user
+	for _, s := range scenes {
+		row := rows[s.ID]
+		s.Rating = nullIntPtr(row.Rating)
+		s.ResumeTime = row.ResumeTime
+		s.PlayDuration = row.PlayDuration
+	}
+
+	return nil
+}
+
+const sceneUserAlias = "scene_user"
+
+// sceneUserColumn returns the expression for the per-user scenes column for
+// the user in the context, along with the join that it requires. Returns the
+// scenes column and a nil join if the context does not have a user.
+func sceneUserColumn(ctx context.Context, column string) (string, *join) {
+	userID, ok := models.UserIDFromContext(ctx)
+	if !ok {
+		return sceneTable + "." + column, nil
+	}
+
+	j := &join{
+		table:    scenesUsersTable,
+		as:       sceneUserAlias,
+		onClause: fmt.Sprintf("%[1]s.%[2]s = scenes.id AND %[1]s.%[3]s = %[4]d", sceneUserAlias, sceneIDColumn, userIDColumn, userID),
+		joinType: "LEFT",
+	}
+
+	col := sceneUserAlias + "." + column
+	if column != "rating" {
+		// scenes without a row have not been played by the user
+		col = "COALESCE(" + col + ", 0)"
+	}
+
+	return col, j
+}
+
+// sceneHistoryUserClause returns the condition that restricts the rows of the
+// scene view or o date table with the provided alias to those of the user in
+// the context. See viewHistoryTable.userFilter.
+func sceneHistoryUserClause(ctx context.Context, alias string) string {
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		return fmt.Sprintf("%s.%s = %d", alias, userIDColumn, userID)
+	}
+
+	return fmt.Sprintf("%s.%s IS NULL", alias, userIDColumn)
+}
+
+// sceneHistoryCountSubquery returns a subquery that counts the rows of the
+// scene view or o date table for the scene and the user in the context.
+func sceneHistoryCountSubquery(ctx context.Context, table string) string {
+	return fmt.Sprintf("(SELECT COUNT(*) FROM %s AS h WHERE h.%s = %s.id AND %s)", table, sceneIDColumn, sceneTable, sceneHistoryUserClause(ctx, "h"))
+}
+
+// sceneHistoryMaxSubquery returns a subquery that returns the latest date in
+// the scene view or o date table for the scene and the user in the context.
+func sceneHistoryMaxSubquery(ctx context.Context, table string, dateColumn string) string {
+	return fmt.Sprintf("(SELECT MAX(h.%s) FROM %s AS h WHERE h.%s = %s.id AND %s)", dateColumn, table, sceneIDColumn, sceneTable, sceneHistoryUserClause(ctx, "h"))
+}
@@ -0,0 +1,219 @@
+package sqlite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// relevanceSort sorts by the relevance of the results to the search query.
+	relevanceSort = "relevance"
+
+	// searchAlias is the alias of the search index join.
+	searchAlias = "search"
+)
+
+// searchIndex is a full-text search index of the objects in a table. The
+// index tables are maintained by triggers - see the search index migration.
+type searchIndex struct {
+	// table is the FTS5 table name. The rowid of the table is the object ID.
+	table string
+	// weights are the relevance weights of the index columns, in column order.
+	weights []float64
+}
+
+var (
+	sceneSearchIndex = searchIndex{
+		table: "scenes_fts",
+		// title, details, code, markers, paths, fingerprints
+		weights: []float64{10, 1, 5, 3, 2, 1},
+	}
+	imageSearchIndex = searchIndex{
+		table: "images_fts",
+		// title, details, code, paths, fingerprints
+		weights: []float64{10, 1, 5, 2, 1},
+	}
+	gallerySearchIndex = searchIndex{
+		table: "galleries_fts",
+		// title, details, code, chapters, paths, fingerprints
+		weights: []float64{10, 1, 5, 3, 2, 1},
+	}
+	performerSearchIndex = searchIndex{
+		table: "performers_fts",
+		// name, disambiguation, aliases, details
+		weights: []float64{10, 3, 8, 1},
+	}
+	studioSearchIndex = searchIndex{
+		table: "studios_fts",
+		// name, aliases, details
+		weights: []float64{10, 8, 1},
+	}
+	tagSearchIndex = searchIndex{
+		table: "tags_fts",
+		// name, aliases, description
+		weights: []float64{10, 8, 1},
+	}
+	sceneMarkerSearchIndex = searchIndex{
+		table: "scene_markers_fts",
+		// title, scene_title, primary_tag
+		weights: []float64{10, 2, 5},
+	}
+)
+
+// rankFunction returns the ranking function of the index, for use with the
+// rank column. The bm25 function cannot be called directly, since the search
+// subquery may be flattened into the outer query.
+func (i searchIndex) rankFunction() string {
+	weights := make([]string, len(i.weights))
+	for j, w := range i.weights {
+		weights[j] = strconv.FormatFloat(w, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("bm25(%s)", strings.Join(weights, ", "))
+}
+
+// searchTerm returns the FTS5 query for a single search term. Terms
+// containing multiple words are matched as a phrase. Single words are
+// matched as a prefix. Returns an empty string if the term has no searchable
+// characters.
+func searchTerm(t string) string {
+	if strings.IndexFunc(t, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}) == -1 {
+		return ""
+	}
+
+	ret := `"` + strings.ReplaceAll(t, `"`, `""`) + `"`
+	if len(strings.Fields(t)) == 1 {
+		ret += "*"
+	}
+
+	return ret
+}
+
+// searchMatchExpression returns the FTS5 query matching the objects that
+// include the required terms of specs. Returns an empty string if specs has
+// no required terms.
+func searchMatchExpression(specs models.SearchSpecs) string {
+	var clauses []string
+
+	for _, t := range specs.MustHave {
+		if term := searchTerm(t); term != "" {
+			clauses = append(clauses, term)
+		}
+	}
+
+	for _, set := range specs.AnySets {
+		var terms []string
+		for _, t := range set {
+			if term := searchTerm(t); term != "" {
+				terms = append(terms, term)
+			}
+		}
+
+		if len(terms) > 0 {
+			clauses = append(clauses, "("+strings.Join(terms, " OR ")+")")
+		}
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// searchTermIsWords returns true if the term consists only of letters and
+// numbers separated by single spaces. Other terms, such as paths, file names
+// and punctuation, cannot be matched exactly by the full-text search index.
+func searchTermIsWords(t string) bool {
+	if t != strings.Join(strings.Fields(t), " ") {
+		return false
+	}
+
+	return strings.IndexFunc(t, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r) && r != ' '
+	}) == -1
+}
+
+// canSearchIndex returns true if all of the terms of specs can be matched
+// using the full-text search index.
+func canSearchIndex(specs models.SearchSpecs) bool {
+	var terms []string
+	terms = append(terms, specs.MustHave...)
+	terms = append(terms, specs.MustNot...)
+	for _, set := range specs.AnySets {
+		terms = append(terms, set...)
+	}
+
+	for _, t := range terms {
+		if !searchTermIsWords(t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addSearch filters the query to objects matching the search string q, using
+// the full-text search index. idColumn is the object ID column of the query.
+// The search is joined to the query as searchAlias, with the relevance of
+// each result in the score column.
+//
+// Returns false without modifying the query if q cannot be matched using the
+// index. The caller should then fall back to parseQueryString.
+//
+// addSearch must be called before any arguments are added to the query.
+func (qb *queryBuilder) addSearch(index searchIndex, idColumn string, q string) bool {
+	specs := models.ParseSearchString(q)
+	if !canSearchIndex(specs) {
+		return false
+	}
+
+	if match := searchMatchExpression(specs); match != "" {
+		qb.addJoins(join{
+			// rank is lower for better matches
+			table:    fmt.Sprintf("(SELECT rowid, -rank AS score FROM %s WHERE %s MATCH ? AND rank MATCH '%s')", index.table, index.table, index.rankFunction()),
+			as:       searchAlias,
+			onClause: searchAlias + ".rowid = " + idColumn,
+			joinType: "INNER",
+		})
+		qb.addArg(match)
+	}
+
+	for _, t := range specs.MustNot {
+		if term := searchTerm(t); term != "" {
+			qb.addWhere(fmt.Sprintf("%s NOT IN (SELECT rowid FROM %s WHERE %s MATCH ?)", idColumn, index.table, index.table))
+			qb.addArg(term)
+		}
+	}
+
+	return true
+}
+
+// searchSort returns the sort clause when sorting by relevance. Results are
+// sorted by relevance if the sort is relevanceSort, or if the find filter
+// has a search query and no sort. Descending order returns the most relevant
+// results first. Returns false if the results should not be sorted by
+// relevance.
+func (qb *queryBuilder) searchSort(findFilter *models.FindFilterType, idColumn string) (string, bool) {
+	if findFilter == nil {
+		return "", false
+	}
+
+	direction := findFilter.GetDirection()
+	switch {
+	case findFilter.Sort != nil && *findFilter.Sort == relevanceSort:
+	case (findFilter.Sort == nil || *findFilter.Sort == "") && findFilter.Q != nil && *findFilter.Q != "":
+		direction = "DESC"
+	default:
+		return "", false
+	}
+
+	if !qb.hasJoin(searchAlias) {
+		// no relevance without a search
+		return " ORDER BY " + idColumn + " " + getSortDirection(direction), true
+	}
+
+	return " ORDER BY " + searchAlias + ".score " + getSortDirection(direction) + ", " + idColumn + " ASC", true
+}
@@ -0,0 +1,216 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchSceneIDs(ctx context.Context, t *testing.T, findFilter *models.FindFilterType) []int {
+	t.Helper()
+	result, err := db.Scene.Query(ctx, models.SceneQueryOptions{
+		QueryOptions: models.QueryOptions{
+			FindFilter: findFilter,
+		},
+	})
+	if err != nil {
+		t.Errorf("Error querying scene: %v", err)
+		return nil
+	}
+
+	return result.IDs
+}
+
+func TestSceneSearch(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		scene := models.Scene{
+			Title:   "Midnight Harbour Lights",
+			Details: "A long walk along the quay",
+		}
+		if err := qb.Create(ctx, &scene, nil); err != nil {
+			t.Errorf("SceneStore.Create() error = %v", err)
+			return nil
+		}
+
+		tests := []struct {
+			name  string
+			q     string
+			match bool
+		}{
+			{"prefix", "harb", true},
+			{"details", "quay", true},
+			{"diacritics", "hárbour", true},
+			{"phrase", `"harbour lights"`, true},
+			{"phrase out of order", `"lights harbour"`, false},
+			{"any", "xyzzy or quay", true},
+			{"excluded", "harbour -midnight", false},
+			{"missing", "harbour xyzzy", false},
+		}
+
+		for _, tt := range tests {
+			q := tt.q
+			ids := searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q})
+			assert.Equal(t, tt.match, sliceutil.Contains(ids, scene.ID), tt.name)
+		}
+
+		// index is updated when the scene changes
+		if _, err := qb.UpdatePartial(ctx, scene.ID, models.ScenePartial{
+			Title: models.NewOptionalString("Dawn Chorus"),
+		}); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		q := "midnight"
+		assert.NotContains(t, searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q}), scene.ID)
+		q = "chorus"
+		assert.Contains(t, searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q}), scene.ID)
+
+		return nil
+	})
+}
+
+func TestSceneSearchPath(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		const sceneIdx = 2
+		q := getSceneBasename(sceneIdx)
+
+		ids := searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q})
+		assert.Equal(t, []int{sceneIDs[sceneIdx]}, ids)
+
+		return nil
+	})
+}
+
+func TestSceneSearchFallback(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		scene := models.Scene{
+			Title: "Episode #12: Lights/Camera",
+		}
+		if err := qb.Create(ctx, &scene, nil); err != nil {
+			t.Errorf("SceneStore.Create() error = %v", err)
+			return nil
+		}
+
+		tests := []struct {
+			name  string
+			q     string
+			match bool
+		}{
+			{"non-word", "#12", true},
+			{"non-word missing", "#13", false},
+			{"path-like", "lights/cam", true},
+			{"excluded non-word", "episode -#12", false},
+		}
+
+		for _, tt := range tests {
+			q := tt.q
+			ids := searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q})
+			assert.Equal(t, tt.match, sliceutil.Contains(ids, scene.ID), tt.name)
+		}
+
+		return nil
+	})
+}
+
+func TestSceneSearchFullPath(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		const sceneIdx = 2
+		q := getFilePath(folderIdxWithSceneFiles, getSceneBasename(sceneIdx))
+
+		ids := searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q})
+		assert.Equal(t, []int{sceneIDs[sceneIdx]}, ids)
+
+		return nil
+	})
+}
+
+func TestSceneSearchRelevance(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		inDetails := models.Scene{
+			Title:   "Unrelated",
+			Details: "A scene mentioning quokka",
+		}
+		inTitle := models.Scene{
+			Title: "Quokka",
+		}
+
+		for _, s := range []*models.Scene{&inDetails, &inTitle} {
+			if err := qb.Create(ctx, s, nil); err != nil {
+				t.Errorf("SceneStore.Create() error = %v", err)
+				return nil
+			}
+		}
+
+		q := "quokka"
+
+		// sorted by relevance by default
+		ids := searchSceneIDs(ctx, t, &models.FindFilterType{Q: &q})
+		assert.Equal(t, []int{inTitle.ID, inDetails.ID}, ids)
+
+		sort := "relevance"
+		direction := models.SortDirectionEnumAsc
+		ids = searchSceneIDs(ctx, t, &models.FindFilterType{
+			Q:         &q,
+			Sort:      &sort,
+			Direction: &direction,
+		})
+		assert.Equal(t, []int{inDetails.ID, inTitle.ID}, ids)
+
+		return nil
+	})
+}
+
+func TestPerformerSearchAlias(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		performer := models.Performer{
+			Name:    "Performer with alias",
+			Aliases: models.NewRelatedStrings([]string{"Zephyrine"}),
+		}
+		if err := qb.Create(ctx, &performer); err != nil {
+			t.Errorf("PerformerStore.Create() error = %v", err)
+			return nil
+		}
+
+		q := "zephyr"
+		performers := queryPerformers(ctx, t, nil, &models.FindFilterType{Q: &q})
+		assert.Len(t, performers, 1)
+		if len(performers) > 0 {
+			assert.Equal(t, performer.ID, performers[0].ID)
+		}
+
+		// index is updated when the aliases change
+		if _, err := qb.UpdatePartial(ctx, performer.ID, models.PerformerPartial{
+			Aliases: &models.UpdateStrings{
+				Values: []string{"Marigold"},
+				Mode:   models.RelationshipUpdateModeSet,
+			},
+		}); err != nil {
+			t.Errorf("PerformerStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		performers = queryPerformers(ctx, t, nil, &models.FindFilterType{Q: &q})
+		assert.Len(t, performers, 0)
+
+		q = "marigold"
+		performers = queryPerformers(ctx, t, nil, &models.FindFilterType{Q: &q})
+		assert.Len(t, performers, 1)
+
+		return nil
+	})
+}
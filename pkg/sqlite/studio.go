@@ -139,6 +139,7 @@ var (
 )
 
 type StudioStore struct {
+	customFieldsStore
 	blobJoinQueryBuilder
 	tagRelationshipStore
 
@@ -147,6 +148,9 @@ type StudioStore struct {
 
 func NewStudioStore(blobStore *BlobStore) *StudioStore {
 	return &StudioStore{
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: studiosCustomFieldsTableMgr,
+		},
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
 			joinTable: studioTable,
@@ -284,6 +288,49 @@ func (qb *StudioStore) Destroy(ctx context.Context, id int) error {
 	return studioRepository.destroyExisting(ctx, []int{id})
 }
 
+// Merge reassigns the scenes, images, galleries, groups and child studios of
+// the source studios to the destination studio, and then destroys the source
+// studios.
+func (qb *StudioStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	for _, table := range []string{sceneTable, imageTable, galleryTable, groupTable} {
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+table+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...); err != nil {
+			return err
+		}
+	}
+
+	// re-parent the children of the source studios, excluding the destination
+	// itself, which is handled by the caller
+	childArgs := append(args, destination)
+	if _, err := dbWrapper.Exec(ctx, "UPDATE "+studioTable+" SET parent_id = ? WHERE parent_id IN "+inBinding+" AND id != ?", childArgs...); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // returns nil, nil if not found
 func (qb *StudioStore) Find(ctx context.Context, id int) (*models.Studio, error) {
 	ret, err := qb.find(ctx, id)
@@ -525,9 +572,11 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 	distinctIDs(&query, studioTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(studioAliasesTable, "", "studio_aliases.studio_id = studios.id")
-		searchColumns := []string{"studios.name", "studio_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		if !query.addSearch(studioSearchIndex, "studios.id", *q) {
+			query.join(studioAliasesTable, "", "studio_aliases.studio_id = studios.id")
+			searchColumns := []string{"studios.name", "studio_aliases.alias"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &studioFilterHandler{
@@ -539,7 +588,7 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 	}
 
 	var err error
-	query.sortAndPagination, err = qb.getStudioSort(findFilter)
+	query.sortAndPagination, err = qb.getStudioSort(&query, findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -589,7 +638,11 @@ var studioSortOptions = sortOptions{
 	"updated_at",
 }
 
-func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string, error) {
+func (qb *StudioStore) getStudioSort(query *queryBuilder, findFilter *models.FindFilterType) (string, error) {
+	if sortClause, ok := query.searchSort(findFilter, "studios.id"); ok {
+		return sortClause, nil
+	}
+
 	var sort string
 	var direction string
 	if findFilter == nil {
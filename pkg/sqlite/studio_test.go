@@ -323,6 +323,50 @@ func TestStudioDestroyParent(t *testing.T) {
 	}
 }
 
+func TestStudioMerge(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		dest, err := createStudio(ctx, qb, "merge dest", nil)
+		if err != nil {
+			return err
+		}
+		src, err := createStudio(ctx, qb, "merge src", nil)
+		if err != nil {
+			return err
+		}
+		child, err := createStudio(ctx, qb, "merge src child", &src.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := qb.Merge(ctx, []int{dest.ID}, dest.ID); err == nil {
+			return errors.New("expected error merging studio into itself")
+		}
+
+		if err := qb.Merge(ctx, []int{src.ID}, dest.ID); err != nil {
+			return err
+		}
+
+		found, err := qb.Find(ctx, src.ID)
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, found)
+
+		// children of the source are re-parented to the destination
+		found, err = qb.Find(ctx, child.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, &dest.ID, found.ParentID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestStudioFindChildren(t *testing.T) {
 	withTxn(func(ctx context.Context) error {
 		sqb := db.Studio
@@ -43,6 +43,7 @@ var (
 
 	tagsAliasesJoinTable  = goqu.T(tagAliasesTable)
 	tagRelationsJoinTable = goqu.T(tagRelationsTable)
+	tagsStashIDsJoinTable = goqu.T("tag_stash_ids")
 )
 
 var (
@@ -208,6 +209,7 @@ var (
 			idColumn: goqu.T(scenesViewDatesTable).Col(sceneIDColumn),
 		},
 		dateColumn: goqu.T(scenesViewDatesTable).Col(sceneViewDateColumn),
+		userColumn: goqu.T(scenesViewDatesTable).Col(userIDColumn),
 	}
 
 	scenesOTableMgr = &viewHistoryTable{
@@ -216,6 +218,7 @@ var (
 			idColumn: goqu.T(scenesODatesTable).Col(sceneIDColumn),
 		},
 		dateColumn: goqu.T(scenesODatesTable).Col(sceneODateColumn),
+		userColumn: goqu.T(scenesODatesTable).Col(userIDColumn),
 	}
 )
 
@@ -337,6 +340,13 @@ var (
 	}
 
 	tagsChildTagsTableMgr = *tagsParentTagsTableMgr.invert()
+
+	tagsStashIDsTableMgr = &stashIDTable{
+		table: table{
+			table:    tagsStashIDsJoinTable,
+			idColumn: tagsStashIDsJoinTable.Col(tagIDColumn),
+		},
+	}
 )
 
 var (
@@ -381,3 +391,96 @@ var (
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
 )
+
+var (
+	jobHistoryTableMgr = &table{
+		table:    goqu.T(jobHistoryTable),
+		idColumn: goqu.T(jobHistoryTable).Col(idColumn),
+	}
+)
+
+var (
+	webhookDeliveryTableMgr = &table{
+		table:    goqu.T(webhookDeliveryTable),
+		idColumn: goqu.T(webhookDeliveryTable).Col(idColumn),
+	}
+)
+
+var (
+	userTableMgr = &table{
+		table:    goqu.T(userTable),
+		idColumn: goqu.T(userTable).Col(idColumn),
+	}
+
+	scenesUsersTableMgr = &table{
+		table:    goqu.T(scenesUsersTable),
+		idColumn: goqu.T(scenesUsersTable).Col(sceneIDColumn),
+	}
+)
+
+var (
+	trashedFileTableMgr = &table{
+		table:    goqu.T(trashedFileTable),
+		idColumn: goqu.T(trashedFileTable).Col(idColumn),
+	}
+)
+
+var (
+	entityHistoryTableMgr = &table{
+		table:    goqu.T(entityHistoryTable),
+		idColumn: goqu.T(entityHistoryTable).Col(idColumn),
+	}
+)
+
+var (
+	scenesCustomFieldsJoinTable     = goqu.T("scene_custom_fields")
+	performersCustomFieldsJoinTable = goqu.T("performer_custom_fields")
+	studiosCustomFieldsJoinTable    = goqu.T("studio_custom_fields")
+	tagsCustomFieldsJoinTable       = goqu.T("tag_custom_fields")
+	galleriesCustomFieldsJoinTable  = goqu.T("gallery_custom_fields")
+	groupsCustomFieldsJoinTable     = goqu.T("group_custom_fields")
+)
+
+var (
+	scenesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    scenesCustomFieldsJoinTable,
+			idColumn: scenesCustomFieldsJoinTable.Col(sceneIDColumn),
+		},
+	}
+
+	performersCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    performersCustomFieldsJoinTable,
+			idColumn: performersCustomFieldsJoinTable.Col(performerIDColumn),
+		},
+	}
+
+	studiosCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    studiosCustomFieldsJoinTable,
+			idColumn: studiosCustomFieldsJoinTable.Col(studioIDColumn),
+		},
+	}
+
+	tagsCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    tagsCustomFieldsJoinTable,
+			idColumn: tagsCustomFieldsJoinTable.Col(tagIDColumn),
+		},
+	}
+
+	galleriesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    galleriesCustomFieldsJoinTable,
+			idColumn: galleriesCustomFieldsJoinTable.Col(galleryIDColumn),
+		},
+	}
+
+	groupsCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    groupsCustomFieldsJoinTable,
+			idColumn: groupsCustomFieldsJoinTable.Col(groupIDColumn),
+		},
+	}
+)
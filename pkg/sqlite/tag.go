@@ -97,7 +97,8 @@ func (r *tagRowRecord) fromPartial(o models.TagPartial) {
 type tagRepositoryType struct {
 	repository
 
-	aliases stringRepository
+	aliases  stringRepository
+	stashIDs stashIDRepository
 
 	scenes    joinRepository
 	images    joinRepository
@@ -117,6 +118,12 @@ var (
 			},
 			stringColumn: tagAliasColumn,
 		},
+		stashIDs: stashIDRepository{
+			repository{
+				tableName: "tag_stash_ids",
+				idColumn:  tagIDColumn,
+			},
+		},
 		scenes: joinRepository{
 			repository: repository{
 				tableName: scenesTagsTable,
@@ -145,6 +152,7 @@ var (
 )
 
 type TagStore struct {
+	customFieldsStore
 	blobJoinQueryBuilder
 
 	tableMgr *table
@@ -152,6 +160,9 @@ type TagStore struct {
 
 func NewTagStore(blobStore *BlobStore) *TagStore {
 	return &TagStore{
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: tagsCustomFieldsTableMgr,
+		},
 		blobJoinQueryBuilder: blobJoinQueryBuilder{
 			blobStore: blobStore,
 			joinTable: tagTable,
@@ -195,6 +206,12 @@ func (qb *TagStore) Create(ctx context.Context, newObject *models.Tag) error {
 		}
 	}
 
+	if newObject.StashIDs.Loaded() {
+		if err := tagsStashIDsTableMgr.insertJoins(ctx, id, newObject.StashIDs.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -238,6 +255,12 @@ func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.Ta
 		}
 	}
 
+	if partial.StashIDs != nil {
+		if err := tagsStashIDsTableMgr.modifyJoins(ctx, id, partial.StashIDs.StashIDs, partial.StashIDs.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -267,6 +290,12 @@ func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error
 		}
 	}
 
+	if updatedObject.StashIDs.Loaded() {
+		if err := tagsStashIDsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.StashIDs.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -505,6 +534,59 @@ func (qb *TagStore) FindByNames(ctx context.Context, names []string, nocase bool
 	return ret, nil
 }
 
+func (qb *TagStore) findBySubquery(ctx context.Context, sq *goqu.SelectDataset) ([]*models.Tag, error) {
+	table := qb.table()
+
+	q := qb.selectDataset().Where(
+		table.Col(idColumn).Eq(
+			sq,
+		),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *TagStore) FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Tag, error) {
+	sq := dialect.From(tagsStashIDsJoinTable).Select(tagsStashIDsJoinTable.Col(tagIDColumn)).Where(
+		tagsStashIDsJoinTable.Col("stash_id").Eq(stashID.StashID),
+		tagsStashIDsJoinTable.Col("endpoint").Eq(stashID.Endpoint),
+	)
+	ret, err := qb.findBySubquery(ctx, sq)
+
+	if err != nil {
+		return nil, fmt.Errorf("getting tags for stash ID %s: %w", stashID.StashID, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *TagStore) FindByStashIDStatus(ctx context.Context, hasStashID bool, stashboxEndpoint string) ([]*models.Tag, error) {
+	table := qb.table()
+	sq := dialect.From(table).LeftJoin(
+		tagsStashIDsJoinTable,
+		goqu.On(table.Col(idColumn).Eq(tagsStashIDsJoinTable.Col(tagIDColumn))),
+	).Select(table.Col(idColumn))
+
+	if hasStashID {
+		sq = sq.Where(
+			tagsStashIDsJoinTable.Col("stash_id").IsNotNull(),
+			tagsStashIDsJoinTable.Col("endpoint").Eq(stashboxEndpoint),
+		)
+	} else {
+		sq = sq.Where(
+			tagsStashIDsJoinTable.Col("stash_id").IsNull(),
+		)
+	}
+
+	ret, err := qb.findBySubquery(ctx, sq)
+
+	if err != nil {
+		return nil, fmt.Errorf("getting tags for stash-box endpoint %s: %w", stashboxEndpoint, err)
+	}
+
+	return ret, nil
+}
+
 func (qb *TagStore) GetParentIDs(ctx context.Context, relatedID int) ([]int, error) {
 	return tagsParentTagsTableMgr.get(ctx, relatedID)
 }
@@ -602,9 +684,11 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 	distinctIDs(&query, tagTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
-		searchColumns := []string{"tags.name", "tag_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		if !query.addSearch(tagSearchIndex, "tags.id", *q) {
+			query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
+			searchColumns := []string{"tags.name", "tag_aliases.alias"}
+			query.parseQueryString(searchColumns, *q)
+		}
 	}
 
 	filter := filterBuilderFromHandler(ctx, &tagFilterHandler{
@@ -655,6 +739,10 @@ func (qb *TagStore) getDefaultTagSort() string {
 }
 
 func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilterType) (string, error) {
+	if sortClause, ok := query.searchSort(findFilter, "tags.id"); ok {
+		return sortClause, nil
+	}
+
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -755,6 +843,10 @@ func (qb *TagStore) GetAliases(ctx context.Context, tagID int) ([]string, error)
 	return tagRepository.aliases.get(ctx, tagID)
 }
 
+func (qb *TagStore) GetStashIDs(ctx context.Context, tagID int) ([]models.StashID, error) {
+	return tagsStashIDsTableMgr.get(ctx, tagID)
+}
+
 func (qb *TagStore) UpdateAliases(ctx context.Context, tagID int, aliases []string) error {
 	return tagRepository.aliases.replace(ctx, tagID, aliases)
 }
@@ -819,6 +911,16 @@ AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idCo
 		return err
 	}
 
+	// move stash ids to the destination, skipping any that it already has
+	_, err = dbWrapper.Exec(ctx, `UPDATE tag_stash_ids SET tag_id = ?
+WHERE tag_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM tag_stash_ids o WHERE o.tag_id = ? AND o.endpoint = tag_stash_ids.endpoint AND o.stash_id = tag_stash_ids.stash_id)`,
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+
 	for _, id := range source {
 		err = qb.Destroy(ctx, id)
 		if err != nil {
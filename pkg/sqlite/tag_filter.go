@@ -68,6 +68,13 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 		stringCriterionHandler(tagFilter.Description, tagTable+".description"),
 		boolCriterionHandler(tagFilter.IgnoreAutoTag, tagTable+".ignore_auto_tag", nil),
 
+		&stashIDCriterionHandler{
+			c:                 tagFilter.StashIDEndpoint,
+			stashIDRepository: &tagRepository.stashIDs,
+			stashIDTableAs:    "tag_stash_ids",
+			parentIDCol:       "tags.id",
+		},
+
 		qb.isMissingCriterionHandler(tagFilter.IsMissing),
 		qb.sceneCountCriterionHandler(tagFilter.SceneCount),
 		qb.imageCountCriterionHandler(tagFilter.ImageCount),
@@ -86,6 +93,12 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{tagFilter.CreatedAt, "tags.created_at", nil},
 		&timestampCriterionHandler{tagFilter.UpdatedAt, "tags.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			c:           tagFilter.CustomFields,
+			table:       tagsCustomFieldsTableMgr,
+			parentIDCol: "tags.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_tags.scene_id",
 			relatedRepo:    sceneRepository.repository,
@@ -135,6 +148,9 @@ func (qb *tagFilterHandler) isMissingCriterionHandler(isMissing *string) criteri
 			switch *isMissing {
 			case "image":
 				f.addWhere("tags.image_blob IS NULL")
+			case "stash_id":
+				tagRepository.stashIDs.join(f, "tag_stash_ids", "tags.id")
+				f.addWhere("tag_stash_ids.tag_id IS NULL")
 			default:
 				f.addWhere("(tags." + *isMissing + " IS NULL OR TRIM(tags." + *isMissing + ") = '')")
 			}
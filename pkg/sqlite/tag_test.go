@@ -900,6 +900,92 @@ func TestTagUpdateAlias(t *testing.T) {
 	}
 }
 
+func TestTagStashIDs(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Tag
+
+		// create tag to test against
+		const name = "TestTagStashIDs"
+		tag := models.Tag{
+			Name: name,
+		}
+		if err := qb.Create(ctx, &tag); err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		if err := tag.LoadStashIDs(ctx, qb); err != nil {
+			return err
+		}
+
+		// ensure no stash IDs to begin with
+		assert.Len(t, tag.StashIDs.List(), 0)
+
+		stashID := models.StashID{
+			StashID:  "stashID",
+			Endpoint: "endpoint",
+		}
+
+		// add stash id and ensure was updated
+		updated, err := qb.UpdatePartial(ctx, tag.ID, models.TagPartial{
+			StashIDs: &models.UpdateStashIDs{
+				StashIDs: []models.StashID{stashID},
+				Mode:     models.RelationshipUpdateModeSet,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := updated.LoadStashIDs(ctx, qb); err != nil {
+			return err
+		}
+
+		assert.Equal(t, []models.StashID{stashID}, updated.StashIDs.List())
+
+		found, err := qb.FindByStashID(ctx, stashID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []int{tag.ID}, tagsToIDs(found))
+
+		found, err = qb.FindByStashIDStatus(ctx, true, stashID.Endpoint)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, []int{tag.ID}, tagsToIDs(found))
+
+		// remove stash id and ensure was updated
+		updated, err = qb.UpdatePartial(ctx, tag.ID, models.TagPartial{
+			StashIDs: &models.UpdateStashIDs{
+				StashIDs: []models.StashID{stashID},
+				Mode:     models.RelationshipUpdateModeRemove,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := updated.LoadStashIDs(ctx, qb); err != nil {
+			return err
+		}
+
+		assert.Len(t, updated.StashIDs.List(), 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func tagsToIDs(i []*models.Tag) []int {
+	ret := make([]int, len(i))
+	for i, v := range i {
+		ret[i] = v.ID
+	}
+
+	return ret
+}
+
 func TestTagMerge(t *testing.T) {
 	assert := assert.New(t)
 
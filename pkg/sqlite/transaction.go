@@ -125,19 +125,24 @@ func (db *Database) IsLocked(err error) bool {
 
 func (db *Database) Repository() models.Repository {
 	return models.Repository{
-		TxnManager:     db,
-		Blob:           db.Blobs,
-		File:           db.File,
-		Folder:         db.Folder,
-		Gallery:        db.Gallery,
-		GalleryChapter: db.GalleryChapter,
-		Image:          db.Image,
-		Group:          db.Group,
-		Performer:      db.Performer,
-		Scene:          db.Scene,
-		SceneMarker:    db.SceneMarker,
-		Studio:         db.Studio,
-		Tag:            db.Tag,
-		SavedFilter:    db.SavedFilter,
+		TxnManager:      db,
+		Blob:            db.Blobs,
+		File:            db.File,
+		Folder:          db.Folder,
+		Gallery:         db.Gallery,
+		GalleryChapter:  db.GalleryChapter,
+		Image:           db.Image,
+		Group:           db.Group,
+		Performer:       db.Performer,
+		Scene:           db.Scene,
+		SceneMarker:     db.SceneMarker,
+		Studio:          db.Studio,
+		Tag:             db.Tag,
+		SavedFilter:     db.SavedFilter,
+		JobHistory:      db.JobHistory,
+		WebhookDelivery: db.WebhookDelivery,
+		User:            db.User,
+		TrashedFile:     db.TrashedFile,
+		EntityHistory:   db.EntityHistory,
 	}
 }
@@ -0,0 +1,236 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	trashedFileTable = "trashed_files"
+)
+
+type trashedFileRow struct {
+	ID             int          `db:"id" goqu:"skipinsert"`
+	OriginalPath   string       `db:"original_path"`
+	TrashPath      string       `db:"trash_path"`
+	Size           int64        `db:"size"`
+	EntityType     string       `db:"entity_type"`
+	EntityID       int          `db:"entity_id"`
+	EntityTitle    string       `db:"entity_title"`
+	EntityMetadata string       `db:"entity_metadata"`
+	TrashedAt      UTCTimestamp `db:"trashed_at"`
+}
+
+func (r *trashedFileRow) fromTrashedFile(o models.TrashedFile) {
+	r.ID = o.ID
+	r.OriginalPath = o.OriginalPath
+	r.TrashPath = o.TrashPath
+	r.Size = o.Size
+	r.EntityType = o.EntityType.String()
+	r.EntityID = o.EntityID
+	r.EntityTitle = o.EntityTitle
+	r.EntityMetadata = o.EntityMetadata
+	r.TrashedAt = UTCTimestamp{Timestamp{Timestamp: o.TrashedAt}}
+}
+
+func (r *trashedFileRow) resolve() *models.TrashedFile {
+	return &models.TrashedFile{
+		ID:             r.ID,
+		OriginalPath:   r.OriginalPath,
+		TrashPath:      r.TrashPath,
+		Size:           r.Size,
+		EntityType:     models.TrashedEntityType(r.EntityType),
+		EntityID:       r.EntityID,
+		EntityTitle:    r.EntityTitle,
+		EntityMetadata: r.EntityMetadata,
+		TrashedAt:      r.TrashedAt.Timestamp.Timestamp,
+	}
+}
+
+type TrashedFileStore struct {
+	tableMgr *table
+}
+
+func NewTrashedFileStore() *TrashedFileStore {
+	return &TrashedFileStore{
+		tableMgr: trashedFileTableMgr,
+	}
+}
+
+func (qb *TrashedFileStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *TrashedFileStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *TrashedFileStore) Create(ctx context.Context, newObject *models.TrashedFile) error {
+	var r trashedFileRow
+	r.fromTrashedFile(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *TrashedFileStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *TrashedFileStore) Find(ctx context.Context, id int) (*models.TrashedFile, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *TrashedFileStore) FindMany(ctx context.Context, ids []int) ([]*models.TrashedFile, error) {
+	ret := make([]*models.TrashedFile, len(ids))
+
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(ids))
+	unsorted, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range unsorted {
+		i := sliceutil.Index(ids, f.ID)
+		ret[i] = f
+	}
+
+	for i := range ret {
+		if ret[i] == nil {
+			return nil, fmt.Errorf("trashed file with id %d not found", ids[i])
+		}
+	}
+
+	return ret, nil
+}
+
+func (qb *TrashedFileStore) FindBefore(ctx context.Context, t time.Time) ([]*models.TrashedFile, error) {
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(
+		table.Col("trashed_at").Lt(UTCTimestamp{Timestamp{Timestamp: t}}),
+	).Order(table.Col("trashed_at").Asc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *TrashedFileStore) All(ctx context.Context) ([]*models.TrashedFile, error) {
+	table := qb.table()
+	return qb.getMany(ctx, qb.selectDataset().Order(table.Col("trashed_at").Asc()))
+}
+
+var trashedFileSortOptions = []string{
+	"entity_title",
+	"id",
+	"original_path",
+	"size",
+	"trashed_at",
+}
+
+func (qb *TrashedFileStore) Query(ctx context.Context, trashedFilter *models.TrashedFileFilter, findFilter *models.FindFilterType) ([]*models.TrashedFile, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	table := qb.table()
+
+	var where []exp.Expression
+	if trashedFilter != nil {
+		if trashedFilter.EntityType != nil {
+			where = append(where, table.Col("entity_type").Eq(trashedFilter.EntityType.String()))
+		}
+	}
+
+	if q := findFilter.Q; q != nil && *q != "" {
+		like := "%" + *q + "%"
+		where = append(where, goqu.Or(
+			table.Col("original_path").Like(like),
+			table.Col("entity_title").Like(like),
+		))
+	}
+
+	countQuery := dialect.From(table).Prepared(true).Select(goqu.COUNT("*")).Where(where...)
+	var count int
+	if err := querySimple(ctx, countQuery, &count); err != nil {
+		return nil, 0, err
+	}
+
+	// most recently trashed first by default
+	sort := "trashed_at"
+	direction := "DESC"
+	if findFilter.Sort != nil && *findFilter.Sort != "" {
+		sort = findFilter.GetSort(sort)
+		direction = findFilter.GetDirection()
+	}
+
+	// ensure sort is in the list of allowed sorts
+	if !sliceutil.Contains(trashedFileSortOptions, sort) {
+		return nil, 0, fmt.Errorf("invalid sort: %s", sort)
+	}
+
+	sortCol := table.Col(sort)
+	var order exp.OrderedExpression
+	if direction == "DESC" {
+		order = sortCol.Desc()
+	} else {
+		order = sortCol.Asc()
+	}
+
+	q := qb.selectDataset().Prepared(true).Where(where...).Order(order, table.Col(idColumn).Desc())
+
+	if !findFilter.IsGetAll() {
+		pageSize := findFilter.GetPageSize()
+		q = q.Limit(uint(pageSize)).Offset(uint((findFilter.GetPage() - 1) * pageSize))
+	}
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ret, count, nil
+}
+
+func (qb *TrashedFileStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.TrashedFile, error) {
+	const single = false
+	var ret []*models.TrashedFile
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f trashedFileRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,126 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashedFileQuery(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.TrashedFile
+
+		oldTime := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		newTime := time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)
+
+		sceneFile := models.TrashedFile{
+			OriginalPath:   "/stash/scene.mp4",
+			TrashPath:      "/trash/1/scene.mp4",
+			Size:           1024,
+			EntityType:     models.TrashedEntityTypeScene,
+			EntityID:       1,
+			EntityTitle:    "scene title",
+			EntityMetadata: `{"title":"scene title"}`,
+			TrashedAt:      oldTime,
+		}
+		imageFile := models.TrashedFile{
+			OriginalPath:   "/stash/image.jpg",
+			TrashPath:      "/trash/2/image.jpg",
+			Size:           512,
+			EntityType:     models.TrashedEntityTypeImage,
+			EntityID:       2,
+			EntityTitle:    "image title",
+			EntityMetadata: `{"title":"image title"}`,
+			TrashedAt:      newTime,
+		}
+
+		for _, o := range []*models.TrashedFile{&sceneFile, &imageFile} {
+			if err := qb.Create(ctx, o); err != nil {
+				t.Errorf("Error creating trashed file: %v", err)
+				return nil
+			}
+		}
+
+		assert := assert.New(t)
+
+		got, count, err := qb.Query(ctx, nil, nil)
+		if err != nil {
+			t.Errorf("Error querying trashed files: %v", err)
+			return nil
+		}
+
+		// most recently trashed first
+		assert.Equal(2, count)
+		if assert.Len(got, 2) {
+			assert.Equal(imageFile.ID, got[0].ID)
+			assert.Equal(sceneFile.ID, got[1].ID)
+			assert.Equal(sceneFile.OriginalPath, got[1].OriginalPath)
+			assert.Equal(sceneFile.TrashPath, got[1].TrashPath)
+			assert.Equal(sceneFile.Size, got[1].Size)
+			assert.Equal(sceneFile.EntityType, got[1].EntityType)
+			assert.Equal(sceneFile.EntityID, got[1].EntityID)
+			assert.Equal(sceneFile.EntityTitle, got[1].EntityTitle)
+			assert.Equal(sceneFile.EntityMetadata, got[1].EntityMetadata)
+			assert.True(oldTime.Equal(got[1].TrashedAt))
+		}
+
+		entityType := models.TrashedEntityTypeScene
+		got, count, err = qb.Query(ctx, &models.TrashedFileFilter{EntityType: &entityType}, nil)
+		if err != nil {
+			t.Errorf("Error querying trashed files: %v", err)
+			return nil
+		}
+
+		assert.Equal(1, count)
+		if assert.Len(got, 1) {
+			assert.Equal(sceneFile.ID, got[0].ID)
+		}
+
+		q := "image"
+		got, count, err = qb.Query(ctx, nil, &models.FindFilterType{Q: &q})
+		if err != nil {
+			t.Errorf("Error querying trashed files: %v", err)
+			return nil
+		}
+
+		assert.Equal(1, count)
+		if assert.Len(got, 1) {
+			assert.Equal(imageFile.ID, got[0].ID)
+		}
+
+		got, err = qb.FindBefore(ctx, newTime)
+		if err != nil {
+			t.Errorf("Error finding trashed files: %v", err)
+			return nil
+		}
+
+		if assert.Len(got, 1) {
+			assert.Equal(sceneFile.ID, got[0].ID)
+		}
+
+		if _, err := qb.FindMany(ctx, []int{sceneFile.ID, imageFile.ID + 1}); err == nil {
+			t.Errorf("Expected error finding missing trashed file")
+		}
+
+		if err := qb.Destroy(ctx, sceneFile.ID); err != nil {
+			t.Errorf("Error destroying trashed file: %v", err)
+			return nil
+		}
+
+		found, err := qb.Find(ctx, sceneFile.ID)
+		if err != nil {
+			t.Errorf("Error finding trashed file: %v", err)
+			return nil
+		}
+
+		assert.Nil(found)
+
+		return nil
+	})
+}
@@ -0,0 +1,209 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserStore(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.User
+
+		now := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		u := models.User{
+			Username:     "viewer",
+			PasswordHash: "hash",
+			Role:         models.UserRoleViewer,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		if err := qb.Create(ctx, &u); err != nil {
+			t.Errorf("UserStore.Create() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.FindByUsername(ctx, "viewer")
+		if err != nil {
+			t.Errorf("UserStore.FindByUsername() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, &u, got)
+
+		u.Role = models.UserRoleEditor
+		u.APIKey = "key"
+		if err := qb.Update(ctx, &u); err != nil {
+			t.Errorf("UserStore.Update() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(ctx, u.ID)
+		if err != nil {
+			t.Errorf("UserStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, &u, got)
+
+		got, err = qb.FindByUsername(ctx, "missing")
+		assert.Nil(t, err)
+		assert.Nil(t, got)
+
+		if err := qb.Destroy(ctx, u.ID); err != nil {
+			t.Errorf("UserStore.Destroy() error = %v", err)
+			return nil
+		}
+
+		all, err := qb.All(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, all, 0)
+
+		return nil
+	})
+}
+
+func TestSceneUserData(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		now := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		u := models.User{
+			Username:     "viewer",
+			PasswordHash: "hash",
+			Role:         models.UserRoleViewer,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		if err := db.User.Create(ctx, &u); err != nil {
+			t.Errorf("UserStore.Create() error = %v", err)
+			return nil
+		}
+
+		qb := db.Scene
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		shared, err := qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+
+		sharedViews, err := qb.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+			return nil
+		}
+
+		userCtx := models.WithUserID(ctx, u.ID)
+
+		// user starts with no activity
+		got, err := qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, got.Rating)
+		assert.Zero(t, got.ResumeTime)
+
+		rating := 80
+		if _, err := qb.UpdatePartial(userCtx, sceneID, models.ScenePartial{
+			Rating: models.NewOptionalInt(rating),
+		}); err != nil {
+			t.Errorf("SceneStore.UpdatePartial() error = %v", err)
+			return nil
+		}
+
+		resumeTime := 12.5
+		if _, err := qb.SaveActivity(userCtx, sceneID, &resumeTime, nil); err != nil {
+			t.Errorf("SceneStore.SaveActivity() error = %v", err)
+			return nil
+		}
+
+		if _, err := qb.AddViews(userCtx, sceneID, []time.Time{now}); err != nil {
+			t.Errorf("SceneStore.AddViews() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, &rating, got.Rating)
+		assert.Equal(t, resumeTime, got.ResumeTime)
+
+		views, err := qb.CountViews(userCtx, sceneID)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, views)
+
+		// shared values are unchanged
+		got, err = qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.Equal(t, shared.Rating, got.Rating)
+		assert.Equal(t, shared.ResumeTime, got.ResumeTime)
+
+		views, err = qb.CountViews(ctx, sceneID)
+		assert.Nil(t, err)
+		assert.Equal(t, sharedViews, views)
+
+		// filtering and sorting use the values of the user
+		scenes := queryScene(userCtx, t, qb, &models.SceneFilterType{
+			Rating100: &models.IntCriterionInput{
+				Value:    0,
+				Modifier: models.CriterionModifierNotNull,
+			},
+			ResumeTime: &models.IntCriterionInput{
+				Value:    10,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}, nil)
+		if assert.Len(t, scenes, 1) {
+			assert.Equal(t, sceneID, scenes[0].ID)
+		}
+
+		// play and o counts only include the history of the user
+		scenes = queryScene(userCtx, t, qb, &models.SceneFilterType{
+			PlayCount: &models.IntCriterionInput{
+				Value:    0,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+			LastPlayedAt: &models.TimestampCriterionInput{
+				Value:    now.Add(-time.Hour).Format(time.RFC3339),
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}, nil)
+		if assert.Len(t, scenes, 1) {
+			assert.Equal(t, sceneID, scenes[0].ID)
+		}
+
+		scenes = queryScene(userCtx, t, qb, &models.SceneFilterType{
+			OCounter: &models.IntCriterionInput{
+				Value:    0,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}, nil)
+		assert.Len(t, scenes, 0)
+
+		direction := models.SortDirectionEnumDesc
+		for _, sort := range []string{"rating", "play_count", "last_played_at"} {
+			sort := sort
+			scenes = queryScene(userCtx, t, qb, nil, &models.FindFilterType{
+				Sort:      &sort,
+				Direction: &direction,
+			})
+			if assert.NotEmpty(t, scenes) {
+				assert.Equal(t, sceneID, scenes[0].ID, sort)
+			}
+		}
+
+		return nil
+	})
+}
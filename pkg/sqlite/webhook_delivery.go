@@ -0,0 +1,199 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	webhookDeliveryTable = "webhook_deliveries"
+)
+
+type webhookDeliveryRow struct {
+	ID         int          `db:"id" goqu:"skipinsert"`
+	WebhookID  string       `db:"webhook_id"`
+	URL        string       `db:"url"`
+	Trigger    string       `db:"trigger"`
+	EntityID   int          `db:"entity_id"`
+	Attempt    int          `db:"attempt"`
+	Success    bool         `db:"success"`
+	StatusCode null.Int     `db:"status_code"`
+	Error      null.String  `db:"error"`
+	Duration   int64        `db:"duration"`
+	CreatedAt  UTCTimestamp `db:"created_at"`
+}
+
+func (r *webhookDeliveryRow) fromWebhookDelivery(o models.WebhookDelivery) {
+	r.ID = o.ID
+	r.WebhookID = o.WebhookID
+	r.URL = o.URL
+	r.Trigger = o.Trigger
+	r.EntityID = o.EntityID
+	r.Attempt = o.Attempt
+	r.Success = o.Success
+	r.StatusCode = intFromPtr(o.StatusCode)
+	r.Error = null.StringFromPtr(o.Error)
+	// duration is stored in milliseconds
+	r.Duration = o.Duration.Milliseconds()
+	r.CreatedAt = UTCTimestamp{Timestamp{Timestamp: o.CreatedAt}}
+}
+
+func (r *webhookDeliveryRow) resolve() *models.WebhookDelivery {
+	ret := &models.WebhookDelivery{
+		ID:        r.ID,
+		WebhookID: r.WebhookID,
+		URL:       r.URL,
+		Trigger:   r.Trigger,
+		EntityID:  r.EntityID,
+		Attempt:   r.Attempt,
+		Success:   r.Success,
+		Error:     r.Error.Ptr(),
+		Duration:  time.Duration(r.Duration) * time.Millisecond,
+		CreatedAt: r.CreatedAt.Timestamp.Timestamp,
+	}
+
+	if r.StatusCode.Valid {
+		statusCode := int(r.StatusCode.Int64)
+		ret.StatusCode = &statusCode
+	}
+
+	return ret
+}
+
+type WebhookDeliveryStore struct {
+	tableMgr *table
+}
+
+func NewWebhookDeliveryStore() *WebhookDeliveryStore {
+	return &WebhookDeliveryStore{
+		tableMgr: webhookDeliveryTableMgr,
+	}
+}
+
+func (qb *WebhookDeliveryStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *WebhookDeliveryStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *WebhookDeliveryStore) Create(ctx context.Context, newObject *models.WebhookDelivery) error {
+	var r webhookDeliveryRow
+	r.fromWebhookDelivery(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *WebhookDeliveryStore) DestroyBefore(ctx context.Context, t time.Time) error {
+	table := qb.table()
+	q := dialect.Delete(table).Prepared(true).Where(
+		table.Col("created_at").Lt(UTCTimestamp{Timestamp{Timestamp: t}}),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying webhook deliveries before %v: %w", t, err)
+	}
+
+	return nil
+}
+
+var webhookDeliverySortOptions = []string{
+	"created_at",
+	"id",
+	"trigger",
+	"webhook_id",
+}
+
+func (qb *WebhookDeliveryStore) Query(ctx context.Context, deliveryFilter *models.WebhookDeliveryFilter, findFilter *models.FindFilterType) ([]*models.WebhookDelivery, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	table := qb.table()
+
+	var where []exp.Expression
+	if deliveryFilter != nil {
+		if deliveryFilter.WebhookID != nil {
+			where = append(where, table.Col("webhook_id").Eq(*deliveryFilter.WebhookID))
+		}
+		if deliveryFilter.Success != nil {
+			where = append(where, table.Col("success").Eq(*deliveryFilter.Success))
+		}
+	}
+
+	countQuery := dialect.From(table).Prepared(true).Select(goqu.COUNT("*")).Where(where...)
+	var count int
+	if err := querySimple(ctx, countQuery, &count); err != nil {
+		return nil, 0, err
+	}
+
+	// most recent first by default
+	sort := "created_at"
+	direction := "DESC"
+	if findFilter.Sort != nil && *findFilter.Sort != "" {
+		sort = findFilter.GetSort(sort)
+		direction = findFilter.GetDirection()
+	}
+
+	// ensure sort is in the list of allowed sorts
+	if !sliceutil.Contains(webhookDeliverySortOptions, sort) {
+		return nil, 0, fmt.Errorf("invalid sort: %s", sort)
+	}
+
+	sortCol := table.Col(sort)
+	var order exp.OrderedExpression
+	if direction == "DESC" {
+		order = sortCol.Desc()
+	} else {
+		order = sortCol.Asc()
+	}
+
+	q := qb.selectDataset().Prepared(true).Where(where...).Order(order, table.Col(idColumn).Desc())
+
+	if !findFilter.IsGetAll() {
+		pageSize := findFilter.GetPageSize()
+		q = q.Limit(uint(pageSize)).Offset(uint((findFilter.GetPage() - 1) * pageSize))
+	}
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ret, count, nil
+}
+
+func (qb *WebhookDeliveryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.WebhookDelivery, error) {
+	const single = false
+	var ret []*models.WebhookDelivery
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f webhookDeliveryRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
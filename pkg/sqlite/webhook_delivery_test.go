@@ -0,0 +1,113 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookDeliveryQuery(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.WebhookDelivery
+
+		errStr := "unexpected status code 500"
+		statusCode := 500
+		oldTime := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+		newTime := time.Date(2024, 2, 1, 3, 0, 0, 0, time.UTC)
+
+		failed := models.WebhookDelivery{
+			WebhookID:  "1",
+			URL:        "http://localhost/hook",
+			Trigger:    "Scene.Update.Post",
+			EntityID:   1,
+			Attempt:    1,
+			StatusCode: &statusCode,
+			Error:      &errStr,
+			Duration:   150 * time.Millisecond,
+			CreatedAt:  oldTime,
+		}
+		succeeded := models.WebhookDelivery{
+			WebhookID: "2",
+			URL:       "http://localhost/other",
+			Trigger:   "Tag.Create.Post",
+			EntityID:  2,
+			Attempt:   1,
+			Success:   true,
+			CreatedAt: newTime,
+		}
+
+		for _, o := range []*models.WebhookDelivery{&failed, &succeeded} {
+			if err := qb.Create(ctx, o); err != nil {
+				t.Errorf("Error creating webhook delivery: %v", err)
+				return nil
+			}
+		}
+
+		assert := assert.New(t)
+
+		got, count, err := qb.Query(ctx, nil, nil)
+		if err != nil {
+			t.Errorf("Error querying webhook deliveries: %v", err)
+			return nil
+		}
+
+		// most recent first
+		assert.Equal(2, count)
+		if assert.Len(got, 2) {
+			assert.Equal(succeeded.ID, got[0].ID)
+			assert.Nil(got[0].StatusCode)
+			assert.Equal(failed.ID, got[1].ID)
+			assert.Equal(statusCode, *got[1].StatusCode)
+			assert.Equal(errStr, *got[1].Error)
+			assert.Equal(failed.Duration, got[1].Duration)
+			assert.True(oldTime.Equal(got[1].CreatedAt))
+		}
+
+		success := false
+		got, count, err = qb.Query(ctx, &models.WebhookDeliveryFilter{Success: &success}, nil)
+		if err != nil {
+			t.Errorf("Error querying webhook deliveries: %v", err)
+			return nil
+		}
+
+		assert.Equal(1, count)
+		if assert.Len(got, 1) {
+			assert.Equal(failed.ID, got[0].ID)
+		}
+
+		webhookID := "2"
+		got, count, err = qb.Query(ctx, &models.WebhookDeliveryFilter{WebhookID: &webhookID}, nil)
+		if err != nil {
+			t.Errorf("Error querying webhook deliveries: %v", err)
+			return nil
+		}
+
+		assert.Equal(1, count)
+		if assert.Len(got, 1) {
+			assert.Equal(succeeded.ID, got[0].ID)
+		}
+
+		if err := qb.DestroyBefore(ctx, newTime); err != nil {
+			t.Errorf("Error destroying webhook deliveries: %v", err)
+			return nil
+		}
+
+		got, _, err = qb.Query(ctx, nil, nil)
+		if err != nil {
+			t.Errorf("Error querying webhook deliveries: %v", err)
+			return nil
+		}
+
+		if assert.Len(got, 1) {
+			assert.Equal(succeeded.ID, got[0].ID)
+		}
+
+		return nil
+	})
+}
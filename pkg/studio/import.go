@@ -14,6 +14,7 @@ import (
 
 type ImporterReaderWriter interface {
 	models.StudioCreatorUpdater
+	models.CustomFieldsWriter
 	FindByName(ctx context.Context, name string, nocase bool) (*models.Studio, error)
 }
 
@@ -170,6 +171,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		}
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting studio custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
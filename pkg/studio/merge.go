@@ -0,0 +1,140 @@
+package studio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+var ErrMergeDestinationInSource = errors.New("destination studio cannot be in source list")
+
+// Merge merges the source studios into the destination studio.
+// Scenes, images, galleries, groups and child studios of the source studios
+// are reassigned to the destination. The names and aliases of the source
+// studios are added as aliases of the destination, and their tags and stash
+// ids are added to the destination. The source studios are destroyed.
+//
+// If the parent of the destination is one of the source studios, then the
+// destination takes the parent of that source studio. Merges that would make
+// the destination its own ancestor are rejected.
+//
+// Values set in the provided partial are applied to the destination after the
+// relationships have been merged.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, values models.StudioPartial, qb models.StudioReaderWriter) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	// ensure destination is not in source list
+	if sliceutil.Contains(sourceIDs, destinationID) {
+		return ErrMergeDestinationInSource
+	}
+
+	dest, err := qb.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination studio ID %d: %w", destinationID, err)
+	}
+
+	if dest == nil {
+		return &NotFoundError{destinationID}
+	}
+
+	sources, err := qb.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source studios: %w", err)
+	}
+
+	if err := loadMergeRelationships(ctx, dest, qb); err != nil {
+		return err
+	}
+
+	sourceMap := make(map[int]*models.Studio)
+	aliases := dest.Aliases.List()
+	tagIDs := dest.TagIDs.List()
+	stashIDs := dest.StashIDs.List()
+
+	for _, src := range sources {
+		if err := loadMergeRelationships(ctx, src, qb); err != nil {
+			return err
+		}
+
+		sourceMap[src.ID] = src
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, src.Aliases.List()...)
+		tagIDs = sliceutil.AppendUniques(tagIDs, src.TagIDs.List())
+		stashIDs = sliceutil.AppendUniques(stashIDs, src.StashIDs.List())
+	}
+
+	parentID := dest.ParentID
+	if values.ParentID.Set {
+		parentID = values.ParentID.Ptr()
+	}
+
+	// source studios are removed, so take the nearest ancestor that is not
+	// being merged
+	for parentID != nil && sourceMap[*parentID] != nil {
+		parentID = sourceMap[*parentID].ParentID
+	}
+
+	if err := ValidateMerge(ctx, sourceIDs, destinationID, parentID, qb); err != nil {
+		return err
+	}
+
+	name := dest.Name
+	if values.Name.Set {
+		name = values.Name.Value
+	}
+
+	values.ID = destinationID
+	values.ParentID = models.NewOptionalIntPtr(parentID)
+
+	// apply any relationship changes in the values on top of the merged values
+	values.Aliases = &models.UpdateStrings{
+		Values: stringslice.UniqueFoldExcept(values.Aliases.Apply(aliases), name),
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+	values.TagIDs = &models.UpdateIDs{
+		IDs:  values.TagIDs.Apply(tagIDs),
+		Mode: models.RelationshipUpdateModeSet,
+	}
+	values.StashIDs = &models.UpdateStashIDs{
+		StashIDs: values.StashIDs.Apply(stashIDs),
+		Mode:     models.RelationshipUpdateModeSet,
+	}
+
+	// reassign related objects and destroy the source studios
+	if err := qb.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return fmt.Errorf("merging studios: %w", err)
+	}
+
+	// validate after the source studios have been removed, so that their
+	// names may be used as aliases of the destination
+	if err := ValidateModify(ctx, values, qb); err != nil {
+		return err
+	}
+
+	if _, err := qb.UpdatePartial(ctx, values); err != nil {
+		return fmt.Errorf("updating studio: %w", err)
+	}
+
+	return nil
+}
+
+func loadMergeRelationships(ctx context.Context, s *models.Studio, qb models.StudioReader) error {
+	if err := s.LoadAliases(ctx, qb); err != nil {
+		return fmt.Errorf("loading studio aliases from %d: %w", s.ID, err)
+	}
+
+	if err := s.LoadTagIDs(ctx, qb); err != nil {
+		return fmt.Errorf("loading studio tags from %d: %w", s.ID, err)
+	}
+
+	if err := s.LoadStashIDs(ctx, qb); err != nil {
+		return fmt.Errorf("loading studio stash ids from %d: %w", s.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMerge(t *testing.T) {
+	const (
+		destID = iota + 1
+		srcID1
+		srcID2
+		grandParentID
+		missingID
+
+		tagID1
+		tagID2
+	)
+
+	const (
+		destName = "dest"
+		srcName1 = "src1"
+		srcName2 = "src2"
+
+		endpoint = "endpoint"
+	)
+
+	db := mocks.NewDatabase()
+
+	// the destination is a child of src1, so takes the parent of src1
+	srcParentID := srcID1
+	gpID := grandParentID
+	dest := &models.Studio{ID: destID, Name: destName, ParentID: &srcParentID}
+	src1 := &models.Studio{ID: srcID1, Name: srcName1, ParentID: &gpID}
+	src2 := &models.Studio{ID: srcID2, Name: srcName2}
+	grandParent := &models.Studio{ID: grandParentID, Name: "grandparent"}
+
+	db.Studio.On("Find", testCtx, destID).Return(dest, nil)
+	db.Studio.On("Find", testCtx, grandParentID).Return(grandParent, nil)
+	db.Studio.On("Find", testCtx, missingID).Return(nil, nil)
+	db.Studio.On("FindMany", testCtx, []int{srcID1, srcID2}).Return([]*models.Studio{src1, src2}, nil)
+
+	db.Studio.On("GetAliases", testCtx, destID).Return([]string{"alias"}, nil)
+	db.Studio.On("GetAliases", testCtx, srcID1).Return([]string{"Alias", "src alias"}, nil)
+	db.Studio.On("GetAliases", testCtx, srcID2).Return([]string{destName}, nil)
+
+	db.Studio.On("GetTagIDs", testCtx, destID).Return([]int{tagID1}, nil)
+	db.Studio.On("GetTagIDs", testCtx, srcID1).Return([]int{tagID2}, nil)
+	db.Studio.On("GetTagIDs", testCtx, srcID2).Return([]int{tagID1}, nil)
+
+	db.Studio.On("GetStashIDs", testCtx, destID).Return([]models.StashID{{StashID: "a", Endpoint: endpoint}}, nil)
+	db.Studio.On("GetStashIDs", testCtx, srcID1).Return([]models.StashID{{StashID: "a", Endpoint: endpoint}}, nil)
+	db.Studio.On("GetStashIDs", testCtx, srcID2).Return([]models.StashID{{StashID: "b", Endpoint: endpoint}}, nil)
+
+	// aliases are not used by other studios
+	db.Studio.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	db.Studio.On("Merge", testCtx, []int{srcID1, srcID2}, destID).Return(nil).Once()
+
+	matchPartial := mock.MatchedBy(func(p models.StudioPartial) bool {
+		return p.ID == destID &&
+			assert.ObjectsAreEqual(models.NewOptionalInt(grandParentID), p.ParentID) &&
+			assert.ObjectsAreEqual([]string{"alias", srcName1, "src alias", srcName2}, p.Aliases.Values) &&
+			assert.ObjectsAreEqual([]int{tagID1, tagID2}, p.TagIDs.IDs) &&
+			assert.ObjectsAreEqual([]models.StashID{
+				{StashID: "a", Endpoint: endpoint},
+				{StashID: "b", Endpoint: endpoint},
+			}, p.StashIDs.StashIDs)
+	})
+	db.Studio.On("UpdatePartial", testCtx, matchPartial).Return(dest, nil).Once()
+
+	values := models.NewStudioPartial()
+
+	assert.ErrorIs(t, Merge(testCtx, []int{srcID1, destID}, destID, values, db.Studio), ErrMergeDestinationInSource)
+	assert.Equal(t, &NotFoundError{missingID}, Merge(testCtx, []int{srcID1}, missingID, values, db.Studio))
+	assert.Nil(t, Merge(testCtx, []int{srcID1, srcID2, srcID1}, destID, values, db.Studio))
+
+	db.AssertExpectations(t)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 var (
@@ -13,6 +14,14 @@ var (
 	ErrStudioOwnAncestor = errors.New("studio cannot be an ancestor of itself")
 )
 
+type NotFoundError struct {
+	id int
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("studio with id %d not found", e.id)
+}
+
 type NameExistsError struct {
 	Name string
 }
@@ -114,7 +123,7 @@ func ValidateModify(ctx context.Context, s models.StudioPartial, qb ValidateModi
 		return err
 	}
 	if existing == nil {
-		return fmt.Errorf("studio with id %d not found", s.ID)
+		return &NotFoundError{s.ID}
 	}
 
 	newParentID := s.ParentID.Ptr()
@@ -146,7 +155,13 @@ func ValidateModify(ctx context.Context, s models.StudioPartial, qb ValidateModi
 }
 
 func validateParent(ctx context.Context, studioID int, newParentID int, qb models.StudioGetter) error {
-	if newParentID == studioID {
+	return validateAncestors(ctx, []int{studioID}, newParentID, qb)
+}
+
+// validateAncestors returns ErrStudioOwnAncestor if the new parent or any of
+// its ancestors is one of the provided studio IDs.
+func validateAncestors(ctx context.Context, studioIDs []int, newParentID int, qb models.StudioGetter) error {
+	if sliceutil.Contains(studioIDs, newParentID) {
 		return ErrStudioOwnAncestor
 	}
 
@@ -157,12 +172,28 @@ func validateParent(ctx context.Context, studioID int, newParentID int, qb model
 	}
 
 	if parentStudio == nil {
-		return fmt.Errorf("studio with id %d not found", newParentID)
+		return &NotFoundError{newParentID}
 	}
 
 	if parentStudio.ParentID != nil {
-		return validateParent(ctx, studioID, *parentStudio.ParentID, qb)
+		return validateAncestors(ctx, studioIDs, *parentStudio.ParentID, qb)
 	}
 
 	return nil
 }
+
+// ValidateMerge returns ErrStudioOwnAncestor if merging the source studios
+// into the destination studio would create a cycle in the studio hierarchy.
+// newParentID is the parent of the destination studio after the merge.
+//
+// The children of the source studios become children of the destination, so
+// the new parent must not be the destination, one of the sources, or a
+// descendant of any of them.
+func ValidateMerge(ctx context.Context, sourceIDs []int, destinationID int, newParentID *int, qb models.StudioGetter) error {
+	if newParentID == nil {
+		return nil
+	}
+
+	studioIDs := append([]int{destinationID}, sourceIDs...)
+	return validateAncestors(ctx, studioIDs, *newParentID, qb)
+}
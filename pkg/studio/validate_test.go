@@ -102,3 +102,53 @@ func TestValidateUpdateName(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMerge(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	const (
+		destID = iota + 1
+		srcID
+		srcChildID
+		destChildID
+		otherID
+		otherParentID
+	)
+
+	intPtr := func(v int) *int {
+		return &v
+	}
+
+	studios := []*models.Studio{
+		{ID: destID},
+		{ID: srcID},
+		{ID: srcChildID, ParentID: intPtr(srcID)},
+		{ID: destChildID, ParentID: intPtr(destID)},
+		{ID: otherID, ParentID: intPtr(otherParentID)},
+		{ID: otherParentID},
+	}
+
+	for _, s := range studios {
+		db.Studio.On("Find", testCtx, s.ID).Return(s, nil)
+	}
+
+	tests := []struct {
+		name        string
+		newParentID *int
+		want        error
+	}{
+		{"no parent", nil, nil},
+		{"unrelated parent", intPtr(otherID), nil},
+		{"destination", intPtr(destID), ErrStudioOwnAncestor},
+		{"source", intPtr(srcID), ErrStudioOwnAncestor},
+		{"child of source", intPtr(srcChildID), ErrStudioOwnAncestor},
+		{"child of destination", intPtr(destChildID), ErrStudioOwnAncestor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateMerge(testCtx, []int{srcID}, destID, tt.newParentID, db.Studio)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -33,6 +33,13 @@ var (
 	updateTime     = time.Date(2002, 01, 01, 0, 0, 0, 0, time.UTC)
 )
 
+var stashIDs = []models.StashID{
+	{
+		StashID:  "StashID",
+		Endpoint: "Endpoint",
+	},
+}
+
 func createTag(id int) models.Tag {
 	return models.Tag{
 		ID:            id,
@@ -40,6 +47,7 @@ func createTag(id int) models.Tag {
 		Favorite:      true,
 		Description:   description,
 		IgnoreAutoTag: autoTagIgnored,
+		StashIDs:      models.NewRelatedStashIDs(stashIDs),
 		CreatedAt:     createTime,
 		UpdatedAt:     updateTime,
 	}
@@ -58,8 +66,9 @@ func createJSONTag(aliases []string, image string, parents []string) *jsonschema
 		UpdatedAt: json.JSONTime{
 			Time: updateTime,
 		},
-		Image:   image,
-		Parents: parents,
+		Image:    image,
+		Parents:  parents,
+		StashIDs: stashIDs,
 	}
 }
 
@@ -11,6 +11,7 @@ import (
 
 type ImporterReaderWriter interface {
 	models.TagCreatorUpdater
+	models.CustomFieldsWriter
 	FindByName(ctx context.Context, name string, nocase bool) (*models.Tag, error)
 }
 
@@ -41,6 +42,7 @@ func (i *Importer) PreImport(ctx context.Context) error {
 		Description:   i.Input.Description,
 		Favorite:      i.Input.Favorite,
 		IgnoreAutoTag: i.Input.IgnoreAutoTag,
+		StashIDs:      models.NewRelatedStashIDs(i.Input.StashIDs),
 		CreatedAt:     i.Input.CreatedAt.GetTime(),
 		UpdatedAt:     i.Input.UpdatedAt.GetTime(),
 	}
@@ -76,6 +78,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		return fmt.Errorf("error setting parents: %v", err)
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting tag custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,328 @@
+// Package webhook sends notifications of entity lifecycle events to
+// configured URLs.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+const (
+	// SignatureHeader contains the hex encoded HMAC-SHA256 signature of the
+	// request body, prefixed with "sha256=". Only set if the webhook has a
+	// secret.
+	SignatureHeader = "X-Stash-Signature-256"
+	// TriggerHeader contains the trigger that caused the delivery.
+	TriggerHeader = "X-Stash-Trigger"
+
+	defaultMaxAttempts    = 5
+	defaultInitialBackoff = 10 * time.Second
+	defaultMaxBackoff     = 10 * time.Minute
+	defaultTimeout        = 30 * time.Second
+
+	queueSize   = 1000
+	workerCount = 4
+)
+
+// Webhook is a URL that is sent a payload when one of its triggers occurs.
+type Webhook struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+	// Triggers that cause a delivery. All triggers if empty.
+	Triggers []hook.TriggerEnum `json:"triggers"`
+	// Secret used to sign the payload. Payloads are not signed if empty.
+	Secret string `json:"secret"`
+}
+
+// Matches returns true if the webhook is enabled and should be sent for the
+// provided trigger.
+func (w *Webhook) Matches(trigger hook.TriggerEnum) bool {
+	if !w.Enabled {
+		return false
+	}
+
+	if len(w.Triggers) == 0 {
+		return true
+	}
+
+	for _, t := range w.Triggers {
+		if t == trigger {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Payload is the JSON body sent to the webhook URL.
+type Payload struct {
+	Trigger string `json:"trigger"`
+	// ID of the entity that the trigger applies to
+	ID int `json:"id"`
+	// Input provided to the operation, if any
+	Input interface{} `json:"input,omitempty"`
+	// Fields that were set in the input, for update operations
+	InputFields []string  `json:"inputFields,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// Sign returns the signature of the body using the provided secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Recorder records the result of each delivery attempt.
+type Recorder func(ctx context.Context, d *models.WebhookDelivery)
+
+type delivery struct {
+	webhook Webhook
+	trigger hook.TriggerEnum
+	id      int
+	body    []byte
+	attempt int
+}
+
+// Dispatcher queues and sends webhook deliveries. Failed deliveries are
+// retried with exponential backoff.
+type Dispatcher struct {
+	Client   *http.Client
+	Recorder Recorder
+
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	mutex    sync.RWMutex
+	webhooks []Webhook
+
+	queue chan *delivery
+	stop  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// NewDispatcher returns a new Dispatcher. The dispatcher does not send
+// deliveries until Start is called.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{
+		Client: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		queue:          make(chan *delivery, queueSize),
+	}
+}
+
+// SetWebhooks replaces the configured webhooks. Queued deliveries are not
+// affected.
+func (d *Dispatcher) SetWebhooks(webhooks []*Webhook) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	d.webhooks = make([]Webhook, len(webhooks))
+	for i, w := range webhooks {
+		d.webhooks[i] = *w
+	}
+}
+
+// Start starts sending queued deliveries.
+func (d *Dispatcher) Start() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.stop != nil {
+		return
+	}
+
+	d.stop = make(chan struct{})
+	for i := 0; i < workerCount; i++ {
+		d.wg.Add(1)
+		go d.worker(d.stop)
+	}
+}
+
+// Stop stops sending deliveries, waiting for any in-progress deliveries to
+// complete. Queued deliveries and pending retries are discarded.
+func (d *Dispatcher) Stop() {
+	d.mutex.Lock()
+	if d.stop == nil {
+		d.mutex.Unlock()
+		return
+	}
+
+	close(d.stop)
+	d.stop = nil
+	d.mutex.Unlock()
+
+	d.wg.Wait()
+}
+
+// OnPostHook queues a delivery to each webhook that matches the trigger.
+func (d *Dispatcher) OnPostHook(ctx context.Context, id int, trigger hook.TriggerEnum, input interface{}, inputFields []string) {
+	d.mutex.RLock()
+	var matched []Webhook
+	for _, w := range d.webhooks {
+		if w.Matches(trigger) {
+			matched = append(matched, w)
+		}
+	}
+	d.mutex.RUnlock()
+
+	if len(matched) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(Payload{
+		Trigger:     trigger.String(),
+		ID:          id,
+		Input:       input,
+		InputFields: inputFields,
+		Timestamp:   time.Now(),
+	})
+	if err != nil {
+		logger.Errorf("error encoding webhook payload for %s: %v", trigger, err)
+		return
+	}
+
+	for _, w := range matched {
+		d.enqueue(&delivery{
+			webhook: w,
+			trigger: trigger,
+			id:      id,
+			body:    body,
+			attempt: 1,
+		})
+	}
+}
+
+func (d *Dispatcher) enqueue(del *delivery) {
+	select {
+	case d.queue <- del:
+	default:
+		logger.Errorf("webhook queue is full, dropping delivery of %s to %q", del.trigger, del.webhook.Name)
+	}
+}
+
+func (d *Dispatcher) worker(stop chan struct{}) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case del := <-d.queue:
+			d.deliver(del, stop)
+		}
+	}
+}
+
+// backoff returns the time to wait before the next attempt.
+func (d *Dispatcher) backoff(attempt int) time.Duration {
+	ret := d.InitialBackoff
+	for i := 1; i < attempt && ret < d.MaxBackoff; i++ {
+		ret *= 2
+	}
+
+	if ret > d.MaxBackoff {
+		ret = d.MaxBackoff
+	}
+
+	return ret
+}
+
+func (d *Dispatcher) deliver(del *delivery, stop chan struct{}) {
+	start := time.Now()
+	statusCode, err := d.send(del)
+
+	record := &models.WebhookDelivery{
+		WebhookID: del.webhook.ID,
+		URL:       del.webhook.URL,
+		Trigger:   del.trigger.String(),
+		EntityID:  del.id,
+		Attempt:   del.attempt,
+		Success:   err == nil,
+		Duration:  time.Since(start),
+		CreatedAt: start,
+	}
+	if statusCode != 0 {
+		record.StatusCode = &statusCode
+	}
+	if err != nil {
+		errStr := err.Error()
+		record.Error = &errStr
+	}
+
+	if d.Recorder != nil {
+		d.Recorder(context.Background(), record)
+	}
+
+	if err == nil {
+		return
+	}
+
+	if del.attempt >= d.MaxAttempts {
+		logger.Errorf("webhook %q: giving up delivery of %s after %d attempts: %v", del.webhook.Name, del.trigger, del.attempt, err)
+		return
+	}
+
+	wait := d.backoff(del.attempt)
+	logger.Warnf("webhook %q: delivery of %s failed, retrying in %s: %v", del.webhook.Name, del.trigger, wait, err)
+
+	next := *del
+	next.attempt++
+
+	go func() {
+		select {
+		case <-stop:
+		case <-time.After(wait):
+			d.enqueue(&next)
+		}
+	}()
+}
+
+func (d *Dispatcher) send(del *delivery) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, del.webhook.URL, bytes.NewReader(del.body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "stash")
+	req.Header.Set(TriggerHeader, del.trigger.String())
+	if del.webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(del.webhook.Secret, del.body))
+	}
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+func TestWebhookMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		webhook Webhook
+		trigger hook.TriggerEnum
+		want    bool
+	}{
+		{"disabled", Webhook{Enabled: false}, hook.SceneUpdatePost, false},
+		{"all triggers", Webhook{Enabled: true}, hook.SceneUpdatePost, true},
+		{"matching trigger", Webhook{Enabled: true, Triggers: []hook.TriggerEnum{hook.SceneCreatePost, hook.SceneUpdatePost}}, hook.SceneUpdatePost, true},
+		{"other trigger", Webhook{Enabled: true, Triggers: []hook.TriggerEnum{hook.SceneCreatePost}}, hook.SceneUpdatePost, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.webhook.Matches(tt.trigger); got != tt.want {
+				t.Errorf("Webhook.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatcherBackoff(t *testing.T) {
+	d := NewDispatcher()
+	d.InitialBackoff = time.Second
+	d.MaxBackoff = 5 * time.Second
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := d.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+type recorded struct {
+	mutex      sync.Mutex
+	deliveries []*models.WebhookDelivery
+	done       chan struct{}
+}
+
+func (r *recorded) record(ctx context.Context, d *models.WebhookDelivery) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.deliveries = append(r.deliveries, d)
+	if d.Success {
+		close(r.done)
+	}
+}
+
+func TestDispatcherDeliver(t *testing.T) {
+	const secret = "secret"
+
+	var (
+		mutex    sync.Mutex
+		requests int
+		body     []byte
+		header   http.Header
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		requests++
+		// fail the first attempt to exercise retry
+		if requests == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	rec := &recorded{done: make(chan struct{})}
+
+	d := NewDispatcher()
+	d.InitialBackoff = time.Millisecond
+	d.Recorder = rec.record
+	d.SetWebhooks([]*Webhook{
+		{ID: "1", Name: "matching", URL: server.URL, Enabled: true, Secret: secret, Triggers: []hook.TriggerEnum{hook.SceneUpdatePost}},
+		{ID: "2", Name: "not matching", URL: server.URL, Enabled: true, Triggers: []hook.TriggerEnum{hook.TagCreatePost}},
+	})
+	d.Start()
+	defer d.Stop()
+
+	d.OnPostHook(context.Background(), 12, hook.SceneUpdatePost, map[string]interface{}{"title": "new"}, []string{"title"})
+
+	select {
+	case <-rec.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for delivery")
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+
+	if got := header.Get(TriggerHeader); got != hook.SceneUpdatePost.String() {
+		t.Errorf("%s = %q, want %q", TriggerHeader, got, hook.SceneUpdatePost)
+	}
+	if got, want := header.Get(SignatureHeader), Sign(secret, body); got != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+
+	var p Payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		t.Fatalf("unmarshalling payload: %v", err)
+	}
+	if p.Trigger != hook.SceneUpdatePost.String() || p.ID != 12 || len(p.InputFields) != 1 || p.InputFields[0] != "title" {
+		t.Errorf("unexpected payload: %+v", p)
+	}
+
+	rec.mutex.Lock()
+	defer rec.mutex.Unlock()
+
+	if len(rec.deliveries) != 2 {
+		t.Fatalf("recorded %d deliveries, want 2", len(rec.deliveries))
+	}
+
+	first, second := rec.deliveries[0], rec.deliveries[1]
+	if first.Success || first.Attempt != 1 || first.StatusCode == nil || *first.StatusCode != http.StatusInternalServerError {
+		t.Errorf("unexpected first delivery: %+v", first)
+	}
+	if !second.Success || second.Attempt != 2 || second.WebhookID != "1" || second.EntityID != 12 {
+		t.Errorf("unexpected second delivery: %+v", second)
+	}
+}
@@ -93,7 +93,7 @@ export const DeleteGalleriesDialog: React.FC<IDeleteGalleryDialogProps> = (
               singularEntity: intl.formatMessage({ id: "file" }),
               pluralEntity: intl.formatMessage({ id: "files" }),
             }}
-            id="dialogs.delete_alert"
+            id="dialogs.trash_alert"
           />
         </p>
         <ul>
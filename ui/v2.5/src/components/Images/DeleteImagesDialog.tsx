@@ -89,7 +89,7 @@ export const DeleteImagesDialog: React.FC<IDeleteImageDialogProps> = (
               singularEntity: intl.formatMessage({ id: "file" }),
               pluralEntity: intl.formatMessage({ id: "files" }),
             }}
-            id="dialogs.delete_alert"
+            id="dialogs.trash_alert"
           />
         </p>
         <ul>
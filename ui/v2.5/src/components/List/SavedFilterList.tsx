@@ -17,12 +17,20 @@ import {
 } from "src/core/StashService";
 import { useToast } from "src/hooks/Toast";
 import { ListFilterModel } from "src/models/list-filter/filter";
-import { SavedFilterDataFragment } from "src/core/generated-graphql";
+import {
+  FilterMode,
+  SavedFilterDataFragment,
+} from "src/core/generated-graphql";
 import { View } from "./views";
 import { FormattedMessage, useIntl } from "react-intl";
 import { Icon } from "../Shared/Icon";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
-import { faBookmark, faSave, faTimes } from "@fortawesome/free-solid-svg-icons";
+import {
+  faBookmark,
+  faSave,
+  faTimes,
+  faTv,
+} from "@fortawesome/free-solid-svg-icons";
 
 interface ISavedFilterListProps {
   filter: ListFilterModel;
@@ -93,6 +101,40 @@ export const SavedFilterList: React.FC<ISavedFilterListProps> = ({
     }
   }
 
+  async function onToggleDLNA(f: SavedFilterDataFragment) {
+    try {
+      setSaving(true);
+
+      await saveFilter({
+        variables: {
+          input: {
+            id: f.id,
+            mode: f.mode,
+            name: f.name,
+            find_filter: f.find_filter
+              ? {
+                  q: f.find_filter.q,
+                  page: f.find_filter.page,
+                  per_page: f.find_filter.per_page,
+                  sort: f.find_filter.sort,
+                  direction: f.find_filter.direction,
+                }
+              : undefined,
+            object_filter: f.object_filter,
+            ui_options: f.ui_options,
+            dlna_enabled: !f.dlna_enabled,
+          },
+        },
+      });
+
+      refetch();
+    } catch (err) {
+      Toast.error(err);
+    } finally {
+      setSaving(false);
+    }
+  }
+
   async function onDeleteFilter(f: SavedFilterDataFragment) {
     try {
       setSaving(true);
@@ -183,6 +225,23 @@ export const SavedFilterList: React.FC<ISavedFilterListProps> = ({
           <span>{item.name}</span>
         </Dropdown.Item>
         <ButtonGroup>
+          {item.mode === FilterMode.Scenes && (
+            <Button
+              className="dlna-button"
+              variant="secondary"
+              size="sm"
+              active={item.dlna_enabled}
+              title={intl.formatMessage({
+                id: "config.dlna.expose_over_dlna",
+              })}
+              onClick={(e) => {
+                onToggleDLNA(item);
+                e.stopPropagation();
+              }}
+            >
+              <Icon icon={faTv} />
+            </Button>
+          )}
           <Button
             className="save-button"
             variant="secondary"
@@ -103,7 +103,7 @@ export const DeleteScenesDialog: React.FC<IDeleteSceneDialogProps> = (
               singularEntity: intl.formatMessage({ id: "file" }),
               pluralEntity: intl.formatMessage({ id: "files" }),
             }}
-            id="dialogs.delete_alert"
+            id="dialogs.trash_alert"
           />
         </p>
         <ul>
@@ -107,17 +107,24 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           value={TextUtils.secondsToTimestamp(props.file.duration ?? 0)}
           truncate
         />
-        <TextField
-          id="dimensions"
-          value={`${props.file.width} x ${props.file.height}`}
-          truncate
-        />
-        <TextField id="framerate">
-          <FormattedMessage
-            id="frames_per_second"
-            values={{ value: intl.formatNumber(props.file.frame_rate ?? 0) }}
-          />
-        </TextField>
+        {/* audio files have no video stream */}
+        {props.file.video_codec && (
+          <>
+            <TextField
+              id="dimensions"
+              value={`${props.file.width} x ${props.file.height}`}
+              truncate
+            />
+            <TextField id="framerate">
+              <FormattedMessage
+                id="frames_per_second"
+                values={{
+                  value: intl.formatNumber(props.file.frame_rate ?? 0),
+                }}
+              />
+            </TextField>
+          </>
+        )}
         <TextField id="bitrate">
           <FormattedMessage
             id="megabits_per_second"
@@ -138,6 +145,13 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           value={props.file.audio_codec ?? ""}
           truncate
         />
+        <TextField
+          id="media_info.audio_channels"
+          value={
+            props.file.audio_channels ? `${props.file.audio_channels}` : ""
+          }
+          truncate
+        />
       </dl>
       {props.ofMany && props.onSetPrimaryFile && !props.primary && (
         <div>
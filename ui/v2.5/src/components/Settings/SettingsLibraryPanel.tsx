@@ -8,6 +8,7 @@ import { useSettings } from "./context";
 import { useIntl } from "react-intl";
 import { faQuestionCircle } from "@fortawesome/free-solid-svg-icons";
 import { ExternalLink } from "../Shared/ExternalLink";
+import * as GQL from "src/core/generated-graphql";
 
 export const SettingsLibraryPanel: React.FC = () => {
   const intl = useIntl();
@@ -26,6 +27,16 @@ export const SettingsLibraryPanel: React.FC = () => {
     }
   }
 
+  function saveSceneFileRanking(input: Partial<GQL.SceneFileRankingInput>) {
+    if (!general.sceneFileRanking) {
+      return;
+    }
+
+    saveGeneral({
+      sceneFileRanking: { ...general.sceneFileRanking, ...input },
+    });
+  }
+
   if (error) return <h1>{error.message}</h1>;
   if (loading) return <LoadingIndicator />;
 
@@ -47,6 +58,16 @@ export const SettingsLibraryPanel: React.FC = () => {
           }
         />
 
+        <StringSetting
+          id="audio-extensions"
+          headingID="config.general.audio_ext_head"
+          subHeadingID="config.general.audio_ext_desc"
+          value={listToCommaDelimited(general.audioExtensions ?? undefined)}
+          onChange={(v) =>
+            saveGeneral({ audioExtensions: commaDelimitedToList(v) })
+          }
+        />
+
         <StringSetting
           id="image-extensions"
           headingID="config.general.image_ext_head"
@@ -140,6 +161,47 @@ export const SettingsLibraryPanel: React.FC = () => {
         />
       </SettingSection>
 
+      <SettingSection headingID="config.library.scene_file_ranking.heading">
+        <StringListSetting
+          id="scene-file-ranking-criteria"
+          headingID="config.library.scene_file_ranking.criteria_head"
+          subHeadingID="config.library.scene_file_ranking.criteria_desc"
+          value={general.sceneFileRanking?.criteria}
+          onChange={(v) =>
+            saveSceneFileRanking({
+              criteria: v.map(
+                (c) => c.trim().toUpperCase() as GQL.SceneFileRankingCriterion
+              ),
+            })
+          }
+          defaultNewValue="RESOLUTION"
+        />
+        <StringListSetting
+          id="scene-file-ranking-codecs"
+          headingID="config.library.scene_file_ranking.preferred_codecs_head"
+          subHeadingID="config.library.scene_file_ranking.preferred_codecs_desc"
+          value={general.sceneFileRanking?.preferredCodecs}
+          onChange={(v) => saveSceneFileRanking({ preferredCodecs: v })}
+          defaultNewValue="hevc"
+        />
+        <StringListSetting
+          id="scene-file-ranking-containers"
+          headingID="config.library.scene_file_ranking.preferred_containers_head"
+          subHeadingID="config.library.scene_file_ranking.preferred_containers_desc"
+          value={general.sceneFileRanking?.preferredContainers}
+          onChange={(v) => saveSceneFileRanking({ preferredContainers: v })}
+          defaultNewValue="mp4"
+        />
+        <StringListSetting
+          id="scene-file-ranking-paths"
+          headingID="config.library.scene_file_ranking.path_priority_head"
+          subHeadingID="config.library.scene_file_ranking.path_priority_desc"
+          value={general.sceneFileRanking?.pathPriority}
+          onChange={(v) => saveSceneFileRanking({ pathPriority: v })}
+          defaultNewValue="/media/preferred"
+        />
+      </SettingSection>
+
       <SettingSection headingID="config.ui.delete_options.heading">
         <BooleanSetting
           id="delete-file-default"
@@ -222,6 +222,22 @@ export const SettingsConfigurationPanel: React.FC = () => {
           value={general.backupDirectoryPath ?? undefined}
           onChange={(v) => saveGeneral({ backupDirectoryPath: v })}
         />
+
+        <StringSetting
+          id="trash-path"
+          headingID="config.general.trash_path.heading"
+          subHeadingID="config.general.trash_path.description"
+          value={general.trashPath ?? undefined}
+          onChange={(v) => saveGeneral({ trashPath: v })}
+        />
+
+        <NumberSetting
+          id="trash-retention"
+          headingID="config.general.trash_retention.heading"
+          subHeadingID="config.general.trash_retention.description"
+          value={general.trashRetention ?? undefined}
+          onChange={(v) => saveGeneral({ trashRetention: v })}
+        />
       </SettingSection>
 
       <SettingSection headingID="config.general.database">
@@ -5,7 +5,7 @@ import { FormattedMessage } from "react-intl";
 import { Icon } from "src/components/Shared/Icon";
 import * as GQL from "src/core/generated-graphql";
 import { FolderSelectDialog } from "../Shared/FolderSelect/FolderSelectDialog";
-import { BooleanSetting } from "./Inputs";
+import { BooleanSetting, SettingModal } from "./Inputs";
 import { SettingSection } from "./SettingSection";
 
 interface IStashProps {
@@ -32,12 +32,48 @@ const Stash: React.FC<IStashProps> = ({
     onSave(newObj);
   };
 
+  const [isEditingTemplate, setIsEditingTemplate] = useState(false);
+
   const classAdd = index % 2 === 1 ? "bg-dark" : "";
 
   return (
     <Row className={`stash-row align-items-center ${classAdd}`}>
+      {isEditingTemplate && (
+        <SettingModal<string>
+          headingID="config.general.rename_template.heading"
+          subHeading={
+            <FormattedMessage
+              id="config.general.rename_template.description"
+              values={{
+                variables:
+                  "{title}, {code}, {date}, {year}, {studio}, {performers}, {basename}, {ext}",
+              }}
+            />
+          }
+          value={stash.renameTemplate ?? ""}
+          renderField={(value, setValue) => (
+            <Form.Control
+              className="text-input"
+              value={value}
+              placeholder="{studio}/{date} {title} [{performers}].{ext}"
+              onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                setValue(e.currentTarget.value)
+              }
+            />
+          )}
+          close={(v) => {
+            if (v !== undefined) {
+              handleInput("renameTemplate", v.trim() || null);
+            }
+            setIsEditingTemplate(false);
+          }}
+        />
+      )}
       <Form.Label column md={7}>
         {stash.path}
+        {stash.renameTemplate && (
+          <div className="text-muted small">{stash.renameTemplate}</div>
+        )}
       </Form.Label>
       <Col md={2} xs={4} className="col form-label">
         {/* NOTE - language is opposite to meaning:
@@ -79,6 +115,9 @@ const Stash: React.FC<IStashProps> = ({
             <Dropdown.Item onClick={() => onEdit()}>
               <FormattedMessage id="actions.edit" />
             </Dropdown.Item>
+            <Dropdown.Item onClick={() => setIsEditingTemplate(true)}>
+              <FormattedMessage id="actions.edit_rename_template" />
+            </Dropdown.Item>
             <Dropdown.Item onClick={() => onDelete()}>
               <FormattedMessage id="actions.delete" />
             </Dropdown.Item>
@@ -12,6 +12,8 @@ import {
   mutateMigrateBlobs,
   mutateOptimiseDatabase,
   mutateCleanGenerated,
+  mutateMetadataRename,
+  mutateMetadataRankSceneFiles,
 } from "src/core/StashService";
 import { useToast } from "src/hooks/Toast";
 import downloadFile from "src/utils/download";
@@ -31,6 +33,8 @@ import {
   faTrashAlt,
 } from "@fortawesome/free-solid-svg-icons";
 import { CleanGeneratedDialog } from "./CleanGeneratedDialog";
+import { RenameFilesDialog } from "./RenameFilesDialog";
+import { SelectPrimaryFilesDialog } from "./SelectPrimaryFilesDialog";
 
 interface ICleanDialog {
   pathSelection?: boolean;
@@ -170,6 +174,8 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     clean: false,
     cleanAlert: false,
     cleanGenerated: false,
+    renameFiles: false,
+    selectPrimaryFiles: false,
   });
 
   const [cleanOptions, setCleanOptions] = useState<GQL.CleanMetadataInput>({
@@ -210,6 +216,20 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onDeltaImport() {
+    try {
+      await mutateMetadataImport({ delta: true });
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          { operation_name: intl.formatMessage({ id: "actions.import" }) }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   function renderImportAlert() {
     return (
       <ModalComponent
@@ -255,6 +275,42 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
+  async function onRenameFiles(input: GQL.RenameMetadataInput) {
+    try {
+      await mutateMetadataRename(input);
+
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({ id: "actions.rename_files" }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onSelectPrimaryFiles(input: GQL.RankSceneFilesInput) {
+    try {
+      await mutateMetadataRankSceneFiles(input);
+
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.tasks.added_job_to_queue" },
+          {
+            operation_name: intl.formatMessage({
+              id: "actions.select_primary_files",
+            }),
+          }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   async function onCleanGenerated(options: GQL.CleanGeneratedInput) {
     try {
       await mutateCleanGenerated({
@@ -330,9 +386,9 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
     }
   }
 
-  async function onExport() {
+  async function onExport(incremental = false) {
     try {
-      await mutateMetadataExport();
+      await mutateMetadataExport({ incremental });
       Toast.success(
         intl.formatMessage(
           { id: "config.tasks.added_job_to_queue" },
@@ -439,6 +495,28 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           }}
         />
       )}
+      {dialogOpen.renameFiles && (
+        <RenameFilesDialog
+          onClose={(input) => {
+            if (input) {
+              onRenameFiles(input);
+            }
+
+            setDialogOpen({ renameFiles: false });
+          }}
+        />
+      )}
+      {dialogOpen.selectPrimaryFiles && (
+        <SelectPrimaryFilesDialog
+          onClose={(input) => {
+            if (input) {
+              onSelectPrimaryFiles(input);
+            }
+
+            setDialogOpen({ selectPrimaryFiles: false });
+          }}
+        />
+      )}
 
       <SettingSection headingID="config.tasks.maintenance">
         <div className="setting-group">
@@ -489,6 +567,36 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           </Setting>
         </div>
 
+        <div className="setting-group">
+          <Setting
+            heading={<FormattedMessage id="actions.rename_files" />}
+            subHeadingID="config.tasks.rename_files.description"
+          >
+            <Button
+              variant="danger"
+              type="submit"
+              onClick={() => setDialogOpen({ renameFiles: true })}
+            >
+              <FormattedMessage id="actions.rename_files" />…
+            </Button>
+          </Setting>
+        </div>
+
+        <div className="setting-group">
+          <Setting
+            heading={<FormattedMessage id="actions.select_primary_files" />}
+            subHeadingID="config.tasks.select_primary_files.description"
+          >
+            <Button
+              variant="secondary"
+              type="submit"
+              onClick={() => setDialogOpen({ selectPrimaryFiles: true })}
+            >
+              <FormattedMessage id="actions.select_primary_files" />…
+            </Button>
+          </Setting>
+        </div>
+
         <Setting
           headingID="actions.optimise_database"
           subHeading={
@@ -524,6 +632,20 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           </Button>
         </Setting>
 
+        <Setting
+          headingID="actions.incremental_export"
+          subHeadingID="config.tasks.incremental_export_to_json"
+        >
+          <Button
+            id="incremental-export"
+            variant="secondary"
+            type="submit"
+            onClick={() => onExport(true)}
+          >
+            <FormattedMessage id="actions.incremental_export" />…
+          </Button>
+        </Setting>
+
         <Setting
           headingID="actions.full_import"
           subHeadingID="config.tasks.import_from_exported_json"
@@ -538,6 +660,20 @@ export const DataManagementTasks: React.FC<IDataManagementTasks> = ({
           </Button>
         </Setting>
 
+        <Setting
+          headingID="actions.incremental_export_import"
+          subHeadingID="config.tasks.incremental_export_import"
+        >
+          <Button
+            id="delta-import"
+            variant="danger"
+            type="submit"
+            onClick={() => onDeltaImport()}
+          >
+            <FormattedMessage id="actions.incremental_export_import" />
+          </Button>
+        </Setting>
+
         <Setting
           headingID="actions.import_from_file"
           subHeadingID="config.tasks.incremental_import"
@@ -171,6 +171,12 @@ export const GenerateOptions: React.FC<IGenerateOptions> = ({
             headingID="dialogs.scene_gen.image_thumbnails"
             onChange={(v) => setOptions({ imageThumbnails: v })}
           />
+          <BooleanSetting
+            id="image-phashes"
+            checked={options.imagePhashes ?? false}
+            headingID="dialogs.scene_gen.image_phashes"
+            onChange={(v) => setOptions({ imagePhashes: v })}
+          />
         </>
       )}
       <BooleanSetting
@@ -1,11 +1,16 @@
-import React, { useState } from "react";
-import { Form } from "react-bootstrap";
-import { mutateImportObjects } from "src/core/StashService";
+import React, { useCallback, useState } from "react";
+import { Button, Form, Table } from "react-bootstrap";
+import {
+  mutateImportObjects,
+  mutateImportObjectsDryRun,
+  queryImportDryRunReport,
+} from "src/core/StashService";
 import { ModalComponent } from "src/components/Shared/Modal";
 import * as GQL from "src/core/generated-graphql";
 import { useToast } from "src/hooks/Toast";
-import { useIntl } from "react-intl";
+import { FormattedMessage, useIntl } from "react-intl";
 import { faPencilAlt } from "@fortawesome/free-solid-svg-icons";
+import { JobFragment, useMonitorJob } from "src/utils/job";
 
 interface IImportDialogProps {
   onClose: () => void;
@@ -24,12 +29,43 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
 
   const [file, setFile] = useState<File | undefined>();
 
+  const [report, setReport] = useState<GQL.ImportReport>();
+
   // Network state
   const [isRunning, setIsRunning] = useState(false);
 
+  const [dryRunJobID, setDryRunJobID] = useState<string>();
+
   const intl = useIntl();
   const Toast = useToast();
 
+  const onDryRunComplete = useCallback(
+    async (job?: JobFragment) => {
+      if (!dryRunJobID) return;
+
+      setDryRunJobID(undefined);
+
+      try {
+        if (job?.status === GQL.JobStatus.Failed) {
+          Toast.error(job.error);
+          return;
+        }
+
+        if (job?.status === GQL.JobStatus.Cancelled) return;
+
+        const result = await queryImportDryRunReport(dryRunJobID);
+        setReport(result.data.importDryRunReport ?? undefined);
+      } catch (e) {
+        Toast.error(e);
+      } finally {
+        setIsRunning(false);
+      }
+    },
+    [dryRunJobID, Toast]
+  );
+
+  useMonitorJob(dryRunJobID, onDryRunComplete);
+
   function duplicateHandlingToString(
     value: GQL.ImportDuplicateEnum | undefined
   ) {
@@ -91,6 +127,29 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
       event.target.files.length > 0
     ) {
       setFile(event.target.files[0]);
+      setReport(undefined);
+    }
+  }
+
+  function importInput(): GQL.ImportObjectsInput {
+    return {
+      duplicateBehaviour: translateDuplicateHandling(duplicateBehaviour),
+      missingRefBehaviour: translateMissingRefHandling(missingRefBehaviour),
+      file,
+    };
+  }
+
+  async function onDryRun() {
+    if (!file) return;
+
+    try {
+      setIsRunning(true);
+      setReport(undefined);
+      const result = await mutateImportObjectsDryRun(importInput());
+      setDryRunJobID(result.data?.importObjectsDryRun);
+    } catch (e) {
+      Toast.error(e);
+      setIsRunning(false);
     }
   }
 
@@ -99,11 +158,7 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
 
     try {
       setIsRunning(true);
-      await mutateImportObjects({
-        duplicateBehaviour: translateDuplicateHandling(duplicateBehaviour),
-        missingRefBehaviour: translateMissingRefHandling(missingRefBehaviour),
-        file,
-      });
+      await mutateImportObjects(importInput());
       setIsRunning(false);
       Toast.success(intl.formatMessage({ id: "toast.started_importing" }));
     } catch (e) {
@@ -113,11 +168,76 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
     }
   }
 
+  function renderReport() {
+    if (!report) return;
+
+    const conflicts = report.objects.filter(
+      (o) => o.duplicate || o.action === GQL.ImportReportAction.Fail
+    );
+
+    return (
+      <div className="import-dry-run-report">
+        <p>
+          <FormattedMessage
+            id="config.tasks.import_dry_run.summary"
+            values={{
+              created: report.created,
+              updated: report.updated,
+              skipped: report.skipped,
+              failed: report.failed,
+              duplicates: report.duplicates,
+            }}
+          />
+        </p>
+        {report.missing_references.length > 0 && (
+          <>
+            <h6>
+              <FormattedMessage
+                id="config.tasks.import_dry_run.missing_references"
+              />
+            </h6>
+            <Table size="sm">
+              <tbody>
+                {report.missing_references.map((r) => (
+                  <tr key={`${r.type}-${r.name}`}>
+                    <td>{r.type}</td>
+                    <td>{r.name}</td>
+                    <td>{r.referenced_by.join(", ")}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </Table>
+          </>
+        )}
+        {conflicts.length > 0 && (
+          <>
+            <h6>
+              <FormattedMessage id="config.tasks.import_dry_run.conflicts" />
+            </h6>
+            <Table size="sm">
+              <tbody>
+                {conflicts.map((o, i) => (
+                  <tr key={i}>
+                    <td>{o.type}</td>
+                    <td>{o.name}</td>
+                    <td>{o.action}</td>
+                    <td className="text-danger">{o.error}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </Table>
+          </>
+        )}
+      </div>
+    );
+  }
+
   return (
     <ModalComponent
       show
       icon={faPencilAlt}
       header={intl.formatMessage({ id: "actions.import" })}
+      dialogClassName={report ? "modal-dialog-scrollable modal-xl" : undefined}
       accept={{
         onClick: () => {
           onImport();
@@ -131,6 +251,15 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
       }}
       disabled={!file}
       isRunning={isRunning}
+      leftFooterButtons={
+        <Button
+          variant="secondary"
+          disabled={!file || isRunning}
+          onClick={() => onDryRun()}
+        >
+          <FormattedMessage id="actions.dry_run" />
+        </Button>
+      }
     >
       <div className="dialog-container">
         <Form>
@@ -144,9 +273,10 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
               className="w-auto input-control"
               as="select"
               value={duplicateBehaviour}
-              onChange={(e: React.ChangeEvent<HTMLSelectElement>) =>
-                setDuplicateBehaviour(e.currentTarget.value)
-              }
+              onChange={(e: React.ChangeEvent<HTMLSelectElement>) => {
+                setDuplicateBehaviour(e.currentTarget.value);
+                setReport(undefined);
+              }}
             >
               {Object.values(GQL.ImportDuplicateEnum).map((p) => (
                 <option key={p}>{duplicateHandlingToString(p)}</option>
@@ -160,9 +290,10 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
               className="w-auto input-control"
               as="select"
               value={missingRefBehaviour}
-              onChange={(e: React.ChangeEvent<HTMLSelectElement>) =>
-                setMissingRefBehaviour(e.currentTarget.value)
-              }
+              onChange={(e: React.ChangeEvent<HTMLSelectElement>) => {
+                setMissingRefBehaviour(e.currentTarget.value);
+                setReport(undefined);
+              }}
             >
               {Object.values(GQL.ImportMissingRefEnum).map((p) => (
                 <option key={p}>{missingRefHandlingToString(p)}</option>
@@ -170,6 +301,7 @@ export const ImportDialog: React.FC<IImportDialogProps> = (
             </Form.Control>
           </Form.Group>
         </Form>
+        {renderReport()}
       </div>
     </ModalComponent>
   );
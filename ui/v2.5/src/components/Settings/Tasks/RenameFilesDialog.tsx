@@ -0,0 +1,109 @@
+import React, { useEffect, useState } from "react";
+import { FormattedMessage, useIntl } from "react-intl";
+import { Table } from "react-bootstrap";
+import { faFileSignature } from "@fortawesome/free-solid-svg-icons";
+import { ModalComponent } from "src/components/Shared/Modal";
+import { LoadingIndicator } from "src/components/Shared/LoadingIndicator";
+import * as GQL from "src/core/generated-graphql";
+import { queryPlanFileRenames } from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+
+export const RenameFilesDialog: React.FC<{
+  onClose: (input?: GQL.RenameMetadataInput) => void;
+}> = ({ onClose }) => {
+  const intl = useIntl();
+  const Toast = useToast();
+
+  const [renames, setRenames] = useState<GQL.PlannedFileRename[]>();
+
+  useEffect(() => {
+    queryPlanFileRenames({ dryRun: true })
+      .then((result) => setRenames(result.data.planFileRenames))
+      .catch((e) => {
+        Toast.error(e);
+        onClose();
+      });
+    // only plan once when the dialog is opened
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, []);
+
+  const moves = renames?.filter((r) => !r.error) ?? [];
+  const errors = renames?.filter((r) => r.error) ?? [];
+
+  function renderContent() {
+    if (!renames) {
+      return <LoadingIndicator />;
+    }
+
+    if (renames.length === 0) {
+      return (
+        <p>
+          <FormattedMessage id="config.tasks.rename_files.no_changes" />
+        </p>
+      );
+    }
+
+    return (
+      <>
+        {moves.length > 0 && (
+          <>
+            <p>
+              <FormattedMessage
+                id="config.tasks.rename_files.planned"
+                values={{ count: moves.length }}
+              />
+            </p>
+            <Table size="sm" className="rename-files-table">
+              <tbody>
+                {moves.map((r) => (
+                  <tr key={r.file_id}>
+                    <td>{r.old_path}</td>
+                    <td>{r.new_path}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </Table>
+          </>
+        )}
+        {errors.length > 0 && (
+          <>
+            <p>
+              <FormattedMessage
+                id="config.tasks.rename_files.skipped"
+                values={{ count: errors.length }}
+              />
+            </p>
+            <Table size="sm" className="rename-files-table">
+              <tbody>
+                {errors.map((r) => (
+                  <tr key={r.file_id}>
+                    <td>{r.old_path}</td>
+                    <td className="text-danger">{r.error}</td>
+                  </tr>
+                ))}
+              </tbody>
+            </Table>
+          </>
+        )}
+      </>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      header={<FormattedMessage id="actions.rename_files" />}
+      icon={faFileSignature}
+      dialogClassName="modal-dialog-scrollable modal-xl"
+      disabled={moves.length === 0}
+      accept={{
+        text: intl.formatMessage({ id: "actions.rename_files" }),
+        variant: "danger",
+        onClick: () => onClose({ dryRun: false }),
+      }}
+      cancel={{ onClick: () => onClose() }}
+    >
+      <div className="dialog-container">{renderContent()}</div>
+    </ModalComponent>
+  );
+};
@@ -19,6 +19,7 @@ export const ScanOptions: React.FC<IScanOptions> = ({
     scanGeneratePhashes,
     scanGenerateThumbnails,
     scanGenerateClipPreviews,
+    scanGenerateImagePhashes,
   } = options;
 
   function setOptions(input: Partial<GQL.ScanMetadataInput>) {
@@ -77,6 +78,13 @@ export const ScanOptions: React.FC<IScanOptions> = ({
         headingID="config.tasks.generate_clip_previews_during_scan"
         onChange={(v) => setOptions({ scanGenerateClipPreviews: v })}
       />
+      <BooleanSetting
+        id="scan-generate-image-phashes"
+        checked={scanGenerateImagePhashes ?? false}
+        headingID="config.tasks.generate_image_phashes_during_scan"
+        tooltipID="config.tasks.generate_image_phashes_during_scan_tooltip"
+        onChange={(v) => setOptions({ scanGenerateImagePhashes: v })}
+      />
     </>
   );
 };
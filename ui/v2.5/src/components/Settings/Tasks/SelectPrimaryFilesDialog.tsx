@@ -0,0 +1,149 @@
+import React, { useEffect, useState } from "react";
+import { FormattedMessage, useIntl } from "react-intl";
+import { Form, Table } from "react-bootstrap";
+import { Link } from "react-router-dom";
+import { faStar } from "@fortawesome/free-solid-svg-icons";
+import { ModalComponent } from "src/components/Shared/Modal";
+import { LoadingIndicator } from "src/components/Shared/LoadingIndicator";
+import * as GQL from "src/core/generated-graphql";
+import { queryPlanSceneFileRanking } from "src/core/StashService";
+import { useToast } from "src/hooks/Toast";
+
+const inferiorFileHandlingIDs: Record<GQL.InferiorFileHandling, string> = {
+  [GQL.InferiorFileHandling.Keep]:
+    "config.tasks.select_primary_files.inferior_files.keep",
+  [GQL.InferiorFileHandling.Trash]:
+    "config.tasks.select_primary_files.inferior_files.trash",
+  [GQL.InferiorFileHandling.Delete]:
+    "config.tasks.select_primary_files.inferior_files.delete",
+};
+
+export const SelectPrimaryFilesDialog: React.FC<{
+  onClose: (input?: GQL.RankSceneFilesInput) => void;
+}> = ({ onClose }) => {
+  const intl = useIntl();
+  const Toast = useToast();
+
+  const [plans, setPlans] = useState<GQL.PlannedPrimaryFile[]>();
+  const [inferiorFiles, setInferiorFiles] = useState(
+    GQL.InferiorFileHandling.Keep
+  );
+
+  useEffect(() => {
+    queryPlanSceneFileRanking({ dryRun: true })
+      .then((result) => setPlans(result.data.planSceneFileRanking))
+      .catch((e) => {
+        Toast.error(e);
+        onClose();
+      });
+    // only plan once when the dialog is opened
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, []);
+
+  const keep = inferiorFiles === GQL.InferiorFileHandling.Keep;
+  const changes = plans?.filter((p) => p.changed || !keep) ?? [];
+
+  function renderContent() {
+    if (!plans) {
+      return <LoadingIndicator />;
+    }
+
+    if (changes.length === 0) {
+      return (
+        <p>
+          <FormattedMessage id="config.tasks.select_primary_files.no_changes" />
+        </p>
+      );
+    }
+
+    return (
+      <>
+        <p>
+          <FormattedMessage
+            id="config.tasks.select_primary_files.planned"
+            values={{ count: changes.length }}
+          />
+        </p>
+        <Table size="sm" className="select-primary-files-table">
+          <thead>
+            <tr>
+              <th>
+                <FormattedMessage id="scene" />
+              </th>
+              <th>
+                <FormattedMessage
+                  id="config.tasks.select_primary_files.primary_file"
+                />
+              </th>
+              <th>
+                <FormattedMessage
+                  id="config.tasks.select_primary_files.other_files"
+                />
+              </th>
+            </tr>
+          </thead>
+          <tbody>
+            {changes.map((p) => (
+              <tr key={p.scene_id}>
+                <td>
+                  <Link to={`/scenes/${p.scene_id}`}>{p.scene_title}</Link>
+                </td>
+                <td className={p.changed ? "text-success" : undefined}>
+                  {p.primary_path}
+                </td>
+                <td className={keep ? undefined : "text-danger"}>
+                  {p.inferior_paths.map((path) => (
+                    <div key={path}>{path}</div>
+                  ))}
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </Table>
+      </>
+    );
+  }
+
+  return (
+    <ModalComponent
+      show
+      header={<FormattedMessage id="actions.select_primary_files" />}
+      icon={faStar}
+      dialogClassName="modal-dialog-scrollable modal-xl"
+      disabled={changes.length === 0}
+      accept={{
+        text: intl.formatMessage({ id: "actions.select_primary_files" }),
+        variant: keep ? "primary" : "danger",
+        onClick: () => onClose({ inferiorFiles, dryRun: false }),
+      }}
+      cancel={{ onClick: () => onClose() }}
+    >
+      <div className="dialog-container">
+        <Form.Group id="inferior-files">
+          <h6>
+            <FormattedMessage
+              id="config.tasks.select_primary_files.inferior_files.heading"
+            />
+          </h6>
+          <Form.Control
+            className="w-auto input-control"
+            as="select"
+            value={inferiorFiles}
+            onChange={(e: React.ChangeEvent<HTMLSelectElement>) =>
+              setInferiorFiles(
+                e.currentTarget.value as GQL.InferiorFileHandling
+              )
+            }
+          >
+            {Object.values(GQL.InferiorFileHandling).map((v) => (
+              <option key={v} value={v}>
+                {intl.formatMessage({ id: inferiorFileHandlingIDs[v] })}
+              </option>
+            ))}
+          </Form.Control>
+        </Form.Group>
+        {renderContent()}
+      </div>
+    </ModalComponent>
+  );
+};
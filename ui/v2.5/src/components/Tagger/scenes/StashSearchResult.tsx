@@ -708,6 +708,12 @@ const StashSearchResult: React.FC<IStashSearchResultProps> = ({
 
   async function onCreateTag(t: GQL.ScrapedTag) {
     const toCreate: GQL.TagCreateInput = { name: t.name };
+
+    const endpoint = currentSource?.sourceInput.stash_box_endpoint;
+    if (endpoint && t.remote_site_id) {
+      toCreate.stash_ids = [{ endpoint, stash_id: t.remote_site_id }];
+    }
+
     const newTagID = await createNewTag(t, toCreate);
     if (newTagID !== undefined) {
       setTagIDs([...tagIDs, newTagID]);
@@ -2,6 +2,7 @@ import React from "react";
 import { TagLink } from "src/components/Shared/TagLink";
 import { DetailItem } from "src/components/Shared/DetailItem";
 import * as GQL from "src/core/generated-graphql";
+import { StashIDPill } from "src/components/Shared/StashID";
 
 interface ITagDetails {
   tag: GQL.TagDataFragment;
@@ -51,6 +52,24 @@ export const TagDetailsPanel: React.FC<ITagDetails> = ({ tag, fullWidth }) => {
     );
   }
 
+  function renderStashIDs() {
+    if (!tag.stash_ids?.length) {
+      return;
+    }
+
+    return (
+      <ul className="pl-0">
+        {tag.stash_ids.map((stashID) => {
+          return (
+            <li key={stashID.stash_id} className="row no-gutters">
+              <StashIDPill stashID={stashID} linkType="tags" />
+            </li>
+          );
+        })}
+      </ul>
+    );
+  }
+
   return (
     <div className="detail-group">
       <DetailItem
@@ -68,6 +87,11 @@ export const TagDetailsPanel: React.FC<ITagDetails> = ({ tag, fullWidth }) => {
         value={renderChildrenField()}
         fullWidth={fullWidth}
       />
+      <DetailItem
+        id="stash_ids"
+        value={renderStashIDs()}
+        fullWidth={fullWidth}
+      />
     </div>
   );
 };
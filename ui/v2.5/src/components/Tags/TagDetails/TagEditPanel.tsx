@@ -15,6 +15,7 @@ import { handleUnsavedChanges } from "src/utils/navigation";
 import { formikUtils } from "src/utils/form";
 import { yupFormikValidate, yupUniqueAliases } from "src/utils/yup";
 import { Tag, TagSelect } from "../TagSelect";
+import { getStashIDs } from "src/utils/stashIds";
 
 interface ITagEditPanel {
   tag: Partial<GQL.TagDataFragment>;
@@ -51,6 +52,7 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
     parent_ids: yup.array(yup.string().required()).defined(),
     child_ids: yup.array(yup.string().required()).defined(),
     ignore_auto_tag: yup.boolean().defined(),
+    stash_ids: yup.mixed<GQL.StashIdInput[]>().defined(),
     image: yup.string().nullable().optional(),
   });
 
@@ -61,6 +63,7 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
     parent_ids: (tag?.parents ?? []).map((t) => t.id),
     child_ids: (tag?.children ?? []).map((t) => t.id),
     ignore_auto_tag: tag?.ignore_auto_tag ?? false,
+    stash_ids: getStashIDs(tag?.stash_ids),
   };
 
   type InputValues = yup.InferType<typeof schema>;
@@ -138,10 +141,12 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
     ImageUtils.onImageChange(event, onImageLoad);
   }
 
-  const { renderField, renderInputField, renderStringListField } = formikUtils(
-    intl,
-    formik
-  );
+  const {
+    renderField,
+    renderInputField,
+    renderStringListField,
+    renderStashIDsField,
+  } = formikUtils(intl, formik);
 
   function renderParentTagsField() {
     const title = intl.formatMessage({ id: "parent_tags" });
@@ -207,6 +212,7 @@ export const TagEditPanel: React.FC<ITagEditPanel> = ({
         {renderInputField("description", "textarea")}
         {renderParentTagsField()}
         {renderSubTagsField()}
+        {renderStashIDsField("stash_ids", "tags")}
         <hr />
         {renderInputField("ignore_auto_tag", "checkbox")}
       </Form>
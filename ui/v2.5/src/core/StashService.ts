@@ -2262,6 +2262,12 @@ export const mutateStashBoxBatchStudioTag = (
     variables: { input },
   });
 
+export const mutateStashBoxBatchTagTag = (input: GQL.StashBoxBatchTagInput) =>
+  client.mutate<GQL.StashBoxBatchTagTagMutation>({
+    mutation: GQL.StashBoxBatchTagTagDocument,
+    variables: { input },
+  });
+
 export const useListGroupScrapers = () => GQL.useListGroupScrapersQuery();
 
 export const queryScrapeGroupURL = (url: string) =>
@@ -2686,6 +2692,34 @@ export const mutateMetadataClean = (input: GQL.CleanMetadataInput) =>
     variables: { input },
   });
 
+export const mutateMetadataRename = (input: GQL.RenameMetadataInput) =>
+  client.mutate<GQL.MetadataRenameMutation>({
+    mutation: GQL.MetadataRenameDocument,
+    variables: { input },
+  });
+
+export const queryPlanFileRenames = (input: GQL.RenameMetadataInput) =>
+  client.query<GQL.PlanFileRenamesQuery>({
+    query: GQL.PlanFileRenamesDocument,
+    variables: { input },
+    fetchPolicy: "network-only",
+  });
+
+export const mutateMetadataRankSceneFiles = (
+  input: GQL.RankSceneFilesInput
+) =>
+  client.mutate<GQL.MetadataRankSceneFilesMutation>({
+    mutation: GQL.MetadataRankSceneFilesDocument,
+    variables: { input },
+  });
+
+export const queryPlanSceneFileRanking = (input: GQL.RankSceneFilesInput) =>
+  client.query<GQL.PlanSceneFileRankingQuery>({
+    query: GQL.PlanSceneFileRankingDocument,
+    variables: { input },
+    fetchPolicy: "network-only",
+  });
+
 export const mutateCleanGenerated = (input: GQL.CleanGeneratedInput) =>
   client.mutate<GQL.MetadataCleanGeneratedMutation>({
     mutation: GQL.MetadataCleanGeneratedDocument,
@@ -2702,9 +2736,10 @@ export const mutateRunPluginTask = (
     variables: { plugin_id: pluginId, task_name: taskName, args },
   });
 
-export const mutateMetadataExport = () =>
+export const mutateMetadataExport = (input?: GQL.ExportMetadataInput) =>
   client.mutate<GQL.MetadataExportMutation>({
     mutation: GQL.MetadataExportDocument,
+    variables: { input },
   });
 
 export const mutateExportObjects = (input: GQL.ExportObjectsInput) =>
@@ -2713,9 +2748,10 @@ export const mutateExportObjects = (input: GQL.ExportObjectsInput) =>
     variables: { input },
   });
 
-export const mutateMetadataImport = () =>
+export const mutateMetadataImport = (input?: GQL.ImportMetadataInput) =>
   client.mutate<GQL.MetadataImportMutation>({
     mutation: GQL.MetadataImportDocument,
+    variables: { input },
   });
 
 export const mutateImportObjects = (input: GQL.ImportObjectsInput) =>
@@ -2724,6 +2760,19 @@ export const mutateImportObjects = (input: GQL.ImportObjectsInput) =>
     variables: { input },
   });
 
+export const mutateImportObjectsDryRun = (input: GQL.ImportObjectsInput) =>
+  client.mutate<GQL.ImportObjectsDryRunMutation>({
+    mutation: GQL.ImportObjectsDryRunDocument,
+    variables: { input },
+  });
+
+export const queryImportDryRunReport = (jobID: string) =>
+  client.query<GQL.ImportDryRunReportQuery>({
+    query: GQL.ImportDryRunReportDocument,
+    variables: { job_id: jobID },
+    fetchPolicy: "network-only",
+  });
+
 export const mutateBackupDatabase = (input: GQL.BackupDatabaseInput) =>
   client.mutate<GQL.BackupDatabaseMutation>({
     mutation: GQL.BackupDatabaseDocument,
@@ -26,6 +26,7 @@ const sortByOptions = [
   "tag_count",
   "random",
   "rating",
+  "relevance",
   "penis_length",
   "play_count",
   "last_played_at",